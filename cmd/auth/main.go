@@ -7,8 +7,11 @@ import (
 
 	authapp "github.com/tizzhh/micro-banking/internal/app/auth"
 	"github.com/tizzhh/micro-banking/internal/config"
+	"github.com/tizzhh/micro-banking/internal/services/auth"
 	"github.com/tizzhh/micro-banking/internal/storage/postgres"
+	"github.com/tizzhh/micro-banking/pkg/jwt"
 	"github.com/tizzhh/micro-banking/pkg/logger/sl"
+	"github.com/tizzhh/micro-banking/pkg/mtls"
 )
 
 func main() {
@@ -22,7 +25,46 @@ func main() {
 		panic(err)
 	}
 
-	authapp := authapp.New(log, cfg.GRPC.AuthPort, cfg.TokenTTL, storage)
+	keys, err := jwt.LoadKeyRing(cfg.JWT.SigningKey, cfg.JWT.VerificationKeys)
+	if err != nil {
+		panic(err)
+	}
+
+	creds, err := mtls.ServerCredentials(cfg.GRPC.TLS.Options())
+	if err != nil {
+		panic(err)
+	}
+
+	authapp := authapp.New(
+		log,
+		cfg.GRPC.AuthPort,
+		cfg.TokenTTL,
+		cfg.RefreshTTL,
+		cfg.MFA.Issuer,
+		auth.Verification{
+			LinkURL:        cfg.EmailVerification.LinkURL,
+			ResendInterval: cfg.EmailVerification.ResendInterval,
+		},
+		auth.PasswordReset{
+			TokenTTL:        cfg.PasswordReset.TokenTTL,
+			LinkURL:         cfg.PasswordReset.LinkURL,
+			RequestInterval: cfg.PasswordReset.RequestInterval,
+		},
+		auth.LoginThrottle{
+			MaxAccountFailures: cfg.LoginThrottle.MaxAccountFailures,
+			MaxIPFailures:      cfg.LoginThrottle.MaxIPFailures,
+			MaxMFAFailures:     cfg.LoginThrottle.MaxMFAFailures,
+			FailureWindow:      cfg.LoginThrottle.FailureWindow,
+			BaseBackoff:        cfg.LoginThrottle.BaseBackoff,
+			MaxBackoff:         cfg.LoginThrottle.MaxBackoff,
+			LockoutDuration:    cfg.LoginThrottle.LockoutDuration,
+		},
+		cfg.Redis.PingTimeout,
+		keys,
+		cfg.ServiceAuth.Trusted,
+		creds,
+		storage,
+	)
 	go authapp.GRPCServer.MustRun()
 
 	stop := make(chan os.Signal, 1)
@@ -1,20 +1,21 @@
 package main
 
 import (
+	"context"
+	"log/slog"
 	"os"
 	"os/signal"
-	"strings"
 	"syscall"
+	"time"
 
 	"github.com/tizzhh/micro-banking/internal/app/bank"
-	"github.com/tizzhh/micro-banking/internal/clients/kafka/producer"
 	"github.com/tizzhh/micro-banking/internal/config"
 	"github.com/tizzhh/micro-banking/internal/storage/postgres"
 	"github.com/tizzhh/micro-banking/pkg/logger/sl"
 )
 
 const (
-	KafkaTopic = "Mail"
+	reconcileTimeout = 30 * time.Second
 )
 
 // @title Micro-bank api
@@ -44,13 +45,9 @@ func main() {
 		panic(err)
 	}
 
-	brokers := strings.Split(cfg.Kafka.Brokers, ";")
-	producer, err := producer.New(log, brokers, cfg.Kafka.Producer, KafkaTopic)
-	if err != nil {
-		panic(err)
-	}
+	reconcileLedger(log, storage)
 
-	bankapp := bank.New(log, cfg, storage, producer)
+	bankapp := bank.New(log, cfg, storage)
 
 	go bankapp.HTTPServer.MustRun()
 	stop := make(chan os.Signal, 1)
@@ -62,9 +59,29 @@ func main() {
 	if err = storage.Stop(); err != nil {
 		log.Error("failed to stop storage", sl.Error(err))
 	}
-	if err = producer.Stop(); err != nil {
-		log.Error("failed to stop producer", sl.Error(err))
-	}
 
 	log.Info("bank app stopped")
 }
+
+func reconcileLedger(log *slog.Logger, storage *postgres.Storage) {
+	ctx, cancel := context.WithTimeout(context.Background(), reconcileTimeout)
+	defer cancel()
+
+	mismatches, err := storage.Mismatches(ctx)
+	if err != nil {
+		log.Error("failed to reconcile ledger", sl.Error(err))
+		return
+	}
+
+	for _, mismatch := range mismatches {
+		log.Warn(
+			"balance does not match ledger",
+			slog.Uint64("user_id", mismatch.UserID),
+			slog.Uint64("currency_id", mismatch.CurrencyID),
+			slog.String("kind", string(mismatch.Kind)),
+			slog.Int64("stored_balance", mismatch.StoredBalance),
+			slog.Int64("ledger_balance", mismatch.LedgerBalance),
+		)
+	}
+	log.Info("ledger reconciled", slog.Int("mismatches", len(mismatches)))
+}
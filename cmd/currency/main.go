@@ -3,18 +3,16 @@ package main
 import (
 	"os"
 	"os/signal"
-	"strings"
 	"syscall"
 
+	"github.com/tizzhh/micro-banking/internal/api/principal"
 	currencyapp "github.com/tizzhh/micro-banking/internal/app/currency"
-	"github.com/tizzhh/micro-banking/internal/clients/kafka/producer"
+	authgrpc "github.com/tizzhh/micro-banking/internal/clients/auth/grpc"
 	"github.com/tizzhh/micro-banking/internal/config"
 	"github.com/tizzhh/micro-banking/internal/storage/postgres"
+	"github.com/tizzhh/micro-banking/pkg/jwt"
 	"github.com/tizzhh/micro-banking/pkg/logger/sl"
-)
-
-const (
-	KafkaTopic = "Mail"
+	"github.com/tizzhh/micro-banking/pkg/mtls"
 )
 
 func main() {
@@ -27,13 +25,42 @@ func main() {
 		panic(err)
 	}
 
-	brokers := strings.Split(cfg.Kafka.Brokers, ";")
-	producer, err := producer.New(log, brokers, cfg.Kafka.Producer, KafkaTopic)
+	clientCreds, err := mtls.ClientCredentials(cfg.Clients.TLS.Options())
+	if err != nil {
+		panic(err)
+	}
+	serverCreds, err := mtls.ServerCredentials(cfg.GRPC.TLS.Options())
+	if err != nil {
+		panic(err)
+	}
+
+	authClient, err := authgrpc.New(
+		log,
+		cfg.Clients.AuthClient.Addr,
+		cfg.Clients.AuthClient.Timeout,
+		cfg.Clients.AuthClient.RetriesCount,
+		principal.Service{Name: cfg.ServiceAuth.Name, Token: cfg.ServiceAuth.Token},
+		clientCreds,
+	)
 	if err != nil {
 		panic(err)
 	}
+	tokens := jwt.New(cfg.TokenTTL, jwt.NewRemoteKeySet(authClient.JWKS, cfg.JWT.JWKSRefresh))
 
-	currencyApp := currencyapp.New(log, cfg.GRPC.CurrencyPort, cfg.Redis.PingTimeout, cfg.CurrencyApi.Timeout, storage, producer)
+	currencyApp := currencyapp.New(
+		log,
+		cfg.GRPC.CurrencyPort,
+		cfg.Redis.PingTimeout,
+		cfg.CurrencyApi.Timeout,
+		cfg.Idempotency.KeyTTL,
+		cfg.CurrencyCatalog.RefreshInterval,
+		cfg.Quote.TTL,
+		cfg.Pricing.Options(),
+		tokens,
+		cfg.ServiceAuth.Trusted,
+		serverCreds,
+		storage,
+	)
 	go currencyApp.GRPCServer.MustRun()
 
 	stop := make(chan os.Signal, 1)
@@ -45,9 +72,6 @@ func main() {
 	if err = storage.Stop(); err != nil {
 		log.Error("failed to stop storage", sl.Error(err))
 	}
-	if err = producer.Stop(); err != nil {
-		log.Error("failed to stop producer", sl.Error(err))
-	}
 
 	log.Info("auth app stopped")
 }
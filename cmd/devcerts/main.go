@@ -0,0 +1,25 @@
+package main
+
+import (
+	"flag"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/tizzhh/micro-banking/pkg/mtls"
+)
+
+// devcerts writes a self-signed CA and certificates issued by it for running
+// the gRPC services with mTLS locally.
+func main() {
+	out := flag.String("out", "./config/certs", "directory to write the certificates to")
+	hosts := flag.String("hosts", "localhost,127.0.0.1,auth,currency", "comma separated names the server certificate is valid for")
+	validity := flag.Duration("validity", 365*24*time.Hour, "validity of the certificates")
+	flag.Parse()
+
+	// no config is loaded, so the standard logger is used
+	if err := mtls.GenerateDevCA(*out, strings.Split(*hosts, ","), *validity); err != nil {
+		log.Fatalf("failed to generate certificates: %v", err)
+	}
+	log.Printf("certificates written to %s", *out)
+}
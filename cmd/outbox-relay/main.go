@@ -0,0 +1,49 @@
+package main
+
+import (
+	"context"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/tizzhh/micro-banking/internal/clients/kafka/producer"
+	"github.com/tizzhh/micro-banking/internal/config"
+	"github.com/tizzhh/micro-banking/internal/services/outbox"
+	"github.com/tizzhh/micro-banking/internal/storage/postgres"
+	"github.com/tizzhh/micro-banking/pkg/logger/sl"
+)
+
+const (
+	KafkaTopic = "Mail"
+)
+
+func main() {
+	cfg := config.Get()
+	log := sl.Get()
+	log.Info("starting outbox relay")
+
+	storage, err := postgres.Get()
+	if err != nil {
+		panic(err)
+	}
+
+	brokers := strings.Split(cfg.Kafka.Brokers, ";")
+	producer, err := producer.New(log, brokers, cfg.Kafka.Producer, KafkaTopic)
+	if err != nil {
+		panic(err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	outbox.New(log, storage, producer, cfg.Outbox).Run(ctx)
+
+	if err = storage.Stop(); err != nil {
+		log.Error("failed to stop storage", sl.Error(err))
+	}
+	if err = producer.Stop(); err != nil {
+		log.Error("failed to stop producer", sl.Error(err))
+	}
+
+	log.Info("outbox relay stopped")
+}
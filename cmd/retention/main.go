@@ -0,0 +1,34 @@
+package main
+
+import (
+	"context"
+	"os/signal"
+	"syscall"
+
+	"github.com/tizzhh/micro-banking/internal/config"
+	"github.com/tizzhh/micro-banking/internal/services/retention"
+	"github.com/tizzhh/micro-banking/internal/storage/postgres"
+	"github.com/tizzhh/micro-banking/pkg/logger/sl"
+)
+
+func main() {
+	cfg := config.Get()
+	log := sl.Get()
+	log.Info("starting retention job")
+
+	storage, err := postgres.Get()
+	if err != nil {
+		panic(err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	retention.New(log, storage, cfg.Retention).Run(ctx)
+
+	if err = storage.Stop(); err != nil {
+		log.Error("failed to stop storage", sl.Error(err))
+	}
+
+	log.Info("retention job stopped")
+}
@@ -19,18 +19,28 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	Currency_Buy_FullMethodName     = "/currency.Currency/Buy"
-	Currency_Sell_FullMethodName    = "/currency.Currency/Sell"
-	Currency_Wallets_FullMethodName = "/currency.Currency/Wallets"
+	Currency_Quote_FullMethodName          = "/currency.Currency/Quote"
+	Currency_Buy_FullMethodName            = "/currency.Currency/Buy"
+	Currency_Sell_FullMethodName           = "/currency.Currency/Sell"
+	Currency_Convert_FullMethodName        = "/currency.Currency/Convert"
+	Currency_Wallets_FullMethodName        = "/currency.Currency/Wallets"
+	Currency_Transactions_FullMethodName   = "/currency.Currency/Transactions"
+	Currency_ListCurrencies_FullMethodName = "/currency.Currency/ListCurrencies"
+	Currency_RateHistory_FullMethodName    = "/currency.Currency/RateHistory"
 )
 
 // CurrencyClient is the client API for Currency service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type CurrencyClient interface {
+	Quote(ctx context.Context, in *QuoteRequest, opts ...grpc.CallOption) (*QuoteResponse, error)
 	Buy(ctx context.Context, in *BuyRequest, opts ...grpc.CallOption) (*BuyResponse, error)
 	Sell(ctx context.Context, in *SellRequest, opts ...grpc.CallOption) (*SellResponse, error)
+	Convert(ctx context.Context, in *ConvertRequest, opts ...grpc.CallOption) (*ConvertResponse, error)
 	Wallets(ctx context.Context, in *WalletRequest, opts ...grpc.CallOption) (*WalletResponse, error)
+	Transactions(ctx context.Context, in *TransactionsRequest, opts ...grpc.CallOption) (*TransactionsResponse, error)
+	ListCurrencies(ctx context.Context, in *ListCurrenciesRequest, opts ...grpc.CallOption) (*ListCurrenciesResponse, error)
+	RateHistory(ctx context.Context, in *RateHistoryRequest, opts ...grpc.CallOption) (*RateHistoryResponse, error)
 }
 
 type currencyClient struct {
@@ -41,6 +51,15 @@ func NewCurrencyClient(cc grpc.ClientConnInterface) CurrencyClient {
 	return &currencyClient{cc}
 }
 
+func (c *currencyClient) Quote(ctx context.Context, in *QuoteRequest, opts ...grpc.CallOption) (*QuoteResponse, error) {
+	out := new(QuoteResponse)
+	err := c.cc.Invoke(ctx, Currency_Quote_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *currencyClient) Buy(ctx context.Context, in *BuyRequest, opts ...grpc.CallOption) (*BuyResponse, error) {
 	out := new(BuyResponse)
 	err := c.cc.Invoke(ctx, Currency_Buy_FullMethodName, in, out, opts...)
@@ -59,6 +78,15 @@ func (c *currencyClient) Sell(ctx context.Context, in *SellRequest, opts ...grpc
 	return out, nil
 }
 
+func (c *currencyClient) Convert(ctx context.Context, in *ConvertRequest, opts ...grpc.CallOption) (*ConvertResponse, error) {
+	out := new(ConvertResponse)
+	err := c.cc.Invoke(ctx, Currency_Convert_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *currencyClient) Wallets(ctx context.Context, in *WalletRequest, opts ...grpc.CallOption) (*WalletResponse, error) {
 	out := new(WalletResponse)
 	err := c.cc.Invoke(ctx, Currency_Wallets_FullMethodName, in, out, opts...)
@@ -68,13 +96,45 @@ func (c *currencyClient) Wallets(ctx context.Context, in *WalletRequest, opts ..
 	return out, nil
 }
 
+func (c *currencyClient) Transactions(ctx context.Context, in *TransactionsRequest, opts ...grpc.CallOption) (*TransactionsResponse, error) {
+	out := new(TransactionsResponse)
+	err := c.cc.Invoke(ctx, Currency_Transactions_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *currencyClient) ListCurrencies(ctx context.Context, in *ListCurrenciesRequest, opts ...grpc.CallOption) (*ListCurrenciesResponse, error) {
+	out := new(ListCurrenciesResponse)
+	err := c.cc.Invoke(ctx, Currency_ListCurrencies_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *currencyClient) RateHistory(ctx context.Context, in *RateHistoryRequest, opts ...grpc.CallOption) (*RateHistoryResponse, error) {
+	out := new(RateHistoryResponse)
+	err := c.cc.Invoke(ctx, Currency_RateHistory_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // CurrencyServer is the server API for Currency service.
 // All implementations must embed UnimplementedCurrencyServer
 // for forward compatibility
 type CurrencyServer interface {
+	Quote(context.Context, *QuoteRequest) (*QuoteResponse, error)
 	Buy(context.Context, *BuyRequest) (*BuyResponse, error)
 	Sell(context.Context, *SellRequest) (*SellResponse, error)
+	Convert(context.Context, *ConvertRequest) (*ConvertResponse, error)
 	Wallets(context.Context, *WalletRequest) (*WalletResponse, error)
+	Transactions(context.Context, *TransactionsRequest) (*TransactionsResponse, error)
+	ListCurrencies(context.Context, *ListCurrenciesRequest) (*ListCurrenciesResponse, error)
+	RateHistory(context.Context, *RateHistoryRequest) (*RateHistoryResponse, error)
 	mustEmbedUnimplementedCurrencyServer()
 }
 
@@ -82,15 +142,30 @@ type CurrencyServer interface {
 type UnimplementedCurrencyServer struct {
 }
 
+func (UnimplementedCurrencyServer) Quote(context.Context, *QuoteRequest) (*QuoteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Quote not implemented")
+}
 func (UnimplementedCurrencyServer) Buy(context.Context, *BuyRequest) (*BuyResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Buy not implemented")
 }
 func (UnimplementedCurrencyServer) Sell(context.Context, *SellRequest) (*SellResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Sell not implemented")
 }
+func (UnimplementedCurrencyServer) Convert(context.Context, *ConvertRequest) (*ConvertResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Convert not implemented")
+}
 func (UnimplementedCurrencyServer) Wallets(context.Context, *WalletRequest) (*WalletResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Wallets not implemented")
 }
+func (UnimplementedCurrencyServer) Transactions(context.Context, *TransactionsRequest) (*TransactionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Transactions not implemented")
+}
+func (UnimplementedCurrencyServer) ListCurrencies(context.Context, *ListCurrenciesRequest) (*ListCurrenciesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCurrencies not implemented")
+}
+func (UnimplementedCurrencyServer) RateHistory(context.Context, *RateHistoryRequest) (*RateHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RateHistory not implemented")
+}
 func (UnimplementedCurrencyServer) mustEmbedUnimplementedCurrencyServer() {}
 
 // UnsafeCurrencyServer may be embedded to opt out of forward compatibility for this service.
@@ -104,6 +179,24 @@ func RegisterCurrencyServer(s grpc.ServiceRegistrar, srv CurrencyServer) {
 	s.RegisterService(&Currency_ServiceDesc, srv)
 }
 
+func _Currency_Quote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QuoteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CurrencyServer).Quote(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Currency_Quote_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CurrencyServer).Quote(ctx, req.(*QuoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Currency_Buy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(BuyRequest)
 	if err := dec(in); err != nil {
@@ -140,6 +233,24 @@ func _Currency_Sell_Handler(srv interface{}, ctx context.Context, dec func(inter
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Currency_Convert_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConvertRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CurrencyServer).Convert(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Currency_Convert_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CurrencyServer).Convert(ctx, req.(*ConvertRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Currency_Wallets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(WalletRequest)
 	if err := dec(in); err != nil {
@@ -158,6 +269,60 @@ func _Currency_Wallets_Handler(srv interface{}, ctx context.Context, dec func(in
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Currency_Transactions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransactionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CurrencyServer).Transactions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Currency_Transactions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CurrencyServer).Transactions(ctx, req.(*TransactionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Currency_ListCurrencies_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCurrenciesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CurrencyServer).ListCurrencies(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Currency_ListCurrencies_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CurrencyServer).ListCurrencies(ctx, req.(*ListCurrenciesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Currency_RateHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RateHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CurrencyServer).RateHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Currency_RateHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CurrencyServer).RateHistory(ctx, req.(*RateHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Currency_ServiceDesc is the grpc.ServiceDesc for Currency service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -165,6 +330,10 @@ var Currency_ServiceDesc = grpc.ServiceDesc{
 	ServiceName: "currency.Currency",
 	HandlerType: (*CurrencyServer)(nil),
 	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Quote",
+			Handler:    _Currency_Quote_Handler,
+		},
 		{
 			MethodName: "Buy",
 			Handler:    _Currency_Buy_Handler,
@@ -173,10 +342,26 @@ var Currency_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Sell",
 			Handler:    _Currency_Sell_Handler,
 		},
+		{
+			MethodName: "Convert",
+			Handler:    _Currency_Convert_Handler,
+		},
 		{
 			MethodName: "Wallets",
 			Handler:    _Currency_Wallets_Handler,
 		},
+		{
+			MethodName: "Transactions",
+			Handler:    _Currency_Transactions_Handler,
+		},
+		{
+			MethodName: "ListCurrencies",
+			Handler:    _Currency_ListCurrencies_Handler,
+		},
+		{
+			MethodName: "RateHistory",
+			Handler:    _Currency_RateHistory_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "protos/proto/currency/currency.proto",
@@ -0,0 +1,46 @@
+// Package idempotency holds what the HTTP gateway and the gRPC services share
+// about idempotency keys: header names, errors and request fingerprints.
+package idempotency
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+)
+
+const (
+	Header         = "Idempotency-Key"
+	ReplayedHeader = "Idempotent-Replayed"
+	MetadataKey    = "idempotency-key"
+
+	MaxKeyLength = 255
+)
+
+var (
+	ErrInvalidKey = errors.New("idempotency key must be at most 255 characters")
+	ErrKeyReused  = errors.New("idempotency key was already used with a different request")
+	ErrInProgress = errors.New("a request with this idempotency key is still in progress")
+)
+
+type keyCtx struct{}
+
+func WithKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, keyCtx{}, key)
+}
+
+func KeyFromContext(ctx context.Context) (string, bool) {
+	key, ok := ctx.Value(keyCtx{}).(string)
+	return key, ok && key != ""
+}
+
+// Fingerprint hashes the parts of a request that must match for a stored
+// response to be replayed.
+func Fingerprint(parts ...[]byte) string {
+	hash := sha256.New()
+	for _, part := range parts {
+		hash.Write(part)
+		hash.Write([]byte{0})
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
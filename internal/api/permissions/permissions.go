@@ -2,17 +2,58 @@ package permissions
 
 import "github.com/golang-jwt/jwt/v5"
 
+const (
+	RoleAdmin   = "admin"
+	RoleSupport = "support"
+)
+
+type Permission string
+
+const (
+	ReadUsers      Permission = "users:read"
+	ReadBalances   Permission = "balances:read"
+	FreezeAccounts Permission = "accounts:freeze"
+	UnlockAccounts Permission = "accounts:unlock"
+	AdjustBalances Permission = "balances:adjust"
+	// ManageCurrencies allows changing the currency catalog.
+	ManageCurrencies Permission = "currencies:manage"
+)
+
+// RolePermissions lists the permissions each role grants.
+var RolePermissions = map[string][]Permission{
+	RoleAdmin:   {ReadUsers, ReadBalances, FreezeAccounts, UnlockAccounts, AdjustBalances, ManageCurrencies},
+	RoleSupport: {ReadUsers, ReadBalances, UnlockAccounts},
+}
+
+// Policy maps routes, keyed by method and route pattern, to the permission
+// they require. RPCs have their own policies in the gRPC servers.
+type Policy map[string]Permission
+
+var DefaultPolicy = Policy{
+	"GET /v1/admin/users":                   ReadUsers,
+	"GET /v1/admin/users/{id}":              ReadUsers,
+	"GET /v1/admin/users/{id}/balance":      ReadBalances,
+	"POST /v1/admin/users/{id}/freeze":      FreezeAccounts,
+	"POST /v1/admin/users/{id}/unfreeze":    FreezeAccounts,
+	"POST /v1/admin/users/{id}/unlock":      UnlockAccounts,
+	"POST /v1/admin/users/{id}/adjustments": AdjustBalances,
+	"POST /v1/admin/currencies":             ManageCurrencies,
+	"PATCH /v1/admin/currencies/{code}":     ManageCurrencies,
+}
+
 type JWTChecker interface {
 	CheckToken(token string) (*jwt.Token, error)
 }
 
 type PermissionsChecker struct {
-	jwt JWTChecker
+	jwt    JWTChecker
+	policy Policy
 }
 
-func New(jwt JWTChecker) *PermissionsChecker {
+func New(jwt JWTChecker, policy Policy) *PermissionsChecker {
 	return &PermissionsChecker{
-		jwt: jwt,
+		jwt:    jwt,
+		policy: policy,
 	}
 }
 
@@ -20,3 +61,20 @@ func (pc *PermissionsChecker) CheckPermissions(token string) (*jwt.Token, error)
 	parsedToken, err := pc.jwt.CheckToken(token)
 	return parsedToken, err
 }
+
+// Allowed reports whether roles grant the permission operation requires.
+// Operations missing from the policy are denied.
+func (pc *PermissionsChecker) Allowed(roles []string, operation string) bool {
+	required, ok := pc.policy[operation]
+	if !ok {
+		return false
+	}
+	for _, role := range roles {
+		for _, permission := range RolePermissions[role] {
+			if permission == required {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,222 @@
+// Package principal holds the identity of the caller of a request, as
+// established from its access token, and how it is passed on to the gRPC
+// services.
+package principal
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	MetadataUserID        = "x-user-id"
+	MetadataEmail         = "x-user-email"
+	MetadataRoles         = "x-user-roles"
+	MetadataAuthorization = "authorization"
+	MetadataServiceName   = "x-service-name"
+	MetadataServiceToken  = "x-service-token"
+	MetadataClientIP      = "x-client-ip"
+)
+
+type Principal struct {
+	UserID uint64
+	Email  string
+	Roles  []string
+	// MFAAt is when the user last verified a second factor, zero if not
+	// for the token of the request.
+	MFAAt time.Time
+}
+
+func (p Principal) HasRole(role string) bool {
+	for _, r := range p.Roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+var ErrInvalidServiceCredentials = errors.New("invalid service credentials")
+
+// Service is the credential a trusted internal caller identifies itself
+// with.
+type Service struct {
+	Name  string
+	Token string
+}
+
+type principalCtx struct{}
+
+type tokenCtx struct{}
+
+type serviceCtx struct{}
+
+type clientIPCtx struct{}
+
+func WithPrincipal(ctx context.Context, p Principal) context.Context {
+	return context.WithValue(ctx, principalCtx{}, p)
+}
+
+func FromContext(ctx context.Context) (Principal, bool) {
+	p, ok := ctx.Value(principalCtx{}).(Principal)
+	return p, ok
+}
+
+// WithToken returns a copy of ctx carrying the bearer token the principal
+// was established from.
+func WithToken(ctx context.Context, token string) context.Context {
+	return context.WithValue(ctx, tokenCtx{}, token)
+}
+
+func TokenFromContext(ctx context.Context) string {
+	token, _ := ctx.Value(tokenCtx{}).(string)
+	return token
+}
+
+// WithService returns a copy of ctx marking the call as made by the trusted
+// service name.
+func WithService(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, serviceCtx{}, name)
+}
+
+func ServiceFromContext(ctx context.Context) (string, bool) {
+	name, ok := ctx.Value(serviceCtx{}).(string)
+	return name, ok
+}
+
+// WithClientIP returns a copy of ctx carrying the address of the client the
+// request came from.
+func WithClientIP(ctx context.Context, ip string) context.Context {
+	return context.WithValue(ctx, clientIPCtx{}, ip)
+}
+
+func ClientIPFromContext(ctx context.Context) string {
+	ip, _ := ctx.Value(clientIPCtx{}).(string)
+	return ip
+}
+
+// AppendToOutgoingContext adds the principal of ctx, if any, to the metadata
+// of outgoing gRPC calls.
+func AppendToOutgoingContext(ctx context.Context) context.Context {
+	p, ok := FromContext(ctx)
+	if !ok {
+		return ctx
+	}
+	kv := []string{
+		MetadataUserID, strconv.FormatUint(p.UserID, 10),
+		MetadataEmail, p.Email,
+	}
+	if len(p.Roles) > 0 {
+		kv = append(kv, MetadataRoles, strings.Join(p.Roles, ","))
+	}
+	return metadata.AppendToOutgoingContext(ctx, kv...)
+}
+
+// FromIncomingContext reads the principal forwarded by the caller of a gRPC
+// call.
+func FromIncomingContext(ctx context.Context) (Principal, bool) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return Principal{}, false
+	}
+
+	ids := md.Get(MetadataUserID)
+	emails := md.Get(MetadataEmail)
+	if len(ids) != 1 || len(emails) != 1 {
+		return Principal{}, false
+	}
+	uid, err := strconv.ParseUint(ids[0], 10, 64)
+	if err != nil {
+		return Principal{}, false
+	}
+
+	p := Principal{UserID: uid, Email: emails[0]}
+	for _, roles := range md.Get(MetadataRoles) {
+		for _, role := range strings.Split(roles, ",") {
+			if role != "" {
+				p.Roles = append(p.Roles, role)
+			}
+		}
+	}
+	return p, true
+}
+
+// ServiceFromIncomingContext returns the name of the caller of a gRPC call
+// if it presented the token of one of services.
+func ServiceFromIncomingContext(ctx context.Context, services map[string]string) (string, bool, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return "", false, nil
+	}
+
+	names := md.Get(MetadataServiceName)
+	tokens := md.Get(MetadataServiceToken)
+	if len(names) == 0 && len(tokens) == 0 {
+		return "", false, nil
+	}
+	if len(names) != 1 || len(tokens) != 1 {
+		return "", false, ErrInvalidServiceCredentials
+	}
+
+	expected, ok := services[names[0]]
+	if !ok || expected == "" || subtle.ConstantTimeCompare([]byte(expected), []byte(tokens[0])) != 1 {
+		return "", false, ErrInvalidServiceCredentials
+	}
+	return names[0], true, nil
+}
+
+// ClientIPFromIncomingContext returns the client address forwarded by the
+// caller of a gRPC call.
+func ClientIPFromIncomingContext(ctx context.Context) (string, bool) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return "", false
+	}
+	values := md.Get(MetadataClientIP)
+	if len(values) != 1 || values[0] == "" {
+		return "", false
+	}
+	return values[0], true
+}
+
+// BearerFromIncomingContext returns the bearer token of a gRPC call.
+func BearerFromIncomingContext(ctx context.Context) (string, bool) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return "", false
+	}
+	values := md.Get(MetadataAuthorization)
+	if len(values) != 1 {
+		return "", false
+	}
+	token, ok := strings.CutPrefix(values[0], "Bearer ")
+	if !ok || token == "" {
+		return "", false
+	}
+	return strings.TrimSpace(token), true
+}
+
+// Forward returns a client interceptor that passes the bearer token, the
+// principal and the client address of the HTTP request on to the called
+// service, and identifies the caller as service if it has a name.
+func Forward(service Service) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if service.Name != "" {
+			ctx = metadata.AppendToOutgoingContext(ctx, MetadataServiceName, service.Name, MetadataServiceToken, service.Token)
+		}
+		if token := TokenFromContext(ctx); token != "" {
+			ctx = metadata.AppendToOutgoingContext(ctx, MetadataAuthorization, "Bearer "+token)
+		}
+		if ip := ClientIPFromContext(ctx); ip != "" {
+			ctx = metadata.AppendToOutgoingContext(ctx, MetadataClientIP, ip)
+		}
+		return invoker(AppendToOutgoingContext(ctx), method, req, reply, cc, opts...)
+	}
+}
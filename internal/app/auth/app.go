@@ -1,22 +1,52 @@
 package authapp
 
 import (
+	"context"
 	"log/slog"
 	"time"
 
 	grpcapp "github.com/tizzhh/micro-banking/internal/app/auth/grpc"
 	"github.com/tizzhh/micro-banking/internal/services/auth"
 	"github.com/tizzhh/micro-banking/internal/storage/postgres"
+	"github.com/tizzhh/micro-banking/internal/storage/redis"
+	"github.com/tizzhh/micro-banking/pkg/jwt"
+	"google.golang.org/grpc/credentials"
 )
 
 type App struct {
 	GRPCServer *grpcapp.App
 }
 
-func New(log *slog.Logger, port int, tokenTTL time.Duration, storage *postgres.Storage) *App {
-	authService := auth.New(log, tokenTTL, storage, storage, storage, storage)
+func New(
+	log *slog.Logger,
+	port int,
+	tokenTTL time.Duration,
+	refreshTTL time.Duration,
+	mfaIssuer string,
+	verification auth.Verification,
+	passwordReset auth.PasswordReset,
+	loginThrottle auth.LoginThrottle,
+	pingTimeout time.Duration,
+	keys *jwt.KeyRing,
+	trustedServices map[string]string,
+	creds credentials.TransportCredentials,
+	storage *postgres.Storage,
+) *App {
+	cache, err := redis.Get(log)
+	if err != nil {
+		panic(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), pingTimeout)
+	defer cancel()
+	if err := cache.MustPing(ctx); err != nil {
+		panic(err)
+	}
+
+	tokens := jwt.New(tokenTTL, keys)
+
+	authService := auth.New(log, tokens, refreshTTL, mfaIssuer, verification, passwordReset, loginThrottle, storage, storage, storage, storage, storage, storage, storage, storage, storage, cache, cache, cache)
 
-	grpcApp := grpcapp.New(log, port, tokenTTL, authService)
+	grpcApp := grpcapp.New(log, port, tokenTTL, authService, tokens, cache, trustedServices, creds)
 
 	return &App{
 		GRPCServer: grpcApp,
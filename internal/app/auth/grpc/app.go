@@ -7,8 +7,10 @@ import (
 	"time"
 
 	authgrpc "github.com/tizzhh/micro-banking/internal/delivery/grpc/auth"
+	"github.com/tizzhh/micro-banking/internal/delivery/grpc/interceptors"
 	"github.com/tizzhh/micro-banking/pkg/logger/sl"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 )
 
 type App struct {
@@ -17,8 +19,25 @@ type App struct {
 	port       int
 }
 
-func New(log *slog.Logger, port int, tokenTTL time.Duration, authService authgrpc.Auth) *App {
-	grpcServer := grpc.NewServer()
+func New(
+	log *slog.Logger,
+	port int,
+	tokenTTL time.Duration,
+	authService authgrpc.Auth,
+	tokenVerifier interceptors.TokenVerifier,
+	revocationChecker interceptors.TokenRevocationChecker,
+	trustedServices map[string]string,
+	creds credentials.TransportCredentials,
+) *App {
+	grpcServer := grpc.NewServer(
+		grpc.Creds(creds),
+		grpc.ChainUnaryInterceptor(
+			interceptors.Auth(log, tokenVerifier, revocationChecker, trustedServices, authgrpc.Policy),
+		),
+		grpc.ChainStreamInterceptor(
+			interceptors.AuthStream(log, tokenVerifier, revocationChecker, trustedServices, authgrpc.Policy),
+		),
+	)
 
 	authgrpc.Register(grpcServer, authService)
 
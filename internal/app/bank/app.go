@@ -1,27 +1,40 @@
 package bank
 
 import (
+	"context"
 	"log/slog"
 
+	"github.com/tizzhh/micro-banking/internal/api/principal"
 	httpapp "github.com/tizzhh/micro-banking/internal/app/bank/http"
 	authgrpc "github.com/tizzhh/micro-banking/internal/clients/auth/grpc"
 	currencygrpc "github.com/tizzhh/micro-banking/internal/clients/currency/grpc"
-	"github.com/tizzhh/micro-banking/internal/clients/kafka/producer"
 	"github.com/tizzhh/micro-banking/internal/config"
+	"github.com/tizzhh/micro-banking/internal/services/admin"
 	"github.com/tizzhh/micro-banking/internal/services/bank"
+	"github.com/tizzhh/micro-banking/internal/services/currency"
 	"github.com/tizzhh/micro-banking/internal/storage/postgres"
+	"github.com/tizzhh/micro-banking/internal/storage/redis"
+	"github.com/tizzhh/micro-banking/pkg/mtls"
 )
 
 type App struct {
 	HTTPServer *httpapp.App
 }
 
-func New(log *slog.Logger, cfg *config.Config, storage *postgres.Storage, producer *producer.Producer) *App {
+func New(log *slog.Logger, cfg *config.Config, storage *postgres.Storage) *App {
+	service := principal.Service{Name: cfg.ServiceAuth.Name, Token: cfg.ServiceAuth.Token}
+	creds, err := mtls.ClientCredentials(cfg.Clients.TLS.Options())
+	if err != nil {
+		panic(err)
+	}
+
 	authv1Client, err := authgrpc.New(
 		log,
 		cfg.Clients.AuthClient.Addr,
 		cfg.Clients.AuthClient.Timeout,
 		cfg.Clients.AuthClient.RetriesCount,
+		service,
+		creds,
 	)
 	if err != nil {
 		panic(err)
@@ -32,12 +45,38 @@ func New(log *slog.Logger, cfg *config.Config, storage *postgres.Storage, produc
 		cfg.Clients.CurrencyClient.Addr,
 		cfg.Clients.CurrencyClient.Timeout,
 		cfg.Clients.CurrencyClient.RetriesCount,
+		service,
+		creds,
 	)
 	if err != nil {
 		panic(err)
 	}
 
-	bank := bank.New(log, storage, storage, producer)
+	cache, err := redis.Get(log)
+	if err != nil {
+		panic(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.Redis.PingTimeout)
+	defer cancel()
+	if err := cache.MustPing(ctx); err != nil {
+		panic(err)
+	}
+
+	// step-up thresholds are parsed with the exponents of the catalog
+	currencies, err := storage.Currencies(ctx)
+	if err != nil {
+		panic(err)
+	}
+	currency.RegisterExponents(currencies)
+	go currency.WatchExponents(context.Background(), log, storage, cfg.CurrencyCatalog.RefreshInterval)
+
+	stepUp, err := bank.ParseStepUp(cfg.MFA.StepUpThresholds, cfg.MFA.StepUpMaxAge)
+	if err != nil {
+		panic(err)
+	}
+
+	bank := bank.New(log, storage, storage, stepUp)
+	admin := admin.New(log, storage, storage, authv1Client, storage)
 
 	app := httpapp.New(
 		log,
@@ -49,7 +88,12 @@ func New(log *slog.Logger, cfg *config.Config, storage *postgres.Storage, produc
 		cfg.Http.Port,
 		cfg.Http.ShutdownTimeout,
 		cfg.TokenTTL,
+		cfg.JWT.JWKSRefresh,
 		bank,
+		admin,
+		storage,
+		cfg.Idempotency.KeyTTL,
+		cache,
 	)
 	return &App{HTTPServer: app}
 }
@@ -12,6 +12,9 @@ import (
 	"github.com/tizzhh/micro-banking/internal/delivery/http/bank/resource/auth"
 	"github.com/tizzhh/micro-banking/internal/delivery/http/bank/resource/currency"
 	"github.com/tizzhh/micro-banking/internal/delivery/http/bank/router"
+	authentication "github.com/tizzhh/micro-banking/internal/delivery/http/bank/router/middleware/auth"
+	"github.com/tizzhh/micro-banking/internal/delivery/http/bank/router/middleware/idempotency"
+	"github.com/tizzhh/micro-banking/internal/services/admin"
 	"github.com/tizzhh/micro-banking/internal/services/bank"
 	"github.com/tizzhh/micro-banking/pkg/logger/sl"
 )
@@ -35,9 +38,14 @@ func New(
 	port int,
 	shutdownTimeout time.Duration,
 	tokenTTL time.Duration,
+	jwksRefresh time.Duration,
 	bank *bank.Bank,
+	admin *admin.Admin,
+	idempotencyStore idempotency.Store,
+	idempotencyTTL time.Duration,
+	revocationChecker authentication.TokenRevocationChecker,
 ) *App {
-	router := router.New(log, validator.New(), authClient, currencyClient, tokenTTL, bank)
+	router := router.New(log, validator.New(), authClient, currencyClient, tokenTTL, jwksRefresh, bank, admin, idempotencyStore, idempotencyTTL, revocationChecker)
 	return &App{
 		log:             log,
 		readTimeout:     readTimeout,
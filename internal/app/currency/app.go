@@ -6,18 +6,33 @@ import (
 	"time"
 
 	grpcapp "github.com/tizzhh/micro-banking/internal/app/currency/grpc"
-	"github.com/tizzhh/micro-banking/internal/clients/kafka/producer"
 	"github.com/tizzhh/micro-banking/internal/services/currency"
 	"github.com/tizzhh/micro-banking/internal/storage/postgres"
 	"github.com/tizzhh/micro-banking/internal/storage/redis"
 	"github.com/tizzhh/micro-banking/pkg/currencyapi"
+	"github.com/tizzhh/micro-banking/pkg/jwt"
+	"github.com/tizzhh/micro-banking/pkg/pricing"
+	"google.golang.org/grpc/credentials"
 )
 
 type App struct {
 	GRPCServer *grpcapp.App
 }
 
-func New(log *slog.Logger, port int, pingTimeout time.Duration, ratesApiTimeout time.Duration, storage *postgres.Storage, producer *producer.Producer) *App {
+func New(
+	log *slog.Logger,
+	port int,
+	pingTimeout time.Duration,
+	ratesApiTimeout time.Duration,
+	idempotencyTTL time.Duration,
+	catalogRefresh time.Duration,
+	quoteTTL time.Duration,
+	pricingCfg pricing.Config,
+	tokenVerifier *jwt.JWT,
+	trustedServices map[string]string,
+	creds credentials.TransportCredentials,
+	storage *postgres.Storage,
+) *App {
 	cache, err := redis.Get(log)
 	if err != nil {
 		panic(err)
@@ -28,11 +43,23 @@ func New(log *slog.Logger, port int, pingTimeout time.Duration, ratesApiTimeout
 		panic(err)
 	}
 
+	currencies, err := storage.Currencies(ctx)
+	if err != nil {
+		panic(err)
+	}
+	currency.RegisterExponents(currencies)
+	go currency.WatchExponents(context.Background(), log, storage, catalogRefresh)
+
 	ratesQuerier := currencyapi.New(log, ratesApiTimeout)
 
-	currencyService := currency.New(log, storage, storage, cache, ratesQuerier)
+	pricer, err := pricing.New(pricingCfg)
+	if err != nil {
+		panic(err)
+	}
+
+	currencyService := currency.New(log, storage, storage, cache, ratesQuerier, storage, storage, cache, quoteTTL, pricer, storage)
 
-	grpcApp := grpcapp.New(log, port, currencyService, producer)
+	grpcApp := grpcapp.New(log, port, currencyService, storage, idempotencyTTL, tokenVerifier, cache, trustedServices, creds)
 
 	return &App{
 		GRPCServer: grpcApp,
@@ -4,10 +4,14 @@ import (
 	"fmt"
 	"log/slog"
 	"net"
+	"time"
 
+	currencyv1 "github.com/tizzhh/micro-banking/gen/go/protos/proto/currency"
 	currencygrpc "github.com/tizzhh/micro-banking/internal/delivery/grpc/currency"
+	"github.com/tizzhh/micro-banking/internal/delivery/grpc/interceptors"
 	"github.com/tizzhh/micro-banking/pkg/logger/sl"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 )
 
 type App struct {
@@ -16,10 +20,36 @@ type App struct {
 	port       int
 }
 
-func New(log *slog.Logger, port int, currencyService currencygrpc.Currency, producer currencygrpc.Producer) *App {
-	grpcServer := grpc.NewServer()
-
-	currencygrpc.Register(grpcServer, currencyService, producer, log)
+func New(
+	log *slog.Logger,
+	port int,
+	currencyService currencygrpc.Currency,
+	idempotencyStore interceptors.IdempotencyStore,
+	idempotencyTTL time.Duration,
+	tokenVerifier interceptors.TokenVerifier,
+	revocationChecker interceptors.TokenRevocationChecker,
+	trustedServices map[string]string,
+	creds credentials.TransportCredentials,
+) *App {
+	grpcServer := grpc.NewServer(
+		grpc.Creds(creds),
+		grpc.ChainUnaryInterceptor(
+			interceptors.Auth(log, tokenVerifier, revocationChecker, trustedServices, currencygrpc.Policy),
+			interceptors.Idempotency(
+				log,
+				idempotencyStore,
+				idempotencyTTL,
+				currencyv1.Currency_Buy_FullMethodName,
+				currencyv1.Currency_Sell_FullMethodName,
+				currencyv1.Currency_Convert_FullMethodName,
+			),
+		),
+		grpc.ChainStreamInterceptor(
+			interceptors.AuthStream(log, tokenVerifier, revocationChecker, trustedServices, currencygrpc.Policy),
+		),
+	)
+
+	currencygrpc.Register(grpcServer, currencyService, log)
 
 	return &App{
 		log:        log,
@@ -9,9 +9,10 @@ import (
 	grpclog "github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/logging"
 	grpcretry "github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/retry"
 	authv1 "github.com/tizzhh/micro-banking/gen/go/protos/proto/auth"
+	"github.com/tizzhh/micro-banking/internal/api/principal"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/credentials"
 )
 
 type Client struct {
@@ -19,7 +20,7 @@ type Client struct {
 	log *slog.Logger
 }
 
-func New(log *slog.Logger, addr string, timeout time.Duration, retriesCount int) (*Client, error) {
+func New(log *slog.Logger, addr string, timeout time.Duration, retriesCount int, service principal.Service, creds credentials.TransportCredentials) (*Client, error) {
 	const caller = "clients.auth.grpc.New"
 
 	retryOpts := []grpcretry.CallOption{
@@ -33,9 +34,10 @@ func New(log *slog.Logger, addr string, timeout time.Duration, retriesCount int)
 	}
 
 	grpc, err := grpc.NewClient(addr,
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithTransportCredentials(creds),
 		grpc.WithChainUnaryInterceptor(
 			grpclog.UnaryClientInterceptor(InterceptorLogger(log), logOpts...),
+			principal.Forward(service),
 			grpcretry.UnaryClientInterceptor(retryOpts...),
 		),
 	)
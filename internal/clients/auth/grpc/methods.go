@@ -6,6 +6,7 @@ import (
 
 	authv1 "github.com/tizzhh/micro-banking/gen/go/protos/proto/auth"
 	"github.com/tizzhh/micro-banking/internal/delivery/http/bank/resource/auth"
+	"github.com/tizzhh/micro-banking/pkg/jwt"
 	"github.com/tizzhh/micro-banking/pkg/logger/sl"
 )
 
@@ -28,7 +29,7 @@ func (c *Client) Register(ctx context.Context, email string, password string, fi
 	return resp.GetUserId(), nil
 }
 
-func (c *Client) Login(ctx context.Context, email string, password string) (string, error) {
+func (c *Client) Login(ctx context.Context, email string, password string) (auth.LoginResponse, error) {
 	const caller = "clients.auth.grpc.Login"
 	log := sl.AddCaller(c.log, caller)
 	log.Info("getting token for user")
@@ -38,10 +39,47 @@ func (c *Client) Login(ctx context.Context, email string, password string) (stri
 	})
 	if err != nil {
 		log.Error("failed to get token", sl.Error(err))
-		return "", fmt.Errorf("%s: %w", caller, err)
+		return auth.LoginResponse{}, fmt.Errorf("%s: %w", caller, err)
 	}
 
-	return resp.GetToken(), nil
+	return auth.LoginResponse{
+		Token:        resp.GetToken(),
+		RefreshToken: resp.GetRefreshToken(),
+		MFAToken:     resp.GetMfaToken(),
+	}, nil
+}
+
+func (c *Client) Refresh(ctx context.Context, refreshToken string) (auth.LoginResponse, error) {
+	const caller = "clients.auth.grpc.Refresh"
+	log := sl.AddCaller(c.log, caller)
+	log.Info("refreshing tokens")
+	resp, err := c.api.Refresh(ctx, &authv1.RefreshRequest{
+		RefreshToken: refreshToken,
+	})
+	if err != nil {
+		log.Error("failed to refresh tokens", sl.Error(err))
+		return auth.LoginResponse{}, fmt.Errorf("%s: %w", caller, err)
+	}
+
+	return auth.LoginResponse{
+		Token:        resp.GetToken(),
+		RefreshToken: resp.GetRefreshToken(),
+	}, nil
+}
+
+func (c *Client) Logout(ctx context.Context, token string, refreshToken string) error {
+	const caller = "clients.auth.grpc.Logout"
+	log := sl.AddCaller(c.log, caller)
+	log.Info("logging user out")
+	_, err := c.api.Logout(ctx, &authv1.LogoutRequest{
+		Token:        token,
+		RefreshToken: refreshToken,
+	})
+	if err != nil {
+		log.Error("failed to logout user", sl.Error(err))
+		return fmt.Errorf("%s: %w", caller, err)
+	}
+	return nil
 }
 
 func (c *Client) UpdatePassword(ctx context.Context, email string, oldPassword string, newPassword string) error {
@@ -86,11 +124,250 @@ func (c *Client) User(ctx context.Context, email string) (auth.UserResponse, err
 		log.Error("failed to get user", sl.Error(err))
 		return auth.UserResponse{}, fmt.Errorf("%s: %w", caller, err)
 	}
+	return toUserResponse(resp), nil
+}
+
+func toUserResponse(resp *authv1.UserResponse) auth.UserResponse {
+	createdAt := resp.GetCreatedAt().AsTime()
+	updatedAt := resp.GetUpdatedAt().AsTime()
 	return auth.UserResponse{
-		Email:     resp.GetEmail(),
-		FirstName: resp.GetFirstName(),
-		LastName:  resp.GetLastName(),
-		Balance:   resp.GetBalance(),
-		Age:       resp.GetAge(),
+		ID:            resp.GetUserId(),
+		Email:         resp.GetEmail(),
+		FirstName:     resp.GetFirstName(),
+		LastName:      resp.GetLastName(),
+		Balance:       resp.GetBalance(),
+		Age:           resp.GetAge(),
+		EmailVerified: resp.GetEmailVerified(),
+		PhoneNumber:   resp.GetPhoneNumber(),
+		Status:        resp.GetStatus(),
+		CreatedAt:     &createdAt,
+		UpdatedAt:     &updatedAt,
+	}
+}
+
+func (c *Client) JWKS(ctx context.Context) (jwt.JWKS, error) {
+	const caller = "clients.auth.grpc.JWKS"
+	log := sl.AddCaller(c.log, caller)
+	log.Info("getting token verification keys")
+	resp, err := c.api.JWKS(ctx, &authv1.JWKSRequest{})
+	if err != nil {
+		log.Error("failed to get keys", sl.Error(err))
+		return jwt.JWKS{}, fmt.Errorf("%s: %w", caller, err)
+	}
+
+	jwks := jwt.JWKS{Keys: make([]jwt.JWK, 0, len(resp.GetKeys()))}
+	for _, key := range resp.GetKeys() {
+		jwks.Keys = append(jwks.Keys, jwt.JWK{
+			Kty: key.GetKty(),
+			Kid: key.GetKid(),
+			Use: key.GetUse(),
+			Alg: key.GetAlg(),
+			N:   key.GetN(),
+			E:   key.GetE(),
+			Crv: key.GetCrv(),
+			X:   key.GetX(),
+		})
+	}
+	return jwks, nil
+}
+
+func (c *Client) EnrollMFA(ctx context.Context, email string) (auth.EnrollMFAResponse, error) {
+	const caller = "clients.auth.grpc.EnrollMFA"
+	log := sl.AddCaller(c.log, caller)
+	log.Info("enrolling mfa")
+	resp, err := c.api.EnrollMFA(ctx, &authv1.EnrollMFARequest{
+		Email: email,
+	})
+	if err != nil {
+		log.Error("failed to enroll mfa", sl.Error(err))
+		return auth.EnrollMFAResponse{}, fmt.Errorf("%s: %w", caller, err)
+	}
+	return auth.EnrollMFAResponse{
+		Secret: resp.GetSecret(),
+		URI:    resp.GetUri(),
+	}, nil
+}
+
+func (c *Client) ConfirmMFA(ctx context.Context, email string, code string) ([]string, error) {
+	const caller = "clients.auth.grpc.ConfirmMFA"
+	log := sl.AddCaller(c.log, caller)
+	log.Info("confirming mfa")
+	resp, err := c.api.ConfirmMFA(ctx, &authv1.ConfirmMFARequest{
+		Email: email,
+		Code:  code,
+	})
+	if err != nil {
+		log.Error("failed to confirm mfa", sl.Error(err))
+		return nil, fmt.Errorf("%s: %w", caller, err)
+	}
+	return resp.GetRecoveryCodes(), nil
+}
+
+func (c *Client) DisableMFA(ctx context.Context, email string, password string, code string) error {
+	const caller = "clients.auth.grpc.DisableMFA"
+	log := sl.AddCaller(c.log, caller)
+	log.Info("disabling mfa")
+	_, err := c.api.DisableMFA(ctx, &authv1.DisableMFARequest{
+		Email:    email,
+		Password: password,
+		Code:     code,
+	})
+	if err != nil {
+		log.Error("failed to disable mfa", sl.Error(err))
+		return fmt.Errorf("%s: %w", caller, err)
+	}
+	return nil
+}
+
+func (c *Client) RegenerateRecoveryCodes(ctx context.Context, email string, code string) ([]string, error) {
+	const caller = "clients.auth.grpc.RegenerateRecoveryCodes"
+	log := sl.AddCaller(c.log, caller)
+	log.Info("regenerating recovery codes")
+	resp, err := c.api.RegenerateRecoveryCodes(ctx, &authv1.RegenerateRecoveryCodesRequest{
+		Email: email,
+		Code:  code,
+	})
+	if err != nil {
+		log.Error("failed to regenerate recovery codes", sl.Error(err))
+		return nil, fmt.Errorf("%s: %w", caller, err)
+	}
+	return resp.GetRecoveryCodes(), nil
+}
+
+func (c *Client) VerifyMFA(ctx context.Context, mfaToken string, code string) (auth.LoginResponse, error) {
+	const caller = "clients.auth.grpc.VerifyMFA"
+	log := sl.AddCaller(c.log, caller)
+	log.Info("verifying mfa")
+	resp, err := c.api.VerifyMFA(ctx, &authv1.VerifyMFARequest{
+		MfaToken: mfaToken,
+		Code:     code,
+	})
+	if err != nil {
+		log.Error("failed to verify mfa", sl.Error(err))
+		return auth.LoginResponse{}, fmt.Errorf("%s: %w", caller, err)
+	}
+	return auth.LoginResponse{
+		Token:        resp.GetToken(),
+		RefreshToken: resp.GetRefreshToken(),
 	}, nil
 }
+
+func (c *Client) StepUp(ctx context.Context, email string, code string) (string, error) {
+	const caller = "clients.auth.grpc.StepUp"
+	log := sl.AddCaller(c.log, caller)
+	log.Info("stepping up authentication")
+	resp, err := c.api.StepUp(ctx, &authv1.StepUpRequest{
+		Email: email,
+		Code:  code,
+	})
+	if err != nil {
+		log.Error("failed to step up authentication", sl.Error(err))
+		return "", fmt.Errorf("%s: %w", caller, err)
+	}
+	return resp.GetToken(), nil
+}
+
+func (c *Client) VerifyEmail(ctx context.Context, token string) error {
+	const caller = "clients.auth.grpc.VerifyEmail"
+	log := sl.AddCaller(c.log, caller)
+	log.Info("verifying email")
+	_, err := c.api.VerifyEmail(ctx, &authv1.VerifyEmailRequest{
+		Token: token,
+	})
+	if err != nil {
+		log.Error("failed to verify email", sl.Error(err))
+		return fmt.Errorf("%s: %w", caller, err)
+	}
+	return nil
+}
+
+func (c *Client) ResendVerification(ctx context.Context, email string) error {
+	const caller = "clients.auth.grpc.ResendVerification"
+	log := sl.AddCaller(c.log, caller)
+	log.Info("resending verification mail")
+	_, err := c.api.ResendVerification(ctx, &authv1.ResendVerificationRequest{
+		Email: email,
+	})
+	if err != nil {
+		log.Error("failed to resend verification mail", sl.Error(err))
+		return fmt.Errorf("%s: %w", caller, err)
+	}
+	return nil
+}
+
+func (c *Client) RequestPasswordReset(ctx context.Context, email string) error {
+	const caller = "clients.auth.grpc.RequestPasswordReset"
+	log := sl.AddCaller(c.log, caller)
+	log.Info("requesting password reset")
+	_, err := c.api.RequestPasswordReset(ctx, &authv1.RequestPasswordResetRequest{
+		Email: email,
+	})
+	if err != nil {
+		log.Error("failed to request password reset", sl.Error(err))
+		return fmt.Errorf("%s: %w", caller, err)
+	}
+	return nil
+}
+
+func (c *Client) ResetPassword(ctx context.Context, token string, newPassword string) error {
+	const caller = "clients.auth.grpc.ResetPassword"
+	log := sl.AddCaller(c.log, caller)
+	log.Info("resetting password")
+	_, err := c.api.ResetPassword(ctx, &authv1.ResetPasswordRequest{
+		Token:       token,
+		NewPassword: newPassword,
+	})
+	if err != nil {
+		log.Error("failed to reset password", sl.Error(err))
+		return fmt.Errorf("%s: %w", caller, err)
+	}
+	return nil
+}
+
+func (c *Client) UnlockAccount(ctx context.Context, email string) error {
+	const caller = "clients.auth.grpc.UnlockAccount"
+	log := sl.AddCaller(c.log, caller)
+	log.Info("unlocking account")
+	_, err := c.api.UnlockAccount(ctx, &authv1.UnlockAccountRequest{
+		Email: email,
+	})
+	if err != nil {
+		log.Error("failed to unlock account", sl.Error(err))
+		return fmt.Errorf("%s: %w", caller, err)
+	}
+	return nil
+}
+
+func (c *Client) UpdateProfile(ctx context.Context, email string, update auth.UpdateProfileRequest) (auth.UserResponse, error) {
+	const caller = "clients.auth.grpc.UpdateProfile"
+	log := sl.AddCaller(c.log, caller)
+	log.Info("updating profile")
+	resp, err := c.api.UpdateProfile(ctx, &authv1.UpdateProfileRequest{
+		Email:       email,
+		FirstName:   update.FirstName,
+		LastName:    update.LastName,
+		Age:         update.Age,
+		PhoneNumber: update.PhoneNumber,
+	})
+	if err != nil {
+		log.Error("failed to update profile", sl.Error(err))
+		return auth.UserResponse{}, fmt.Errorf("%s: %w", caller, err)
+	}
+	return toUserResponse(resp), nil
+}
+
+func (c *Client) ChangeEmail(ctx context.Context, email string, password string, newEmail string) error {
+	const caller = "clients.auth.grpc.ChangeEmail"
+	log := sl.AddCaller(c.log, caller)
+	log.Info("changing email")
+	_, err := c.api.ChangeEmail(ctx, &authv1.ChangeEmailRequest{
+		Email:    email,
+		Password: password,
+		NewEmail: newEmail,
+	})
+	if err != nil {
+		log.Error("failed to change email", sl.Error(err))
+		return fmt.Errorf("%s: %w", caller, err)
+	}
+	return nil
+}
@@ -9,9 +9,12 @@ import (
 	grpclog "github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/logging"
 	grpcretry "github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/retry"
 	currencyv1 "github.com/tizzhh/micro-banking/gen/go/protos/proto/currency"
+	"github.com/tizzhh/micro-banking/internal/api/idempotency"
+	"github.com/tizzhh/micro-banking/internal/api/principal"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
 )
 
 type Client struct {
@@ -19,7 +22,7 @@ type Client struct {
 	log *slog.Logger
 }
 
-func New(log *slog.Logger, addr string, timeout time.Duration, retriesCount int) (*Client, error) {
+func New(log *slog.Logger, addr string, timeout time.Duration, retriesCount int, service principal.Service, creds credentials.TransportCredentials) (*Client, error) {
 	const caller = "clients.auth.grpc.New"
 
 	retryOpts := []grpcretry.CallOption{
@@ -33,9 +36,11 @@ func New(log *slog.Logger, addr string, timeout time.Duration, retriesCount int)
 	}
 
 	grpc, err := grpc.NewClient(addr,
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithTransportCredentials(creds),
 		grpc.WithChainUnaryInterceptor(
 			grpclog.UnaryClientInterceptor(InterceptorLogger(log), logOpts...),
+			forwardIdempotencyKey,
+			principal.Forward(service),
 			grpcretry.UnaryClientInterceptor(retryOpts...),
 		),
 	)
@@ -47,6 +52,15 @@ func New(log *slog.Logger, addr string, timeout time.Duration, retriesCount int)
 	return &Client{api: currencyv1.NewCurrencyClient(grpc), log: log}, nil
 }
 
+// forwardIdempotencyKey passes the key of the HTTP request on, so that
+// retries of a call are deduplicated by the currency service as well.
+func forwardIdempotencyKey(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	if key, ok := idempotency.KeyFromContext(ctx); ok {
+		ctx = metadata.AppendToOutgoingContext(ctx, idempotency.MetadataKey, key)
+	}
+	return invoker(ctx, method, req, reply, cc, opts...)
+}
+
 func InterceptorLogger(log *slog.Logger) grpclog.Logger {
 	return grpclog.LoggerFunc(func(ctx context.Context, level grpclog.Level, msg string, fields ...any) {
 		log.Log(ctx, slog.Level(level), msg, fields...)
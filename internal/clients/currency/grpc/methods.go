@@ -7,38 +7,98 @@ import (
 	currencyv1 "github.com/tizzhh/micro-banking/gen/go/protos/proto/currency"
 	currencyResponse "github.com/tizzhh/micro-banking/internal/delivery/http/bank/resource/currency"
 	"github.com/tizzhh/micro-banking/pkg/logger/sl"
+	"github.com/tizzhh/micro-banking/pkg/money"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
-func (c *Client) Buy(ctx context.Context, email string, currencyCode string, amount uint64) (float32, error) {
+const (
+	baseCurrencyCode = "USD"
+)
+
+// Quote returns the price of buying or selling amount, which is held until
+// the quote expires.
+func (c *Client) Quote(ctx context.Context, email string, amount money.Money, side string) (currencyResponse.QuoteResponse, error) {
+	const caller = "clients.currency.grpc.Quote"
+	log := sl.AddCaller(c.log, caller)
+	log.Info("quoting currency")
+	resp, err := c.api.Quote(ctx, &currencyv1.QuoteRequest{
+		Email:        email,
+		CurrencyCode: amount.Currency(),
+		Amount:       amount.Amount(),
+		Side:         side,
+	})
+	if err != nil {
+		log.Error("failed to quote currency", sl.Error(err))
+		return currencyResponse.QuoteResponse{}, fmt.Errorf("%s: %w", caller, err)
+	}
+	return currencyResponse.QuoteResponse{
+		QuoteID:      resp.GetQuoteId(),
+		Side:         resp.GetSide(),
+		Amount:       money.New(resp.GetAmount(), resp.GetCurrencyCode()).String(),
+		CurrencyCode: resp.GetCurrencyCode(),
+		Cost:         money.New(resp.GetCost(), baseCurrencyCode).String(),
+		Rate:         resp.GetRate(),
+		ExpiresAt:    resp.GetExpiresAt().AsTime(),
+		Fees:         toFees(resp.GetFees()),
+	}, nil
+}
+
+func (c *Client) Buy(ctx context.Context, email string, amount money.Money, quoteID string) (money.Money, currencyResponse.Fees, error) {
 	const caller = "clients.currency.grpc.Buy"
 	log := sl.AddCaller(c.log, caller)
 	log.Info("buying currency")
 	resp, err := c.api.Buy(ctx, &currencyv1.BuyRequest{
 		Email:        email,
-		CurrencyCode: currencyCode,
-		Amount:       amount,
+		CurrencyCode: amount.Currency(),
+		Amount:       amount.Amount(),
+		QuoteId:      quoteID,
 	})
 	if err != nil {
 		log.Error("failed to buy currency", sl.Error(err))
-		return 0, fmt.Errorf("%s: %w", caller, err)
+		return money.Money{}, currencyResponse.Fees{}, fmt.Errorf("%s: %w", caller, err)
 	}
-	return resp.GetBought(), nil
+	return money.New(resp.GetCost(), baseCurrencyCode), toFees(resp.GetFees()), nil
 }
 
-func (c *Client) Sell(ctx context.Context, email string, currencyCode string, amount uint64) (float32, error) {
+func (c *Client) Sell(ctx context.Context, email string, amount money.Money, quoteID string) (money.Money, currencyResponse.Fees, error) {
 	const caller = "clients.currency.grpc.Sell"
 	log := sl.AddCaller(c.log, caller)
 	log.Info("selling currency")
 	resp, err := c.api.Sell(ctx, &currencyv1.SellRequest{
 		Email:        email,
-		CurrencyCode: currencyCode,
-		Amount:       amount,
+		CurrencyCode: amount.Currency(),
+		Amount:       amount.Amount(),
+		QuoteId:      quoteID,
 	})
 	if err != nil {
 		log.Error("failed to sell currency", sl.Error(err))
-		return 0, fmt.Errorf("%s: %w", caller, err)
+		return money.Money{}, currencyResponse.Fees{}, fmt.Errorf("%s: %w", caller, err)
+	}
+	return money.New(resp.GetProceeds(), baseCurrencyCode), toFees(resp.GetFees()), nil
+}
+
+// Convert returns the amount of the currency to credited for amount and the
+// applied rate.
+func (c *Client) Convert(ctx context.Context, email string, amount money.Money, to string) (money.Money, money.Rate, error) {
+	const caller = "clients.currency.grpc.Convert"
+	log := sl.AddCaller(c.log, caller)
+	log.Info("converting currency")
+	resp, err := c.api.Convert(ctx, &currencyv1.ConvertRequest{
+		Email:            email,
+		FromCurrencyCode: amount.Currency(),
+		ToCurrencyCode:   to,
+		Amount:           amount.Amount(),
+	})
+	if err != nil {
+		log.Error("failed to convert currency", sl.Error(err))
+		return money.Money{}, money.Rate{}, fmt.Errorf("%s: %w", caller, err)
 	}
-	return resp.GetSold(), nil
+	rate, err := money.ParseRate(resp.GetRate())
+	if err != nil {
+		log.Error("failed to parse rate", sl.Error(err))
+		return money.Money{}, money.Rate{}, fmt.Errorf("%s: %w", caller, err)
+	}
+	return money.New(resp.GetConverted(), to), rate, nil
 }
 
 func (c *Client) Wallets(ctx context.Context, email string) (currencyResponse.WalletResponse, error) {
@@ -58,9 +118,140 @@ func (c *Client) Wallets(ctx context.Context, email string) (currencyResponse.Wa
 	for _, currency := range userWalletResp {
 		userWallet = append(userWallet, currencyResponse.Wallet{
 			CurrencyCode: currency.GetCurrencyCode(),
-			Balance:      currency.GetBalance(),
+			Balance:      money.New(int64(currency.GetBalance()), currency.GetCurrencyCode()).String(),
 		})
 	}
 
 	return currencyResponse.WalletResponse{Wallets: userWallet}, nil
 }
+
+func (c *Client) Transactions(ctx context.Context, email string, filter currencyResponse.TransactionsFilter) (currencyResponse.TransactionsResponse, error) {
+	const caller = "clients.currency.grpc.Transactions"
+	log := sl.AddCaller(c.log, caller)
+	log.Info("getting user transactions")
+
+	req := &currencyv1.TransactionsRequest{
+		Email:        email,
+		Types:        filter.Types,
+		CurrencyCode: filter.CurrencyCode,
+		Order:        filter.Order,
+		Cursor:       filter.Cursor,
+		Limit:        filter.Limit,
+	}
+	if !filter.From.IsZero() {
+		req.From = timestamppb.New(filter.From)
+	}
+	if !filter.To.IsZero() {
+		req.To = timestamppb.New(filter.To)
+	}
+
+	resp, err := c.api.Transactions(ctx, req)
+	if err != nil {
+		log.Error("failed to get user's transactions", sl.Error(err))
+		return currencyResponse.TransactionsResponse{}, fmt.Errorf("%s: %w", caller, err)
+	}
+
+	transactionsResp := resp.GetTransactions()
+	transactions := make([]currencyResponse.Transaction, 0, len(transactionsResp))
+	for _, transaction := range transactionsResp {
+		transactions = append(transactions, currencyResponse.Transaction{
+			TransactionID: transaction.GetTransactionId(),
+			Type:          transaction.GetType(),
+			CurrencyCode:  transaction.GetCurrencyCode(),
+			Direction:     transaction.GetDirection(),
+			Amount:        money.New(int64(transaction.GetAmount()), transaction.GetCurrencyCode()).String(),
+			CreatedAt:     transaction.GetCreatedAt().AsTime(),
+		})
+	}
+
+	return currencyResponse.TransactionsResponse{Transactions: transactions, NextCursor: resp.GetNextCursor()}, nil
+}
+
+// ListCurrencies returns the currency catalog. Its exponents are registered
+// so that amounts of new currencies are formatted right.
+func (c *Client) ListCurrencies(ctx context.Context) (currencyResponse.CurrenciesResponse, error) {
+	const caller = "clients.currency.grpc.ListCurrencies"
+	log := sl.AddCaller(c.log, caller)
+	log.Info("listing currencies")
+	resp, err := c.api.ListCurrencies(ctx, &currencyv1.ListCurrenciesRequest{})
+	if err != nil {
+		log.Error("failed to list currencies", sl.Error(err))
+		return currencyResponse.CurrenciesResponse{}, fmt.Errorf("%s: %w", caller, err)
+	}
+
+	catalog := resp.GetCurrencies()
+	currencies := make([]currencyResponse.Currency, 0, len(catalog))
+	for _, entry := range catalog {
+		money.SetExponent(entry.GetCode(), int(entry.GetExponent()))
+		currency := currencyResponse.Currency{
+			Code:     entry.GetCode(),
+			Name:     entry.GetName(),
+			Exponent: int(entry.GetExponent()),
+			Enabled:  entry.GetEnabled(),
+			Tradable: entry.GetTradable(),
+			MinOrder: money.New(int64(entry.GetMinOrder()), entry.GetCode()).String(),
+		}
+		if entry.GetMaxOrder() > 0 {
+			currency.MaxOrder = money.New(int64(entry.GetMaxOrder()), entry.GetCode()).String()
+		}
+		currencies = append(currencies, currency)
+	}
+
+	return currencyResponse.CurrenciesResponse{Currencies: currencies}, nil
+}
+
+// RateHistory returns the rates of a currency and their candles.
+func (c *Client) RateHistory(ctx context.Context, filter currencyResponse.RateHistoryFilter) (currencyResponse.RateHistoryResponse, error) {
+	const caller = "clients.currency.grpc.RateHistory"
+	log := sl.AddCaller(c.log, caller)
+	log.Info("getting rate history")
+
+	req := &currencyv1.RateHistoryRequest{
+		CurrencyCode: filter.CurrencyCode,
+		Interval:     filter.Interval,
+	}
+	if !filter.From.IsZero() {
+		req.From = timestamppb.New(filter.From)
+	}
+	if !filter.To.IsZero() {
+		req.To = timestamppb.New(filter.To)
+	}
+
+	resp, err := c.api.RateHistory(ctx, req)
+	if err != nil {
+		log.Error("failed to get rate history", sl.Error(err))
+		return currencyResponse.RateHistoryResponse{}, fmt.Errorf("%s: %w", caller, err)
+	}
+
+	rates := make([]currencyResponse.RatePoint, 0, len(resp.GetRates()))
+	for _, rate := range resp.GetRates() {
+		rates = append(rates, currencyResponse.RatePoint{
+			Rate:      rate.GetRate(),
+			Source:    rate.GetSource(),
+			FetchedAt: rate.GetFetchedAt().AsTime(),
+		})
+	}
+	candles := make([]currencyResponse.RateCandle, 0, len(resp.GetCandles()))
+	for _, candle := range resp.GetCandles() {
+		candles = append(candles, currencyResponse.RateCandle{
+			Start:   candle.GetStart().AsTime(),
+			Open:    candle.GetOpen(),
+			High:    candle.GetHigh(),
+			Low:     candle.GetLow(),
+			Close:   candle.GetClose(),
+			Samples: candle.GetSamples(),
+		})
+	}
+
+	return currencyResponse.RateHistoryResponse{CurrencyCode: resp.GetCurrencyCode(), Rates: rates, Candles: candles}, nil
+}
+
+func toFees(fees *currencyv1.Fees) currencyResponse.Fees {
+	return currencyResponse.Fees{
+		MidRate: fees.GetMidRate(),
+		Rate:    fees.GetRate(),
+		Gross:   money.New(fees.GetGross(), baseCurrencyCode).String(),
+		Spread:  money.New(fees.GetSpread(), baseCurrencyCode).String(),
+		Fee:     money.New(fees.GetFee(), baseCurrencyCode).String(),
+	}
+}
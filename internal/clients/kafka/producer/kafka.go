@@ -17,11 +17,17 @@ type Producer struct {
 	topic    string
 }
 
+// New returns a producer whose sends return once all in-sync replicas have
+// stored the message. The outbox relay marks a message sent when Produce
+// returns, so its at-least-once delivery relies on this; retries are
+// idempotent, so they do not duplicate messages on the broker.
 func New(log *slog.Logger, brokers []string, cfg config.KafkaProducer, topic string) (*Producer, error) {
 	config := sarama.NewConfig()
 	config.Producer.Return.Successes = cfg.ReturnSuccesses
-	config.Producer.RequiredAcks = sarama.RequiredAcks(cfg.RequiredAcks)
+	config.Producer.RequiredAcks = sarama.WaitForAll
+	config.Producer.Idempotent = true
 	config.Producer.Retry.Max = cfg.RetryMax
+	config.Net.MaxOpenRequests = 1
 
 	producer, err := sarama.NewSyncProducer(brokers, config)
 	if err != nil {
@@ -39,7 +45,8 @@ func (p *Producer) Produce(emailAddr string, msg string) error {
 	const caller = "clients.kafka.producer.Produce"
 	log := sl.AddCaller(p.log, caller)
 
-	log.Info("producing message", slog.String("addr", emailAddr), slog.String("msg", msg))
+	// messages may carry verification and password reset tokens
+	log.Info("producing message", slog.String("addr", emailAddr))
 
 	newMessage := &kafka.Message{
 		EmailAddr: emailAddr,
@@ -6,11 +6,14 @@ import (
 	"time"
 
 	"github.com/ilyakaznacheev/cleanenv"
+	"github.com/tizzhh/micro-banking/pkg/mtls"
+	"github.com/tizzhh/micro-banking/pkg/pricing"
 )
 
 type Config struct {
 	Env         string        `yaml:"env" env-required:"true"`
 	TokenTTL    time.Duration `yaml:"token_ttl" env-default:"1h"`
+	RefreshTTL  time.Duration `yaml:"refresh_token_ttl" env-default:"720h"`
 	GRPC        GRPCConfig    `yaml:"grpc" env-required:"true"`
 	DB          DBConfig      `yaml:"db" env-required:"true"`
 	Redis       RedisConfig   `yaml:"redis" env-required:"true"`
@@ -19,7 +22,149 @@ type Config struct {
 	Clients     Clients       `yaml:"clients" env-required:"true"`
 	Http        Http          `yaml:"http" env-required:"true"`
 	Kafka       Kafka         `yaml:"kafka" env-required:"true"`
-	SecretKey   string        `yaml:"secret_key" env-required:"true"`
+	JWT         JWT           `yaml:"jwt"`
+	Idempotency Idempotency   `yaml:"idempotency"`
+	Outbox      Outbox        `yaml:"outbox"`
+	ServiceAuth ServiceAuth   `yaml:"service_auth"`
+	MFA         MFA           `yaml:"mfa"`
+	// EmailVerification configures the verification of the email
+	// addresses of new users.
+	EmailVerification EmailVerification `yaml:"email_verification"`
+	PasswordReset     PasswordReset     `yaml:"password_reset"`
+	LoginThrottle     LoginThrottle     `yaml:"login_throttle"`
+	Retention         Retention         `yaml:"retention"`
+	CurrencyCatalog   CurrencyCatalog   `yaml:"currency_catalog"`
+	Quote             Quote             `yaml:"quote"`
+	Pricing           Pricing           `yaml:"pricing"`
+}
+
+// Pricing configures the spread around the mid rate the bank buys and sells
+// currency at, in basis points per side, and the commission on orders.
+// Amounts are in USD cents. Without it currency trades at the mid rate for
+// free.
+type Pricing struct {
+	DefaultSpreadBps int64            `yaml:"default_spread_bps" env-default:"0"`
+	SpreadBps        map[string]int64 `yaml:"spread_bps"`
+	FeeTiers         []FeeTier        `yaml:"fee_tiers"`
+	MinFeeCents      int64            `yaml:"min_fee_cents" env-default:"0"`
+}
+
+// FeeTier charges Bps of orders worth up to UpToCents, without a limit if it
+// is zero.
+type FeeTier struct {
+	UpToCents int64 `yaml:"up_to_cents"`
+	Bps       int64 `yaml:"bps"`
+}
+
+func (p Pricing) Options() pricing.Config {
+	tiers := make([]pricing.FeeTier, 0, len(p.FeeTiers))
+	for _, tier := range p.FeeTiers {
+		tiers = append(tiers, pricing.FeeTier{UpTo: tier.UpToCents, Bps: tier.Bps})
+	}
+	return pricing.Config{
+		DefaultSpreadBps: p.DefaultSpreadBps,
+		SpreadBps:        p.SpreadBps,
+		FeeTiers:         tiers,
+		MinFee:           p.MinFeeCents,
+	}
+}
+
+// Quote configures how long a quoted FX price is held for the user to buy
+// or sell at.
+type Quote struct {
+	TTL time.Duration `yaml:"ttl" env-default:"30s"`
+}
+
+// CurrencyCatalog configures how often the services reload the currency
+// catalog managed by admins.
+type CurrencyCatalog struct {
+	RefreshInterval time.Duration `yaml:"refresh_interval" env-default:"1m"`
+}
+
+// Retention configures the job that anonymizes closed accounts once Period
+// has passed since they were closed.
+type Retention struct {
+	Period       time.Duration `yaml:"period" env-default:"43800h"`
+	PollInterval time.Duration `yaml:"poll_interval" env-default:"1h"`
+	BatchSize    int           `yaml:"batch_size" env-default:"100"`
+}
+
+// LoginThrottle limits failed logins per account and per client IP. Every
+// failure blocks further logins to the account for a backoff doubling from
+// BaseBackoff up to MaxBackoff. Accounts with MaxAccountFailures and IPs with
+// MaxIPFailures within FailureWindow are locked out for LockoutDuration, and
+// so are the second factor checks of users with MaxMFAFailures wrong codes.
+type LoginThrottle struct {
+	MaxAccountFailures int           `yaml:"max_account_failures" env-default:"5"`
+	MaxIPFailures      int           `yaml:"max_ip_failures" env-default:"20"`
+	MaxMFAFailures     int           `yaml:"max_mfa_failures" env-default:"5"`
+	FailureWindow      time.Duration `yaml:"failure_window" env-default:"15m"`
+	BaseBackoff        time.Duration `yaml:"base_backoff" env-default:"1s"`
+	MaxBackoff         time.Duration `yaml:"max_backoff" env-default:"1m"`
+	LockoutDuration    time.Duration `yaml:"lockout_duration" env-default:"15m"`
+}
+
+type PasswordReset struct {
+	TokenTTL time.Duration `yaml:"token_ttl" env-default:"1h"`
+	// LinkURL is the page reset mails link to, with the token in the token
+	// query parameter. Without it mails carry the bare token.
+	LinkURL string `yaml:"link_url"`
+	// RequestInterval is how often a reset mail is sent to the same user.
+	RequestInterval time.Duration `yaml:"request_interval" env-default:"1m"`
+}
+
+type EmailVerification struct {
+	TokenTTL time.Duration `yaml:"token_ttl" env-default:"24h"`
+	// LinkURL is the page verification mails link to, with the token in
+	// the token query parameter. Without it mails carry the bare token.
+	LinkURL string `yaml:"link_url"`
+	// ResendInterval is how often a user may ask for another mail.
+	ResendInterval time.Duration `yaml:"resend_interval" env-default:"1m"`
+}
+
+type MFA struct {
+	// Issuer names the service in authenticator apps.
+	Issuer       string        `yaml:"issuer" env-default:"micro-banking"`
+	ChallengeTTL time.Duration `yaml:"challenge_ttl" env-default:"5m"`
+	// StepUpThresholds maps currency codes to the amount, e.g. "1000.00",
+	// from which withdrawals and transfers require a second factor verified
+	// within StepUpMaxAge. Currencies without a threshold never do.
+	StepUpThresholds map[string]string `yaml:"step_up_thresholds"`
+	StepUpMaxAge     time.Duration     `yaml:"step_up_max_age" env-default:"5m"`
+}
+
+// ServiceAuth holds the credentials the services identify each other with.
+type ServiceAuth struct {
+	// Name and Token identify this service to the ones it calls.
+	Name  string `yaml:"name"`
+	Token string `yaml:"token" env:"SERVICE_TOKEN"`
+	// Trusted maps the names of the internal callers a service accepts to
+	// their tokens.
+	Trusted map[string]string `yaml:"trusted"`
+}
+
+type JWT struct {
+	Issuer   string        `yaml:"issuer" env-default:"micro-banking-auth"`
+	Audience string        `yaml:"audience" env-default:"micro-banking"`
+	Leeway   time.Duration `yaml:"leeway" env-default:"30s"`
+	// SigningKey is the PEM private key the auth service signs tokens with.
+	SigningKey string `yaml:"signing_key"`
+	// VerificationKeys are PEM public keys tokens are accepted from besides
+	// the signing key, e.g. the previous signing key during a rotation.
+	VerificationKeys []string      `yaml:"verification_keys"`
+	JWKSRefresh      time.Duration `yaml:"jwks_refresh" env-default:"5m"`
+}
+
+type Outbox struct {
+	PollInterval time.Duration `yaml:"poll_interval" env-default:"1s"`
+	BatchSize    int           `yaml:"batch_size" env-default:"100"`
+	Lease        time.Duration `yaml:"lease" env-default:"30s"`
+	MinBackoff   time.Duration `yaml:"min_backoff" env-default:"1s"`
+	MaxBackoff   time.Duration `yaml:"max_backoff" env-default:"5m"`
+}
+
+type Idempotency struct {
+	KeyTTL time.Duration `yaml:"key_ttl" env-default:"24h"`
 }
 
 type Kafka struct {
@@ -30,7 +175,6 @@ type Kafka struct {
 
 type KafkaProducer struct {
 	ReturnSuccesses bool `yaml:"return_successes" env-default:"true"`
-	RequiredAcks    int  `yaml:"required_acks" env-default:"0"`
 	RetryMax        int  `yaml:"retry_max" env-default:"3"`
 }
 
@@ -41,6 +185,33 @@ type KafkaConsumer struct {
 type Clients struct {
 	AuthClient     AuthClient     `yaml:"auth" env-required:"true"`
 	CurrencyClient CurrencyClient `yaml:"currency" env-required:"true"`
+	TLS            TLS            `yaml:"tls"`
+}
+
+// TLS configures the transport security of the gRPC servers, or of the
+// clients under clients.
+type TLS struct {
+	Enabled  bool   `yaml:"enabled"`
+	CertFile string `yaml:"cert_file"`
+	KeyFile  string `yaml:"key_file"`
+	CAFile   string `yaml:"ca_file"`
+	// ClientAuth makes servers require client certificates issued by CAFile.
+	ClientAuth bool `yaml:"client_auth"`
+	// ServerName overrides the name clients verify server certificates for.
+	ServerName     string        `yaml:"server_name"`
+	ReloadInterval time.Duration `yaml:"reload_interval" env-default:"30s"`
+}
+
+func (t TLS) Options() mtls.Options {
+	return mtls.Options{
+		Enabled:        t.Enabled,
+		CertFile:       t.CertFile,
+		KeyFile:        t.KeyFile,
+		CAFile:         t.CAFile,
+		ClientAuth:     t.ClientAuth,
+		ServerName:     t.ServerName,
+		ReloadInterval: t.ReloadInterval,
+	}
 }
 
 type AuthClient struct {
@@ -80,6 +251,7 @@ type GRPCConfig struct {
 	AuthPort     int           `yaml:"auth_port" env-required:"true"`
 	CurrencyPort int           `yaml:"currency_port" env-required:"true"`
 	Timeout      time.Duration `yaml:"timeout" env-default:"10s"`
+	TLS          TLS           `yaml:"tls"`
 }
 
 type DBConfig struct {
@@ -6,11 +6,14 @@ import (
 
 	"github.com/bufbuild/protovalidate-go"
 	authv1 "github.com/tizzhh/micro-banking/gen/go/protos/proto/auth"
+	"github.com/tizzhh/micro-banking/internal/delivery/grpc/interceptors"
 	"github.com/tizzhh/micro-banking/internal/domain/auth/models"
 	auth "github.com/tizzhh/micro-banking/internal/services/auth/errors"
+	"github.com/tizzhh/micro-banking/pkg/jwt"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 type serverApi struct {
@@ -18,16 +21,64 @@ type serverApi struct {
 	auth Auth
 }
 
+// Policy is who may call each method of the auth service. Logout, VerifyMFA,
+// VerifyEmail and ResetPassword are authorized by the tokens in their
+// requests. UnlockAccount is called by the gateway for admins.
+var Policy = interceptors.Policy{
+	authv1.Auth_Register_FullMethodName:       interceptors.Public,
+	authv1.Auth_Login_FullMethodName:          interceptors.Public,
+	authv1.Auth_Refresh_FullMethodName:        interceptors.Public,
+	authv1.Auth_Logout_FullMethodName:         interceptors.Public,
+	authv1.Auth_JWKS_FullMethodName:           interceptors.Public,
+	authv1.Auth_UpdatePassword_FullMethodName: interceptors.Owner,
+	authv1.Auth_Unregister_FullMethodName:     interceptors.Owner,
+	authv1.Auth_User_FullMethodName:           interceptors.Owner,
+
+	authv1.Auth_VerifyMFA_FullMethodName:               interceptors.Public,
+	authv1.Auth_EnrollMFA_FullMethodName:               interceptors.Owner,
+	authv1.Auth_ConfirmMFA_FullMethodName:              interceptors.Owner,
+	authv1.Auth_DisableMFA_FullMethodName:              interceptors.Owner,
+	authv1.Auth_RegenerateRecoveryCodes_FullMethodName: interceptors.Owner,
+	authv1.Auth_StepUp_FullMethodName:                  interceptors.Owner,
+
+	authv1.Auth_VerifyEmail_FullMethodName:        interceptors.Public,
+	authv1.Auth_ResendVerification_FullMethodName: interceptors.Owner,
+
+	authv1.Auth_RequestPasswordReset_FullMethodName: interceptors.Public,
+	authv1.Auth_ResetPassword_FullMethodName:        interceptors.Public,
+
+	authv1.Auth_UnlockAccount_FullMethodName: interceptors.Internal,
+
+	authv1.Auth_UpdateProfile_FullMethodName: interceptors.Owner,
+	authv1.Auth_ChangeEmail_FullMethodName:   interceptors.Owner,
+}
+
 func Register(gRPC *grpc.Server, auth Auth) {
 	authv1.RegisterAuthServer(gRPC, &serverApi{auth: auth})
 }
 
 type Auth interface {
 	Register(ctx context.Context, email string, password string, firstName string, lastName string, age uint32) (uint64, error)
-	Login(ctx context.Context, email string, password string) (string, error)
+	Login(ctx context.Context, email string, password string) (models.TokenPair, error)
+	Refresh(ctx context.Context, refreshToken string) (models.TokenPair, error)
+	Logout(ctx context.Context, accessToken string, refreshToken string) error
+	JWKS(ctx context.Context) (jwt.JWKS, error)
 	UpdatePassword(ctx context.Context, email string, oldPassword string, newPassword string) error
 	Unregister(ctx context.Context, email string, password string) error
 	User(ctx context.Context, email string) (models.User, error)
+	EnrollMFA(ctx context.Context, email string) (models.MFAEnrollment, error)
+	ConfirmMFA(ctx context.Context, email string, code string) ([]string, error)
+	DisableMFA(ctx context.Context, email string, password string, code string) error
+	RegenerateRecoveryCodes(ctx context.Context, email string, code string) ([]string, error)
+	VerifyMFA(ctx context.Context, mfaToken string, code string) (models.TokenPair, error)
+	StepUp(ctx context.Context, email string, code string) (string, error)
+	VerifyEmail(ctx context.Context, token string) error
+	ResendVerification(ctx context.Context, email string) error
+	RequestPasswordReset(ctx context.Context, email string) error
+	ResetPassword(ctx context.Context, token string, newPassword string) error
+	UnlockAccount(ctx context.Context, email string) error
+	UpdateProfile(ctx context.Context, email string, update models.ProfileUpdate) (models.User, error)
+	ChangeEmail(ctx context.Context, email string, password string, newEmail string) error
 }
 
 func (s *serverApi) Register(ctx context.Context, req *authv1.RegisterRequest) (*authv1.RegisterResponse, error) {
@@ -64,19 +115,69 @@ func (s *serverApi) Login(ctx context.Context, req *authv1.LoginRequest) (*authv
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
-	token, err := s.auth.Login(ctx, req.GetEmail(), req.GetPassword())
+	tokens, err := s.auth.Login(ctx, req.GetEmail(), req.GetPassword())
 	if err != nil {
 		if errors.Is(err, auth.ErrInvalidCredentials) {
 			return nil, status.Error(codes.InvalidArgument, "invalid credentials")
 		}
+		if errors.Is(err, auth.ErrTooManyAttempts) {
+			return nil, status.Error(codes.ResourceExhausted, auth.ErrTooManyAttempts.Error())
+		}
 		return nil, status.Error(codes.Internal, "internal error")
 	}
 
 	return &authv1.LoginResponse{
-		Token: token,
+		Token:        tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
+		MfaToken:     tokens.MFAToken,
 	}, nil
 }
 
+func (s *serverApi) Refresh(ctx context.Context, req *authv1.RefreshRequest) (*authv1.RefreshResponse, error) {
+	validator, err := protovalidate.New()
+	if err != nil {
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	if err = validator.Validate(req); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	tokens, err := s.auth.Refresh(ctx, req.GetRefreshToken())
+	if err != nil {
+		if errors.Is(err, auth.ErrInvalidToken) {
+			return nil, status.Error(codes.Unauthenticated, "invalid refresh token")
+		}
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	return &authv1.RefreshResponse{
+		Token:        tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
+	}, nil
+}
+
+func (s *serverApi) Logout(ctx context.Context, req *authv1.LogoutRequest) (*authv1.LogoutResponse, error) {
+	validator, err := protovalidate.New()
+	if err != nil {
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	if err = validator.Validate(req); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	err = s.auth.Logout(ctx, req.GetToken(), req.GetRefreshToken())
+	if err != nil {
+		if errors.Is(err, auth.ErrInvalidToken) {
+			return nil, status.Error(codes.Unauthenticated, "invalid token")
+		}
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	return &authv1.LogoutResponse{}, nil
+}
+
 func (s *serverApi) UpdatePassword(ctx context.Context, req *authv1.UpdatePasswordRequest) (*authv1.UpdatePasswordResponse, error) {
 	validator, err := protovalidate.New()
 	if err != nil {
@@ -115,6 +216,9 @@ func (s *serverApi) Unregister(ctx context.Context, req *authv1.UnregisterReques
 		if errors.Is(err, auth.ErrInvalidCredentials) {
 			return nil, status.Error(codes.NotFound, "user not found")
 		}
+		if errors.Is(err, auth.ErrUserHasFunds) {
+			return nil, status.Error(codes.FailedPrecondition, "account has a non-zero balance, withdraw or sell it first")
+		}
 		return nil, status.Error(codes.Internal, "internal error")
 	}
 
@@ -141,11 +245,352 @@ func (s *serverApi) User(ctx context.Context, req *authv1.UserRequest) (*authv1.
 		return nil, status.Error(codes.Internal, "internal error")
 	}
 
+	return toUserResponse(user), nil
+}
+
+func toUserResponse(user models.User) *authv1.UserResponse {
 	return &authv1.UserResponse{
-		Email:     user.Email,
-		FirstName: user.FirstName,
-		LastName:  user.LastName,
-		Age:       user.Age,
-		Balance:   user.Balance,
+		UserId:        user.ID,
+		Email:         user.Email,
+		FirstName:     user.FirstName,
+		LastName:      user.LastName,
+		Age:           user.Age,
+		Balance:       user.Balance,
+		EmailVerified: user.Verified(),
+		PhoneNumber:   user.PhoneNumber,
+		Status:        string(user.Status),
+		CreatedAt:     timestamppb.New(user.CreatedAt),
+		UpdatedAt:     timestamppb.New(user.UpdatedAt),
+	}
+}
+
+func (s *serverApi) JWKS(ctx context.Context, req *authv1.JWKSRequest) (*authv1.JWKSResponse, error) {
+	jwks, err := s.auth.JWKS(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	keys := make([]*authv1.JWK, 0, len(jwks.Keys))
+	for _, key := range jwks.Keys {
+		keys = append(keys, &authv1.JWK{
+			Kty: key.Kty,
+			Kid: key.Kid,
+			Use: key.Use,
+			Alg: key.Alg,
+			N:   key.N,
+			E:   key.E,
+			Crv: key.Crv,
+			X:   key.X,
+		})
+	}
+
+	return &authv1.JWKSResponse{
+		Keys: keys,
+	}, nil
+}
+
+func (s *serverApi) EnrollMFA(ctx context.Context, req *authv1.EnrollMFARequest) (*authv1.EnrollMFAResponse, error) {
+	validator, err := protovalidate.New()
+	if err != nil {
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	if err = validator.Validate(req); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	enrollment, err := s.auth.EnrollMFA(ctx, req.GetEmail())
+	if err != nil {
+		return nil, mfaError(err)
+	}
+
+	return &authv1.EnrollMFAResponse{
+		Secret: enrollment.Secret,
+		Uri:    enrollment.URI,
+	}, nil
+}
+
+func (s *serverApi) ConfirmMFA(ctx context.Context, req *authv1.ConfirmMFARequest) (*authv1.ConfirmMFAResponse, error) {
+	validator, err := protovalidate.New()
+	if err != nil {
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	if err = validator.Validate(req); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	recoveryCodes, err := s.auth.ConfirmMFA(ctx, req.GetEmail(), req.GetCode())
+	if err != nil {
+		return nil, mfaError(err)
+	}
+
+	return &authv1.ConfirmMFAResponse{
+		RecoveryCodes: recoveryCodes,
+	}, nil
+}
+
+func (s *serverApi) DisableMFA(ctx context.Context, req *authv1.DisableMFARequest) (*authv1.DisableMFAResponse, error) {
+	validator, err := protovalidate.New()
+	if err != nil {
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	if err = validator.Validate(req); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	err = s.auth.DisableMFA(ctx, req.GetEmail(), req.GetPassword(), req.GetCode())
+	if err != nil {
+		return nil, mfaError(err)
+	}
+
+	return &authv1.DisableMFAResponse{}, nil
+}
+
+func (s *serverApi) RegenerateRecoveryCodes(ctx context.Context, req *authv1.RegenerateRecoveryCodesRequest) (*authv1.RegenerateRecoveryCodesResponse, error) {
+	validator, err := protovalidate.New()
+	if err != nil {
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	if err = validator.Validate(req); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	recoveryCodes, err := s.auth.RegenerateRecoveryCodes(ctx, req.GetEmail(), req.GetCode())
+	if err != nil {
+		return nil, mfaError(err)
+	}
+
+	return &authv1.RegenerateRecoveryCodesResponse{
+		RecoveryCodes: recoveryCodes,
+	}, nil
+}
+
+func (s *serverApi) VerifyMFA(ctx context.Context, req *authv1.VerifyMFARequest) (*authv1.VerifyMFAResponse, error) {
+	validator, err := protovalidate.New()
+	if err != nil {
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	if err = validator.Validate(req); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	tokens, err := s.auth.VerifyMFA(ctx, req.GetMfaToken(), req.GetCode())
+	if err != nil {
+		if errors.Is(err, auth.ErrInvalidToken) {
+			return nil, status.Error(codes.Unauthenticated, "invalid mfa token")
+		}
+		return nil, mfaError(err)
+	}
+
+	return &authv1.VerifyMFAResponse{
+		Token:        tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
+	}, nil
+}
+
+func (s *serverApi) StepUp(ctx context.Context, req *authv1.StepUpRequest) (*authv1.StepUpResponse, error) {
+	validator, err := protovalidate.New()
+	if err != nil {
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	if err = validator.Validate(req); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	token, err := s.auth.StepUp(ctx, req.GetEmail(), req.GetCode())
+	if err != nil {
+		return nil, mfaError(err)
+	}
+
+	return &authv1.StepUpResponse{
+		Token: token,
 	}, nil
 }
+
+func mfaError(err error) error {
+	switch {
+	case errors.Is(err, auth.ErrInvalidCredentials):
+		return status.Error(codes.InvalidArgument, "invalid credentials")
+	case errors.Is(err, auth.ErrInvalidMFACode):
+		return status.Error(codes.InvalidArgument, "invalid mfa code")
+	case errors.Is(err, auth.ErrMFAAlreadyEnabled):
+		return status.Error(codes.AlreadyExists, "mfa is already enabled")
+	case errors.Is(err, auth.ErrMFANotEnabled):
+		return status.Error(codes.FailedPrecondition, "mfa is not enabled")
+	case errors.Is(err, auth.ErrTooManyMFAAttempts):
+		return status.Error(codes.ResourceExhausted, auth.ErrTooManyMFAAttempts.Error())
+	default:
+		return status.Error(codes.Internal, "internal error")
+	}
+}
+
+func (s *serverApi) VerifyEmail(ctx context.Context, req *authv1.VerifyEmailRequest) (*authv1.VerifyEmailResponse, error) {
+	validator, err := protovalidate.New()
+	if err != nil {
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	if err = validator.Validate(req); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	err = s.auth.VerifyEmail(ctx, req.GetToken())
+	if err != nil {
+		if errors.Is(err, auth.ErrInvalidToken) {
+			return nil, status.Error(codes.InvalidArgument, "invalid verification token")
+		}
+		if errors.Is(err, auth.ErrEmailAlreadyVerified) {
+			return nil, status.Error(codes.FailedPrecondition, auth.ErrEmailAlreadyVerified.Error())
+		}
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	return &authv1.VerifyEmailResponse{}, nil
+}
+
+func (s *serverApi) ResendVerification(ctx context.Context, req *authv1.ResendVerificationRequest) (*authv1.ResendVerificationResponse, error) {
+	validator, err := protovalidate.New()
+	if err != nil {
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	if err = validator.Validate(req); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	err = s.auth.ResendVerification(ctx, req.GetEmail())
+	if err != nil {
+		if errors.Is(err, auth.ErrInvalidCredentials) {
+			return nil, status.Error(codes.NotFound, "user not found")
+		}
+		if errors.Is(err, auth.ErrEmailAlreadyVerified) {
+			return nil, status.Error(codes.FailedPrecondition, auth.ErrEmailAlreadyVerified.Error())
+		}
+		if errors.Is(err, auth.ErrTooManyRequests) {
+			return nil, status.Error(codes.ResourceExhausted, auth.ErrTooManyRequests.Error())
+		}
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	return &authv1.ResendVerificationResponse{}, nil
+}
+
+func (s *serverApi) RequestPasswordReset(ctx context.Context, req *authv1.RequestPasswordResetRequest) (*authv1.RequestPasswordResetResponse, error) {
+	validator, err := protovalidate.New()
+	if err != nil {
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	if err = validator.Validate(req); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if err = s.auth.RequestPasswordReset(ctx, req.GetEmail()); err != nil {
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	return &authv1.RequestPasswordResetResponse{}, nil
+}
+
+func (s *serverApi) ResetPassword(ctx context.Context, req *authv1.ResetPasswordRequest) (*authv1.ResetPasswordResponse, error) {
+	validator, err := protovalidate.New()
+	if err != nil {
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	if err = validator.Validate(req); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	err = s.auth.ResetPassword(ctx, req.GetToken(), req.GetNewPassword())
+	if err != nil {
+		if errors.Is(err, auth.ErrInvalidToken) {
+			return nil, status.Error(codes.InvalidArgument, "invalid password reset token")
+		}
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	return &authv1.ResetPasswordResponse{}, nil
+}
+
+func (s *serverApi) UnlockAccount(ctx context.Context, req *authv1.UnlockAccountRequest) (*authv1.UnlockAccountResponse, error) {
+	validator, err := protovalidate.New()
+	if err != nil {
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	if err = validator.Validate(req); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	err = s.auth.UnlockAccount(ctx, req.GetEmail())
+	if err != nil {
+		if errors.Is(err, auth.ErrInvalidCredentials) {
+			return nil, status.Error(codes.NotFound, "user not found")
+		}
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	return &authv1.UnlockAccountResponse{}, nil
+}
+
+func (s *serverApi) UpdateProfile(ctx context.Context, req *authv1.UpdateProfileRequest) (*authv1.UserResponse, error) {
+	validator, err := protovalidate.New()
+	if err != nil {
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	if err = validator.Validate(req); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	user, err := s.auth.UpdateProfile(ctx, req.GetEmail(), models.ProfileUpdate{
+		FirstName:   req.FirstName,
+		LastName:    req.LastName,
+		Age:         req.Age,
+		PhoneNumber: req.PhoneNumber,
+	})
+	if err != nil {
+		if errors.Is(err, auth.ErrInvalidCredentials) {
+			return nil, status.Error(codes.NotFound, "user not found")
+		}
+		if errors.Is(err, auth.ErrEmptyProfileUpdate) {
+			return nil, status.Error(codes.InvalidArgument, auth.ErrEmptyProfileUpdate.Error())
+		}
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	return toUserResponse(user), nil
+}
+
+func (s *serverApi) ChangeEmail(ctx context.Context, req *authv1.ChangeEmailRequest) (*authv1.ChangeEmailResponse, error) {
+	validator, err := protovalidate.New()
+	if err != nil {
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	if err = validator.Validate(req); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	err = s.auth.ChangeEmail(ctx, req.GetEmail(), req.GetPassword(), req.GetNewEmail())
+	if err != nil {
+		if errors.Is(err, auth.ErrInvalidCredentials) {
+			return nil, status.Error(codes.InvalidArgument, "invalid credentials")
+		}
+		if errors.Is(err, auth.ErrEmailUnchanged) {
+			return nil, status.Error(codes.InvalidArgument, auth.ErrEmailUnchanged.Error())
+		}
+		if errors.Is(err, auth.ErrUserAlreadyExists) {
+			return nil, status.Error(codes.AlreadyExists, "user already exists")
+		}
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	return &authv1.ChangeEmailResponse{}, nil
+}
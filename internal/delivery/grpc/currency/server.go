@@ -2,45 +2,112 @@ package currency
 
 import (
 	"context"
+	"encoding/base64"
 	"errors"
-	"fmt"
 	"log/slog"
+	"strconv"
+	"time"
 
 	"github.com/bufbuild/protovalidate-go"
 	currencyv1 "github.com/tizzhh/micro-banking/gen/go/protos/proto/currency"
+	"github.com/tizzhh/micro-banking/internal/delivery/grpc/interceptors"
 	"github.com/tizzhh/micro-banking/internal/domain/currency/models"
+	ledgerModels "github.com/tizzhh/micro-banking/internal/domain/ledger/models"
 	currency "github.com/tizzhh/micro-banking/internal/services/currency/errors"
-	"github.com/tizzhh/micro-banking/pkg/logger/sl"
+	"github.com/tizzhh/micro-banking/pkg/money"
+	"github.com/tizzhh/micro-banking/pkg/pricing"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 type serverApi struct {
 	currencyv1.UnimplementedCurrencyServer
 	currency Currency
-	producer Producer
 	log      *slog.Logger
 }
 
-func Register(gRPC *grpc.Server, currency Currency, producer Producer, log *slog.Logger) {
-	currencyv1.RegisterCurrencyServer(gRPC, &serverApi{currency: currency, producer: producer, log: log})
+// Policy is who may call each method of the currency service.
+var Policy = interceptors.Policy{
+	currencyv1.Currency_Quote_FullMethodName:          interceptors.Owner,
+	currencyv1.Currency_Buy_FullMethodName:            interceptors.Owner,
+	currencyv1.Currency_Sell_FullMethodName:           interceptors.Owner,
+	currencyv1.Currency_Convert_FullMethodName:        interceptors.Owner,
+	currencyv1.Currency_Wallets_FullMethodName:        interceptors.Owner,
+	currencyv1.Currency_Transactions_FullMethodName:   interceptors.Owner,
+	currencyv1.Currency_ListCurrencies_FullMethodName: interceptors.Public,
+	currencyv1.Currency_RateHistory_FullMethodName:    interceptors.Public,
 }
 
-type Producer interface {
-	Produce(emailAddr string, msg string) error
+func Register(gRPC *grpc.Server, currency Currency, log *slog.Logger) {
+	currencyv1.RegisterCurrencyServer(gRPC, &serverApi{currency: currency, log: log})
 }
 
 type Currency interface {
-	Buy(ctx context.Context, email string, currencyCode string, amount uint64) (float32, error)
-	Sell(ctx context.Context, email string, currencyCode string, amount uint64) (float32, error)
+	Quote(ctx context.Context, email string, amount money.Money, side models.QuoteSide) (models.Quote, error)
+	Buy(ctx context.Context, email string, amount money.Money, quoteID string) (pricing.Breakdown, error)
+	Sell(ctx context.Context, email string, amount money.Money, quoteID string) (pricing.Breakdown, error)
+	Convert(ctx context.Context, email string, amount money.Money, to string) (money.Money, money.Rate, error)
 	Wallets(ctx context.Context, email string) ([]models.UserWallet, error)
+	Transactions(ctx context.Context, email string, filter ledgerModels.HistoryFilter) ([]ledgerModels.HistoryEntry, uint64, error)
+	ListCurrencies(ctx context.Context) ([]models.Currency, error)
+	RateHistory(ctx context.Context, code string, from, to time.Time, interval string) (models.RateHistory, error)
 }
 
-func (s *serverApi) Buy(ctx context.Context, req *currencyv1.BuyRequest) (*currencyv1.BuyResponse, error) {
-	const caller = "delivery.grpc.currency.buy"
-	log := sl.AddCaller(s.log, caller)
+func (s *serverApi) Quote(ctx context.Context, req *currencyv1.QuoteRequest) (*currencyv1.QuoteResponse, error) {
+	validator, err := protovalidate.New()
+	if err != nil {
+		return nil, status.Error(codes.Internal, currency.ErrInternal.Error())
+	}
+
+	if err = validator.Validate(req); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	amount := money.New(req.GetAmount(), req.GetCurrencyCode())
+	quote, err := s.currency.Quote(ctx, req.GetEmail(), amount, models.QuoteSide(req.GetSide()))
+	if err != nil {
+		if errors.Is(err, currency.ErrInvalidAmount) {
+			return nil, status.Error(codes.InvalidArgument, currency.ErrInvalidAmount.Error())
+		}
+		if errors.Is(err, currency.ErrCurrencyNotTradable) {
+			return nil, status.Error(codes.FailedPrecondition, currency.ErrCurrencyNotTradable.Error())
+		}
+		if errors.Is(err, currency.ErrOrderTooSmall) {
+			return nil, status.Error(codes.InvalidArgument, currency.ErrOrderTooSmall.Error())
+		}
+		if errors.Is(err, currency.ErrOrderTooLarge) {
+			return nil, status.Error(codes.InvalidArgument, currency.ErrOrderTooLarge.Error())
+		}
+		if errors.Is(err, currency.ErrCurrencyCodeNotFound) {
+			return nil, status.Error(codes.NotFound, currency.ErrCurrencyCodeNotFound.Error())
+		}
+		if errors.Is(err, currency.ErrUserNotFound) {
+			return nil, status.Error(codes.NotFound, currency.ErrUserNotFound.Error())
+		}
+		return nil, status.Error(codes.Internal, currency.ErrInternal.Error())
+	}
+
+	return &currencyv1.QuoteResponse{
+		QuoteId:      quote.ID,
+		Side:         string(quote.Side),
+		CurrencyCode: quote.CurrencyCode,
+		Amount:       quote.Amount,
+		Cost:         quote.Cost,
+		Rate:         quote.Rate,
+		ExpiresAt:    timestamppb.New(quote.ExpiresAt),
+		Fees: &currencyv1.Fees{
+			MidRate: quote.MidRate,
+			Rate:    quote.Rate,
+			Gross:   quote.Gross,
+			Spread:  quote.Spread,
+			Fee:     quote.Fee,
+		},
+	}, nil
+}
 
+func (s *serverApi) Buy(ctx context.Context, req *currencyv1.BuyRequest) (*currencyv1.BuyResponse, error) {
 	validator, err := protovalidate.New()
 	if err != nil {
 		return nil, status.Error(codes.Internal, currency.ErrInternal.Error())
@@ -50,11 +117,36 @@ func (s *serverApi) Buy(ctx context.Context, req *currencyv1.BuyRequest) (*curre
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
-	boughtAmount, err := s.currency.Buy(ctx, req.GetEmail(), req.GetCurrencyCode(), req.GetAmount())
+	amount := money.New(req.GetAmount(), req.GetCurrencyCode())
+	price, err := s.currency.Buy(ctx, req.GetEmail(), amount, req.GetQuoteId())
 	if err != nil {
+		if errors.Is(err, currency.ErrInvalidAmount) {
+			return nil, status.Error(codes.InvalidArgument, currency.ErrInvalidAmount.Error())
+		}
+		if errors.Is(err, currency.ErrQuoteExpired) {
+			return nil, status.Error(codes.FailedPrecondition, currency.ErrQuoteExpired.Error())
+		}
+		if errors.Is(err, currency.ErrQuoteMismatch) {
+			return nil, status.Error(codes.InvalidArgument, currency.ErrQuoteMismatch.Error())
+		}
 		if errors.Is(err, currency.ErrNotEnoughMoney) {
 			return nil, status.Error(codes.FailedPrecondition, currency.ErrNotEnoughMoney.Error())
 		}
+		if errors.Is(err, currency.ErrAccountFrozen) {
+			return nil, status.Error(codes.PermissionDenied, currency.ErrAccountFrozen.Error())
+		}
+		if errors.Is(err, currency.ErrEmailNotVerified) {
+			return nil, status.Error(codes.PermissionDenied, currency.ErrEmailNotVerified.Error())
+		}
+		if errors.Is(err, currency.ErrCurrencyNotTradable) {
+			return nil, status.Error(codes.FailedPrecondition, currency.ErrCurrencyNotTradable.Error())
+		}
+		if errors.Is(err, currency.ErrOrderTooSmall) {
+			return nil, status.Error(codes.InvalidArgument, currency.ErrOrderTooSmall.Error())
+		}
+		if errors.Is(err, currency.ErrOrderTooLarge) {
+			return nil, status.Error(codes.InvalidArgument, currency.ErrOrderTooLarge.Error())
+		}
 		if errors.Is(err, currency.ErrCurrencyCodeNotFound) {
 			return nil, status.Error(codes.NotFound, currency.ErrCurrencyCodeNotFound.Error())
 		}
@@ -64,17 +156,10 @@ func (s *serverApi) Buy(ctx context.Context, req *currencyv1.BuyRequest) (*curre
 		return nil, status.Error(codes.Internal, currency.ErrInternal.Error())
 	}
 
-	if err = s.producer.Produce(req.GetEmail(), fmt.Sprintf("Sucessfully bought %f %s", boughtAmount, req.GetCurrencyCode())); err != nil {
-		log.Error("failed to produce", sl.Error(err))
-	}
-
-	return &currencyv1.BuyResponse{Email: req.GetEmail(), Bought: boughtAmount}, nil
+	return &currencyv1.BuyResponse{Email: req.GetEmail(), Bought: amount.Amount(), Cost: price.Total.Amount(), Fees: toFees(price)}, nil
 }
 
 func (s *serverApi) Sell(ctx context.Context, req *currencyv1.SellRequest) (*currencyv1.SellResponse, error) {
-	const caller = "delivery.grpc.currency.buy"
-	log := sl.AddCaller(s.log, caller)
-
 	validator, err := protovalidate.New()
 	if err != nil {
 		return nil, status.Error(codes.Internal, currency.ErrInternal.Error())
@@ -84,11 +169,36 @@ func (s *serverApi) Sell(ctx context.Context, req *currencyv1.SellRequest) (*cur
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
-	soldAmount, err := s.currency.Sell(ctx, req.GetEmail(), req.GetCurrencyCode(), req.GetAmount())
+	amount := money.New(req.GetAmount(), req.GetCurrencyCode())
+	price, err := s.currency.Sell(ctx, req.GetEmail(), amount, req.GetQuoteId())
 	if err != nil {
+		if errors.Is(err, currency.ErrInvalidAmount) {
+			return nil, status.Error(codes.InvalidArgument, currency.ErrInvalidAmount.Error())
+		}
+		if errors.Is(err, currency.ErrQuoteExpired) {
+			return nil, status.Error(codes.FailedPrecondition, currency.ErrQuoteExpired.Error())
+		}
+		if errors.Is(err, currency.ErrQuoteMismatch) {
+			return nil, status.Error(codes.InvalidArgument, currency.ErrQuoteMismatch.Error())
+		}
 		if errors.Is(err, currency.ErrNotEnoughCurrency) {
 			return nil, status.Error(codes.FailedPrecondition, currency.ErrNotEnoughCurrency.Error())
 		}
+		if errors.Is(err, currency.ErrAccountFrozen) {
+			return nil, status.Error(codes.PermissionDenied, currency.ErrAccountFrozen.Error())
+		}
+		if errors.Is(err, currency.ErrEmailNotVerified) {
+			return nil, status.Error(codes.PermissionDenied, currency.ErrEmailNotVerified.Error())
+		}
+		if errors.Is(err, currency.ErrCurrencyNotTradable) {
+			return nil, status.Error(codes.FailedPrecondition, currency.ErrCurrencyNotTradable.Error())
+		}
+		if errors.Is(err, currency.ErrOrderTooSmall) {
+			return nil, status.Error(codes.InvalidArgument, currency.ErrOrderTooSmall.Error())
+		}
+		if errors.Is(err, currency.ErrOrderTooLarge) {
+			return nil, status.Error(codes.InvalidArgument, currency.ErrOrderTooLarge.Error())
+		}
 		if errors.Is(err, currency.ErrCurrencyCodeNotFound) {
 			return nil, status.Error(codes.NotFound, currency.ErrCurrencyCodeNotFound.Error())
 		}
@@ -98,11 +208,59 @@ func (s *serverApi) Sell(ctx context.Context, req *currencyv1.SellRequest) (*cur
 		return nil, status.Error(codes.Internal, currency.ErrInternal.Error())
 	}
 
-	if err = s.producer.Produce(req.GetEmail(), fmt.Sprintf("Sucessfully sold %f %s", soldAmount, req.GetCurrencyCode())); err != nil {
-		log.Error("failed to produce", sl.Error(err))
+	return &currencyv1.SellResponse{Email: req.GetEmail(), Sold: amount.Amount(), Proceeds: price.Total.Amount(), Fees: toFees(price)}, nil
+}
+
+func (s *serverApi) Convert(ctx context.Context, req *currencyv1.ConvertRequest) (*currencyv1.ConvertResponse, error) {
+	validator, err := protovalidate.New()
+	if err != nil {
+		return nil, status.Error(codes.Internal, currency.ErrInternal.Error())
+	}
+
+	if err = validator.Validate(req); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	amount := money.New(req.GetAmount(), req.GetFromCurrencyCode())
+	converted, rate, err := s.currency.Convert(ctx, req.GetEmail(), amount, req.GetToCurrencyCode())
+	if err != nil {
+		if errors.Is(err, currency.ErrInvalidAmount) {
+			return nil, status.Error(codes.InvalidArgument, currency.ErrInvalidAmount.Error())
+		}
+		if errors.Is(err, currency.ErrSameCurrency) {
+			return nil, status.Error(codes.InvalidArgument, currency.ErrSameCurrency.Error())
+		}
+		if errors.Is(err, currency.ErrBaseCurrency) {
+			return nil, status.Error(codes.InvalidArgument, currency.ErrBaseCurrency.Error())
+		}
+		if errors.Is(err, currency.ErrNotEnoughCurrency) {
+			return nil, status.Error(codes.FailedPrecondition, currency.ErrNotEnoughCurrency.Error())
+		}
+		if errors.Is(err, currency.ErrAccountFrozen) {
+			return nil, status.Error(codes.PermissionDenied, currency.ErrAccountFrozen.Error())
+		}
+		if errors.Is(err, currency.ErrEmailNotVerified) {
+			return nil, status.Error(codes.PermissionDenied, currency.ErrEmailNotVerified.Error())
+		}
+		if errors.Is(err, currency.ErrCurrencyNotTradable) {
+			return nil, status.Error(codes.FailedPrecondition, currency.ErrCurrencyNotTradable.Error())
+		}
+		if errors.Is(err, currency.ErrOrderTooSmall) {
+			return nil, status.Error(codes.InvalidArgument, currency.ErrOrderTooSmall.Error())
+		}
+		if errors.Is(err, currency.ErrOrderTooLarge) {
+			return nil, status.Error(codes.InvalidArgument, currency.ErrOrderTooLarge.Error())
+		}
+		if errors.Is(err, currency.ErrCurrencyCodeNotFound) {
+			return nil, status.Error(codes.NotFound, currency.ErrCurrencyCodeNotFound.Error())
+		}
+		if errors.Is(err, currency.ErrUserNotFound) {
+			return nil, status.Error(codes.NotFound, currency.ErrUserNotFound.Error())
+		}
+		return nil, status.Error(codes.Internal, currency.ErrInternal.Error())
 	}
 
-	return &currencyv1.SellResponse{Email: req.GetEmail(), Sold: soldAmount}, nil
+	return &currencyv1.ConvertResponse{Email: req.GetEmail(), Converted: converted.Amount(), Rate: rate.String()}, nil
 }
 
 func (s *serverApi) Wallets(ctx context.Context, req *currencyv1.WalletRequest) (*currencyv1.WalletResponse, error) {
@@ -130,3 +288,166 @@ func (s *serverApi) Wallets(ctx context.Context, req *currencyv1.WalletRequest)
 
 	return &currencyv1.WalletResponse{UserWallet: wallets}, nil
 }
+
+func (s *serverApi) Transactions(ctx context.Context, req *currencyv1.TransactionsRequest) (*currencyv1.TransactionsResponse, error) {
+	validator, err := protovalidate.New()
+	if err != nil {
+		return nil, status.Error(codes.Internal, currency.ErrInternal.Error())
+	}
+
+	if err = validator.Validate(req); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	after, err := decodeCursor(req.GetCursor())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, currency.ErrInvalidCursor.Error())
+	}
+
+	filter := ledgerModels.HistoryFilter{
+		CurrencyCode: req.GetCurrencyCode(),
+		Ascending:    req.GetOrder() == "asc",
+		After:        after,
+		Limit:        int(req.GetLimit()),
+	}
+	for _, txType := range req.GetTypes() {
+		filter.Types = append(filter.Types, ledgerModels.TransactionType(txType))
+	}
+	if req.GetFrom() != nil {
+		filter.From = req.GetFrom().AsTime()
+	}
+	if req.GetTo() != nil {
+		filter.To = req.GetTo().AsTime()
+	}
+
+	entries, next, err := s.currency.Transactions(ctx, req.GetEmail(), filter)
+	if err != nil {
+		if errors.Is(err, currency.ErrUserNotFound) {
+			return nil, status.Error(codes.NotFound, currency.ErrUserNotFound.Error())
+		}
+		return nil, status.Error(codes.Internal, currency.ErrInternal.Error())
+	}
+
+	transactions := make([]*currencyv1.TransactionEntry, 0, len(entries))
+	for _, entry := range entries {
+		transactions = append(transactions, &currencyv1.TransactionEntry{
+			TransactionId: entry.TransactionID,
+			Type:          string(entry.Type),
+			CurrencyCode:  entry.CurrencyCode,
+			Direction:     string(entry.Direction),
+			Amount:        entry.Amount,
+			CreatedAt:     timestamppb.New(entry.CreatedAt),
+		})
+	}
+
+	return &currencyv1.TransactionsResponse{Transactions: transactions, NextCursor: encodeCursor(next)}, nil
+}
+
+func (s *serverApi) ListCurrencies(ctx context.Context, req *currencyv1.ListCurrenciesRequest) (*currencyv1.ListCurrenciesResponse, error) {
+	catalog, err := s.currency.ListCurrencies(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Internal, currency.ErrInternal.Error())
+	}
+
+	currencies := make([]*currencyv1.CurrencyInfo, 0, len(catalog))
+	for _, entry := range catalog {
+		currencies = append(currencies, &currencyv1.CurrencyInfo{
+			Code:     entry.Code,
+			Name:     entry.Name,
+			Exponent: int32(entry.Exponent),
+			Enabled:  entry.Enabled,
+			Tradable: entry.Tradable,
+			MinOrder: entry.MinOrder,
+			MaxOrder: entry.MaxOrder,
+		})
+	}
+
+	return &currencyv1.ListCurrenciesResponse{Currencies: currencies}, nil
+}
+
+func (s *serverApi) RateHistory(ctx context.Context, req *currencyv1.RateHistoryRequest) (*currencyv1.RateHistoryResponse, error) {
+	validator, err := protovalidate.New()
+	if err != nil {
+		return nil, status.Error(codes.Internal, currency.ErrInternal.Error())
+	}
+
+	if err = validator.Validate(req); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	var from, to time.Time
+	if req.GetFrom() != nil {
+		from = req.GetFrom().AsTime()
+	}
+	if req.GetTo() != nil {
+		to = req.GetTo().AsTime()
+	}
+
+	history, err := s.currency.RateHistory(ctx, req.GetCurrencyCode(), from, to, req.GetInterval())
+	if err != nil {
+		if errors.Is(err, currency.ErrInvalidInterval) {
+			return nil, status.Error(codes.InvalidArgument, currency.ErrInvalidInterval.Error())
+		}
+		if errors.Is(err, currency.ErrInvalidPeriod) {
+			return nil, status.Error(codes.InvalidArgument, currency.ErrInvalidPeriod.Error())
+		}
+		if errors.Is(err, currency.ErrBaseCurrency) {
+			return nil, status.Error(codes.InvalidArgument, currency.ErrBaseCurrency.Error())
+		}
+		if errors.Is(err, currency.ErrCurrencyCodeNotFound) {
+			return nil, status.Error(codes.NotFound, currency.ErrCurrencyCodeNotFound.Error())
+		}
+		return nil, status.Error(codes.Internal, currency.ErrInternal.Error())
+	}
+
+	rates := make([]*currencyv1.RatePoint, 0, len(history.Rates))
+	for _, rate := range history.Rates {
+		rates = append(rates, &currencyv1.RatePoint{
+			Rate:      rate.Rate,
+			Source:    rate.Source,
+			FetchedAt: timestamppb.New(rate.FetchedAt),
+		})
+	}
+	candles := make([]*currencyv1.RateCandle, 0, len(history.Candles))
+	for _, candle := range history.Candles {
+		candles = append(candles, &currencyv1.RateCandle{
+			Start:   timestamppb.New(candle.Start),
+			Open:    candle.Open,
+			High:    candle.High,
+			Low:     candle.Low,
+			Close:   candle.Close,
+			Samples: uint32(candle.Samples),
+		})
+	}
+
+	return &currencyv1.RateHistoryResponse{CurrencyCode: history.CurrencyCode, Rates: rates, Candles: candles}, nil
+}
+
+// Cursors are opaque to clients: the base64 of the last entry id of a page.
+func encodeCursor(id uint64) string {
+	if id == 0 {
+		return ""
+	}
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatUint(id, 10)))
+}
+
+func decodeCursor(cursor string) (uint64, error) {
+	if cursor == "" {
+		return 0, nil
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseUint(string(raw), 10, 64)
+}
+
+func toFees(price pricing.Breakdown) *currencyv1.Fees {
+	return &currencyv1.Fees{
+		MidRate: price.MidRate.String(),
+		Rate:    price.Rate.String(),
+		Gross:   price.Gross.Amount(),
+		Spread:  price.Spread.Amount(),
+		Fee:     price.Fee.Amount(),
+	}
+}
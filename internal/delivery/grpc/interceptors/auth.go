@@ -0,0 +1,235 @@
+package interceptors
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/tizzhh/micro-banking/internal/api/principal"
+	jwtpkg "github.com/tizzhh/micro-banking/pkg/jwt"
+	"github.com/tizzhh/micro-banking/pkg/logger/sl"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+type TokenVerifier interface {
+	CheckToken(token string) (*jwt.Token, error)
+}
+
+type TokenRevocationChecker interface {
+	IsTokenRevoked(ctx context.Context, claims *jwtpkg.Claims) (bool, error)
+}
+
+// Access is who may call a method.
+type Access int
+
+const (
+	// Public methods can be called without credentials.
+	Public Access = iota
+	// Owner methods act on the account named by the email of the request.
+	// Users may only call them for their own account, trusted services for
+	// any account.
+	Owner
+	// Internal methods can only be called by trusted services.
+	Internal
+)
+
+// Policy maps full method names to their access. Methods missing from it
+// cannot be called.
+type Policy map[string]Access
+
+var (
+	errUnauthenticated  = status.Error(codes.Unauthenticated, "unauthenticated")
+	errPermissionDenied = status.Error(codes.PermissionDenied, "permission denied")
+)
+
+type emailRequest interface {
+	GetEmail() string
+}
+
+type authenticator struct {
+	log               *slog.Logger
+	verifier          TokenVerifier
+	revocationChecker TokenRevocationChecker
+	services          map[string]string
+	policy            Policy
+}
+
+// Auth authenticates callers by the bearer token or the service credentials
+// in the metadata and enforces policy. The principal of the token, or the one
+// forwarded by a trusted service, and the name of the service are put into
+// the context of the handler.
+func Auth(log *slog.Logger, verifier TokenVerifier, revocationChecker TokenRevocationChecker, services map[string]string, policy Policy) grpc.UnaryServerInterceptor {
+	const caller = "delivery.grpc.interceptors.Auth"
+	a := &authenticator{
+		log:               sl.AddCaller(log, caller),
+		verifier:          verifier,
+		revocationChecker: revocationChecker,
+		services:          services,
+		policy:            policy,
+	}
+
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, access, err := a.authenticate(ctx, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+		if err := a.authorize(ctx, access, req); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// AuthStream is Auth for streaming methods. Owner access is checked for every
+// received message.
+func AuthStream(log *slog.Logger, verifier TokenVerifier, revocationChecker TokenRevocationChecker, services map[string]string, policy Policy) grpc.StreamServerInterceptor {
+	const caller = "delivery.grpc.interceptors.AuthStream"
+	a := &authenticator{
+		log:               sl.AddCaller(log, caller),
+		verifier:          verifier,
+		revocationChecker: revocationChecker,
+		services:          services,
+		policy:            policy,
+	}
+
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, access, err := a.authenticate(ss.Context(), info.FullMethod)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &authorizedStream{ServerStream: ss, ctx: ctx, access: access, authenticator: a})
+	}
+}
+
+func (a *authenticator) authenticate(ctx context.Context, method string) (context.Context, Access, error) {
+	access, ok := a.policy[method]
+	if !ok {
+		a.log.Warn("method without policy", slog.String("method", method))
+		return nil, 0, errPermissionDenied
+	}
+
+	service, isService, err := principal.ServiceFromIncomingContext(ctx, a.services)
+	if err != nil {
+		a.log.Warn("invalid service credentials", slog.String("method", method))
+		return nil, 0, errUnauthenticated
+	}
+	if isService {
+		ctx = principal.WithService(ctx, service)
+	}
+	ctx = withClientIP(ctx, isService)
+
+	if token, ok := principal.BearerFromIncomingContext(ctx); ok {
+		p, err := a.verify(ctx, token)
+		if err != nil {
+			a.log.Info("invalid token", slog.String("method", method), sl.Error(err))
+			if errors.Is(err, errRevocationCheck) {
+				return nil, 0, status.Error(codes.Internal, "internal error")
+			}
+			return nil, 0, errUnauthenticated
+		}
+		ctx = principal.WithToken(principal.WithPrincipal(ctx, p), token)
+	} else if isService {
+		// only trusted services may name the user they act for
+		if p, ok := principal.FromIncomingContext(ctx); ok {
+			ctx = principal.WithPrincipal(ctx, p)
+		}
+	} else if access != Public {
+		return nil, 0, errUnauthenticated
+	}
+
+	return ctx, access, nil
+}
+
+// withClientIP puts the address of the client into ctx, the one forwarded by
+// a trusted service or else the address of the caller.
+func withClientIP(ctx context.Context, isService bool) context.Context {
+	if isService {
+		if ip, ok := principal.ClientIPFromIncomingContext(ctx); ok {
+			return principal.WithClientIP(ctx, ip)
+		}
+	}
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ctx
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		host = p.Addr.String()
+	}
+	return principal.WithClientIP(ctx, host)
+}
+
+var errRevocationCheck = errors.New("failed to check token revocation")
+
+func (a *authenticator) verify(ctx context.Context, token string) (principal.Principal, error) {
+	parsed, err := a.verifier.CheckToken(token)
+	if err != nil {
+		return principal.Principal{}, err
+	}
+	claims, ok := parsed.Claims.(*jwtpkg.Claims)
+	if !ok || claims.Email == "" {
+		return principal.Principal{}, jwtpkg.ErrInvalidToken
+	}
+	uid, err := claims.UserID()
+	if err != nil {
+		return principal.Principal{}, err
+	}
+
+	revoked, err := a.revocationChecker.IsTokenRevoked(ctx, claims)
+	if err != nil {
+		a.log.Error("failed to check token revocation", sl.Error(err))
+		return principal.Principal{}, errRevocationCheck
+	}
+	if revoked {
+		return principal.Principal{}, jwtpkg.ErrInvalidToken
+	}
+
+	return principal.Principal{UserID: uid, Email: claims.Email, Roles: claims.Roles, MFAAt: claims.MFATime()}, nil
+}
+
+func (a *authenticator) authorize(ctx context.Context, access Access, req any) error {
+	_, isService := principal.ServiceFromContext(ctx)
+	p, isUser := principal.FromContext(ctx)
+
+	switch access {
+	case Public:
+		return nil
+	case Internal:
+		if isService {
+			return nil
+		}
+	case Owner:
+		// a user token restricts even a trusted service to that user
+		if isUser && principal.TokenFromContext(ctx) != "" {
+			if r, ok := req.(emailRequest); ok && r.GetEmail() == p.Email {
+				return nil
+			}
+		} else if isService {
+			return nil
+		}
+	}
+	return errPermissionDenied
+}
+
+type authorizedStream struct {
+	grpc.ServerStream
+	ctx           context.Context
+	access        Access
+	authenticator *authenticator
+}
+
+func (s *authorizedStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *authorizedStream) RecvMsg(m any) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	return s.authenticator.authorize(s.ctx, s.access, m)
+}
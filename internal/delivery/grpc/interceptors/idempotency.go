@@ -0,0 +1,180 @@
+package interceptors
+
+import (
+	"context"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"github.com/tizzhh/micro-banking/internal/api/idempotency"
+	"github.com/tizzhh/micro-banking/internal/api/principal"
+	"github.com/tizzhh/micro-banking/internal/domain/idempotency/models"
+	"github.com/tizzhh/micro-banking/pkg/logger/sl"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	spb "google.golang.org/genproto/googleapis/rpc/status"
+)
+
+type IdempotencyStore interface {
+	ReserveIdempotencyKey(ctx context.Context, key, fingerprint string, ttl time.Duration) (models.Record, bool, error)
+	CompleteIdempotencyKey(ctx context.Context, key string, statusCode int, response []byte) error
+	ReleaseIdempotencyKey(ctx context.Context, key string) error
+}
+
+// Idempotency runs each of methods at most once per idempotency-key metadata
+// value. Duplicates get the stored response or error; reusing a key for
+// another request is InvalidArgument and a duplicate of an unfinished call is
+// Aborted. Keys are scoped to the caller's principal, so users do not share
+// them. Calls without the key, or to other methods, are passed through.
+func Idempotency(log *slog.Logger, store IdempotencyStore, ttl time.Duration, methods ...string) grpc.UnaryServerInterceptor {
+	const caller = "delivery.grpc.interceptors.Idempotency"
+	log = sl.AddCaller(log, caller)
+
+	guarded := make(map[string]struct{}, len(methods))
+	for _, method := range methods {
+		guarded[method] = struct{}{}
+	}
+
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if _, ok := guarded[info.FullMethod]; !ok {
+			return handler(ctx, req)
+		}
+		key := keyFromMetadata(ctx)
+		if key == "" {
+			return handler(ctx, req)
+		}
+		if len(key) > idempotency.MaxKeyLength {
+			return nil, status.Error(codes.InvalidArgument, idempotency.ErrInvalidKey.Error())
+		}
+
+		msg, ok := req.(proto.Message)
+		if !ok {
+			return handler(ctx, req)
+		}
+		body, err := proto.MarshalOptions{Deterministic: true}.Marshal(msg)
+		if err != nil {
+			log.Error("failed to marshal request", sl.Error(err))
+			return nil, status.Error(codes.Internal, "internal error")
+		}
+
+		storeKey := "grpc:" + info.FullMethod + ":" + key
+		// keys are picked by clients, so two users may send the same one
+		if p, ok := principal.FromContext(ctx); ok {
+			storeKey = "grpc:" + strconv.FormatUint(p.UserID, 10) + ":" + info.FullMethod + ":" + key
+		}
+		fingerprint := idempotency.Fingerprint([]byte(info.FullMethod), body)
+
+		log := log.With(slog.String("idempotency_key", key), slog.String("method", info.FullMethod))
+
+		record, reserved, err := store.ReserveIdempotencyKey(ctx, storeKey, fingerprint, ttl)
+		if err != nil {
+			log.Error("failed to reserve idempotency key", sl.Error(err))
+			return nil, status.Error(codes.Internal, "internal error")
+		}
+		if !reserved {
+			return replay(log, record, fingerprint)
+		}
+
+		resp, handlerErr := handler(ctx, req)
+
+		storeCtx := context.WithoutCancel(ctx)
+
+		code, stored, err := encodeResult(resp, handlerErr)
+		if err != nil || retryable(code) {
+			if err != nil {
+				log.Error("failed to encode response", sl.Error(err))
+			}
+			if err := store.ReleaseIdempotencyKey(storeCtx, storeKey); err != nil {
+				log.Error("failed to release idempotency key", sl.Error(err))
+			}
+			return resp, handlerErr
+		}
+
+		if err := store.CompleteIdempotencyKey(storeCtx, storeKey, int(code), stored); err != nil {
+			log.Error("failed to store idempotent response", sl.Error(err))
+		}
+
+		return resp, handlerErr
+	}
+}
+
+func keyFromMetadata(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	values := md.Get(idempotency.MetadataKey)
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+// encodeResult stores a successful response as an Any, so that it can be
+// replayed without knowing its type, and a failure as its status.
+func encodeResult(resp any, handlerErr error) (codes.Code, []byte, error) {
+	if handlerErr != nil {
+		st := status.Convert(handlerErr)
+		stored, err := proto.Marshal(st.Proto())
+		return st.Code(), stored, err
+	}
+
+	msg, ok := resp.(proto.Message)
+	if !ok {
+		return codes.Internal, nil, status.Error(codes.Internal, "response is not a proto message")
+	}
+	wrapped, err := anypb.New(msg)
+	if err != nil {
+		return codes.Internal, nil, err
+	}
+	stored, err := proto.Marshal(wrapped)
+	return codes.OK, stored, err
+}
+
+func replay(log *slog.Logger, record models.Record, fingerprint string) (any, error) {
+	if record.Fingerprint != fingerprint {
+		log.Warn("idempotency key reused with a different request")
+		return nil, status.Error(codes.InvalidArgument, idempotency.ErrKeyReused.Error())
+	}
+	if !record.Completed() {
+		log.Info("idempotent request is still in progress")
+		return nil, status.Error(codes.Aborted, idempotency.ErrInProgress.Error())
+	}
+
+	log.Info("replaying idempotent response")
+
+	if codes.Code(record.StatusCode) != codes.OK {
+		var st spb.Status
+		if err := proto.Unmarshal(record.Response, &st); err != nil {
+			log.Error("failed to decode stored status", sl.Error(err))
+			return nil, status.Error(codes.Internal, "internal error")
+		}
+		return nil, status.ErrorProto(&st)
+	}
+
+	var wrapped anypb.Any
+	if err := proto.Unmarshal(record.Response, &wrapped); err != nil {
+		log.Error("failed to decode stored response", sl.Error(err))
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+	resp, err := wrapped.UnmarshalNew()
+	if err != nil {
+		log.Error("failed to decode stored response", sl.Error(err))
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+	return resp, nil
+}
+
+// retryable reports whether a failure should leave the key free for a retry.
+func retryable(code codes.Code) bool {
+	switch code {
+	case codes.Internal, codes.Unavailable, codes.Unknown, codes.DeadlineExceeded, codes.Canceled, codes.Aborted:
+		return true
+	}
+	return false
+}
@@ -6,9 +6,11 @@ import (
 	"net/http"
 
 	"github.com/go-playground/validator/v10"
+	"github.com/tizzhh/micro-banking/internal/api/principal"
 	"github.com/tizzhh/micro-banking/internal/api/response"
 	"github.com/tizzhh/micro-banking/internal/api/validate"
 	"github.com/tizzhh/micro-banking/pkg/logger/sl"
+	"github.com/tizzhh/micro-banking/pkg/money"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -25,6 +27,24 @@ func HandleValidationErr(w http.ResponseWriter, r *http.Request, err error) {
 	}
 }
 
+// Principal returns the caller authenticated by the auth middleware. Without
+// one it responds with 401.
+func Principal(w http.ResponseWriter, r *http.Request) (principal.Principal, bool) {
+	p, ok := principal.FromContext(r.Context())
+	if !ok {
+		response.RespondWithError(w, r, "unauthenticated", http.StatusUnauthorized)
+	}
+	return p, ok
+}
+
+func HandleAmountErr(w http.ResponseWriter, r *http.Request, err error) {
+	if errors.Is(err, money.ErrTooPrecise) {
+		response.RespondWithError(w, r, money.ErrTooPrecise.Error(), http.StatusBadRequest)
+	} else {
+		response.RespondWithError(w, r, "field Amount is not valid", http.StatusBadRequest)
+	}
+}
+
 func HandleGrpcError(log *slog.Logger, w http.ResponseWriter, r *http.Request, err error) {
 	const caller = "bank.auth.handler.handleGrpcError"
 	log = sl.AddCaller(log, caller)
@@ -46,6 +66,12 @@ func HandleGrpcError(log *slog.Logger, w http.ResponseWriter, r *http.Request, e
 		response.RespondWithError(w, r, grpcErr.Message(), http.StatusBadRequest)
 	case codes.NotFound:
 		response.RespondWithError(w, r, grpcErr.Message(), http.StatusNotFound)
+	case codes.Unauthenticated:
+		response.RespondWithError(w, r, grpcErr.Message(), http.StatusUnauthorized)
+	case codes.PermissionDenied:
+		response.RespondWithError(w, r, grpcErr.Message(), http.StatusForbidden)
+	case codes.ResourceExhausted:
+		response.RespondWithError(w, r, grpcErr.Message(), http.StatusTooManyRequests)
 	default:
 		response.RespondWithError(w, r, "internal error", http.StatusInternalServerError)
 	}
@@ -0,0 +1,181 @@
+package admin
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/tizzhh/micro-banking/internal/api/response"
+	"github.com/tizzhh/micro-banking/internal/api/validate"
+	"github.com/tizzhh/micro-banking/internal/delivery/http/bank/common"
+	currencyModels "github.com/tizzhh/micro-banking/internal/domain/currency/models"
+	"github.com/tizzhh/micro-banking/pkg/logger/sl"
+	"github.com/tizzhh/micro-banking/pkg/money"
+)
+
+var ErrInvalidOrderLimit = errors.New("order limits must be non-negative amounts of the currency")
+
+// CreateCurrency godoc
+// @Summary Create currency
+// @Description Adds a currency to the catalog. Requires the currencies:manage permission
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param CreateCurrencyRequest body CreateCurrencyRequest true "Create currency request"
+// @Success 201 {object} Currency
+// @Failure 400 {object} response.Error
+// @Failure 401 {object} response.Error
+// @Failure 403 {object} response.Error
+// @Failure 409 {object} response.Error
+// @Failure 500 {object} response.Error
+// @Router /admin/currencies [post]
+// @Security BearerAuth
+func (aa *AdminApi) CreateCurrency() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const caller = "bank.admin.handler.CreateCurrency"
+		log := sl.AddRequestId(sl.AddCaller(aa.log, caller), middleware.GetReqID(r.Context()))
+		log.Info("creating currency")
+
+		var createRequest CreateCurrencyRequest
+
+		err := validate.ValidateRequest(aa.log, &createRequest, r.Body)
+		if err != nil {
+			log.Error("validation err", sl.Error(err))
+			common.HandleValidationErr(w, r, err)
+			return
+		}
+
+		currency := currencyModels.Currency{
+			Code:     createRequest.Code,
+			Name:     createRequest.Name,
+			Exponent: *createRequest.Exponent,
+			Enabled:  createRequest.Enabled == nil || *createRequest.Enabled,
+			Tradable: createRequest.Tradable,
+		}
+		currency.MinOrder, err = orderLimit(createRequest.MinOrder, currency.Code, currency.Exponent)
+		if err != nil {
+			log.Error("invalid min order", sl.Error(err))
+			response.RespondWithError(w, r, ErrInvalidOrderLimit.Error(), http.StatusBadRequest)
+			return
+		}
+		currency.MaxOrder, err = orderLimit(createRequest.MaxOrder, currency.Code, currency.Exponent)
+		if err != nil {
+			log.Error("invalid max order", sl.Error(err))
+			response.RespondWithError(w, r, ErrInvalidOrderLimit.Error(), http.StatusBadRequest)
+			return
+		}
+
+		currency, err = aa.admin.CreateCurrency(r.Context(), currency)
+		if err != nil {
+			handleAdminErr(w, r, err)
+			return
+		}
+
+		log.Info("currency created")
+
+		render.Status(r, http.StatusCreated)
+		render.JSON(w, r, toCurrency(currency))
+	}
+}
+
+// UpdateCurrency godoc
+// @Summary Update currency
+// @Description Changes the name, flags or order limits of a currency. The base currency cannot be disabled or made tradable. Requires the currencies:manage permission
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param code path string true "Currency code"
+// @Param UpdateCurrencyRequest body UpdateCurrencyRequest true "Update currency request"
+// @Success 200 {object} Currency
+// @Failure 400 {object} response.Error
+// @Failure 401 {object} response.Error
+// @Failure 403 {object} response.Error
+// @Failure 404 {object} response.Error
+// @Failure 500 {object} response.Error
+// @Router /admin/currencies/{code} [patch]
+// @Security BearerAuth
+func (aa *AdminApi) UpdateCurrency() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const caller = "bank.admin.handler.UpdateCurrency"
+		log := sl.AddRequestId(sl.AddCaller(aa.log, caller), middleware.GetReqID(r.Context()))
+		log.Info("updating currency")
+
+		code := strings.ToUpper(chi.URLParam(r, "code"))
+
+		var updateRequest UpdateCurrencyRequest
+
+		err := validate.ValidateRequest(aa.log, &updateRequest, r.Body)
+		if err != nil {
+			log.Error("validation err", sl.Error(err))
+			common.HandleValidationErr(w, r, err)
+			return
+		}
+
+		update := currencyModels.CurrencyUpdate{
+			Name:     updateRequest.Name,
+			Enabled:  updateRequest.Enabled,
+			Tradable: updateRequest.Tradable,
+		}
+		if updateRequest.MinOrder != nil {
+			minOrder, err := orderLimit(*updateRequest.MinOrder, code, money.Exponent(code))
+			if err != nil {
+				log.Error("invalid min order", sl.Error(err))
+				response.RespondWithError(w, r, ErrInvalidOrderLimit.Error(), http.StatusBadRequest)
+				return
+			}
+			update.MinOrder = &minOrder
+		}
+		if updateRequest.MaxOrder != nil {
+			maxOrder, err := orderLimit(*updateRequest.MaxOrder, code, money.Exponent(code))
+			if err != nil {
+				log.Error("invalid max order", sl.Error(err))
+				response.RespondWithError(w, r, ErrInvalidOrderLimit.Error(), http.StatusBadRequest)
+				return
+			}
+			update.MaxOrder = &maxOrder
+		}
+
+		currency, err := aa.admin.UpdateCurrency(r.Context(), code, update)
+		if err != nil {
+			handleAdminErr(w, r, err)
+			return
+		}
+
+		log.Info("currency updated")
+
+		render.JSON(w, r, toCurrency(currency))
+	}
+}
+
+// orderLimit returns the minor units of the decimal value, zero if empty.
+func orderLimit(value string, code string, exponent int) (uint64, error) {
+	if value == "" {
+		return 0, nil
+	}
+	amount, err := money.ParseExponent(value, code, exponent)
+	if err != nil {
+		return 0, err
+	}
+	if amount.IsNegative() {
+		return 0, money.ErrInvalidAmount
+	}
+	return uint64(amount.Amount()), nil
+}
+
+func toCurrency(currency currencyModels.Currency) Currency {
+	resp := Currency{
+		Code:     currency.Code,
+		Name:     currency.Name,
+		Exponent: currency.Exponent,
+		Enabled:  currency.Enabled,
+		Tradable: currency.Tradable,
+		MinOrder: money.New(int64(currency.MinOrder), currency.Code).String(),
+	}
+	if currency.MaxOrder > 0 {
+		resp.MaxOrder = money.New(int64(currency.MaxOrder), currency.Code).String()
+	}
+	return resp
+}
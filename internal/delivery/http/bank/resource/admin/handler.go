@@ -0,0 +1,432 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+	"github.com/tizzhh/micro-banking/internal/api/response"
+	"github.com/tizzhh/micro-banking/internal/api/validate"
+	"github.com/tizzhh/micro-banking/internal/delivery/http/bank/common"
+	adminModels "github.com/tizzhh/micro-banking/internal/domain/admin/models"
+	"github.com/tizzhh/micro-banking/internal/domain/auth/models"
+	currencyModels "github.com/tizzhh/micro-banking/internal/domain/currency/models"
+	adminErrors "github.com/tizzhh/micro-banking/internal/services/admin/errors"
+	"github.com/tizzhh/micro-banking/pkg/logger/sl"
+	"github.com/tizzhh/micro-banking/pkg/money"
+)
+
+const (
+	baseCurrencyCode = "USD"
+)
+
+var (
+	ErrInvalidUserID = errors.New("invalid user id")
+	ErrInvalidQuery  = errors.New("invalid query")
+)
+
+type AdminApi struct {
+	log       *slog.Logger
+	validator *validator.Validate
+	admin     Administrator
+}
+
+func New(log *slog.Logger, validator *validator.Validate, admin Administrator) *AdminApi {
+	return &AdminApi{
+		log:       log,
+		validator: validator,
+		admin:     admin,
+	}
+}
+
+//go:generate go run github.com/vektra/mockery/v2 --name=Administrator
+type Administrator interface {
+	Users(ctx context.Context, after uint64, limit int) ([]models.User, error)
+	User(ctx context.Context, id uint64) (models.User, error)
+	Balance(ctx context.Context, id uint64) (adminModels.Balance, error)
+	SetFrozen(ctx context.Context, id uint64, frozen bool) error
+	Unlock(ctx context.Context, id uint64) error
+	Adjust(ctx context.Context, adminID, userID uint64, amount money.Money, reason adminModels.ReasonCode, comment string) (money.Money, error)
+	CreateCurrency(ctx context.Context, currency currencyModels.Currency) (currencyModels.Currency, error)
+	UpdateCurrency(ctx context.Context, code string, update currencyModels.CurrencyUpdate) (currencyModels.Currency, error)
+}
+
+// Users godoc
+// @Summary List users
+// @Description Lists users in id order. Requires the users:read permission
+// @Tags admin
+// @Produce json
+// @Param after query int false "Id of the last user of the previous page"
+// @Param limit query int false "Page size, at most 100"
+// @Success 200 {object} UsersResponse
+// @Failure 400 {object} response.Error
+// @Failure 401 {object} response.Error
+// @Failure 403 {object} response.Error
+// @Failure 500 {object} response.Error
+// @Router /admin/users [get]
+// @Security BearerAuth
+func (aa *AdminApi) Users() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const caller = "bank.admin.handler.Users"
+		log := sl.AddRequestId(sl.AddCaller(aa.log, caller), middleware.GetReqID(r.Context()))
+		log.Info("listing users")
+
+		after, limit, err := parseUsersQuery(r)
+		if err != nil {
+			log.Error("invalid query", sl.Error(err))
+			response.RespondWithError(w, r, ErrInvalidQuery.Error(), http.StatusBadRequest)
+			return
+		}
+
+		users, err := aa.admin.Users(r.Context(), after, limit)
+		if err != nil {
+			handleAdminErr(w, r, err)
+			return
+		}
+
+		resp := UsersResponse{Users: make([]User, 0, len(users))}
+		for _, user := range users {
+			resp.Users = append(resp.Users, toUser(user))
+		}
+		if len(users) > 0 && len(users) == limit {
+			resp.NextAfter = users[len(users)-1].ID
+		}
+
+		log.Info("users listed")
+
+		render.JSON(w, r, resp)
+	}
+}
+
+// User godoc
+// @Summary Get user
+// @Description Returns any user. Requires the users:read permission
+// @Tags admin
+// @Produce json
+// @Param id path int true "User id"
+// @Success 200 {object} User
+// @Failure 400 {object} response.Error
+// @Failure 401 {object} response.Error
+// @Failure 403 {object} response.Error
+// @Failure 404 {object} response.Error
+// @Failure 500 {object} response.Error
+// @Router /admin/users/{id} [get]
+// @Security BearerAuth
+func (aa *AdminApi) User() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const caller = "bank.admin.handler.User"
+		log := sl.AddRequestId(sl.AddCaller(aa.log, caller), middleware.GetReqID(r.Context()))
+		log.Info("getting user")
+
+		id, ok := userID(w, r)
+		if !ok {
+			return
+		}
+
+		user, err := aa.admin.User(r.Context(), id)
+		if err != nil {
+			handleAdminErr(w, r, err)
+			return
+		}
+
+		render.JSON(w, r, toUser(user))
+	}
+}
+
+// Balance godoc
+// @Summary Get user balance
+// @Description Returns the balance and wallets of any user. Requires the balances:read permission
+// @Tags admin
+// @Produce json
+// @Param id path int true "User id"
+// @Success 200 {object} BalanceResponse
+// @Failure 400 {object} response.Error
+// @Failure 401 {object} response.Error
+// @Failure 403 {object} response.Error
+// @Failure 404 {object} response.Error
+// @Failure 500 {object} response.Error
+// @Router /admin/users/{id}/balance [get]
+// @Security BearerAuth
+func (aa *AdminApi) Balance() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const caller = "bank.admin.handler.Balance"
+		log := sl.AddRequestId(sl.AddCaller(aa.log, caller), middleware.GetReqID(r.Context()))
+		log.Info("getting user balance")
+
+		id, ok := userID(w, r)
+		if !ok {
+			return
+		}
+
+		balance, err := aa.admin.Balance(r.Context(), id)
+		if err != nil {
+			handleAdminErr(w, r, err)
+			return
+		}
+
+		resp := BalanceResponse{
+			UserID:  balance.UserID,
+			Frozen:  balance.Frozen,
+			Balance: balance.Cash.String(),
+			Wallets: make([]Wallet, 0, len(balance.Wallets)),
+		}
+		for _, wallet := range balance.Wallets {
+			resp.Wallets = append(resp.Wallets, Wallet{CurrencyCode: wallet.Currency(), Balance: wallet.String()})
+		}
+
+		render.JSON(w, r, resp)
+	}
+}
+
+// Freeze godoc
+// @Summary Freeze account
+// @Description Freezes the account of a user. Frozen accounts cannot deposit, withdraw, transfer or trade currency. Requires the accounts:freeze permission
+// @Tags admin
+// @Produce json
+// @Param id path int true "User id"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Error
+// @Failure 401 {object} response.Error
+// @Failure 403 {object} response.Error
+// @Failure 404 {object} response.Error
+// @Failure 500 {object} response.Error
+// @Router /admin/users/{id}/freeze [post]
+// @Security BearerAuth
+func (aa *AdminApi) Freeze() http.HandlerFunc {
+	return aa.setFrozen(true)
+}
+
+// Unfreeze godoc
+// @Summary Unfreeze account
+// @Description Unfreezes the account of a user. Requires the accounts:freeze permission
+// @Tags admin
+// @Produce json
+// @Param id path int true "User id"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Error
+// @Failure 401 {object} response.Error
+// @Failure 403 {object} response.Error
+// @Failure 404 {object} response.Error
+// @Failure 500 {object} response.Error
+// @Router /admin/users/{id}/unfreeze [post]
+// @Security BearerAuth
+func (aa *AdminApi) Unfreeze() http.HandlerFunc {
+	return aa.setFrozen(false)
+}
+
+func (aa *AdminApi) setFrozen(frozen bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const caller = "bank.admin.handler.setFrozen"
+		log := sl.AddRequestId(sl.AddCaller(aa.log, caller), middleware.GetReqID(r.Context()))
+		log.Info("changing account state", slog.Bool("frozen", frozen))
+
+		id, ok := userID(w, r)
+		if !ok {
+			return
+		}
+
+		if err := aa.admin.SetFrozen(r.Context(), id, frozen); err != nil {
+			handleAdminErr(w, r, err)
+			return
+		}
+
+		log.Info("account state changed")
+
+		message := "account unfrozen"
+		if frozen {
+			message = "account frozen"
+		}
+		response.ReponsdWithOK(w, r, message, http.StatusOK)
+	}
+}
+
+// Unlock godoc
+// @Summary Unlock account
+// @Description Lifts the lockout of a user after too many failed logins. Requires the accounts:unlock permission
+// @Tags admin
+// @Produce json
+// @Param id path int true "User id"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Error
+// @Failure 401 {object} response.Error
+// @Failure 403 {object} response.Error
+// @Failure 404 {object} response.Error
+// @Failure 500 {object} response.Error
+// @Router /admin/users/{id}/unlock [post]
+// @Security BearerAuth
+func (aa *AdminApi) Unlock() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const caller = "bank.admin.handler.Unlock"
+		log := sl.AddRequestId(sl.AddCaller(aa.log, caller), middleware.GetReqID(r.Context()))
+		log.Info("unlocking account")
+
+		id, ok := userID(w, r)
+		if !ok {
+			return
+		}
+
+		if err := aa.admin.Unlock(r.Context(), id); err != nil {
+			handleAdminErr(w, r, err)
+			return
+		}
+
+		log.Info("account unlocked")
+
+		response.ReponsdWithOK(w, r, "account unlocked", http.StatusOK)
+	}
+}
+
+// Adjust godoc
+// @Summary Adjust balance
+// @Description Credits a positive or debits a negative amount to the balance of a user. A reason code is required. Requires the balances:adjust permission
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param id path int true "User id"
+// @Param AdjustmentRequest body AdjustmentRequest true "Adjustment request"
+// @Success 200 {object} AdjustmentResponse
+// @Failure 400 {object} response.Error
+// @Failure 401 {object} response.Error
+// @Failure 403 {object} response.Error
+// @Failure 404 {object} response.Error
+// @Failure 500 {object} response.Error
+// @Router /admin/users/{id}/adjustments [post]
+// @Security BearerAuth
+func (aa *AdminApi) Adjust() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const caller = "bank.admin.handler.Adjust"
+		log := sl.AddRequestId(sl.AddCaller(aa.log, caller), middleware.GetReqID(r.Context()))
+		log.Info("adjusting balance")
+
+		p, ok := common.Principal(w, r)
+		if !ok {
+			return
+		}
+
+		id, ok := userID(w, r)
+		if !ok {
+			return
+		}
+
+		var adjustmentRequest AdjustmentRequest
+
+		err := validate.ValidateRequest(aa.log, &adjustmentRequest, r.Body)
+		if err != nil {
+			log.Error("validation err", sl.Error(err))
+			common.HandleValidationErr(w, r, err)
+			return
+		}
+
+		currencyCode := adjustmentRequest.CurrencyCode
+		if currencyCode == "" {
+			currencyCode = baseCurrencyCode
+		}
+
+		amount, err := money.Parse(adjustmentRequest.Amount, currencyCode)
+		if err != nil {
+			log.Error("invalid amount", sl.Error(err))
+			common.HandleAmountErr(w, r, err)
+			return
+		}
+
+		newBalance, err := aa.admin.Adjust(
+			r.Context(),
+			p.UserID,
+			id,
+			amount,
+			adminModels.ReasonCode(adjustmentRequest.ReasonCode),
+			adjustmentRequest.Comment,
+		)
+		if err != nil {
+			handleAdminErr(w, r, err)
+			return
+		}
+
+		log.Info("balance adjusted")
+
+		render.JSON(w, r, AdjustmentResponse{NewBalanceAmount: newBalance.String(), CurrencyCode: currencyCode})
+	}
+}
+
+func userID(w http.ResponseWriter, r *http.Request) (uint64, bool) {
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 64)
+	if err != nil || id == 0 {
+		response.RespondWithError(w, r, ErrInvalidUserID.Error(), http.StatusBadRequest)
+		return 0, false
+	}
+	return id, true
+}
+
+func parseUsersQuery(r *http.Request) (uint64, int, error) {
+	values := r.URL.Query()
+
+	var after uint64
+	if value := values.Get("after"); value != "" {
+		parsed, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			return 0, 0, err
+		}
+		after = parsed
+	}
+
+	var limit int
+	if value := values.Get("limit"); value != "" {
+		parsed, err := strconv.ParseUint(value, 10, 32)
+		if err != nil || parsed > 100 {
+			return 0, 0, ErrInvalidQuery
+		}
+		limit = int(parsed)
+	}
+
+	return after, limit, nil
+}
+
+func toUser(user models.User) User {
+	roles := user.Roles
+	if roles == nil {
+		roles = []string{}
+	}
+	return User{
+		ID:        user.ID,
+		Email:     user.Email,
+		FirstName: user.FirstName,
+		LastName:  user.LastName,
+		Age:       user.Age,
+		Roles:     roles,
+		Frozen:    user.Frozen,
+	}
+}
+
+func handleAdminErr(w http.ResponseWriter, r *http.Request, err error) {
+	if errors.Is(err, adminErrors.ErrUserNotFound) {
+		response.RespondWithError(w, r, adminErrors.ErrUserNotFound.Error(), http.StatusNotFound)
+	} else if errors.Is(err, adminErrors.ErrInvalidAmount) {
+		response.RespondWithError(w, r, adminErrors.ErrInvalidAmount.Error(), http.StatusBadRequest)
+	} else if errors.Is(err, adminErrors.ErrInvalidReasonCode) {
+		response.RespondWithError(w, r, adminErrors.ErrInvalidReasonCode.Error(), http.StatusBadRequest)
+	} else if errors.Is(err, adminErrors.ErrSelfAdjustment) {
+		response.RespondWithError(w, r, adminErrors.ErrSelfAdjustment.Error(), http.StatusForbidden)
+	} else if errors.Is(err, adminErrors.ErrNotEnoughMoney) {
+		response.RespondWithError(w, r, adminErrors.ErrNotEnoughMoney.Error(), http.StatusBadRequest)
+	} else if errors.Is(err, adminErrors.ErrCurrencyCodeNotFound) {
+		response.RespondWithError(w, r, adminErrors.ErrCurrencyCodeNotFound.Error(), http.StatusNotFound)
+	} else if errors.Is(err, adminErrors.ErrWalletNotFound) {
+		response.RespondWithError(w, r, adminErrors.ErrWalletNotFound.Error(), http.StatusNotFound)
+	} else if errors.Is(err, adminErrors.ErrCurrencyExists) {
+		response.RespondWithError(w, r, adminErrors.ErrCurrencyExists.Error(), http.StatusConflict)
+	} else if errors.Is(err, adminErrors.ErrInvalidExponent) {
+		response.RespondWithError(w, r, adminErrors.ErrInvalidExponent.Error(), http.StatusBadRequest)
+	} else if errors.Is(err, adminErrors.ErrInvalidOrderLimits) {
+		response.RespondWithError(w, r, adminErrors.ErrInvalidOrderLimits.Error(), http.StatusBadRequest)
+	} else if errors.Is(err, adminErrors.ErrBaseCurrency) {
+		response.RespondWithError(w, r, adminErrors.ErrBaseCurrency.Error(), http.StatusBadRequest)
+	} else {
+		response.RespondWithError(w, r, "internal error", http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,242 @@
+// Code generated by mockery v2.45.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	authmodels "github.com/tizzhh/micro-banking/internal/domain/auth/models"
+
+	currencymodels "github.com/tizzhh/micro-banking/internal/domain/currency/models"
+
+	mock "github.com/stretchr/testify/mock"
+
+	models "github.com/tizzhh/micro-banking/internal/domain/admin/models"
+
+	money "github.com/tizzhh/micro-banking/pkg/money"
+)
+
+// Administrator is an autogenerated mock type for the Administrator type
+type Administrator struct {
+	mock.Mock
+}
+
+// Adjust provides a mock function with given fields: ctx, adminID, userID, amount, reason, comment
+func (_m *Administrator) Adjust(ctx context.Context, adminID uint64, userID uint64, amount money.Money, reason models.ReasonCode, comment string) (money.Money, error) {
+	ret := _m.Called(ctx, adminID, userID, amount, reason, comment)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Adjust")
+	}
+
+	var r0 money.Money
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64, money.Money, models.ReasonCode, string) (money.Money, error)); ok {
+		return rf(ctx, adminID, userID, amount, reason, comment)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64, money.Money, models.ReasonCode, string) money.Money); ok {
+		r0 = rf(ctx, adminID, userID, amount, reason, comment)
+	} else {
+		r0 = ret.Get(0).(money.Money)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, uint64, money.Money, models.ReasonCode, string) error); ok {
+		r1 = rf(ctx, adminID, userID, amount, reason, comment)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Balance provides a mock function with given fields: ctx, id
+func (_m *Administrator) Balance(ctx context.Context, id uint64) (models.Balance, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Balance")
+	}
+
+	var r0 models.Balance
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) (models.Balance, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) models.Balance); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(models.Balance)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateCurrency provides a mock function with given fields: ctx, currency
+func (_m *Administrator) CreateCurrency(ctx context.Context, currency currencymodels.Currency) (currencymodels.Currency, error) {
+	ret := _m.Called(ctx, currency)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateCurrency")
+	}
+
+	var r0 currencymodels.Currency
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, currencymodels.Currency) (currencymodels.Currency, error)); ok {
+		return rf(ctx, currency)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, currencymodels.Currency) currencymodels.Currency); ok {
+		r0 = rf(ctx, currency)
+	} else {
+		r0 = ret.Get(0).(currencymodels.Currency)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, currencymodels.Currency) error); ok {
+		r1 = rf(ctx, currency)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetFrozen provides a mock function with given fields: ctx, id, frozen
+func (_m *Administrator) SetFrozen(ctx context.Context, id uint64, frozen bool) error {
+	ret := _m.Called(ctx, id, frozen)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetFrozen")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, bool) error); ok {
+		r0 = rf(ctx, id, frozen)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Unlock provides a mock function with given fields: ctx, id
+func (_m *Administrator) Unlock(ctx context.Context, id uint64) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Unlock")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateCurrency provides a mock function with given fields: ctx, code, update
+func (_m *Administrator) UpdateCurrency(ctx context.Context, code string, update currencymodels.CurrencyUpdate) (currencymodels.Currency, error) {
+	ret := _m.Called(ctx, code, update)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateCurrency")
+	}
+
+	var r0 currencymodels.Currency
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, currencymodels.CurrencyUpdate) (currencymodels.Currency, error)); ok {
+		return rf(ctx, code, update)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, currencymodels.CurrencyUpdate) currencymodels.Currency); ok {
+		r0 = rf(ctx, code, update)
+	} else {
+		r0 = ret.Get(0).(currencymodels.Currency)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, currencymodels.CurrencyUpdate) error); ok {
+		r1 = rf(ctx, code, update)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// User provides a mock function with given fields: ctx, id
+func (_m *Administrator) User(ctx context.Context, id uint64) (authmodels.User, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for User")
+	}
+
+	var r0 authmodels.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) (authmodels.User, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) authmodels.User); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(authmodels.User)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Users provides a mock function with given fields: ctx, after, limit
+func (_m *Administrator) Users(ctx context.Context, after uint64, limit int) ([]authmodels.User, error) {
+	ret := _m.Called(ctx, after, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Users")
+	}
+
+	var r0 []authmodels.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, int) ([]authmodels.User, error)); ok {
+		return rf(ctx, after, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, int) []authmodels.User); ok {
+		r0 = rf(ctx, after, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]authmodels.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, int) error); ok {
+		r1 = rf(ctx, after, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewAdministrator creates a new instance of Administrator. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAdministrator(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Administrator {
+	mock := &Administrator{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
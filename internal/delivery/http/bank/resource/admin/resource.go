@@ -0,0 +1,80 @@
+package admin
+
+// Amounts are decimal strings in major units, e.g. "12.34".
+
+type User struct {
+	ID        uint64   `json:"id"`
+	Email     string   `json:"email"`
+	FirstName string   `json:"first_name"`
+	LastName  string   `json:"last_name"`
+	Age       uint32   `json:"age"`
+	Roles     []string `json:"roles"`
+	Frozen    bool     `json:"frozen"`
+}
+
+type UsersResponse struct {
+	Users []User `json:"users"`
+	// NextAfter is passed as after to get the next page. It is empty on the
+	// last page.
+	NextAfter uint64 `json:"next_after,omitempty"`
+}
+
+type BalanceResponse struct {
+	UserID  uint64   `json:"user_id"`
+	Frozen  bool     `json:"frozen"`
+	Balance string   `json:"balance"`
+	Wallets []Wallet `json:"wallets"`
+}
+
+type Wallet struct {
+	CurrencyCode string `json:"currency_code"`
+	Balance      string `json:"balance"`
+}
+
+// AdjustmentRequest credits a positive and debits a negative amount. Without
+// a currency code the USD balance is adjusted.
+type AdjustmentRequest struct {
+	Amount       string `json:"amount" validate:"required,numeric"`
+	CurrencyCode string `json:"currency_code" validate:"omitempty,len=3,alpha,uppercase"`
+	ReasonCode   string `json:"reason_code" validate:"required,oneof=correction chargeback refund goodwill fraud"`
+	Comment      string `json:"comment" validate:"max=500"`
+}
+
+type AdjustmentResponse struct {
+	NewBalanceAmount string `json:"new_balance_amount"`
+	CurrencyCode     string `json:"currency_code"`
+}
+
+// Currency is an entry of the currency catalog. An empty max order means no
+// limit.
+type Currency struct {
+	Code     string `json:"code"`
+	Name     string `json:"name"`
+	Exponent int    `json:"exponent"`
+	Enabled  bool   `json:"enabled"`
+	Tradable bool   `json:"tradable"`
+	MinOrder string `json:"min_order"`
+	MaxOrder string `json:"max_order,omitempty"`
+}
+
+// CreateCurrencyRequest adds a currency, enabled unless enabled is false.
+// The exponent is the number of minor unit digits and cannot be changed.
+type CreateCurrencyRequest struct {
+	Code     string `json:"code" validate:"required,len=3,alpha,uppercase"`
+	Name     string `json:"name" validate:"required,lte=100"`
+	Exponent *int   `json:"exponent" validate:"required,gte=0,lte=8"`
+	Enabled  *bool  `json:"enabled"`
+	Tradable bool   `json:"tradable"`
+	MinOrder string `json:"min_order" validate:"omitempty,numeric"`
+	MaxOrder string `json:"max_order" validate:"omitempty,numeric"`
+}
+
+// UpdateCurrencyRequest changes the fields that are set. A max order of 0
+// removes the limit.
+type UpdateCurrencyRequest struct {
+	Name     *string `json:"name" validate:"omitnil,gte=1,lte=100"`
+	Enabled  *bool   `json:"enabled"`
+	Tradable *bool   `json:"tradable"`
+	MinOrder *string `json:"min_order" validate:"omitnil,numeric"`
+	MaxOrder *string `json:"max_order" validate:"omitnil,numeric"`
+}
@@ -2,15 +2,20 @@ package auth
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
 	"net/http"
+	"time"
 
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/render"
 	"github.com/go-playground/validator/v10"
+	"github.com/tizzhh/micro-banking/internal/api/principal"
 	"github.com/tizzhh/micro-banking/internal/api/response"
 	"github.com/tizzhh/micro-banking/internal/api/validate"
 	"github.com/tizzhh/micro-banking/internal/delivery/http/bank/common"
+	"github.com/tizzhh/micro-banking/internal/domain/auth/models"
+	"github.com/tizzhh/micro-banking/pkg/jwt"
 	"github.com/tizzhh/micro-banking/pkg/logger/sl"
 )
 
@@ -23,12 +28,33 @@ type AuthAPI struct {
 //go:generate go run github.com/vektra/mockery/v2 --name=AuthClient
 type AuthClient interface {
 	Register(ctx context.Context, email string, password string, firstName string, lastName string, age uint32) (uint64, error)
-	Login(ctx context.Context, email string, password string) (string, error)
+	Login(ctx context.Context, email string, password string) (LoginResponse, error)
+	Refresh(ctx context.Context, refreshToken string) (LoginResponse, error)
+	Logout(ctx context.Context, token string, refreshToken string) error
 	UpdatePassword(ctx context.Context, email string, oldPassword string, newPassword string) error
 	Unregister(ctx context.Context, email string, password string) error
 	User(ctx context.Context, email string) (UserResponse, error)
+	JWKS(ctx context.Context) (jwt.JWKS, error)
+	EnrollMFA(ctx context.Context, email string) (EnrollMFAResponse, error)
+	ConfirmMFA(ctx context.Context, email string, code string) ([]string, error)
+	DisableMFA(ctx context.Context, email string, password string, code string) error
+	RegenerateRecoveryCodes(ctx context.Context, email string, code string) ([]string, error)
+	VerifyMFA(ctx context.Context, mfaToken string, code string) (LoginResponse, error)
+	StepUp(ctx context.Context, email string, code string) (string, error)
+	VerifyEmail(ctx context.Context, token string) error
+	ResendVerification(ctx context.Context, email string) error
+	RequestPasswordReset(ctx context.Context, email string) error
+	ResetPassword(ctx context.Context, token string, newPassword string) error
+	UpdateProfile(ctx context.Context, email string, update UpdateProfileRequest) (UserResponse, error)
+	ChangeEmail(ctx context.Context, email string, password string, newEmail string) error
 }
 
+type KeySet interface {
+	JWKS() (jwt.JWKS, error)
+}
+
+const jwksMaxAge = 5 * time.Minute
+
 func New(log *slog.Logger, validator *validator.Validate, authClient AuthClient) *AuthAPI {
 	return &AuthAPI{
 		log:        log,
@@ -39,7 +65,7 @@ func New(log *slog.Logger, validator *validator.Validate, authClient AuthClient)
 
 // NewUser godoc
 // @Summary Register a new user
-// @Description Register a new user
+// @Description Register a new user and send them a mail to verify their email address with. Users cannot move money until it is verified
 // @Tags auth
 // @Accept json
 // @Produce json
@@ -85,13 +111,14 @@ func (aa *AuthAPI) NewUser() http.HandlerFunc {
 			FirstName: registerRequest.FirstName,
 			LastName:  registerRequest.LastName,
 			Age:       registerRequest.Age,
+			Status:    string(models.UserStatusPendingVerification),
 		})
 	}
 }
 
 // Login godoc
 // @Summary Login a user
-// @Description Login a user and get token
+// @Description Login a user and get token. Users with MFA get an mfa_token instead, to complete the login with at /auth/mfa/verify. Failed logins back off and eventually lock out the account and the client IP
 // @Tags auth
 // @Accept json
 // @Produce json
@@ -99,6 +126,7 @@ func (aa *AuthAPI) NewUser() http.HandlerFunc {
 // @Success 200 {object} LoginResponse
 // @Failure 400 {object} response.Error
 // @Failure 404 {object} response.Error
+// @Failure 429 {object} response.Error
 // @Failure 500 {object} response.Error
 // @Router /auth/login [post]
 func (aa *AuthAPI) LoginUser() http.HandlerFunc {
@@ -116,7 +144,7 @@ func (aa *AuthAPI) LoginUser() http.HandlerFunc {
 			return
 		}
 
-		token, err := aa.authClient.Login(
+		tokens, err := aa.authClient.Login(
 			r.Context(),
 			loginRequest.Email,
 			loginRequest.Password,
@@ -129,9 +157,92 @@ func (aa *AuthAPI) LoginUser() http.HandlerFunc {
 
 		log.Info("user created")
 
-		render.JSON(w, r, LoginResponse{
-			Token: token,
-		})
+		render.JSON(w, r, tokens)
+	}
+}
+
+// Refresh godoc
+// @Summary Refresh tokens
+// @Description Exchange a refresh token for a new token pair. A refresh token can be used only once
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param RefreshRequest body RefreshRequest true "Refresh Request"
+// @Success 200 {object} LoginResponse
+// @Failure 400 {object} response.Error
+// @Failure 401 {object} response.Error
+// @Failure 500 {object} response.Error
+// @Router /auth/refresh [post]
+func (aa *AuthAPI) Refresh() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const caller = "bank.auth.handler.Refresh"
+		log := sl.AddRequestId(sl.AddCaller(aa.log, caller), middleware.GetReqID(r.Context()))
+		log.Info("refreshing tokens")
+
+		var refreshRequest RefreshRequest
+
+		err := validate.ValidateRequest(aa.log, &refreshRequest, r.Body)
+		if err != nil {
+			log.Error("validation err", sl.Error(err))
+			common.HandleValidationErr(w, r, err)
+			return
+		}
+
+		tokens, err := aa.authClient.Refresh(r.Context(), refreshRequest.RefreshToken)
+		if err != nil {
+			log.Error("failed to refresh tokens", sl.Error(err))
+			common.HandleGrpcError(aa.log, w, r, err)
+			return
+		}
+
+		log.Info("tokens refreshed")
+
+		render.JSON(w, r, tokens)
+	}
+}
+
+// Logout godoc
+// @Summary Logout a user
+// @Description Revoke the access token of the request and the session of the refresh token
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param LogoutRequest body LogoutRequest true "Logout Request"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Error
+// @Failure 401 {object} response.Error
+// @Failure 500 {object} response.Error
+// @Router /auth/logout [post]
+// @Security BearerAuth
+func (aa *AuthAPI) Logout() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const caller = "bank.auth.handler.Logout"
+		log := sl.AddRequestId(sl.AddCaller(aa.log, caller), middleware.GetReqID(r.Context()))
+		log.Info("logging the user out")
+
+		var logoutRequest LogoutRequest
+
+		err := validate.ValidateRequest(aa.log, &logoutRequest, r.Body)
+		if err != nil {
+			log.Error("validation err", sl.Error(err))
+			common.HandleValidationErr(w, r, err)
+			return
+		}
+
+		err = aa.authClient.Logout(
+			r.Context(),
+			principal.TokenFromContext(r.Context()),
+			logoutRequest.RefreshToken,
+		)
+		if err != nil {
+			log.Error("failed to logout user", sl.Error(err))
+			common.HandleGrpcError(aa.log, w, r, err)
+			return
+		}
+
+		log.Info("user logged out")
+
+		response.ReponsdWithOK(w, r, "Logged out successfully", http.StatusOK)
 	}
 }
 
@@ -154,6 +265,11 @@ func (aa *AuthAPI) UpdatePassword() http.HandlerFunc {
 		log := sl.AddRequestId(sl.AddCaller(aa.log, caller), middleware.GetReqID(r.Context()))
 		log.Info("updating user password")
 
+		p, ok := common.Principal(w, r)
+		if !ok {
+			return
+		}
+
 		var loginRequest UpdatePasswordRequest
 
 		err := validate.ValidateRequest(aa.log, &loginRequest, r.Body)
@@ -165,7 +281,7 @@ func (aa *AuthAPI) UpdatePassword() http.HandlerFunc {
 
 		err = aa.authClient.UpdatePassword(
 			r.Context(),
-			loginRequest.Email,
+			p.Email,
 			loginRequest.OldPassword,
 			loginRequest.NewPassword,
 		)
@@ -183,8 +299,8 @@ func (aa *AuthAPI) UpdatePassword() http.HandlerFunc {
 
 // DeleteUser godoc
 // @Summary Unregister user
-// @Description Unregister user from the service.
-// This action deletes corresponding data from the db
+// @Description Close the account of the authenticated user. The balance and all wallets must be empty.
+// The account is kept with its wallets and transactions, and its personal data is erased after the retention period
 // @Tags auth
 // @Accept json
 // @Produce json
@@ -201,6 +317,11 @@ func (aa *AuthAPI) DeleteUser() http.HandlerFunc {
 		log := sl.AddRequestId(sl.AddCaller(aa.log, caller), middleware.GetReqID(r.Context()))
 		log.Info("deleting user")
 
+		p, ok := common.Principal(w, r)
+		if !ok {
+			return
+		}
+
 		var loginRequest DeleteUserRequest
 
 		err := validate.ValidateRequest(aa.log, &loginRequest, r.Body)
@@ -212,7 +333,7 @@ func (aa *AuthAPI) DeleteUser() http.HandlerFunc {
 
 		err = aa.authClient.Unregister(
 			r.Context(),
-			loginRequest.Email,
+			p.Email,
 			loginRequest.Password,
 		)
 		if err != nil {
@@ -229,13 +350,11 @@ func (aa *AuthAPI) DeleteUser() http.HandlerFunc {
 
 // User godoc
 // @Summary Returns user
-// @Description Returns user
+// @Description Returns the authenticated user
 // @Tags auth
-// @Accept json
 // @Produce json
-// @Param UserRequest body UserRequest true "User Request"
 // @Success 200 {object} UserResponse
-// @Failure 400 {object} response.Error
+// @Failure 401 {object} response.Error
 // @Failure 404 {object} response.Error
 // @Failure 500 {object} response.Error
 // @Router /auth/user [get]
@@ -246,18 +365,14 @@ func (aa *AuthAPI) User() http.HandlerFunc {
 		log := sl.AddRequestId(sl.AddCaller(aa.log, caller), middleware.GetReqID(r.Context()))
 		log.Info("getting user")
 
-		var loginRequest UserRequest
-
-		err := validate.ValidateRequest(aa.log, &loginRequest, r.Body)
-		if err != nil {
-			log.Error("validation err", sl.Error(err))
-			common.HandleValidationErr(w, r, err)
+		p, ok := common.Principal(w, r)
+		if !ok {
 			return
 		}
 
 		user, err := aa.authClient.User(
 			r.Context(),
-			loginRequest.Email,
+			p.Email,
 		)
 		if err != nil {
 			log.Error("failed to delete user", sl.Error(err))
@@ -267,13 +382,31 @@ func (aa *AuthAPI) User() http.HandlerFunc {
 
 		log.Info("user deleted")
 
-		render.JSON(w, r, UserResponse{
-			ID:        user.ID,
-			Email:     user.Email,
-			FirstName: user.FirstName,
-			LastName:  user.LastName,
-			Balance:   user.Balance,
-			Age:       user.Age,
-		})
+		render.JSON(w, r, user)
+	}
+}
+
+// JWKS godoc
+// @Summary Token verification keys
+// @Description Returns the public keys access tokens are signed with as a JSON Web Key Set
+// @Tags auth
+// @Produce json
+// @Success 200 {object} jwt.JWKS
+// @Failure 500 {object} response.Error
+// @Router /.well-known/jwks.json [get]
+func (aa *AuthAPI) JWKS(keys KeySet) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const caller = "bank.auth.handler.JWKS"
+		log := sl.AddRequestId(sl.AddCaller(aa.log, caller), middleware.GetReqID(r.Context()))
+
+		jwks, err := keys.JWKS()
+		if err != nil {
+			log.Error("failed to get keys", sl.Error(err))
+			response.RespondWithError(w, r, "internal error", http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%d", int(jwksMaxAge.Seconds())))
+		render.JSON(w, r, jwks)
 	}
 }
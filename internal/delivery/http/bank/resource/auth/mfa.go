@@ -0,0 +1,275 @@
+package auth
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/tizzhh/micro-banking/internal/api/response"
+	"github.com/tizzhh/micro-banking/internal/api/validate"
+	"github.com/tizzhh/micro-banking/internal/delivery/http/bank/common"
+	"github.com/tizzhh/micro-banking/pkg/logger/sl"
+)
+
+// EnrollMFA godoc
+// @Summary Enroll MFA
+// @Description Generate a TOTP secret and its otpauth URI for an authenticator app. MFA is enabled once confirmed with a code
+// @Tags mfa
+// @Produce json
+// @Success 200 {object} EnrollMFAResponse
+// @Failure 400 {object} response.Error
+// @Failure 401 {object} response.Error
+// @Failure 500 {object} response.Error
+// @Router /auth/mfa/enroll [post]
+// @Security BearerAuth
+func (aa *AuthAPI) EnrollMFA() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const caller = "bank.auth.handler.EnrollMFA"
+		log := sl.AddRequestId(sl.AddCaller(aa.log, caller), middleware.GetReqID(r.Context()))
+		log.Info("enrolling mfa")
+
+		p, ok := common.Principal(w, r)
+		if !ok {
+			return
+		}
+
+		enrollment, err := aa.authClient.EnrollMFA(r.Context(), p.Email)
+		if err != nil {
+			log.Error("failed to enroll mfa", sl.Error(err))
+			common.HandleGrpcError(aa.log, w, r, err)
+			return
+		}
+
+		log.Info("mfa enrolled")
+
+		render.JSON(w, r, enrollment)
+	}
+}
+
+// ConfirmMFA godoc
+// @Summary Confirm MFA
+// @Description Enable MFA with the first code of the authenticator app. Returns single-use recovery codes
+// @Tags mfa
+// @Accept json
+// @Produce json
+// @Param ConfirmMFARequest body ConfirmMFARequest true "Confirm MFA Request"
+// @Success 200 {object} RecoveryCodesResponse
+// @Failure 400 {object} response.Error
+// @Failure 401 {object} response.Error
+// @Failure 500 {object} response.Error
+// @Router /auth/mfa/confirm [post]
+// @Security BearerAuth
+func (aa *AuthAPI) ConfirmMFA() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const caller = "bank.auth.handler.ConfirmMFA"
+		log := sl.AddRequestId(sl.AddCaller(aa.log, caller), middleware.GetReqID(r.Context()))
+		log.Info("confirming mfa")
+
+		p, ok := common.Principal(w, r)
+		if !ok {
+			return
+		}
+
+		var confirmRequest ConfirmMFARequest
+
+		err := validate.ValidateRequest(aa.log, &confirmRequest, r.Body)
+		if err != nil {
+			log.Error("validation err", sl.Error(err))
+			common.HandleValidationErr(w, r, err)
+			return
+		}
+
+		recoveryCodes, err := aa.authClient.ConfirmMFA(r.Context(), p.Email, confirmRequest.Code)
+		if err != nil {
+			log.Error("failed to confirm mfa", sl.Error(err))
+			common.HandleGrpcError(aa.log, w, r, err)
+			return
+		}
+
+		log.Info("mfa enabled")
+
+		render.JSON(w, r, RecoveryCodesResponse{RecoveryCodes: recoveryCodes})
+	}
+}
+
+// DisableMFA godoc
+// @Summary Disable MFA
+// @Description Disable MFA with the password and a TOTP or recovery code
+// @Tags mfa
+// @Accept json
+// @Produce json
+// @Param DisableMFARequest body DisableMFARequest true "Disable MFA Request"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Error
+// @Failure 401 {object} response.Error
+// @Failure 429 {object} response.Error
+// @Failure 500 {object} response.Error
+// @Router /auth/mfa/disable [post]
+// @Security BearerAuth
+func (aa *AuthAPI) DisableMFA() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const caller = "bank.auth.handler.DisableMFA"
+		log := sl.AddRequestId(sl.AddCaller(aa.log, caller), middleware.GetReqID(r.Context()))
+		log.Info("disabling mfa")
+
+		p, ok := common.Principal(w, r)
+		if !ok {
+			return
+		}
+
+		var disableRequest DisableMFARequest
+
+		err := validate.ValidateRequest(aa.log, &disableRequest, r.Body)
+		if err != nil {
+			log.Error("validation err", sl.Error(err))
+			common.HandleValidationErr(w, r, err)
+			return
+		}
+
+		err = aa.authClient.DisableMFA(r.Context(), p.Email, disableRequest.Password, disableRequest.Code)
+		if err != nil {
+			log.Error("failed to disable mfa", sl.Error(err))
+			common.HandleGrpcError(aa.log, w, r, err)
+			return
+		}
+
+		log.Info("mfa disabled")
+
+		response.ReponsdWithOK(w, r, "MFA disabled successfully", http.StatusOK)
+	}
+}
+
+// RegenerateRecoveryCodes godoc
+// @Summary Regenerate recovery codes
+// @Description Replace the recovery codes, given a TOTP or recovery code. The old codes stop working
+// @Tags mfa
+// @Accept json
+// @Produce json
+// @Param MFACodeRequest body MFACodeRequest true "MFA Code Request"
+// @Success 200 {object} RecoveryCodesResponse
+// @Failure 400 {object} response.Error
+// @Failure 401 {object} response.Error
+// @Failure 429 {object} response.Error
+// @Failure 500 {object} response.Error
+// @Router /auth/mfa/recovery-codes [post]
+// @Security BearerAuth
+func (aa *AuthAPI) RegenerateRecoveryCodes() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const caller = "bank.auth.handler.RegenerateRecoveryCodes"
+		log := sl.AddRequestId(sl.AddCaller(aa.log, caller), middleware.GetReqID(r.Context()))
+		log.Info("regenerating recovery codes")
+
+		p, ok := common.Principal(w, r)
+		if !ok {
+			return
+		}
+
+		var codeRequest MFACodeRequest
+
+		err := validate.ValidateRequest(aa.log, &codeRequest, r.Body)
+		if err != nil {
+			log.Error("validation err", sl.Error(err))
+			common.HandleValidationErr(w, r, err)
+			return
+		}
+
+		recoveryCodes, err := aa.authClient.RegenerateRecoveryCodes(r.Context(), p.Email, codeRequest.Code)
+		if err != nil {
+			log.Error("failed to regenerate recovery codes", sl.Error(err))
+			common.HandleGrpcError(aa.log, w, r, err)
+			return
+		}
+
+		log.Info("recovery codes regenerated")
+
+		render.JSON(w, r, RecoveryCodesResponse{RecoveryCodes: recoveryCodes})
+	}
+}
+
+// VerifyMFA godoc
+// @Summary Complete an MFA login
+// @Description Exchange the mfa_token of a login and a TOTP or recovery code for a token pair. An mfa_token can be used once
+// @Tags mfa
+// @Accept json
+// @Produce json
+// @Param VerifyMFARequest body VerifyMFARequest true "Verify MFA Request"
+// @Success 200 {object} LoginResponse
+// @Failure 400 {object} response.Error
+// @Failure 401 {object} response.Error
+// @Failure 429 {object} response.Error
+// @Failure 500 {object} response.Error
+// @Router /auth/mfa/verify [post]
+func (aa *AuthAPI) VerifyMFA() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const caller = "bank.auth.handler.VerifyMFA"
+		log := sl.AddRequestId(sl.AddCaller(aa.log, caller), middleware.GetReqID(r.Context()))
+		log.Info("verifying mfa")
+
+		var verifyRequest VerifyMFARequest
+
+		err := validate.ValidateRequest(aa.log, &verifyRequest, r.Body)
+		if err != nil {
+			log.Error("validation err", sl.Error(err))
+			common.HandleValidationErr(w, r, err)
+			return
+		}
+
+		tokens, err := aa.authClient.VerifyMFA(r.Context(), verifyRequest.MFAToken, verifyRequest.Code)
+		if err != nil {
+			log.Error("failed to verify mfa", sl.Error(err))
+			common.HandleGrpcError(aa.log, w, r, err)
+			return
+		}
+
+		log.Info("user logged in with mfa")
+
+		render.JSON(w, r, tokens)
+	}
+}
+
+// StepUp godoc
+// @Summary Step up authentication
+// @Description Verify a TOTP or recovery code and get an access token that allows large withdrawals and transfers for a short time
+// @Tags mfa
+// @Accept json
+// @Produce json
+// @Param MFACodeRequest body MFACodeRequest true "MFA Code Request"
+// @Success 200 {object} StepUpResponse
+// @Failure 400 {object} response.Error
+// @Failure 401 {object} response.Error
+// @Failure 429 {object} response.Error
+// @Failure 500 {object} response.Error
+// @Router /auth/mfa/step-up [post]
+// @Security BearerAuth
+func (aa *AuthAPI) StepUp() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const caller = "bank.auth.handler.StepUp"
+		log := sl.AddRequestId(sl.AddCaller(aa.log, caller), middleware.GetReqID(r.Context()))
+		log.Info("stepping up authentication")
+
+		p, ok := common.Principal(w, r)
+		if !ok {
+			return
+		}
+
+		var codeRequest MFACodeRequest
+
+		err := validate.ValidateRequest(aa.log, &codeRequest, r.Body)
+		if err != nil {
+			log.Error("validation err", sl.Error(err))
+			common.HandleValidationErr(w, r, err)
+			return
+		}
+
+		token, err := aa.authClient.StepUp(r.Context(), p.Email, codeRequest.Code)
+		if err != nil {
+			log.Error("failed to step up authentication", sl.Error(err))
+			common.HandleGrpcError(aa.log, w, r, err)
+			return
+		}
+
+		log.Info("authentication stepped up")
+
+		render.JSON(w, r, StepUpResponse{Token: token})
+	}
+}
@@ -7,6 +7,8 @@ import (
 
 	auth "github.com/tizzhh/micro-banking/internal/delivery/http/bank/resource/auth"
 
+	jwt "github.com/tizzhh/micro-banking/pkg/jwt"
+
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -15,23 +17,145 @@ type AuthClient struct {
 	mock.Mock
 }
 
+// ChangeEmail provides a mock function with given fields: ctx, email, password, newEmail
+func (_m *AuthClient) ChangeEmail(ctx context.Context, email string, password string, newEmail string) error {
+	ret := _m.Called(ctx, email, password, newEmail)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ChangeEmail")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
+		r0 = rf(ctx, email, password, newEmail)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ConfirmMFA provides a mock function with given fields: ctx, email, code
+func (_m *AuthClient) ConfirmMFA(ctx context.Context, email string, code string) ([]string, error) {
+	ret := _m.Called(ctx, email, code)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ConfirmMFA")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) ([]string, error)); ok {
+		return rf(ctx, email, code)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) []string); ok {
+		r0 = rf(ctx, email, code)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, email, code)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DisableMFA provides a mock function with given fields: ctx, email, password, code
+func (_m *AuthClient) DisableMFA(ctx context.Context, email string, password string, code string) error {
+	ret := _m.Called(ctx, email, password, code)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DisableMFA")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
+		r0 = rf(ctx, email, password, code)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// EnrollMFA provides a mock function with given fields: ctx, email
+func (_m *AuthClient) EnrollMFA(ctx context.Context, email string) (auth.EnrollMFAResponse, error) {
+	ret := _m.Called(ctx, email)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EnrollMFA")
+	}
+
+	var r0 auth.EnrollMFAResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (auth.EnrollMFAResponse, error)); ok {
+		return rf(ctx, email)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) auth.EnrollMFAResponse); ok {
+		r0 = rf(ctx, email)
+	} else {
+		r0 = ret.Get(0).(auth.EnrollMFAResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, email)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// JWKS provides a mock function with given fields: ctx
+func (_m *AuthClient) JWKS(ctx context.Context) (jwt.JWKS, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for JWKS")
+	}
+
+	var r0 jwt.JWKS
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (jwt.JWKS, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) jwt.JWKS); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(jwt.JWKS)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Login provides a mock function with given fields: ctx, email, password
-func (_m *AuthClient) Login(ctx context.Context, email string, password string) (string, error) {
+func (_m *AuthClient) Login(ctx context.Context, email string, password string) (auth.LoginResponse, error) {
 	ret := _m.Called(ctx, email, password)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Login")
 	}
 
-	var r0 string
+	var r0 auth.LoginResponse
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) (string, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (auth.LoginResponse, error)); ok {
 		return rf(ctx, email, password)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) string); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) auth.LoginResponse); ok {
 		r0 = rf(ctx, email, password)
 	} else {
-		r0 = ret.Get(0).(string)
+		r0 = ret.Get(0).(auth.LoginResponse)
 	}
 
 	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
@@ -43,6 +167,82 @@ func (_m *AuthClient) Login(ctx context.Context, email string, password string)
 	return r0, r1
 }
 
+// Logout provides a mock function with given fields: ctx, token, refreshToken
+func (_m *AuthClient) Logout(ctx context.Context, token string, refreshToken string) error {
+	ret := _m.Called(ctx, token, refreshToken)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Logout")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, token, refreshToken)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Refresh provides a mock function with given fields: ctx, refreshToken
+func (_m *AuthClient) Refresh(ctx context.Context, refreshToken string) (auth.LoginResponse, error) {
+	ret := _m.Called(ctx, refreshToken)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Refresh")
+	}
+
+	var r0 auth.LoginResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (auth.LoginResponse, error)); ok {
+		return rf(ctx, refreshToken)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) auth.LoginResponse); ok {
+		r0 = rf(ctx, refreshToken)
+	} else {
+		r0 = ret.Get(0).(auth.LoginResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, refreshToken)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RegenerateRecoveryCodes provides a mock function with given fields: ctx, email, code
+func (_m *AuthClient) RegenerateRecoveryCodes(ctx context.Context, email string, code string) ([]string, error) {
+	ret := _m.Called(ctx, email, code)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RegenerateRecoveryCodes")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) ([]string, error)); ok {
+		return rf(ctx, email, code)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) []string); ok {
+		r0 = rf(ctx, email, code)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, email, code)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Register provides a mock function with given fields: ctx, email, password, firstName, lastName, age
 func (_m *AuthClient) Register(ctx context.Context, email string, password string, firstName string, lastName string, age uint32) (uint64, error) {
 	ret := _m.Called(ctx, email, password, firstName, lastName, age)
@@ -71,6 +271,88 @@ func (_m *AuthClient) Register(ctx context.Context, email string, password strin
 	return r0, r1
 }
 
+// RequestPasswordReset provides a mock function with given fields: ctx, email
+func (_m *AuthClient) RequestPasswordReset(ctx context.Context, email string) error {
+	ret := _m.Called(ctx, email)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RequestPasswordReset")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, email)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ResendVerification provides a mock function with given fields: ctx, email
+func (_m *AuthClient) ResendVerification(ctx context.Context, email string) error {
+	ret := _m.Called(ctx, email)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResendVerification")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, email)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ResetPassword provides a mock function with given fields: ctx, token, newPassword
+func (_m *AuthClient) ResetPassword(ctx context.Context, token string, newPassword string) error {
+	ret := _m.Called(ctx, token, newPassword)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResetPassword")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, token, newPassword)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// StepUp provides a mock function with given fields: ctx, email, code
+func (_m *AuthClient) StepUp(ctx context.Context, email string, code string) (string, error) {
+	ret := _m.Called(ctx, email, code)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StepUp")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (string, error)); ok {
+		return rf(ctx, email, code)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) string); ok {
+		r0 = rf(ctx, email, code)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, email, code)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Unregister provides a mock function with given fields: ctx, email, password
 func (_m *AuthClient) Unregister(ctx context.Context, email string, password string) error {
 	ret := _m.Called(ctx, email, password)
@@ -107,6 +389,34 @@ func (_m *AuthClient) UpdatePassword(ctx context.Context, email string, oldPassw
 	return r0
 }
 
+// UpdateProfile provides a mock function with given fields: ctx, email, update
+func (_m *AuthClient) UpdateProfile(ctx context.Context, email string, update auth.UpdateProfileRequest) (auth.UserResponse, error) {
+	ret := _m.Called(ctx, email, update)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateProfile")
+	}
+
+	var r0 auth.UserResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, auth.UpdateProfileRequest) (auth.UserResponse, error)); ok {
+		return rf(ctx, email, update)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, auth.UpdateProfileRequest) auth.UserResponse); ok {
+		r0 = rf(ctx, email, update)
+	} else {
+		r0 = ret.Get(0).(auth.UserResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, auth.UpdateProfileRequest) error); ok {
+		r1 = rf(ctx, email, update)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // User provides a mock function with given fields: ctx, email
 func (_m *AuthClient) User(ctx context.Context, email string) (auth.UserResponse, error) {
 	ret := _m.Called(ctx, email)
@@ -135,6 +445,52 @@ func (_m *AuthClient) User(ctx context.Context, email string) (auth.UserResponse
 	return r0, r1
 }
 
+// VerifyEmail provides a mock function with given fields: ctx, token
+func (_m *AuthClient) VerifyEmail(ctx context.Context, token string) error {
+	ret := _m.Called(ctx, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for VerifyEmail")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, token)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// VerifyMFA provides a mock function with given fields: ctx, mfaToken, code
+func (_m *AuthClient) VerifyMFA(ctx context.Context, mfaToken string, code string) (auth.LoginResponse, error) {
+	ret := _m.Called(ctx, mfaToken, code)
+
+	if len(ret) == 0 {
+		panic("no return value specified for VerifyMFA")
+	}
+
+	var r0 auth.LoginResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (auth.LoginResponse, error)); ok {
+		return rf(ctx, mfaToken, code)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) auth.LoginResponse); ok {
+		r0 = rf(ctx, mfaToken, code)
+	} else {
+		r0 = ret.Get(0).(auth.LoginResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, mfaToken, code)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewAuthClient creates a new instance of AuthClient. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewAuthClient(t interface {
@@ -0,0 +1,89 @@
+package auth
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/tizzhh/micro-banking/internal/api/response"
+	"github.com/tizzhh/micro-banking/internal/api/validate"
+	"github.com/tizzhh/micro-banking/internal/delivery/http/bank/common"
+	"github.com/tizzhh/micro-banking/pkg/logger/sl"
+)
+
+// RequestPasswordReset godoc
+// @Summary Request a password reset
+// @Description Mail a password reset token to the user. The response is the same whether or not the email is registered
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param PasswordResetRequest body PasswordResetRequest true "Password Reset Request"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Error
+// @Failure 500 {object} response.Error
+// @Router /auth/password-reset [post]
+func (aa *AuthAPI) RequestPasswordReset() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const caller = "bank.auth.handler.RequestPasswordReset"
+		log := sl.AddRequestId(sl.AddCaller(aa.log, caller), middleware.GetReqID(r.Context()))
+		log.Info("requesting password reset")
+
+		var resetRequest PasswordResetRequest
+
+		err := validate.ValidateRequest(aa.log, &resetRequest, r.Body)
+		if err != nil {
+			log.Error("validation err", sl.Error(err))
+			common.HandleValidationErr(w, r, err)
+			return
+		}
+
+		err = aa.authClient.RequestPasswordReset(r.Context(), resetRequest.Email)
+		if err != nil {
+			log.Error("failed to request password reset", sl.Error(err))
+			common.HandleGrpcError(aa.log, w, r, err)
+			return
+		}
+
+		log.Info("password reset requested")
+
+		response.ReponsdWithOK(w, r, "If the email is registered, a password reset mail was sent", http.StatusOK)
+	}
+}
+
+// ResetPassword godoc
+// @Summary Reset password
+// @Description Set a new password with the token from the password reset mail. A token can be used once. All sessions of the user are signed out
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param ResetPasswordRequest body ResetPasswordRequest true "Reset Password Request"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Error
+// @Failure 500 {object} response.Error
+// @Router /auth/password-reset/confirm [post]
+func (aa *AuthAPI) ResetPassword() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const caller = "bank.auth.handler.ResetPassword"
+		log := sl.AddRequestId(sl.AddCaller(aa.log, caller), middleware.GetReqID(r.Context()))
+		log.Info("resetting password")
+
+		var resetRequest ResetPasswordRequest
+
+		err := validate.ValidateRequest(aa.log, &resetRequest, r.Body)
+		if err != nil {
+			log.Error("validation err", sl.Error(err))
+			common.HandleValidationErr(w, r, err)
+			return
+		}
+
+		err = aa.authClient.ResetPassword(r.Context(), resetRequest.Token, resetRequest.NewPassword)
+		if err != nil {
+			log.Error("failed to reset password", sl.Error(err))
+			common.HandleGrpcError(aa.log, w, r, err)
+			return
+		}
+
+		log.Info("password reset")
+
+		response.ReponsdWithOK(w, r, "Password reset successfully", http.StatusOK)
+	}
+}
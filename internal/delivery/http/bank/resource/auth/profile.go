@@ -0,0 +1,105 @@
+package auth
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/tizzhh/micro-banking/internal/api/response"
+	"github.com/tizzhh/micro-banking/internal/api/validate"
+	"github.com/tizzhh/micro-banking/internal/delivery/http/bank/common"
+	"github.com/tizzhh/micro-banking/pkg/logger/sl"
+)
+
+// UpdateProfile godoc
+// @Summary Update profile
+// @Description Change the name, age or phone number of the authenticated user. Only the fields in the request are changed, an empty phone number removes it
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param UpdateProfileRequest body UpdateProfileRequest true "Update Profile Request"
+// @Success 200 {object} UserResponse
+// @Failure 400 {object} response.Error
+// @Failure 401 {object} response.Error
+// @Failure 404 {object} response.Error
+// @Failure 500 {object} response.Error
+// @Router /auth/user [patch]
+// @Security BearerAuth
+func (aa *AuthAPI) UpdateProfile() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const caller = "bank.auth.handler.UpdateProfile"
+		log := sl.AddRequestId(sl.AddCaller(aa.log, caller), middleware.GetReqID(r.Context()))
+		log.Info("updating profile")
+
+		p, ok := common.Principal(w, r)
+		if !ok {
+			return
+		}
+
+		var updateRequest UpdateProfileRequest
+
+		err := validate.ValidateRequest(aa.log, &updateRequest, r.Body)
+		if err != nil {
+			log.Error("validation err", sl.Error(err))
+			common.HandleValidationErr(w, r, err)
+			return
+		}
+
+		user, err := aa.authClient.UpdateProfile(r.Context(), p.Email, updateRequest)
+		if err != nil {
+			log.Error("failed to update profile", sl.Error(err))
+			common.HandleGrpcError(aa.log, w, r, err)
+			return
+		}
+
+		log.Info("profile updated")
+
+		render.JSON(w, r, user)
+	}
+}
+
+// ChangeEmail godoc
+// @Summary Change email
+// @Description Change the email address of the authenticated user. The new address gets a verification mail and the user cannot move money until it is verified. Access tokens issued before are revoked, refresh the session to get one for the new address
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param ChangeEmailRequest body ChangeEmailRequest true "Change Email Request"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Error
+// @Failure 401 {object} response.Error
+// @Failure 500 {object} response.Error
+// @Router /auth/change-email [put]
+// @Security BearerAuth
+func (aa *AuthAPI) ChangeEmail() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const caller = "bank.auth.handler.ChangeEmail"
+		log := sl.AddRequestId(sl.AddCaller(aa.log, caller), middleware.GetReqID(r.Context()))
+		log.Info("changing email")
+
+		p, ok := common.Principal(w, r)
+		if !ok {
+			return
+		}
+
+		var changeRequest ChangeEmailRequest
+
+		err := validate.ValidateRequest(aa.log, &changeRequest, r.Body)
+		if err != nil {
+			log.Error("validation err", sl.Error(err))
+			common.HandleValidationErr(w, r, err)
+			return
+		}
+
+		err = aa.authClient.ChangeEmail(r.Context(), p.Email, changeRequest.Password, changeRequest.NewEmail)
+		if err != nil {
+			log.Error("failed to change email", sl.Error(err))
+			common.HandleGrpcError(aa.log, w, r, err)
+			return
+		}
+
+		log.Info("email changed")
+
+		response.ReponsdWithOK(w, r, "Email changed, a verification mail was sent to the new address", http.StatusOK)
+	}
+}
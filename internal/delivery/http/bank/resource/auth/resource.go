@@ -1,5 +1,7 @@
 package auth
 
+import "time"
+
 type RegisterRequest struct {
 	Email     string `json:"email" validate:"required,email"`
 	Password  string `json:"password" validate:"required,gte=5,lte=100"`
@@ -15,6 +17,30 @@ type UserResponse struct {
 	LastName  string `json:"last_name"`
 	Balance   uint64 `json:"balance"`
 	Age       uint32 `json:"age"`
+	// EmailVerified is false until the user confirms their email address.
+	// Unverified users cannot move money.
+	EmailVerified bool   `json:"email_verified"`
+	PhoneNumber   string `json:"phone_number"`
+	// Status is pending_verification or active.
+	Status string `json:"status"`
+	// CreatedAt and UpdatedAt are missing from the response to a
+	// registration.
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+}
+
+// UpdateProfileRequest changes the fields that are set. An empty phone number
+// removes it.
+type UpdateProfileRequest struct {
+	FirstName   *string `json:"first_name" validate:"omitnil,alphaunicode,gte=2,lte=100"`
+	LastName    *string `json:"last_name" validate:"omitnil,alphaunicode,gte=2,lte=100"`
+	Age         *uint32 `json:"age" validate:"omitnil,gte=18,lt=150"`
+	PhoneNumber *string `json:"phone_number" validate:"omitnil,eq=|e164"`
+}
+
+type ChangeEmailRequest struct {
+	Password string `json:"password" validate:"required,gte=5,lte=100"`
+	NewEmail string `json:"new_email" validate:"required,email,lte=100"`
 }
 
 type LoginRequest struct {
@@ -22,21 +48,72 @@ type LoginRequest struct {
 	Password string `json:"password" validate:"required,gte=5,lte=100"`
 }
 
+// LoginResponse carries either the tokens or, if the user enabled MFA, the
+// token to complete the login with at /auth/mfa/verify.
 type LoginResponse struct {
-	Token string `json:"token"`
+	Token        string `json:"token,omitempty"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+	MFAToken     string `json:"mfa_token,omitempty"`
+}
+
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token" validate:"required,lte=100"`
+}
+
+type LogoutRequest struct {
+	RefreshToken string `json:"refresh_token" validate:"lte=100"`
 }
 
 type UpdatePasswordRequest struct {
-	Email       string `json:"email" validate:"required,email"`
 	OldPassword string `json:"old_password" validate:"required"`
 	NewPassword string `json:"new_password" validate:"required"`
 }
 
 type DeleteUserRequest struct {
-	Email    string `json:"email" validate:"required,email"`
 	Password string `json:"password" validate:"required,gte=5,lte=100"`
 }
 
-type UserRequest struct {
+type EnrollMFAResponse struct {
+	Secret string `json:"secret"`
+	URI    string `json:"uri"`
+}
+
+type ConfirmMFARequest struct {
+	Code string `json:"code" validate:"required,numeric,len=6"`
+}
+
+type RecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+// MFACodeRequest carries a TOTP code or a recovery code.
+type MFACodeRequest struct {
+	Code string `json:"code" validate:"required,gte=6,lte=20"`
+}
+
+type DisableMFARequest struct {
+	Password string `json:"password" validate:"required,gte=5,lte=100"`
+	Code     string `json:"code" validate:"required,gte=6,lte=20"`
+}
+
+type VerifyMFARequest struct {
+	MFAToken string `json:"mfa_token" validate:"required"`
+	Code     string `json:"code" validate:"required,gte=6,lte=20"`
+}
+
+type StepUpResponse struct {
+	Token string `json:"token"`
+}
+
+type VerifyEmailRequest struct {
+	Token string `json:"token" validate:"required"`
+}
+
+type PasswordResetRequest struct {
 	Email string `json:"email" validate:"required,email"`
 }
+
+type ResetPasswordRequest struct {
+	Token       string `json:"token" validate:"required,lte=100"`
+	NewPassword string `json:"new_password" validate:"required,gte=5,lte=100"`
+}
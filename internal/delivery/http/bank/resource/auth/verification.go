@@ -0,0 +1,86 @@
+package auth
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/tizzhh/micro-banking/internal/api/response"
+	"github.com/tizzhh/micro-banking/internal/api/validate"
+	"github.com/tizzhh/micro-banking/internal/delivery/http/bank/common"
+	"github.com/tizzhh/micro-banking/pkg/logger/sl"
+)
+
+// VerifyEmail godoc
+// @Summary Verify email
+// @Description Verify the email address of a new user with the token from the verification mail. A token can be used once
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param VerifyEmailRequest body VerifyEmailRequest true "Verify Email Request"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Error
+// @Failure 500 {object} response.Error
+// @Router /auth/verify-email [post]
+func (aa *AuthAPI) VerifyEmail() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const caller = "bank.auth.handler.VerifyEmail"
+		log := sl.AddRequestId(sl.AddCaller(aa.log, caller), middleware.GetReqID(r.Context()))
+		log.Info("verifying email")
+
+		var verifyRequest VerifyEmailRequest
+
+		err := validate.ValidateRequest(aa.log, &verifyRequest, r.Body)
+		if err != nil {
+			log.Error("validation err", sl.Error(err))
+			common.HandleValidationErr(w, r, err)
+			return
+		}
+
+		err = aa.authClient.VerifyEmail(r.Context(), verifyRequest.Token)
+		if err != nil {
+			log.Error("failed to verify email", sl.Error(err))
+			common.HandleGrpcError(aa.log, w, r, err)
+			return
+		}
+
+		log.Info("email verified")
+
+		response.ReponsdWithOK(w, r, "Email verified successfully", http.StatusOK)
+	}
+}
+
+// ResendVerification godoc
+// @Summary Resend verification mail
+// @Description Send another mail to verify the email address with. It can be requested once per resend interval
+// @Tags auth
+// @Produce json
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Error
+// @Failure 401 {object} response.Error
+// @Failure 429 {object} response.Error
+// @Failure 500 {object} response.Error
+// @Router /auth/verify-email/resend [post]
+// @Security BearerAuth
+func (aa *AuthAPI) ResendVerification() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const caller = "bank.auth.handler.ResendVerification"
+		log := sl.AddRequestId(sl.AddCaller(aa.log, caller), middleware.GetReqID(r.Context()))
+		log.Info("resending verification mail")
+
+		p, ok := common.Principal(w, r)
+		if !ok {
+			return
+		}
+
+		err := aa.authClient.ResendVerification(r.Context(), p.Email)
+		if err != nil {
+			log.Error("failed to resend verification mail", sl.Error(err))
+			common.HandleGrpcError(aa.log, w, r, err)
+			return
+		}
+
+		log.Info("verification mail resent")
+
+		response.ReponsdWithOK(w, r, "Verification mail sent", http.StatusOK)
+	}
+}
@@ -14,6 +14,11 @@ import (
 	"github.com/tizzhh/micro-banking/internal/delivery/http/bank/common"
 	bankErrors "github.com/tizzhh/micro-banking/internal/services/bank/errors"
 	"github.com/tizzhh/micro-banking/pkg/logger/sl"
+	"github.com/tizzhh/micro-banking/pkg/money"
+)
+
+const (
+	baseCurrencyCode = "USD"
 )
 
 type BankApi struct {
@@ -32,8 +37,9 @@ func New(log *slog.Logger, validator *validator.Validate, balance Balancer) *Ban
 
 //go:generate go run github.com/vektra/mockery/v2 --name=Balancer
 type Balancer interface {
-	Deposit(ctx context.Context, email string, amount float32) (float32, error)
-	Withdraw(ctx context.Context, email string, amount float32) (float32, error)
+	Deposit(ctx context.Context, email string, amount money.Money) (money.Money, error)
+	Withdraw(ctx context.Context, email string, amount money.Money) (money.Money, error)
+	Transfer(ctx context.Context, email string, recipientEmail string, amount money.Money) (money.Money, error)
 }
 
 // Liveness godoc
@@ -56,9 +62,13 @@ func (ba *BankApi) Liveness() http.HandlerFunc {
 // @Accept json
 // @Produce json
 // @Param UserRequest body DepositRequest true "Deposit request"
+// @Param Idempotency-Key header string false "Key that makes retries of the request safe"
 // @Success 200 {object} DepositResponse
 // @Failure 400 {object} response.Error
+// @Failure 403 {object} response.Error
 // @Failure 404 {object} response.Error
+// @Failure 409 {object} response.Error
+// @Failure 422 {object} response.Error
 // @Failure 500 {object} response.Error
 // @Router /bank/deposit [post]
 // @Security BearerAuth
@@ -68,6 +78,11 @@ func (ba *BankApi) Deposit() http.HandlerFunc {
 		log := sl.AddRequestId(sl.AddCaller(ba.log, caller), middleware.GetReqID(r.Context()))
 		log.Info("user is making a deposit")
 
+		p, ok := common.Principal(w, r)
+		if !ok {
+			return
+		}
+
 		var depositRequest DepositRequest
 
 		err := validate.ValidateRequest(ba.log, &depositRequest, r.Body)
@@ -77,10 +92,17 @@ func (ba *BankApi) Deposit() http.HandlerFunc {
 			return
 		}
 
+		amount, err := money.Parse(depositRequest.Amount, baseCurrencyCode)
+		if err != nil {
+			log.Error("invalid amount", sl.Error(err))
+			common.HandleAmountErr(w, r, err)
+			return
+		}
+
 		newBalanceAMount, err := ba.balance.Deposit(
 			r.Context(),
-			depositRequest.Email,
-			depositRequest.Amount,
+			p.Email,
+			amount,
 		)
 		if err != nil {
 			handleBankErr(w, r, err)
@@ -89,7 +111,7 @@ func (ba *BankApi) Deposit() http.HandlerFunc {
 
 		log.Info("deposit completed")
 
-		render.JSON(w, r, DepositResponse{NewBalanceAmount: newBalanceAMount})
+		render.JSON(w, r, DepositResponse{NewBalanceAmount: newBalanceAMount.String()})
 	}
 }
 
@@ -100,9 +122,13 @@ func (ba *BankApi) Deposit() http.HandlerFunc {
 // @Accept json
 // @Produce json
 // @Param UserRequest body WithdrawRequest true "Withdraw request"
+// @Param Idempotency-Key header string false "Key that makes retries of the request safe"
 // @Success 200 {object} WithdrawResponse
 // @Failure 400 {object} response.Error
+// @Failure 403 {object} response.Error
 // @Failure 404 {object} response.Error
+// @Failure 409 {object} response.Error
+// @Failure 422 {object} response.Error
 // @Failure 500 {object} response.Error
 // @Router /bank/withdraw [post]
 // @Security BearerAuth
@@ -112,6 +138,11 @@ func (ba *BankApi) Withdraw() http.HandlerFunc {
 		log := sl.AddRequestId(sl.AddCaller(ba.log, caller), middleware.GetReqID(r.Context()))
 		log.Info("user is making a withdrawal")
 
+		p, ok := common.Principal(w, r)
+		if !ok {
+			return
+		}
+
 		var withdrawRequest WithdrawRequest
 
 		err := validate.ValidateRequest(ba.log, &withdrawRequest, r.Body)
@@ -121,10 +152,17 @@ func (ba *BankApi) Withdraw() http.HandlerFunc {
 			return
 		}
 
+		amount, err := money.Parse(withdrawRequest.Amount, baseCurrencyCode)
+		if err != nil {
+			log.Error("invalid amount", sl.Error(err))
+			common.HandleAmountErr(w, r, err)
+			return
+		}
+
 		newBalanceAMount, err := ba.balance.Withdraw(
 			r.Context(),
-			withdrawRequest.Email,
-			withdrawRequest.Amount,
+			p.Email,
+			amount,
 		)
 		if err != nil {
 			handleBankErr(w, r, err)
@@ -133,7 +171,74 @@ func (ba *BankApi) Withdraw() http.HandlerFunc {
 
 		log.Info("deposit completed")
 
-		render.JSON(w, r, WithdrawResponse{NewBalanceAmount: newBalanceAMount})
+		render.JSON(w, r, WithdrawResponse{NewBalanceAmount: newBalanceAMount.String()})
+	}
+}
+
+// Transfer godoc
+// @Summary Transfer
+// @Description Transfer money to another user. Without currency_code USD is moved from the balance account,
+// otherwise the amount is moved from the wallet in that currency
+// @Tags bank
+// @Accept json
+// @Produce json
+// @Param UserRequest body TransferRequest true "Transfer request"
+// @Param Idempotency-Key header string false "Key that makes retries of the request safe"
+// @Success 200 {object} TransferResponse
+// @Failure 400 {object} response.Error
+// @Failure 403 {object} response.Error
+// @Failure 404 {object} response.Error
+// @Failure 409 {object} response.Error
+// @Failure 422 {object} response.Error
+// @Failure 500 {object} response.Error
+// @Router /bank/transfer [post]
+// @Security BearerAuth
+func (ba *BankApi) Transfer() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const caller = "bank.currency.handler.Transfer"
+		log := sl.AddRequestId(sl.AddCaller(ba.log, caller), middleware.GetReqID(r.Context()))
+		log.Info("user is making a transfer")
+
+		p, ok := common.Principal(w, r)
+		if !ok {
+			return
+		}
+
+		var transferRequest TransferRequest
+
+		err := validate.ValidateRequest(ba.log, &transferRequest, r.Body)
+		if err != nil {
+			log.Error("validation err", sl.Error(err))
+			common.HandleValidationErr(w, r, err)
+			return
+		}
+
+		currencyCode := transferRequest.CurrencyCode
+		if currencyCode == "" {
+			currencyCode = baseCurrencyCode
+		}
+
+		amount, err := money.Parse(transferRequest.Amount, currencyCode)
+		if err != nil {
+			log.Error("invalid amount", sl.Error(err))
+			common.HandleAmountErr(w, r, err)
+			return
+		}
+
+		newBalanceAmount, err := ba.balance.Transfer(
+			r.Context(),
+			p.Email,
+			transferRequest.RecipientEmail,
+			amount,
+		)
+		if err != nil {
+			handleBankErr(w, r, err)
+			return
+		}
+
+		log.Info("transfer completed")
+
+		render.JSON(w, r, TransferResponse{NewBalanceAmount: newBalanceAmount.String()})
 	}
 }
 
@@ -142,6 +247,24 @@ func handleBankErr(w http.ResponseWriter, r *http.Request, err error) {
 		response.RespondWithError(w, r, bankErrors.ErrUserNotFound.Error(), http.StatusNotFound)
 	} else if errors.Is(err, bankErrors.ErrNotEnoughMoney) {
 		response.RespondWithError(w, r, bankErrors.ErrNotEnoughMoney.Error(), http.StatusBadRequest)
+	} else if errors.Is(err, bankErrors.ErrRecipientNotFound) {
+		response.RespondWithError(w, r, bankErrors.ErrRecipientNotFound.Error(), http.StatusNotFound)
+	} else if errors.Is(err, bankErrors.ErrSelfTransfer) {
+		response.RespondWithError(w, r, bankErrors.ErrSelfTransfer.Error(), http.StatusBadRequest)
+	} else if errors.Is(err, bankErrors.ErrCurrencyCodeNotFound) {
+		response.RespondWithError(w, r, bankErrors.ErrCurrencyCodeNotFound.Error(), http.StatusNotFound)
+	} else if errors.Is(err, bankErrors.ErrCurrencyDisabled) {
+		response.RespondWithError(w, r, bankErrors.ErrCurrencyDisabled.Error(), http.StatusBadRequest)
+	} else if errors.Is(err, bankErrors.ErrInvalidAmount) {
+		response.RespondWithError(w, r, bankErrors.ErrInvalidAmount.Error(), http.StatusBadRequest)
+	} else if errors.Is(err, bankErrors.ErrWalletNotFound) {
+		response.RespondWithError(w, r, bankErrors.ErrWalletNotFound.Error(), http.StatusNotFound)
+	} else if errors.Is(err, bankErrors.ErrAccountFrozen) {
+		response.RespondWithError(w, r, bankErrors.ErrAccountFrozen.Error(), http.StatusForbidden)
+	} else if errors.Is(err, bankErrors.ErrEmailNotVerified) {
+		response.RespondWithError(w, r, bankErrors.ErrEmailNotVerified.Error(), http.StatusForbidden)
+	} else if errors.Is(err, bankErrors.ErrStepUpRequired) {
+		response.RespondWithError(w, r, bankErrors.ErrStepUpRequired.Error(), http.StatusForbidden)
 	} else {
 		response.RespondWithError(w, r, "internal error", http.StatusInternalServerError)
 	}
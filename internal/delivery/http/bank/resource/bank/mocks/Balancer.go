@@ -6,6 +6,7 @@ import (
 	context "context"
 
 	mock "github.com/stretchr/testify/mock"
+	money "github.com/tizzhh/micro-banking/pkg/money"
 )
 
 // Balancer is an autogenerated mock type for the Balancer type
@@ -14,25 +15,25 @@ type Balancer struct {
 }
 
 // Deposit provides a mock function with given fields: ctx, email, amount
-func (_m *Balancer) Deposit(ctx context.Context, email string, amount float32) (float32, error) {
+func (_m *Balancer) Deposit(ctx context.Context, email string, amount money.Money) (money.Money, error) {
 	ret := _m.Called(ctx, email, amount)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Deposit")
 	}
 
-	var r0 float32
+	var r0 money.Money
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, float32) (float32, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, string, money.Money) (money.Money, error)); ok {
 		return rf(ctx, email, amount)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, float32) float32); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, string, money.Money) money.Money); ok {
 		r0 = rf(ctx, email, amount)
 	} else {
-		r0 = ret.Get(0).(float32)
+		r0 = ret.Get(0).(money.Money)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, float32) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, string, money.Money) error); ok {
 		r1 = rf(ctx, email, amount)
 	} else {
 		r1 = ret.Error(1)
@@ -41,26 +42,54 @@ func (_m *Balancer) Deposit(ctx context.Context, email string, amount float32) (
 	return r0, r1
 }
 
+// Transfer provides a mock function with given fields: ctx, email, recipientEmail, amount
+func (_m *Balancer) Transfer(ctx context.Context, email string, recipientEmail string, amount money.Money) (money.Money, error) {
+	ret := _m.Called(ctx, email, recipientEmail, amount)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Transfer")
+	}
+
+	var r0 money.Money
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, money.Money) (money.Money, error)); ok {
+		return rf(ctx, email, recipientEmail, amount)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, money.Money) money.Money); ok {
+		r0 = rf(ctx, email, recipientEmail, amount)
+	} else {
+		r0 = ret.Get(0).(money.Money)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, money.Money) error); ok {
+		r1 = rf(ctx, email, recipientEmail, amount)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Withdraw provides a mock function with given fields: ctx, email, amount
-func (_m *Balancer) Withdraw(ctx context.Context, email string, amount float32) (float32, error) {
+func (_m *Balancer) Withdraw(ctx context.Context, email string, amount money.Money) (money.Money, error) {
 	ret := _m.Called(ctx, email, amount)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Withdraw")
 	}
 
-	var r0 float32
+	var r0 money.Money
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, float32) (float32, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, string, money.Money) (money.Money, error)); ok {
 		return rf(ctx, email, amount)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, float32) float32); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, string, money.Money) money.Money); ok {
 		r0 = rf(ctx, email, amount)
 	} else {
-		r0 = ret.Get(0).(float32)
+		r0 = ret.Get(0).(money.Money)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, float32) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, string, money.Money) error); ok {
 		r1 = rf(ctx, email, amount)
 	} else {
 		r1 = ret.Error(1)
@@ -1,19 +1,29 @@
 package bank
 
+// Amounts are decimal strings in major units, e.g. "12.34".
+
 type DepositRequest struct {
-	Email  string  `json:"email" validate:"required,email"`
-	Amount float32 `json:"amount" validate:"required,gte=0"`
+	Amount string `json:"amount" validate:"required,numeric"`
 }
 
 type DepositResponse struct {
-	NewBalanceAmount float32 `json:"new_balance_amount" validate:"required,gte=0"`
+	NewBalanceAmount string `json:"new_balance_amount" validate:"required"`
 }
 
 type WithdrawRequest struct {
-	Email  string  `json:"email" validate:"required,email"`
-	Amount float32 `json:"amount" validate:"required,gte=0"`
+	Amount string `json:"amount" validate:"required,numeric"`
 }
 
 type WithdrawResponse struct {
-	NewBalanceAmount float32 `json:"new_balance_amount" validate:"required,gte=0"`
+	NewBalanceAmount string `json:"new_balance_amount" validate:"required"`
+}
+
+type TransferRequest struct {
+	RecipientEmail string `json:"recipient_email" validate:"required,email"`
+	Amount         string `json:"amount" validate:"required,numeric"`
+	CurrencyCode   string `json:"currency_code" validate:"omitempty,len=3,alpha,uppercase"`
+}
+
+type TransferResponse struct {
+	NewBalanceAmount string `json:"new_balance_amount" validate:"required"`
 }
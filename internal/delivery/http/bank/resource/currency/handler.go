@@ -2,15 +2,22 @@ package currency
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/go-chi/chi/middleware"
+	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/render"
 	"github.com/go-playground/validator/v10"
+	"github.com/tizzhh/micro-banking/internal/api/response"
 	"github.com/tizzhh/micro-banking/internal/api/validate"
 	"github.com/tizzhh/micro-banking/internal/delivery/http/bank/common"
 	"github.com/tizzhh/micro-banking/pkg/logger/sl"
+	"github.com/tizzhh/micro-banking/pkg/money"
 )
 
 type CurrencyApi struct {
@@ -21,9 +28,14 @@ type CurrencyApi struct {
 
 //go:generate go run github.com/vektra/mockery/v2 --name=CurrencyClient
 type CurrencyClient interface {
-	Buy(ctx context.Context, email string, currencyCode string, amount uint64) (float32, error)
-	Sell(ctx context.Context, email string, currencyCode string, amount uint64) (float32, error)
+	Quote(ctx context.Context, email string, amount money.Money, side string) (QuoteResponse, error)
+	Buy(ctx context.Context, email string, amount money.Money, quoteID string) (money.Money, Fees, error)
+	Sell(ctx context.Context, email string, amount money.Money, quoteID string) (money.Money, Fees, error)
+	Convert(ctx context.Context, email string, amount money.Money, to string) (money.Money, money.Rate, error)
 	Wallets(ctx context.Context, email string) (WalletResponse, error)
+	Transactions(ctx context.Context, email string, filter TransactionsFilter) (TransactionsResponse, error)
+	ListCurrencies(ctx context.Context) (CurrenciesResponse, error)
+	RateHistory(ctx context.Context, filter RateHistoryFilter) (RateHistoryResponse, error)
 }
 
 func New(log *slog.Logger, validator *validator.Validate, currencyClient CurrencyClient) *CurrencyApi {
@@ -38,11 +50,9 @@ func New(log *slog.Logger, validator *validator.Validate, currencyClient Currenc
 // @Summary MyWallet
 // @Description Return wallet of the user
 // @Tags bank
-// @Accept json
 // @Produce json
-// @Param UserRequest body WalletRequest true "Wallet request"
 // @Success 200 {object} WalletResponse
-// @Failure 400 {object} response.Error
+// @Failure 401 {object} response.Error
 // @Failure 404 {object} response.Error
 // @Failure 500 {object} response.Error
 // @Router /bank/my-wallet [get]
@@ -53,18 +63,14 @@ func (ca *CurrencyApi) MyWallet() http.HandlerFunc {
 		log := sl.AddRequestId(sl.AddCaller(ca.log, caller), middleware.GetReqID(r.Context()))
 		log.Info("getting user's wallet")
 
-		var walletRequest WalletRequest
-
-		err := validate.ValidateRequest(ca.log, &walletRequest, r.Body)
-		if err != nil {
-			log.Error("validation err", sl.Error(err))
-			common.HandleValidationErr(w, r, err)
+		p, ok := common.Principal(w, r)
+		if !ok {
 			return
 		}
 
 		wallets, err := ca.currencyClient.Wallets(
 			r.Context(),
-			walletRequest.Email,
+			p.Email,
 		)
 		if err != nil {
 			log.Error("failed to get user's wallet", sl.Error(err))
@@ -78,16 +84,77 @@ func (ca *CurrencyApi) MyWallet() http.HandlerFunc {
 	}
 }
 
+// QuoteCurrency godoc
+// @Summary Quote currency
+// @Description Quote the price of buying or selling currency, held until the quote expires
+// @Tags currency
+// @Accept json
+// @Produce json
+// @Param QuoteRequest body QuoteRequest true "Quote request"
+// @Success 200 {object} QuoteResponse
+// @Failure 400 {object} response.Error
+// @Failure 404 {object} response.Error
+// @Failure 500 {object} response.Error
+// @Router /currency/quote [post]
+// @Security BearerAuth
+func (ca *CurrencyApi) QuoteCurrency() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const caller = "bank.currency.handler.Quote"
+		log := sl.AddRequestId(sl.AddCaller(ca.log, caller), middleware.GetReqID(r.Context()))
+		log.Info("quoting currency")
+
+		p, ok := common.Principal(w, r)
+		if !ok {
+			return
+		}
+
+		var quoteRequest QuoteRequest
+
+		err := validate.ValidateRequest(ca.log, &quoteRequest, r.Body)
+		if err != nil {
+			log.Error("validation err", sl.Error(err))
+			common.HandleValidationErr(w, r, err)
+			return
+		}
+
+		amount, err := money.Parse(quoteRequest.Amount, quoteRequest.CurrencyCode)
+		if err != nil {
+			log.Error("invalid amount", sl.Error(err))
+			common.HandleAmountErr(w, r, err)
+			return
+		}
+
+		quote, err := ca.currencyClient.Quote(
+			r.Context(),
+			p.Email,
+			amount,
+			quoteRequest.Side,
+		)
+		if err != nil {
+			log.Error("failed to quote currency", sl.Error(err))
+			common.HandleGrpcError(ca.log, w, r, err)
+			return
+		}
+
+		log.Info("currency quoted")
+
+		render.JSON(w, r, quote)
+	}
+}
+
 // BuyCurrency godoc
 // @Summary Buy currency
-// @Description Buy currency
+// @Description Buy currency, at the price of a quote if quote_id is set
 // @Tags currency
 // @Accept json
 // @Produce json
 // @Param BuyRequest body BuyRequest true "Buy request"
+// @Param Idempotency-Key header string false "Key that makes retries of the request safe"
 // @Success 200 {object} BuyResponse
 // @Failure 400 {object} response.Error
 // @Failure 404 {object} response.Error
+// @Failure 409 {object} response.Error
+// @Failure 422 {object} response.Error
 // @Failure 500 {object} response.Error
 // @Router /currency/buy [post]
 // @Security BearerAuth
@@ -97,6 +164,11 @@ func (ca *CurrencyApi) BuyCurrency() http.HandlerFunc {
 		log := sl.AddRequestId(sl.AddCaller(ca.log, caller), middleware.GetReqID(r.Context()))
 		log.Info("buying currency")
 
+		p, ok := common.Principal(w, r)
+		if !ok {
+			return
+		}
+
 		var buyRequest BuyRequest
 
 		err := validate.ValidateRequest(ca.log, &buyRequest, r.Body)
@@ -106,11 +178,18 @@ func (ca *CurrencyApi) BuyCurrency() http.HandlerFunc {
 			return
 		}
 
-		boughtAmount, err := ca.currencyClient.Buy(
+		amount, err := money.Parse(buyRequest.Amount, buyRequest.CurrencyCode)
+		if err != nil {
+			log.Error("invalid amount", sl.Error(err))
+			common.HandleAmountErr(w, r, err)
+			return
+		}
+
+		cost, fees, err := ca.currencyClient.Buy(
 			r.Context(),
-			buyRequest.Email,
-			buyRequest.CurrencyCode,
-			buyRequest.Amount,
+			p.Email,
+			amount,
+			buyRequest.QuoteID,
 		)
 		if err != nil {
 			log.Error("failed to buy currency", sl.Error(err))
@@ -121,22 +200,27 @@ func (ca *CurrencyApi) BuyCurrency() http.HandlerFunc {
 		log.Info("currency bought")
 
 		render.JSON(w, r, BuyResponse{
-			BoughtAmount: boughtAmount,
+			BoughtAmount: amount.String(),
 			CurrencyCode: buyRequest.CurrencyCode,
+			Cost:         cost.String(),
+			Fees:         fees,
 		})
 	}
 }
 
 // SellCurrency godoc
 // @Summary Sell currency
-// @Description Sell currency
+// @Description Sell currency, at the price of a quote if quote_id is set
 // @Tags currency
 // @Accept json
 // @Produce json
 // @Param SellRequest body SellRequest true "Sell request"
+// @Param Idempotency-Key header string false "Key that makes retries of the request safe"
 // @Success 200 {object} SellResponse
 // @Failure 400 {object} response.Error
 // @Failure 404 {object} response.Error
+// @Failure 409 {object} response.Error
+// @Failure 422 {object} response.Error
 // @Failure 500 {object} response.Error
 // @Router /currency/sell [post]
 // @Security BearerAuth
@@ -146,6 +230,11 @@ func (ca *CurrencyApi) SellCurrency() http.HandlerFunc {
 		log := sl.AddRequestId(sl.AddCaller(ca.log, caller), middleware.GetReqID(r.Context()))
 		log.Info("selling currency")
 
+		p, ok := common.Principal(w, r)
+		if !ok {
+			return
+		}
+
 		var sellRequest SellRequest
 
 		err := validate.ValidateRequest(ca.log, &sellRequest, r.Body)
@@ -155,11 +244,18 @@ func (ca *CurrencyApi) SellCurrency() http.HandlerFunc {
 			return
 		}
 
-		soldAmount, err := ca.currencyClient.Sell(
+		amount, err := money.Parse(sellRequest.Amount, sellRequest.CurrencyCode)
+		if err != nil {
+			log.Error("invalid amount", sl.Error(err))
+			common.HandleAmountErr(w, r, err)
+			return
+		}
+
+		proceeds, fees, err := ca.currencyClient.Sell(
 			r.Context(),
-			sellRequest.Email,
-			sellRequest.CurrencyCode,
-			sellRequest.Amount,
+			p.Email,
+			amount,
+			sellRequest.QuoteID,
 		)
 		if err != nil {
 			log.Error("failed to sell currency", sl.Error(err))
@@ -170,8 +266,292 @@ func (ca *CurrencyApi) SellCurrency() http.HandlerFunc {
 		log.Info("currency sold")
 
 		render.JSON(w, r, SellResponse{
-			SoldAmount:   soldAmount,
+			SoldAmount:   amount.String(),
 			CurrencyCode: sellRequest.CurrencyCode,
+			Proceeds:     proceeds.String(),
+			Fees:         fees,
 		})
 	}
 }
+
+// ConvertCurrency godoc
+// @Summary Convert currency
+// @Description Convert currency between two foreign wallets at the cross rate of their USD rates
+// @Tags currency
+// @Accept json
+// @Produce json
+// @Param ConvertRequest body ConvertRequest true "Convert request"
+// @Param Idempotency-Key header string false "Key that makes retries of the request safe"
+// @Success 200 {object} ConvertResponse
+// @Failure 400 {object} response.Error
+// @Failure 404 {object} response.Error
+// @Failure 409 {object} response.Error
+// @Failure 422 {object} response.Error
+// @Failure 500 {object} response.Error
+// @Router /currency/convert [post]
+// @Security BearerAuth
+func (ca *CurrencyApi) ConvertCurrency() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const caller = "bank.currency.handler.Convert"
+		log := sl.AddRequestId(sl.AddCaller(ca.log, caller), middleware.GetReqID(r.Context()))
+		log.Info("converting currency")
+
+		p, ok := common.Principal(w, r)
+		if !ok {
+			return
+		}
+
+		var convertRequest ConvertRequest
+
+		err := validate.ValidateRequest(ca.log, &convertRequest, r.Body)
+		if err != nil {
+			log.Error("validation err", sl.Error(err))
+			common.HandleValidationErr(w, r, err)
+			return
+		}
+
+		amount, err := money.Parse(convertRequest.Amount, convertRequest.FromCurrencyCode)
+		if err != nil {
+			log.Error("invalid amount", sl.Error(err))
+			common.HandleAmountErr(w, r, err)
+			return
+		}
+
+		converted, rate, err := ca.currencyClient.Convert(
+			r.Context(),
+			p.Email,
+			amount,
+			convertRequest.ToCurrencyCode,
+		)
+		if err != nil {
+			log.Error("failed to convert currency", sl.Error(err))
+			common.HandleGrpcError(ca.log, w, r, err)
+			return
+		}
+
+		log.Info("currency converted")
+
+		render.JSON(w, r, ConvertResponse{
+			FromAmount:       amount.String(),
+			FromCurrencyCode: convertRequest.FromCurrencyCode,
+			ToAmount:         converted.String(),
+			ToCurrencyCode:   convertRequest.ToCurrencyCode,
+			Rate:             rate.String(),
+		})
+	}
+}
+
+// Transactions godoc
+// @Summary Transactions
+// @Description Return a page of the user's transaction history
+// @Tags bank
+// @Produce json
+// @Param type query []string false "Transaction types" collectionFormat(multi)
+// @Param currency_code query string false "Currency code"
+// @Param from query string false "Start of the period, RFC3339"
+// @Param to query string false "End of the period, RFC3339"
+// @Param order query string false "asc or desc, desc by default"
+// @Param cursor query string false "Cursor of the next page"
+// @Param limit query int false "Page size, 20 by default, 100 at most"
+// @Success 200 {object} TransactionsResponse
+// @Failure 400 {object} response.Error
+// @Failure 401 {object} response.Error
+// @Failure 404 {object} response.Error
+// @Failure 500 {object} response.Error
+// @Router /bank/transactions [get]
+// @Security BearerAuth
+func (ca *CurrencyApi) Transactions() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const caller = "bank.currency.handler.Transactions"
+		log := sl.AddRequestId(sl.AddCaller(ca.log, caller), middleware.GetReqID(r.Context()))
+		log.Info("getting user's transactions")
+
+		p, ok := common.Principal(w, r)
+		if !ok {
+			return
+		}
+
+		query := parseTransactionsQuery(r)
+		if err := ca.validator.Struct(query); err != nil {
+			log.Error("invalid query", sl.Error(err))
+			common.HandleValidationErr(w, r, err)
+			return
+		}
+
+		filter, err := transactionsFilter(query)
+		if err != nil {
+			log.Error("invalid query", sl.Error(err))
+			response.RespondWithError(w, r, "invalid query", http.StatusBadRequest)
+			return
+		}
+
+		transactions, err := ca.currencyClient.Transactions(
+			r.Context(),
+			p.Email,
+			filter,
+		)
+		if err != nil {
+			log.Error("failed to get user's transactions", sl.Error(err))
+			common.HandleGrpcError(ca.log, w, r, err)
+			return
+		}
+
+		log.Info("user's transactions retrieved")
+
+		render.JSON(w, r, transactions)
+	}
+}
+
+// Currencies godoc
+// @Summary Currencies
+// @Description Return the currency catalog
+// @Tags currency
+// @Produce json
+// @Success 200 {object} CurrenciesResponse
+// @Failure 500 {object} response.Error
+// @Router /currencies [get]
+func (ca *CurrencyApi) Currencies() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const caller = "bank.currency.handler.Currencies"
+		log := sl.AddRequestId(sl.AddCaller(ca.log, caller), middleware.GetReqID(r.Context()))
+		log.Info("listing currencies")
+
+		currencies, err := ca.currencyClient.ListCurrencies(r.Context())
+		if err != nil {
+			log.Error("failed to list currencies", sl.Error(err))
+			common.HandleGrpcError(ca.log, w, r, err)
+			return
+		}
+
+		log.Info("currencies listed")
+
+		render.JSON(w, r, currencies)
+	}
+}
+
+// RateHistory godoc
+// @Summary Rate history
+// @Description Return the rates of a currency in units of it per USD, oldest first, with their OHLC candles by interval
+// @Tags currency
+// @Produce json
+// @Param code path string true "Currency code"
+// @Param from query string false "Start of the period, RFC3339, a day before to by default"
+// @Param to query string false "End of the period, RFC3339, now by default"
+// @Param interval query string false "Candle interval: 1m, 5m, 15m, 1h, 4h or 1d, 1h by default"
+// @Success 200 {object} RateHistoryResponse
+// @Failure 400 {object} response.Error
+// @Failure 404 {object} response.Error
+// @Failure 500 {object} response.Error
+// @Router /rates/{code}/history [get]
+func (ca *CurrencyApi) RateHistory() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const caller = "bank.currency.handler.RateHistory"
+		log := sl.AddRequestId(sl.AddCaller(ca.log, caller), middleware.GetReqID(r.Context()))
+		log.Info("getting rate history")
+
+		values := r.URL.Query()
+		query := RateHistoryQuery{
+			CurrencyCode: strings.ToUpper(chi.URLParam(r, "code")),
+			From:         values.Get("from"),
+			To:           values.Get("to"),
+			Interval:     values.Get("interval"),
+		}
+		if err := ca.validator.Struct(query); err != nil {
+			log.Error("invalid query", sl.Error(err))
+			common.HandleValidationErr(w, r, err)
+			return
+		}
+
+		filter, err := rateHistoryFilter(query)
+		if err != nil {
+			log.Error("invalid query", sl.Error(err))
+			response.RespondWithError(w, r, "invalid query", http.StatusBadRequest)
+			return
+		}
+
+		history, err := ca.currencyClient.RateHistory(r.Context(), filter)
+		if err != nil {
+			log.Error("failed to get rate history", sl.Error(err))
+			common.HandleGrpcError(ca.log, w, r, err)
+			return
+		}
+
+		log.Info("rate history retrieved")
+
+		render.JSON(w, r, history)
+	}
+}
+
+func parseTransactionsQuery(r *http.Request) TransactionsQuery {
+	values := r.URL.Query()
+
+	var types []string
+	for _, value := range values["type"] {
+		for _, txType := range strings.Split(value, ",") {
+			if txType != "" {
+				types = append(types, txType)
+			}
+		}
+	}
+
+	return TransactionsQuery{
+		Types:        types,
+		CurrencyCode: values.Get("currency_code"),
+		From:         values.Get("from"),
+		To:           values.Get("to"),
+		Order:        values.Get("order"),
+		Cursor:       values.Get("cursor"),
+		Limit:        values.Get("limit"),
+	}
+}
+
+func transactionsFilter(query TransactionsQuery) (TransactionsFilter, error) {
+	filter := TransactionsFilter{
+		Types:        query.Types,
+		CurrencyCode: query.CurrencyCode,
+		Order:        query.Order,
+		Cursor:       query.Cursor,
+	}
+
+	var err error
+	if query.From != "" {
+		if filter.From, err = time.Parse(time.RFC3339, query.From); err != nil {
+			return TransactionsFilter{}, err
+		}
+	}
+	if query.To != "" {
+		if filter.To, err = time.Parse(time.RFC3339, query.To); err != nil {
+			return TransactionsFilter{}, err
+		}
+	}
+	if query.Limit != "" {
+		limit, err := strconv.ParseUint(query.Limit, 10, 32)
+		if err != nil || limit > 100 {
+			return TransactionsFilter{}, fmt.Errorf("invalid limit %q", query.Limit)
+		}
+		filter.Limit = uint32(limit)
+	}
+
+	return filter, nil
+}
+
+func rateHistoryFilter(query RateHistoryQuery) (RateHistoryFilter, error) {
+	filter := RateHistoryFilter{
+		CurrencyCode: query.CurrencyCode,
+		Interval:     query.Interval,
+	}
+
+	var err error
+	if query.From != "" {
+		if filter.From, err = time.Parse(time.RFC3339, query.From); err != nil {
+			return RateHistoryFilter{}, err
+		}
+	}
+	if query.To != "" {
+		if filter.To, err = time.Parse(time.RFC3339, query.To); err != nil {
+			return RateHistoryFilter{}, err
+		}
+	}
+
+	return filter, nil
+}
@@ -7,6 +7,8 @@ import (
 
 	mock "github.com/stretchr/testify/mock"
 	currency "github.com/tizzhh/micro-banking/internal/delivery/http/bank/resource/currency"
+
+	money "github.com/tizzhh/micro-banking/pkg/money"
 )
 
 // CurrencyClient is an autogenerated mock type for the CurrencyClient type
@@ -14,27 +16,97 @@ type CurrencyClient struct {
 	mock.Mock
 }
 
-// Buy provides a mock function with given fields: ctx, email, currencyCode, amount
-func (_m *CurrencyClient) Buy(ctx context.Context, email string, currencyCode string, amount uint64) (float32, error) {
-	ret := _m.Called(ctx, email, currencyCode, amount)
+// Buy provides a mock function with given fields: ctx, email, amount, quoteID
+func (_m *CurrencyClient) Buy(ctx context.Context, email string, amount money.Money, quoteID string) (money.Money, currency.Fees, error) {
+	ret := _m.Called(ctx, email, amount, quoteID)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Buy")
 	}
 
-	var r0 float32
+	var r0 money.Money
+	var r1 currency.Fees
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, money.Money, string) (money.Money, currency.Fees, error)); ok {
+		return rf(ctx, email, amount, quoteID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, money.Money, string) money.Money); ok {
+		r0 = rf(ctx, email, amount, quoteID)
+	} else {
+		r0 = ret.Get(0).(money.Money)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, money.Money, string) currency.Fees); ok {
+		r1 = rf(ctx, email, amount, quoteID)
+	} else {
+		r1 = ret.Get(1).(currency.Fees)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, money.Money, string) error); ok {
+		r2 = rf(ctx, email, amount, quoteID)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// Convert provides a mock function with given fields: ctx, email, amount, to
+func (_m *CurrencyClient) Convert(ctx context.Context, email string, amount money.Money, to string) (money.Money, money.Rate, error) {
+	ret := _m.Called(ctx, email, amount, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Convert")
+	}
+
+	var r0 money.Money
+	var r1 money.Rate
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, money.Money, string) (money.Money, money.Rate, error)); ok {
+		return rf(ctx, email, amount, to)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, money.Money, string) money.Money); ok {
+		r0 = rf(ctx, email, amount, to)
+	} else {
+		r0 = ret.Get(0).(money.Money)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, money.Money, string) money.Rate); ok {
+		r1 = rf(ctx, email, amount, to)
+	} else {
+		r1 = ret.Get(1).(money.Rate)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, money.Money, string) error); ok {
+		r2 = rf(ctx, email, amount, to)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// ListCurrencies provides a mock function with given fields: ctx
+func (_m *CurrencyClient) ListCurrencies(ctx context.Context) (currency.CurrenciesResponse, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListCurrencies")
+	}
+
+	var r0 currency.CurrenciesResponse
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, uint64) (float32, error)); ok {
-		return rf(ctx, email, currencyCode, amount)
+	if rf, ok := ret.Get(0).(func(context.Context) (currency.CurrenciesResponse, error)); ok {
+		return rf(ctx)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, uint64) float32); ok {
-		r0 = rf(ctx, email, currencyCode, amount)
+	if rf, ok := ret.Get(0).(func(context.Context) currency.CurrenciesResponse); ok {
+		r0 = rf(ctx)
 	} else {
-		r0 = ret.Get(0).(float32)
+		r0 = ret.Get(0).(currency.CurrenciesResponse)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, string, uint64) error); ok {
-		r1 = rf(ctx, email, currencyCode, amount)
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -42,27 +114,118 @@ func (_m *CurrencyClient) Buy(ctx context.Context, email string, currencyCode st
 	return r0, r1
 }
 
-// Sell provides a mock function with given fields: ctx, email, currencyCode, amount
-func (_m *CurrencyClient) Sell(ctx context.Context, email string, currencyCode string, amount uint64) (float32, error) {
-	ret := _m.Called(ctx, email, currencyCode, amount)
+// Quote provides a mock function with given fields: ctx, email, amount, side
+func (_m *CurrencyClient) Quote(ctx context.Context, email string, amount money.Money, side string) (currency.QuoteResponse, error) {
+	ret := _m.Called(ctx, email, amount, side)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Quote")
+	}
+
+	var r0 currency.QuoteResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, money.Money, string) (currency.QuoteResponse, error)); ok {
+		return rf(ctx, email, amount, side)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, money.Money, string) currency.QuoteResponse); ok {
+		r0 = rf(ctx, email, amount, side)
+	} else {
+		r0 = ret.Get(0).(currency.QuoteResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, money.Money, string) error); ok {
+		r1 = rf(ctx, email, amount, side)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RateHistory provides a mock function with given fields: ctx, filter
+func (_m *CurrencyClient) RateHistory(ctx context.Context, filter currency.RateHistoryFilter) (currency.RateHistoryResponse, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RateHistory")
+	}
+
+	var r0 currency.RateHistoryResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, currency.RateHistoryFilter) (currency.RateHistoryResponse, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, currency.RateHistoryFilter) currency.RateHistoryResponse); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		r0 = ret.Get(0).(currency.RateHistoryResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, currency.RateHistoryFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Sell provides a mock function with given fields: ctx, email, amount, quoteID
+func (_m *CurrencyClient) Sell(ctx context.Context, email string, amount money.Money, quoteID string) (money.Money, currency.Fees, error) {
+	ret := _m.Called(ctx, email, amount, quoteID)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Sell")
 	}
 
-	var r0 float32
+	var r0 money.Money
+	var r1 currency.Fees
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, money.Money, string) (money.Money, currency.Fees, error)); ok {
+		return rf(ctx, email, amount, quoteID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, money.Money, string) money.Money); ok {
+		r0 = rf(ctx, email, amount, quoteID)
+	} else {
+		r0 = ret.Get(0).(money.Money)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, money.Money, string) currency.Fees); ok {
+		r1 = rf(ctx, email, amount, quoteID)
+	} else {
+		r1 = ret.Get(1).(currency.Fees)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, money.Money, string) error); ok {
+		r2 = rf(ctx, email, amount, quoteID)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// Transactions provides a mock function with given fields: ctx, email, filter
+func (_m *CurrencyClient) Transactions(ctx context.Context, email string, filter currency.TransactionsFilter) (currency.TransactionsResponse, error) {
+	ret := _m.Called(ctx, email, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Transactions")
+	}
+
+	var r0 currency.TransactionsResponse
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, uint64) (float32, error)); ok {
-		return rf(ctx, email, currencyCode, amount)
+	if rf, ok := ret.Get(0).(func(context.Context, string, currency.TransactionsFilter) (currency.TransactionsResponse, error)); ok {
+		return rf(ctx, email, filter)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, uint64) float32); ok {
-		r0 = rf(ctx, email, currencyCode, amount)
+	if rf, ok := ret.Get(0).(func(context.Context, string, currency.TransactionsFilter) currency.TransactionsResponse); ok {
+		r0 = rf(ctx, email, filter)
 	} else {
-		r0 = ret.Get(0).(float32)
+		r0 = ret.Get(0).(currency.TransactionsResponse)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, string, uint64) error); ok {
-		r1 = rf(ctx, email, currencyCode, amount)
+	if rf, ok := ret.Get(1).(func(context.Context, string, currency.TransactionsFilter) error); ok {
+		r1 = rf(ctx, email, filter)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1,36 +1,181 @@
 package currency
 
+import "time"
+
 type WalletResponse struct {
 	Wallets []Wallet `json:"wallet"`
 }
 
 type Wallet struct {
 	CurrencyCode string `json:"currency_code"`
-	Balance      uint64 `json:"balance"`
+	Balance      string `json:"balance"`
+}
+
+type QuoteRequest struct {
+	CurrencyCode string `json:"currency_code" validate:"required,len=3,alpha,uppercase"`
+	Amount       string `json:"amount" validate:"required,numeric"`
+	Side         string `json:"side" validate:"required,oneof=buy sell"`
+}
+
+// QuoteResponse holds a price to buy or sell at until ExpiresAt. Cost is the
+// USD charged for a buy or credited for a sell, and Rate is USD per unit of
+// the currency.
+type QuoteResponse struct {
+	QuoteID      string    `json:"quote_id"`
+	Side         string    `json:"side"`
+	Amount       string    `json:"amount"`
+	CurrencyCode string    `json:"currency_code"`
+	Cost         string    `json:"cost"`
+	Rate         string    `json:"rate"`
+	ExpiresAt    time.Time `json:"expires_at"`
+	Fees         Fees      `json:"fees"`
 }
 
-type WalletRequest struct {
-	Email string `json:"email" validate:"required,email"`
+// Fees breaks down a price in USD. MidRate is units of the currency per USD
+// before the spread, Rate is the USD per unit charged or paid. Gross is the
+// amount at Rate, of which Spread is the difference to the mid rate, and Fee
+// is the commission added to a buy or taken from a sell.
+type Fees struct {
+	MidRate string `json:"mid_rate"`
+	Rate    string `json:"rate"`
+	Gross   string `json:"gross"`
+	Spread  string `json:"spread"`
+	Fee     string `json:"fee"`
 }
 
 type BuyRequest struct {
-	Email        string `json:"email" validate:"required,email"`
-	CurrencyCode string `json:"currency_code" validate:"required,oneof=RUB EUR CNY"`
-	Amount       uint64 `json:"amount" validate:"required,gte=0"`
+	CurrencyCode string `json:"currency_code" validate:"required,len=3,alpha,uppercase"`
+	Amount       string `json:"amount" validate:"required,numeric"`
+	QuoteID      string `json:"quote_id,omitempty" validate:"omitempty,len=32,hexadecimal,lowercase"`
 }
 
+// BuyResponse holds the bought amount and its cost in USD, fees included.
 type BuyResponse struct {
-	BoughtAmount float32 `json:"bought_amount"`
-	CurrencyCode string  `json:"currency_code"`
+	BoughtAmount string `json:"bought_amount"`
+	CurrencyCode string `json:"currency_code"`
+	Cost         string `json:"cost"`
+	Fees         Fees   `json:"fees"`
 }
 
 type SellRequest struct {
-	Email        string `json:"email" validate:"required,email"`
-	CurrencyCode string `json:"currency_code" validate:"required,oneof=RUB EUR CNY"`
-	Amount       uint64 `json:"amount" validate:"required,gte=0"`
+	CurrencyCode string `json:"currency_code" validate:"required,len=3,alpha,uppercase"`
+	Amount       string `json:"amount" validate:"required,numeric"`
+	QuoteID      string `json:"quote_id,omitempty" validate:"omitempty,len=32,hexadecimal,lowercase"`
 }
 
+// SellResponse holds the sold amount and the USD received for it, fees
+// deducted.
 type SellResponse struct {
-	SoldAmount   float32 `json:"sold_amount"`
-	CurrencyCode string  `json:"currency_code"`
+	SoldAmount   string `json:"sold_amount"`
+	CurrencyCode string `json:"currency_code"`
+	Proceeds     string `json:"proceeds"`
+	Fees         Fees   `json:"fees"`
+}
+
+type ConvertRequest struct {
+	FromCurrencyCode string `json:"from_currency_code" validate:"required,len=3,alpha,uppercase"`
+	ToCurrencyCode   string `json:"to_currency_code" validate:"required,len=3,alpha,uppercase,nefield=FromCurrencyCode"`
+	Amount           string `json:"amount" validate:"required,numeric"`
+}
+
+// ConvertResponse holds the converted amount, the amount credited for it and
+// the applied rate in units of the to currency per unit of the from one.
+type ConvertResponse struct {
+	FromAmount       string `json:"from_amount"`
+	FromCurrencyCode string `json:"from_currency_code"`
+	ToAmount         string `json:"to_amount"`
+	ToCurrencyCode   string `json:"to_currency_code"`
+	Rate             string `json:"rate"`
+}
+
+// TransactionsQuery is read from the query string of the transactions request.
+type TransactionsQuery struct {
+	Types        []string `validate:"dive,oneof=opening_balance deposit withdrawal buy sell transfer adjustment convert"`
+	CurrencyCode string   `validate:"omitempty,len=3,alpha,uppercase"`
+	From         string   `validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+	To           string   `validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+	Order        string   `validate:"omitempty,oneof=asc desc"`
+	Cursor       string
+	Limit        string `validate:"omitempty,number"`
+}
+
+type TransactionsFilter struct {
+	Types        []string
+	CurrencyCode string
+	From         time.Time
+	To           time.Time
+	Order        string
+	Cursor       string
+	Limit        uint32
+}
+
+type TransactionsResponse struct {
+	Transactions []Transaction `json:"transactions"`
+	NextCursor   string        `json:"next_cursor,omitempty"`
+}
+
+type Transaction struct {
+	TransactionID uint64    `json:"transaction_id"`
+	Type          string    `json:"type"`
+	CurrencyCode  string    `json:"currency_code"`
+	Direction     string    `json:"direction"`
+	Amount        string    `json:"amount"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// Currency is an entry of the currency catalog. Order limits are decimal
+// amounts of the currency, an empty max_order means no limit.
+type Currency struct {
+	Code     string `json:"code"`
+	Name     string `json:"name"`
+	Exponent int    `json:"exponent"`
+	Enabled  bool   `json:"enabled"`
+	Tradable bool   `json:"tradable"`
+	MinOrder string `json:"min_order"`
+	MaxOrder string `json:"max_order,omitempty"`
+}
+
+type CurrenciesResponse struct {
+	Currencies []Currency `json:"currencies"`
+}
+
+// RateHistoryQuery is read from the path and query string of the rate history
+// request.
+type RateHistoryQuery struct {
+	CurrencyCode string `validate:"required,len=3,alpha,uppercase"`
+	From         string `validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+	To           string `validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+	Interval     string `validate:"omitempty,oneof=1m 5m 15m 1h 4h 1d"`
+}
+
+type RateHistoryFilter struct {
+	CurrencyCode string
+	From         time.Time
+	To           time.Time
+	Interval     string
+}
+
+// RateHistoryResponse holds the rates of a currency, in units of it per USD,
+// oldest first, and their candles by interval.
+type RateHistoryResponse struct {
+	CurrencyCode string       `json:"currency_code"`
+	Rates        []RatePoint  `json:"rates"`
+	Candles      []RateCandle `json:"candles"`
+}
+
+type RatePoint struct {
+	Rate      string    `json:"rate"`
+	Source    string    `json:"source"`
+	FetchedAt time.Time `json:"fetched_at"`
+}
+
+// RateCandle holds the first, highest, lowest and last of the rates fetched in
+// the interval starting at start.
+type RateCandle struct {
+	Start   time.Time `json:"start"`
+	Open    string    `json:"open"`
+	High    string    `json:"high"`
+	Low     string    `json:"low"`
+	Close   string    `json:"close"`
+	Samples uint32    `json:"samples"`
 }
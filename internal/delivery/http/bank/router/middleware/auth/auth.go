@@ -1,19 +1,19 @@
 package auth
 
 import (
-	"bytes"
+	"context"
 	"errors"
 
-	"io"
 	"log/slog"
 	"net/http"
 
 	"strings"
 
-	"github.com/go-chi/render"
+	"github.com/go-chi/chi/v5"
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/tizzhh/micro-banking/internal/api/principal"
 	"github.com/tizzhh/micro-banking/internal/api/response"
-	"github.com/tizzhh/micro-banking/internal/api/validate"
+	jwtpkg "github.com/tizzhh/micro-banking/pkg/jwt"
 	"github.com/tizzhh/micro-banking/pkg/logger/sl"
 )
 
@@ -21,8 +21,13 @@ type PermissionsChecker interface {
 	CheckPermissions(token string) (*jwt.Token, error)
 }
 
-type EmailRequest struct {
-	Email string `json:"email"`
+type Authorizer interface {
+	Allowed(roles []string, operation string) bool
+}
+
+//go:generate go run github.com/vektra/mockery/v2 --name=TokenRevocationChecker
+type TokenRevocationChecker interface {
+	IsTokenRevoked(ctx context.Context, claims *jwtpkg.Claims) (bool, error)
 }
 
 var (
@@ -34,9 +39,11 @@ var (
 
 var (
 	ErrMissingEmailToken = errors.New("missing email in token")
+	ErrRevokedToken      = errors.New("token revoked")
+	ErrPermissionDenied  = errors.New("permission denied")
 )
 
-func AuthenticateUser(log *slog.Logger, permissionsChecker PermissionsChecker) func(next http.Handler) http.Handler {
+func AuthenticateUser(log *slog.Logger, permissionsChecker PermissionsChecker, revocationChecker TokenRevocationChecker) func(next http.Handler) http.Handler {
 	const caller = "bank.middleware.auth.AuthenticateUser"
 	log = sl.AddCaller(log, caller)
 	return func(next http.Handler) http.Handler {
@@ -53,68 +60,98 @@ func AuthenticateUser(log *slog.Logger, permissionsChecker PermissionsChecker) f
 
 			log.Info("token in req", slog.String("token", reqToken))
 
-			err := checkEmail(reqToken, r, permissionsChecker)
+			p, err := authenticate(r.Context(), reqToken, permissionsChecker, revocationChecker)
 			if err != nil {
-				log.Info("user does not have permissions", slog.String("token", reqToken), sl.Error(err))
-				handleIncorrectEmail(w, r, err)
+				log.Info("user is not authenticated", slog.String("token", reqToken), sl.Error(err))
+				handleAuthenticationErr(w, r, err)
 				return
 			}
 
-			next.ServeHTTP(w, r)
+			ctx := principal.WithPrincipal(principal.WithToken(r.Context(), reqToken), p)
+			next.ServeHTTP(w, r.WithContext(ctx))
 		})
 	}
 }
 
-func checkEmail(token string, req *http.Request, permissionsChecker PermissionsChecker) error {
-	var emailInRequest EmailRequest
+// Authorize lets a request through only if the roles of the authenticated
+// user grant the permission its route requires. It must be applied to routes
+// directly, e.g. within a group, so that the route pattern is known.
+func Authorize(log *slog.Logger, authorizer Authorizer) func(next http.Handler) http.Handler {
+	const caller = "bank.middleware.auth.Authorize"
+	log = sl.AddCaller(log, caller)
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			p, ok := principal.FromContext(r.Context())
+			if !ok {
+				response.RespondWithError(w, r, "unauthenticated", http.StatusUnauthorized)
+				return
+			}
 
-	bodyBytes, err := io.ReadAll(req.Body)
-	if err != nil {
-		return err
-	}
-	req.Body.Close()
-	// reset body
-	req.Body = io.NopCloser(bytes.NewBuffer(bodyBytes))
+			var operation string
+			if rctx := chi.RouteContext(r.Context()); rctx != nil {
+				operation = r.Method + " " + rctx.RoutePattern()
+			}
+			if !authorizer.Allowed(p.Roles, operation) {
+				log.Warn("permission denied", slog.Uint64("uid", p.UserID), slog.String("operation", operation))
+				response.RespondWithError(w, r, ErrPermissionDenied.Error(), http.StatusForbidden)
+				return
+			}
 
-	err = render.DecodeJSON(bytes.NewBuffer(bodyBytes), &emailInRequest)
-	if errors.Is(err, io.EOF) {
-		return validate.ErrEmptyBody
-	}
-	if err != nil {
-		return validate.ErrDecodeFail
+			next.ServeHTTP(w, r)
+		})
 	}
+}
 
+func authenticate(ctx context.Context, token string, permissionsChecker PermissionsChecker, revocationChecker TokenRevocationChecker) (principal.Principal, error) {
 	parsedToken, err := permissionsChecker.CheckPermissions(token)
+	if errors.Is(err, jwtpkg.ErrTokenExpired) {
+		return principal.Principal{}, jwtpkg.ErrTokenExpired
+	}
 	if err != nil {
-		return ErrInvalidToken
+		return principal.Principal{}, ErrInvalidToken
 	}
 
-	claims, ok := parsedToken.Claims.(jwt.MapClaims)
+	claims, ok := parsedToken.Claims.(*jwtpkg.Claims)
 	if !ok {
-		return ErrInvalidToken
+		return principal.Principal{}, ErrInvalidToken
 	}
 
-	idFromToken, ok := claims["email"].(string)
-	if !ok {
-		return ErrMissingEmailToken
+	uid, err := claims.UserID()
+	if err != nil {
+		return principal.Principal{}, ErrInvalidUID
+	}
+
+	if claims.Email == "" {
+		return principal.Principal{}, ErrMissingEmailToken
 	}
 
-	if idFromToken != emailInRequest.Email {
-		return ErrInvalidToken
+	revoked, err := revocationChecker.IsTokenRevoked(ctx, claims)
+	if err != nil {
+		return principal.Principal{}, err
+	}
+	if revoked {
+		return principal.Principal{}, ErrRevokedToken
 	}
 
-	return nil
+	return principal.Principal{
+		UserID: uid,
+		Email:  claims.Email,
+		Roles:  claims.Roles,
+		MFAAt:  claims.MFATime(),
+	}, nil
 }
 
-func handleIncorrectEmail(w http.ResponseWriter, r *http.Request, err error) {
-	if errors.Is(err, validate.ErrEmptyBody) {
-		response.RespondWithError(w, r, validate.ErrEmptyBody.Error(), http.StatusBadRequest)
-	} else if errors.Is(err, validate.ErrDecodeFail) {
-		response.RespondWithError(w, r, validate.ErrDecodeFail.Error(), http.StatusBadRequest)
-	} else if errors.Is(err, ErrInvalidToken) {
-		response.RespondWithError(w, r, ErrInvalidToken.Error(), http.StatusBadRequest)
+func handleAuthenticationErr(w http.ResponseWriter, r *http.Request, err error) {
+	if errors.Is(err, ErrInvalidToken) {
+		response.RespondWithError(w, r, ErrInvalidToken.Error(), http.StatusUnauthorized)
+	} else if errors.Is(err, jwtpkg.ErrTokenExpired) {
+		response.RespondWithError(w, r, jwtpkg.ErrTokenExpired.Error(), http.StatusUnauthorized)
+	} else if errors.Is(err, ErrRevokedToken) {
+		response.RespondWithError(w, r, ErrRevokedToken.Error(), http.StatusUnauthorized)
+	} else if errors.Is(err, ErrInvalidUID) {
+		response.RespondWithError(w, r, ErrInvalidUID.Error(), http.StatusUnauthorized)
 	} else if errors.Is(err, ErrMissingEmailToken) {
-		response.RespondWithError(w, r, ErrMissingEmailToken.Error(), http.StatusBadRequest)
+		response.RespondWithError(w, r, ErrMissingEmailToken.Error(), http.StatusUnauthorized)
 	} else {
 		response.RespondWithError(w, r, "internal error", http.StatusInternalServerError)
 	}
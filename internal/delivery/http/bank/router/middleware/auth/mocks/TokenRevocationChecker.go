@@ -0,0 +1,57 @@
+// Code generated by mockery v2.45.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+	jwt "github.com/tizzhh/micro-banking/pkg/jwt"
+)
+
+// TokenRevocationChecker is an autogenerated mock type for the TokenRevocationChecker type
+type TokenRevocationChecker struct {
+	mock.Mock
+}
+
+// IsTokenRevoked provides a mock function with given fields: ctx, claims
+func (_m *TokenRevocationChecker) IsTokenRevoked(ctx context.Context, claims *jwt.Claims) (bool, error) {
+	ret := _m.Called(ctx, claims)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsTokenRevoked")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *jwt.Claims) (bool, error)); ok {
+		return rf(ctx, claims)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *jwt.Claims) bool); ok {
+		r0 = rf(ctx, claims)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *jwt.Claims) error); ok {
+		r1 = rf(ctx, claims)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewTokenRevocationChecker creates a new instance of TokenRevocationChecker. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewTokenRevocationChecker(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *TokenRevocationChecker {
+	mock := &TokenRevocationChecker{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,25 @@
+package clientip
+
+import (
+	"net"
+	"net/http"
+
+	"github.com/tizzhh/micro-banking/internal/api/principal"
+)
+
+// ClientIP puts the address of the client into the request context, from
+// where it is forwarded to the gRPC services. Behind a proxy it should run
+// after middleware.RealIP.
+func ClientIP(next http.Handler) http.Handler {
+	fn := func(w http.ResponseWriter, r *http.Request) {
+		host, _, err := net.SplitHostPort(r.RemoteAddr)
+		if err != nil {
+			host = r.RemoteAddr
+		}
+		if host != "" {
+			r = r.WithContext(principal.WithClientIP(r.Context(), host))
+		}
+		next.ServeHTTP(w, r)
+	}
+	return http.HandlerFunc(fn)
+}
@@ -0,0 +1,122 @@
+package idempotency
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/tizzhh/micro-banking/internal/api/idempotency"
+	"github.com/tizzhh/micro-banking/internal/api/principal"
+	"github.com/tizzhh/micro-banking/internal/api/response"
+	"github.com/tizzhh/micro-banking/internal/api/validate"
+	"github.com/tizzhh/micro-banking/internal/domain/idempotency/models"
+	"github.com/tizzhh/micro-banking/pkg/logger/sl"
+)
+
+type Store interface {
+	ReserveIdempotencyKey(ctx context.Context, key, fingerprint string, ttl time.Duration) (models.Record, bool, error)
+	CompleteIdempotencyKey(ctx context.Context, key string, statusCode int, response []byte) error
+	ReleaseIdempotencyKey(ctx context.Context, key string) error
+}
+
+// New makes requests carrying an Idempotency-Key header run at most once.
+// Duplicates get the stored response, reusing a key for another body is
+// rejected with 422, and a duplicate of an unfinished request gets 409.
+// Requests without the header are passed through.
+func New(log *slog.Logger, store Store, ttl time.Duration) func(next http.Handler) http.Handler {
+	const caller = "bank.middleware.idempotency.New"
+	log = sl.AddCaller(log, caller)
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := r.Header.Get(idempotency.Header)
+			if key == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+			if len(key) > idempotency.MaxKeyLength {
+				response.RespondWithError(w, r, idempotency.ErrInvalidKey.Error(), http.StatusBadRequest)
+				return
+			}
+
+			bodyBytes, err := io.ReadAll(r.Body)
+			if err != nil {
+				log.Error("failed to read request body", sl.Error(err))
+				response.RespondWithError(w, r, validate.ErrDecodeFail.Error(), http.StatusBadRequest)
+				return
+			}
+			r.Body.Close()
+			// reset body
+			r.Body = io.NopCloser(bytes.NewBuffer(bodyBytes))
+
+			storeKey := "http:" + r.Method + " " + r.URL.Path + ":" + key
+			// keys are picked by clients, so two users may send the same one
+			if p, ok := principal.FromContext(r.Context()); ok {
+				storeKey = "http:" + strconv.FormatUint(p.UserID, 10) + ":" + r.Method + " " + r.URL.Path + ":" + key
+			}
+			fingerprint := idempotency.Fingerprint([]byte(r.Method), []byte(r.URL.Path), bodyBytes)
+
+			log := log.With(slog.String("idempotency_key", key))
+
+			record, reserved, err := store.ReserveIdempotencyKey(r.Context(), storeKey, fingerprint, ttl)
+			if err != nil {
+				log.Error("failed to reserve idempotency key", sl.Error(err))
+				response.RespondWithError(w, r, "internal error", http.StatusInternalServerError)
+				return
+			}
+
+			if !reserved {
+				replay(log, w, r, record, fingerprint)
+				return
+			}
+
+			ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+			var responseBody bytes.Buffer
+			ww.Tee(&responseBody)
+
+			next.ServeHTTP(ww, r.WithContext(idempotency.WithKey(r.Context(), key)))
+
+			// the response is already sent, storing it must not depend on the client
+			ctx := context.WithoutCancel(r.Context())
+
+			statusCode := ww.Status()
+			if statusCode == 0 {
+				statusCode = http.StatusOK
+			}
+			if statusCode >= http.StatusInternalServerError {
+				if err := store.ReleaseIdempotencyKey(ctx, storeKey); err != nil {
+					log.Error("failed to release idempotency key", sl.Error(err))
+				}
+				return
+			}
+			if err := store.CompleteIdempotencyKey(ctx, storeKey, statusCode, responseBody.Bytes()); err != nil {
+				log.Error("failed to store idempotent response", sl.Error(err))
+			}
+		})
+	}
+}
+
+func replay(log *slog.Logger, w http.ResponseWriter, r *http.Request, record models.Record, fingerprint string) {
+	if record.Fingerprint != fingerprint {
+		log.Warn("idempotency key reused with a different request")
+		response.RespondWithError(w, r, idempotency.ErrKeyReused.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+	if !record.Completed() {
+		log.Info("idempotent request is still in progress")
+		response.RespondWithError(w, r, idempotency.ErrInProgress.Error(), http.StatusConflict)
+		return
+	}
+
+	log.Info("replaying idempotent response")
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set(idempotency.ReplayedHeader, "true")
+	w.WriteHeader(record.StatusCode)
+	if _, err := w.Write(record.Response); err != nil {
+		log.Error("failed to write replayed response", sl.Error(err))
+	}
+}
@@ -0,0 +1,103 @@
+// Code generated by mockery v2.45.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	models "github.com/tizzhh/micro-banking/internal/domain/idempotency/models"
+
+	time "time"
+)
+
+// Store is an autogenerated mock type for the Store type
+type Store struct {
+	mock.Mock
+}
+
+// CompleteIdempotencyKey provides a mock function with given fields: ctx, key, statusCode, response
+func (_m *Store) CompleteIdempotencyKey(ctx context.Context, key string, statusCode int, response []byte) error {
+	ret := _m.Called(ctx, key, statusCode, response)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CompleteIdempotencyKey")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, []byte) error); ok {
+		r0 = rf(ctx, key, statusCode, response)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ReleaseIdempotencyKey provides a mock function with given fields: ctx, key
+func (_m *Store) ReleaseIdempotencyKey(ctx context.Context, key string) error {
+	ret := _m.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReleaseIdempotencyKey")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, key)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ReserveIdempotencyKey provides a mock function with given fields: ctx, key, fingerprint, ttl
+func (_m *Store) ReserveIdempotencyKey(ctx context.Context, key string, fingerprint string, ttl time.Duration) (models.Record, bool, error) {
+	ret := _m.Called(ctx, key, fingerprint, ttl)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReserveIdempotencyKey")
+	}
+
+	var r0 models.Record
+	var r1 bool
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Duration) (models.Record, bool, error)); ok {
+		return rf(ctx, key, fingerprint, ttl)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Duration) models.Record); ok {
+		r0 = rf(ctx, key, fingerprint, ttl)
+	} else {
+		r0 = ret.Get(0).(models.Record)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, time.Duration) bool); ok {
+		r1 = rf(ctx, key, fingerprint, ttl)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, string, time.Duration) error); ok {
+		r2 = rf(ctx, key, fingerprint, ttl)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// NewStore creates a new instance of Store. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewStore(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Store {
+	mock := &Store{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -10,11 +10,15 @@ import (
 	"github.com/go-playground/validator/v10"
 	httpSwagger "github.com/swaggo/http-swagger"
 	"github.com/tizzhh/micro-banking/internal/api/permissions"
+	adminApi "github.com/tizzhh/micro-banking/internal/delivery/http/bank/resource/admin"
 	"github.com/tizzhh/micro-banking/internal/delivery/http/bank/resource/auth"
 	bankApi "github.com/tizzhh/micro-banking/internal/delivery/http/bank/resource/bank"
 	"github.com/tizzhh/micro-banking/internal/delivery/http/bank/resource/currency"
 	authentication "github.com/tizzhh/micro-banking/internal/delivery/http/bank/router/middleware/auth"
+	"github.com/tizzhh/micro-banking/internal/delivery/http/bank/router/middleware/clientip"
+	"github.com/tizzhh/micro-banking/internal/delivery/http/bank/router/middleware/idempotency"
 	mwLogger "github.com/tizzhh/micro-banking/internal/delivery/http/bank/router/middleware/logger"
+	"github.com/tizzhh/micro-banking/internal/services/admin"
 	"github.com/tizzhh/micro-banking/internal/services/bank"
 	"github.com/tizzhh/micro-banking/pkg/jwt"
 )
@@ -25,18 +29,26 @@ func New(
 	authClient auth.AuthClient,
 	currencyClient currency.CurrencyClient,
 	tokenTTL time.Duration,
+	jwksRefresh time.Duration,
 	bank *bank.Bank,
+	admin *admin.Admin,
+	idempotencyStore idempotency.Store,
+	idempotencyTTL time.Duration,
+	revocationChecker authentication.TokenRevocationChecker,
 ) *chi.Mux {
 	router := chi.NewRouter()
 
 	router.Use(middleware.RequestID)
+	router.Use(clientip.ClientIP)
 	router.Use(mwLogger.New(log))
 	router.Use(middleware.Recoverer)
 
 	authApi := auth.New(log, validator, authClient)
 
-	jwt := jwt.New(tokenTTL)
-	permissionChecker := permissions.New(jwt)
+	keys := jwt.NewRemoteKeySet(authClient.JWKS, jwksRefresh)
+	permissionChecker := permissions.New(jwt.New(tokenTTL, keys), permissions.DefaultPolicy)
+
+	router.Get("/.well-known/jwks.json", authApi.JWKS(keys))
 
 	router.Get("/docs/*", http.StripPrefix("/docs/", http.FileServer(http.Dir("./docs"))).ServeHTTP)
 
@@ -47,34 +59,81 @@ func New(
 	router.Route("/v1/auth", func(r chi.Router) {
 		r.Method(http.MethodPost, "/register", authApi.NewUser())
 		r.Method(http.MethodPost, "/login", authApi.LoginUser())
+		r.Method(http.MethodPost, "/refresh", authApi.Refresh())
+		r.Method(http.MethodPost, "/mfa/verify", authApi.VerifyMFA())
+		r.Method(http.MethodPost, "/verify-email", authApi.VerifyEmail())
+		r.Method(http.MethodPost, "/password-reset", authApi.RequestPasswordReset())
+		r.Method(http.MethodPost, "/password-reset/confirm", authApi.ResetPassword())
 
 		r.Group(func(r chi.Router) {
-			r.Use(authentication.AuthenticateUser(log, permissionChecker))
+			r.Use(authentication.AuthenticateUser(log, permissionChecker, revocationChecker))
 
 			r.Method(http.MethodPut, "/change-password", authApi.UpdatePassword())
 			r.Method(http.MethodDelete, "/unregister", authApi.DeleteUser())
 			r.Method(http.MethodGet, "/user", authApi.User())
+			r.Method(http.MethodPatch, "/user", authApi.UpdateProfile())
+			r.Method(http.MethodPut, "/change-email", authApi.ChangeEmail())
+			r.Method(http.MethodPost, "/logout", authApi.Logout())
+
+			r.Method(http.MethodPost, "/mfa/enroll", authApi.EnrollMFA())
+			r.Method(http.MethodPost, "/mfa/confirm", authApi.ConfirmMFA())
+			r.Method(http.MethodPost, "/mfa/disable", authApi.DisableMFA())
+			r.Method(http.MethodPost, "/mfa/recovery-codes", authApi.RegenerateRecoveryCodes())
+			r.Method(http.MethodPost, "/mfa/step-up", authApi.StepUp())
+
+			r.Method(http.MethodPost, "/verify-email/resend", authApi.ResendVerification())
 		})
 	})
 
 	currencyApi := currency.New(log, validator, currencyClient)
 	bankApi := bankApi.New(log, validator, bank)
 
+	idempotent := idempotency.New(log, idempotencyStore, idempotencyTTL)
+
 	router.Route("/v1/bank", func(r chi.Router) {
-		r.Use(authentication.AuthenticateUser(log, permissionChecker))
+		r.Use(authentication.AuthenticateUser(log, permissionChecker, revocationChecker))
 
 		r.Method(http.MethodGet, "/my-wallet", currencyApi.MyWallet())
-		r.Method(http.MethodPost, "/deposit", bankApi.Deposit())
-		r.Method(http.MethodPost, "/withdraw", bankApi.Withdraw())
+		r.Method(http.MethodGet, "/transactions", currencyApi.Transactions())
+		r.With(idempotent).Method(http.MethodPost, "/deposit", bankApi.Deposit())
+		r.With(idempotent).Method(http.MethodPost, "/withdraw", bankApi.Withdraw())
+		r.With(idempotent).Method(http.MethodPost, "/transfer", bankApi.Transfer())
 
 		r.Route("/currency", func(r chi.Router) {
+			r.Use(idempotent)
+
+			r.Method(http.MethodPost, "/quote", currencyApi.QuoteCurrency())
 			r.Method(http.MethodPost, "/buy", currencyApi.BuyCurrency())
 			r.Method(http.MethodPost, "/sell", currencyApi.SellCurrency())
+			r.Method(http.MethodPost, "/convert", currencyApi.ConvertCurrency())
+		})
+	})
+
+	adminApi := adminApi.New(log, validator, admin)
+
+	router.Route("/v1/admin", func(r chi.Router) {
+		r.Use(authentication.AuthenticateUser(log, permissionChecker, revocationChecker))
+
+		r.Group(func(r chi.Router) {
+			// within a group the route pattern is complete when authorizing
+			r.Use(authentication.Authorize(log, permissionChecker))
+
+			r.Method(http.MethodGet, "/users", adminApi.Users())
+			r.Method(http.MethodGet, "/users/{id}", adminApi.User())
+			r.Method(http.MethodGet, "/users/{id}/balance", adminApi.Balance())
+			r.Method(http.MethodPost, "/users/{id}/freeze", adminApi.Freeze())
+			r.Method(http.MethodPost, "/users/{id}/unfreeze", adminApi.Unfreeze())
+			r.Method(http.MethodPost, "/users/{id}/unlock", adminApi.Unlock())
+			r.Method(http.MethodPost, "/users/{id}/adjustments", adminApi.Adjust())
+			r.Method(http.MethodPost, "/currencies", adminApi.CreateCurrency())
+			r.Method(http.MethodPatch, "/currencies/{code}", adminApi.UpdateCurrency())
 		})
 	})
 
 	router.Route("/v1", func(r chi.Router) {
 		r.Method(http.MethodGet, "/liveness", bankApi.Liveness())
+		r.Method(http.MethodGet, "/currencies", currencyApi.Currencies())
+		r.Method(http.MethodGet, "/rates/{code}/history", currencyApi.RateHistory())
 	})
 
 	return router
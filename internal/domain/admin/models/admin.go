@@ -0,0 +1,52 @@
+package models
+
+import (
+	"time"
+
+	"github.com/tizzhh/micro-banking/pkg/money"
+)
+
+// ReasonCode records why an admin adjusted a balance.
+type ReasonCode string
+
+const (
+	ReasonCorrection ReasonCode = "correction"
+	ReasonChargeback ReasonCode = "chargeback"
+	ReasonRefund     ReasonCode = "refund"
+	ReasonGoodwill   ReasonCode = "goodwill"
+	ReasonFraud      ReasonCode = "fraud"
+)
+
+func (r ReasonCode) Valid() bool {
+	switch r {
+	case ReasonCorrection, ReasonChargeback, ReasonRefund, ReasonGoodwill, ReasonFraud:
+		return true
+	}
+	return false
+}
+
+// BalanceAdjustment is the audit record of a ledger transaction an admin
+// posted to a user's account.
+type BalanceAdjustment struct {
+	ID            uint64
+	TransactionID uint64
+	UserID        uint64
+	AdminID       uint64
+	ReasonCode    ReasonCode
+	Comment       string
+	CreatedAt     time.Time
+}
+
+// UserFilter pages through users by id.
+type UserFilter struct {
+	After uint64
+	Limit int
+}
+
+// Balance is a user's cash and wallet balances.
+type Balance struct {
+	UserID  uint64
+	Frozen  bool
+	Cash    money.Money
+	Wallets []money.Money
+}
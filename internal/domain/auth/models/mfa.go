@@ -0,0 +1,34 @@
+package models
+
+import "time"
+
+// MFASecret is a user's TOTP secret. It protects their logins once it is
+// confirmed with a first valid code.
+type MFASecret struct {
+	UserID      uint64 `gorm:"primaryKey"`
+	Secret      string
+	ConfirmedAt *time.Time
+	// LastUsedStep is the time step of the last accepted code. Codes of it
+	// and earlier steps are not accepted again.
+	LastUsedStep int64
+	CreatedAt    time.Time
+}
+
+func (m MFASecret) Confirmed() bool {
+	return m.ConfirmedAt != nil
+}
+
+// RecoveryCode replaces a TOTP code once, e.g. when the authenticator is
+// lost. It is stored by its hash.
+type RecoveryCode struct {
+	ID        uint64
+	UserID    uint64
+	CodeHash  string
+	UsedAt    *time.Time
+	CreatedAt time.Time
+}
+
+type MFAEnrollment struct {
+	Secret string
+	URI    string
+}
@@ -0,0 +1,36 @@
+package models
+
+import "time"
+
+// RefreshToken is stored by the hash of its opaque value. Tokens rotated from
+// the same login share a family, which is revoked as a whole when a rotated
+// token is presented again.
+type RefreshToken struct {
+	ID         uint64
+	UserID     uint64
+	FamilyID   string
+	TokenHash  string
+	ExpiresAt  time.Time
+	CreatedAt  time.Time
+	RevokedAt  *time.Time
+	ReplacedBy *uint64
+}
+
+// PasswordResetToken is stored by the hash of its opaque value and can be
+// used once before it expires.
+type PasswordResetToken struct {
+	ID        uint64
+	UserID    uint64
+	TokenHash string
+	ExpiresAt time.Time
+	CreatedAt time.Time
+	UsedAt    *time.Time
+}
+
+type TokenPair struct {
+	AccessToken  string
+	RefreshToken string
+	// MFAToken is returned instead of the other tokens when the login has to
+	// be completed with a second factor.
+	MFAToken string
+}
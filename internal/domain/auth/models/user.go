@@ -1,5 +1,18 @@
 package models
 
+import "time"
+
+// UserStatus is the state of an account. New accounts wait for their email
+// address to be verified before they can move money. Closed accounts are
+// kept with their wallets and ledger for the retention period.
+type UserStatus string
+
+const (
+	UserStatusPendingVerification UserStatus = "pending_verification"
+	UserStatusActive              UserStatus = "active"
+	UserStatusClosed              UserStatus = "closed"
+)
+
 type User struct {
 	ID        uint64
 	Email     string
@@ -8,4 +21,39 @@ type User struct {
 	LastName  string
 	Balance   uint64 // In USD cents
 	Age       uint32
+	// PhoneNumber is in E.164 format, empty if the user did not give one.
+	PhoneNumber string
+	// Frozen accounts cannot move money until an admin unfreezes them.
+	Frozen    bool
+	Status    UserStatus
+	Roles     []string `gorm:"-"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	ClosedAt  *time.Time
+	// AnonymizedAt is when the personal data of the closed account was
+	// erased.
+	AnonymizedAt *time.Time
+}
+
+func (u User) Verified() bool {
+	return u.Status == UserStatusActive
+}
+
+// ProfileUpdate holds the profile fields to change. Nil fields are kept and
+// an empty phone number removes it.
+type ProfileUpdate struct {
+	FirstName   *string
+	LastName    *string
+	Age         *uint32
+	PhoneNumber *string
+}
+
+func (u ProfileUpdate) Empty() bool {
+	return u.FirstName == nil && u.LastName == nil && u.Age == nil && u.PhoneNumber == nil
+}
+
+// UserRole grants a role to a user.
+type UserRole struct {
+	UserID uint64 `gorm:"primaryKey"`
+	Role   string `gorm:"primaryKey"`
 }
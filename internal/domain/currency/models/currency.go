@@ -1,10 +1,35 @@
 package models
 
-import "github.com/tizzhh/micro-banking/internal/domain/auth/models"
+import (
+	"time"
 
+	"github.com/tizzhh/micro-banking/internal/domain/auth/models"
+)
+
+// Currency is an entry of the currency catalog. Only enabled currencies can
+// be held and transferred, and only tradable ones bought and sold.
 type Currency struct {
 	ID   uint64
 	Code string
+	Name string
+	// Exponent is the number of minor unit digits. It cannot change once
+	// balances are kept in the currency.
+	Exponent int
+	Enabled  bool
+	Tradable bool
+	// MinOrder and MaxOrder bound a buy or sell in minor units. A zero
+	// MaxOrder does not limit it.
+	MinOrder uint64
+	MaxOrder uint64
+}
+
+// CurrencyUpdate holds the catalog fields to change. Nil fields are kept.
+type CurrencyUpdate struct {
+	Name     *string
+	Enabled  *bool
+	Tradable *bool
+	MinOrder *uint64
+	MaxOrder *uint64
 }
 
 type UserWallet struct {
@@ -15,3 +40,92 @@ type UserWallet struct {
 	Currency   Currency
 	Balance    uint64
 }
+
+// Conversion records the amounts and the cross rate of a convert transaction
+// between two wallets of a user.
+type Conversion struct {
+	ID             uint64
+	TransactionID  uint64
+	UserID         uint64
+	FromCurrencyID uint64
+	ToCurrencyID   uint64
+	FromAmount     uint64
+	ToAmount       uint64
+	// Rate is the number of units of the target currency for one unit of the
+	// source currency.
+	Rate      string
+	CreatedAt time.Time
+}
+
+// Trade records the price of a buy or sell transaction. Amount is in minor
+// units of the currency, Total and Fee in USD cents: Total is what was charged
+// for a buy, fee included, or credited for a sell, fee deducted. Rate and
+// MidRate are USD per unit of the currency after and before the spread.
+type Trade struct {
+	ID            uint64
+	TransactionID uint64
+	UserID        uint64
+	CurrencyID    uint64
+	Side          QuoteSide
+	Amount        uint64
+	Total         uint64
+	Fee           uint64
+	MidRate       string
+	Rate          string
+	CreatedAt     time.Time
+}
+
+// ExchangeRate is a rate fetched from Source at FetchedAt, in units of the
+// currency per USD.
+type ExchangeRate struct {
+	ID         uint64
+	CurrencyID uint64
+	Rate       string
+	Source     string
+	FetchedAt  time.Time
+}
+
+// RateCandle aggregates the rates fetched in [Start, Start + interval): the
+// first, highest, lowest and last of them and their number.
+type RateCandle struct {
+	Start   time.Time
+	Open    string
+	High    string
+	Low     string
+	Close   string
+	Samples int
+}
+
+// RateHistory is the time series of a currency's rates and its candles.
+type RateHistory struct {
+	CurrencyCode string
+	Rates        []ExchangeRate
+	Candles      []RateCandle
+}
+
+type QuoteSide string
+
+const (
+	QuoteBuy  QuoteSide = "buy"
+	QuoteSell QuoteSide = "sell"
+)
+
+// Quote is a price held for the user with Email to buy or sell Amount minor
+// units of a currency at until ExpiresAt. Cost is in USD cents, charged for a
+// buy and credited for a sell, and includes Fee. Rate and MidRate are USD per
+// unit of the currency after and before the spread, Gross is the order's value
+// at Rate and Spread what the bank earns on the rate.
+type Quote struct {
+	ID           string    `json:"id"`
+	Email        string    `json:"email"`
+	Side         QuoteSide `json:"side"`
+	CurrencyCode string    `json:"currency_code"`
+	Amount       int64     `json:"amount"`
+	Cost         int64     `json:"cost"`
+	Rate         string    `json:"rate"`
+	MidRate      string    `json:"mid_rate"`
+	Gross        int64     `json:"gross"`
+	Spread       int64     `json:"spread"`
+	Fee          int64     `json:"fee"`
+	ExpiresAt    time.Time `json:"expires_at"`
+}
@@ -0,0 +1,22 @@
+package models
+
+import "time"
+
+// Record is a response stored under an idempotency key. StatusCode is an HTTP
+// status or a gRPC code, depending on who stored it.
+type Record struct {
+	Key         string `gorm:"primaryKey"`
+	Fingerprint string
+	StatusCode  int
+	Response    []byte
+	CreatedAt   time.Time
+	CompletedAt *time.Time
+}
+
+func (Record) TableName() string {
+	return "idempotency_keys"
+}
+
+func (r Record) Completed() bool {
+	return r.CompletedAt != nil
+}
@@ -0,0 +1,102 @@
+package models
+
+import (
+	"time"
+
+	currencyModels "github.com/tizzhh/micro-banking/internal/domain/currency/models"
+)
+
+type AccountKind string
+
+const (
+	AccountUserCash   AccountKind = "user_cash"
+	AccountUserWallet AccountKind = "user_wallet"
+	AccountHouseFX    AccountKind = "house_fx"
+	AccountHouseFees  AccountKind = "house_fees"
+	AccountExternal   AccountKind = "external"
+)
+
+type TransactionType string
+
+const (
+	TransactionOpeningBalance TransactionType = "opening_balance"
+	TransactionDeposit        TransactionType = "deposit"
+	TransactionWithdrawal     TransactionType = "withdrawal"
+	TransactionBuy            TransactionType = "buy"
+	TransactionSell           TransactionType = "sell"
+	TransactionTransfer       TransactionType = "transfer"
+	TransactionAdjustment     TransactionType = "adjustment"
+	TransactionConvert        TransactionType = "convert"
+)
+
+type Direction string
+
+const (
+	Debit  Direction = "debit"
+	Credit Direction = "credit"
+)
+
+// LedgerAccount is nil-user for house and external accounts.
+type LedgerAccount struct {
+	ID         uint64
+	UserID     *uint64
+	CurrencyID uint64
+	Currency   currencyModels.Currency
+	Kind       AccountKind
+}
+
+type Transaction struct {
+	ID        uint64
+	UserID    *uint64
+	Type      TransactionType
+	CreatedAt time.Time
+	Entries   []LedgerEntry
+}
+
+type LedgerEntry struct {
+	ID            uint64
+	TransactionID uint64
+	AccountID     uint64
+	Account       LedgerAccount
+	Direction     Direction
+	Amount        uint64
+	CreatedAt     time.Time
+}
+
+// Posting is a single leg of a transaction before it is written to the ledger.
+type Posting struct {
+	Account   LedgerAccount
+	Direction Direction
+	Amount    uint64
+}
+
+type Mismatch struct {
+	UserID        uint64
+	CurrencyID    uint64
+	Kind          AccountKind
+	StoredBalance int64
+	LedgerBalance int64
+}
+
+// HistoryEntry is a single ledger entry on one of the user's accounts.
+type HistoryEntry struct {
+	ID            uint64
+	TransactionID uint64
+	Type          TransactionType
+	CurrencyCode  string
+	Direction     Direction
+	Amount        uint64
+	CreatedAt     time.Time
+}
+
+// HistoryFilter narrows a history query. Zero values mean no restriction;
+// After is the id of the last entry of the previous page.
+type HistoryFilter struct {
+	Types        []TransactionType
+	CurrencyCode string
+	From         time.Time
+	To           time.Time
+	Ascending    bool
+	After        uint64
+	Limit        int
+}
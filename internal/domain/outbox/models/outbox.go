@@ -0,0 +1,33 @@
+package models
+
+import (
+	"time"
+
+	authModels "github.com/tizzhh/micro-banking/internal/domain/auth/models"
+	"github.com/tizzhh/micro-banking/pkg/money"
+)
+
+// Message is a notification waiting in the outbox to be published to Kafka.
+type Message struct {
+	ID            uint64
+	EmailAddr     string
+	Message       string
+	Attempts      int
+	NextAttemptAt time.Time
+	LastError     string
+	CreatedAt     time.Time
+	SentAt        *time.Time
+}
+
+func (Message) TableName() string {
+	return "outbox"
+}
+
+// Notify builds the notifications of a balance change. It is called inside
+// the transaction of the change with the user's new balance, so that the
+// messages are stored only if the change is committed.
+type Notify func(newBalance money.Money) []Message
+
+// NotifyUser builds the notifications of a new user. It is called inside the
+// transaction that saves the user, once the user has an id.
+type NotifyUser func(user authModels.User) ([]Message, error)
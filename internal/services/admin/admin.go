@@ -0,0 +1,233 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	adminModels "github.com/tizzhh/micro-banking/internal/domain/admin/models"
+	"github.com/tizzhh/micro-banking/internal/domain/auth/models"
+	currencyModels "github.com/tizzhh/micro-banking/internal/domain/currency/models"
+	outboxModels "github.com/tizzhh/micro-banking/internal/domain/outbox/models"
+	adminErrors "github.com/tizzhh/micro-banking/internal/services/admin/errors"
+	"github.com/tizzhh/micro-banking/internal/storage"
+	"github.com/tizzhh/micro-banking/pkg/logger/sl"
+	"github.com/tizzhh/micro-banking/pkg/money"
+)
+
+func New(log *slog.Logger, userProvider UserProvider, balanceOperator BalanceOperator, accountUnlocker AccountUnlocker, currencyCatalog CurrencyCatalog) *Admin {
+	return &Admin{
+		log:             log,
+		userProvider:    userProvider,
+		balanceOperator: balanceOperator,
+		accountUnlocker: accountUnlocker,
+		currencyCatalog: currencyCatalog,
+	}
+}
+
+type Admin struct {
+	log             *slog.Logger
+	userProvider    UserProvider
+	balanceOperator BalanceOperator
+	accountUnlocker AccountUnlocker
+	currencyCatalog CurrencyCatalog
+}
+
+const (
+	AdjustmentMsgTemplate = "Your balance was adjusted by %s %s (%s). New balance: %s"
+
+	DefaultUsersLimit = 50
+	MaxUsersLimit     = 100
+)
+
+type UserProvider interface {
+	Users(ctx context.Context, filter adminModels.UserFilter) ([]models.User, error)
+	UserByID(ctx context.Context, id uint64) (models.User, error)
+	SetFrozen(ctx context.Context, userID uint64, frozen bool) error
+}
+
+type BalanceOperator interface {
+	Wallets(ctx context.Context, user models.User) ([]currencyModels.UserWallet, error)
+	AdjustBalance(ctx context.Context, user models.User, amount money.Money, adjustment adminModels.BalanceAdjustment, notify outboxModels.Notify) (money.Money, error)
+}
+
+// AccountUnlocker lifts the login lockouts of the auth service.
+type AccountUnlocker interface {
+	UnlockAccount(ctx context.Context, email string) error
+}
+
+const (
+	baseCurrencyCode = "USD"
+)
+
+// Users returns up to limit users with ids above after.
+func (a *Admin) Users(ctx context.Context, after uint64, limit int) ([]models.User, error) {
+	const caller = "services.admin.Users"
+	log := sl.AddCaller(a.log, caller)
+	log.Info("listing users")
+
+	if limit <= 0 {
+		limit = DefaultUsersLimit
+	}
+	limit = min(limit, MaxUsersLimit)
+
+	users, err := a.userProvider.Users(ctx, adminModels.UserFilter{After: after, Limit: limit})
+	if err != nil {
+		log.Error("failed to list users", sl.Error(err))
+		return nil, fmt.Errorf("%s: %w", caller, err)
+	}
+
+	return users, nil
+}
+
+func (a *Admin) User(ctx context.Context, id uint64) (models.User, error) {
+	const caller = "services.admin.User"
+	log := sl.AddCaller(a.log, caller)
+	log.Info("getting user", slog.Uint64("uid", id))
+
+	user, err := a.user(ctx, log, id)
+	if err != nil {
+		return models.User{}, fmt.Errorf("%s: %w", caller, err)
+	}
+
+	return user, nil
+}
+
+// Balance returns the cash and wallet balances of the user with id.
+func (a *Admin) Balance(ctx context.Context, id uint64) (adminModels.Balance, error) {
+	const caller = "services.admin.Balance"
+	log := sl.AddCaller(a.log, caller)
+	log.Info("getting user balance", slog.Uint64("uid", id))
+
+	user, err := a.user(ctx, log, id)
+	if err != nil {
+		return adminModels.Balance{}, fmt.Errorf("%s: %w", caller, err)
+	}
+
+	wallets, err := a.balanceOperator.Wallets(ctx, user)
+	if err != nil && !errors.Is(err, storage.ErrWalletNotFound) {
+		log.Error("failed to get wallets", sl.Error(err))
+		return adminModels.Balance{}, fmt.Errorf("%s: %w", caller, err)
+	}
+
+	balance := adminModels.Balance{
+		UserID:  user.ID,
+		Frozen:  user.Frozen,
+		Cash:    money.New(int64(user.Balance), baseCurrencyCode),
+		Wallets: make([]money.Money, 0, len(wallets)),
+	}
+	for _, wallet := range wallets {
+		balance.Wallets = append(balance.Wallets, money.New(int64(wallet.Balance), wallet.Currency.Code))
+	}
+
+	return balance, nil
+}
+
+// SetFrozen freezes or unfreezes the account of the user with id. Frozen
+// accounts cannot deposit, withdraw, transfer or trade currency.
+func (a *Admin) SetFrozen(ctx context.Context, id uint64, frozen bool) error {
+	const caller = "services.admin.SetFrozen"
+	log := sl.AddCaller(a.log, caller)
+	log.Info("changing account state", slog.Uint64("uid", id), slog.Bool("frozen", frozen))
+
+	if err := a.userProvider.SetFrozen(ctx, id, frozen); err != nil {
+		if errors.Is(err, storage.ErrUserNotFound) {
+			log.Warn("user not found", sl.Error(err))
+			return fmt.Errorf("%s: %w", caller, adminErrors.ErrUserNotFound)
+		}
+		log.Error("failed to change account state", sl.Error(err))
+		return fmt.Errorf("%s: %w", caller, err)
+	}
+
+	log.Info("account state changed")
+
+	return nil
+}
+
+// Unlock lifts the lockout of the user with id after too many failed logins.
+func (a *Admin) Unlock(ctx context.Context, id uint64) error {
+	const caller = "services.admin.Unlock"
+	log := sl.AddCaller(a.log, caller)
+	log.Info("unlocking account", slog.Uint64("uid", id))
+
+	user, err := a.user(ctx, log, id)
+	if err != nil {
+		return fmt.Errorf("%s: %w", caller, err)
+	}
+
+	if err := a.accountUnlocker.UnlockAccount(ctx, user.Email); err != nil {
+		log.Error("failed to unlock account", sl.Error(err))
+		return fmt.Errorf("%s: %w", caller, err)
+	}
+
+	log.Info("account unlocked")
+
+	return nil
+}
+
+// Adjust credits a positive or debits a negative amount to the balance of
+// the user with userID, in the name of the admin with adminID. Base currency
+// amounts adjust cash, others the wallet in that currency. The reason code
+// is mandatory.
+func (a *Admin) Adjust(ctx context.Context, adminID, userID uint64, amount money.Money, reason adminModels.ReasonCode, comment string) (money.Money, error) {
+	const caller = "services.admin.Adjust"
+	log := sl.AddCaller(a.log, caller)
+	log.Info("adjusting balance", slog.Uint64("uid", userID), slog.Uint64("admin_id", adminID), slog.String("reason_code", string(reason)))
+
+	if amount.IsZero() {
+		log.Warn("invalid amount", slog.String("amount", amount.String()))
+		return money.Money{}, fmt.Errorf("%s: %w", caller, adminErrors.ErrInvalidAmount)
+	}
+	if !reason.Valid() {
+		log.Warn("invalid reason code")
+		return money.Money{}, fmt.Errorf("%s: %w", caller, adminErrors.ErrInvalidReasonCode)
+	}
+	if adminID == userID {
+		log.Warn("attempt to adjust own balance")
+		return money.Money{}, fmt.Errorf("%s: %w", caller, adminErrors.ErrSelfAdjustment)
+	}
+
+	user, err := a.user(ctx, log, userID)
+	if err != nil {
+		return money.Money{}, fmt.Errorf("%s: %w", caller, err)
+	}
+
+	adjustment := adminModels.BalanceAdjustment{AdminID: adminID, ReasonCode: reason, Comment: comment}
+	newBalance, err := a.balanceOperator.AdjustBalance(ctx, user, amount, adjustment, func(newBalance money.Money) []outboxModels.Message {
+		return []outboxModels.Message{{EmailAddr: user.Email, Message: fmt.Sprintf(AdjustmentMsgTemplate, amount, amount.Currency(), reason, newBalance)}}
+	})
+	if err != nil {
+		if errors.Is(err, storage.ErrNotEnoughMoney) {
+			log.Warn("not enough money to debit")
+			return money.Money{}, fmt.Errorf("%s: %w", caller, adminErrors.ErrNotEnoughMoney)
+		}
+		if errors.Is(err, storage.ErrCurrencyCodeNotFound) {
+			log.Warn("currency code not found")
+			return money.Money{}, fmt.Errorf("%s: %w", caller, adminErrors.ErrCurrencyCodeNotFound)
+		}
+		if errors.Is(err, storage.ErrWalletNotFound) {
+			log.Warn("wallet not found")
+			return money.Money{}, fmt.Errorf("%s: %w", caller, adminErrors.ErrWalletNotFound)
+		}
+		log.Error("failed to adjust balance", sl.Error(err))
+		return money.Money{}, fmt.Errorf("%s: %w", caller, err)
+	}
+
+	log.Info("balance adjusted")
+
+	return newBalance, nil
+}
+
+func (a *Admin) user(ctx context.Context, log *slog.Logger, id uint64) (models.User, error) {
+	user, err := a.userProvider.UserByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, storage.ErrUserNotFound) {
+			log.Warn("user not found", sl.Error(err))
+			return models.User{}, adminErrors.ErrUserNotFound
+		}
+		log.Error("failed to get user", sl.Error(err))
+		return models.User{}, err
+	}
+	return user, nil
+}
@@ -0,0 +1,84 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	currencyModels "github.com/tizzhh/micro-banking/internal/domain/currency/models"
+	adminErrors "github.com/tizzhh/micro-banking/internal/services/admin/errors"
+	"github.com/tizzhh/micro-banking/internal/storage"
+	"github.com/tizzhh/micro-banking/pkg/logger/sl"
+	"github.com/tizzhh/micro-banking/pkg/money"
+)
+
+const maxExponent = 8
+
+type CurrencyCatalog interface {
+	CreateCurrency(ctx context.Context, currency currencyModels.Currency) (currencyModels.Currency, error)
+	UpdateCurrency(ctx context.Context, code string, update currencyModels.CurrencyUpdate) (currencyModels.Currency, error)
+}
+
+// CreateCurrency adds currency to the catalog. Users get wallets in it on
+// first use.
+func (a *Admin) CreateCurrency(ctx context.Context, currency currencyModels.Currency) (currencyModels.Currency, error) {
+	const caller = "services.admin.CreateCurrency"
+	log := sl.AddCaller(a.log, caller)
+	log.Info("creating currency", slog.String("code", currency.Code))
+
+	if currency.Exponent < 0 || currency.Exponent > maxExponent {
+		log.Warn("invalid exponent", slog.Int("exponent", currency.Exponent))
+		return currencyModels.Currency{}, fmt.Errorf("%s: %w", caller, adminErrors.ErrInvalidExponent)
+	}
+	if currency.MaxOrder > 0 && currency.MaxOrder < currency.MinOrder {
+		log.Warn("invalid order limits")
+		return currencyModels.Currency{}, fmt.Errorf("%s: %w", caller, adminErrors.ErrInvalidOrderLimits)
+	}
+
+	currency, err := a.currencyCatalog.CreateCurrency(ctx, currency)
+	if err != nil {
+		if errors.Is(err, storage.ErrCurrencyExists) {
+			log.Warn("currency already exists")
+			return currencyModels.Currency{}, fmt.Errorf("%s: %w", caller, adminErrors.ErrCurrencyExists)
+		}
+		log.Error("failed to create currency", sl.Error(err))
+		return currencyModels.Currency{}, fmt.Errorf("%s: %w", caller, err)
+	}
+	money.SetExponent(currency.Code, currency.Exponent)
+
+	log.Info("currency created")
+
+	return currency, nil
+}
+
+// UpdateCurrency changes the catalog fields set in update of the currency
+// with code. The base currency always stays enabled and is never traded.
+func (a *Admin) UpdateCurrency(ctx context.Context, code string, update currencyModels.CurrencyUpdate) (currencyModels.Currency, error) {
+	const caller = "services.admin.UpdateCurrency"
+	log := sl.AddCaller(a.log, caller)
+	log.Info("updating currency", slog.String("code", code))
+
+	if code == baseCurrencyCode && ((update.Enabled != nil && !*update.Enabled) || (update.Tradable != nil && *update.Tradable)) {
+		log.Warn("attempt to disable or trade the base currency")
+		return currencyModels.Currency{}, fmt.Errorf("%s: %w", caller, adminErrors.ErrBaseCurrency)
+	}
+
+	currency, err := a.currencyCatalog.UpdateCurrency(ctx, code, update)
+	if err != nil {
+		if errors.Is(err, storage.ErrCurrencyCodeNotFound) {
+			log.Warn("currency code not found")
+			return currencyModels.Currency{}, fmt.Errorf("%s: %w", caller, adminErrors.ErrCurrencyCodeNotFound)
+		}
+		if errors.Is(err, storage.ErrInvalidOrderLimits) {
+			log.Warn("invalid order limits")
+			return currencyModels.Currency{}, fmt.Errorf("%s: %w", caller, adminErrors.ErrInvalidOrderLimits)
+		}
+		log.Error("failed to update currency", sl.Error(err))
+		return currencyModels.Currency{}, fmt.Errorf("%s: %w", caller, err)
+	}
+
+	log.Info("currency updated")
+
+	return currency, nil
+}
@@ -0,0 +1,17 @@
+package errors
+
+import "errors"
+
+var (
+	ErrUserNotFound         = errors.New("user not found")
+	ErrInvalidAmount        = errors.New("amount must not be zero")
+	ErrInvalidReasonCode    = errors.New("invalid reason code")
+	ErrSelfAdjustment       = errors.New("cannot adjust your own balance")
+	ErrNotEnoughMoney       = errors.New("not enough money on balance")
+	ErrCurrencyCodeNotFound = errors.New("currency code not found")
+	ErrWalletNotFound       = errors.New("wallet not found")
+	ErrCurrencyExists       = errors.New("currency already exists")
+	ErrInvalidExponent      = errors.New("exponent must be between 0 and 8")
+	ErrInvalidOrderLimits   = errors.New("max order is below min order")
+	ErrBaseCurrency         = errors.New("the base currency cannot be disabled or traded")
+)
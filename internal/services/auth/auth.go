@@ -2,12 +2,18 @@ package auth
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"log/slog"
 	"time"
 
+	"github.com/tizzhh/micro-banking/internal/api/principal"
 	"github.com/tizzhh/micro-banking/internal/domain/auth/models"
+	outboxModels "github.com/tizzhh/micro-banking/internal/domain/outbox/models"
 	"github.com/tizzhh/micro-banking/internal/services/auth/errors"
 	"github.com/tizzhh/micro-banking/internal/storage"
 	"github.com/tizzhh/micro-banking/pkg/jwt"
@@ -15,28 +21,74 @@ import (
 	"golang.org/x/crypto/bcrypt"
 )
 
-func New(log *slog.Logger, tokenTTL time.Duration, userSaver UserSaver, userProvider UserProvider, userUpdater UserUpdater, userDeleter UserDeleter) *Auth {
+func New(
+	log *slog.Logger,
+	tokens *jwt.JWT,
+	refreshTTL time.Duration,
+	mfaIssuer string,
+	verification Verification,
+	passwordReset PasswordReset,
+	loginThrottle LoginThrottle,
+	userSaver UserSaver,
+	userProvider UserProvider,
+	userUpdater UserUpdater,
+	userCloser UserCloser,
+	refreshTokenStore RefreshTokenStore,
+	mfaStore MFAStore,
+	verificationStore VerificationStore,
+	passwordResetStore PasswordResetStore,
+	profileStore ProfileStore,
+	tokenRevoker TokenRevoker,
+	throttler Throttler,
+	loginAttempts LoginAttempts,
+) *Auth {
 	return &Auth{
-		log:          log,
-		tokenTTL:     tokenTTL,
-		userSaver:    userSaver,
-		userProvider: userProvider,
-		userUpdater:  userUpdater,
-		userDeleter:  userDeleter,
+		log:                log,
+		tokens:             tokens,
+		refreshTTL:         refreshTTL,
+		mfaIssuer:          mfaIssuer,
+		verification:       verification,
+		passwordReset:      passwordReset,
+		loginThrottle:      loginThrottle,
+		userSaver:          userSaver,
+		userProvider:       userProvider,
+		userUpdater:        userUpdater,
+		userCloser:         userCloser,
+		refreshTokenStore:  refreshTokenStore,
+		mfaStore:           mfaStore,
+		verificationStore:  verificationStore,
+		passwordResetStore: passwordResetStore,
+		profileStore:       profileStore,
+		tokenRevoker:       tokenRevoker,
+		throttler:          throttler,
+		loginAttempts:      loginAttempts,
 	}
 }
 
 type Auth struct {
-	log          *slog.Logger
-	tokenTTL     time.Duration
-	userSaver    UserSaver
-	userProvider UserProvider
-	userUpdater  UserUpdater
-	userDeleter  UserDeleter
+	log                *slog.Logger
+	tokens             *jwt.JWT
+	refreshTTL         time.Duration
+	mfaIssuer          string
+	verification       Verification
+	passwordReset      PasswordReset
+	loginThrottle      LoginThrottle
+	userSaver          UserSaver
+	userProvider       UserProvider
+	userUpdater        UserUpdater
+	userCloser         UserCloser
+	refreshTokenStore  RefreshTokenStore
+	mfaStore           MFAStore
+	verificationStore  VerificationStore
+	passwordResetStore PasswordResetStore
+	profileStore       ProfileStore
+	tokenRevoker       TokenRevoker
+	throttler          Throttler
+	loginAttempts      LoginAttempts
 }
 
 type UserSaver interface {
-	SaveUser(ctx context.Context, user models.User) (uint64, error)
+	SaveUser(ctx context.Context, user models.User, notify outboxModels.NotifyUser) (uint64, error)
 }
 
 type UserProvider interface {
@@ -47,10 +99,36 @@ type UserUpdater interface {
 	UpdateUser(ctx context.Context, email string, newPassword []byte) error
 }
 
-type UserDeleter interface {
-	DeleteUser(ctx context.Context, email string) error
+type UserCloser interface {
+	CloseUser(ctx context.Context, id uint64, closedAt time.Time) error
 }
 
+type RefreshTokenStore interface {
+	SaveRefreshToken(ctx context.Context, token models.RefreshToken) error
+	RotateRefreshToken(ctx context.Context, tokenHash string, next models.RefreshToken) (models.User, error)
+	RevokeRefreshToken(ctx context.Context, userID uint64, tokenHash string) error
+}
+
+type MFAStore interface {
+	SaveMFASecret(ctx context.Context, secret models.MFASecret) error
+	MFASecret(ctx context.Context, userID uint64) (models.MFASecret, error)
+	ConfirmMFA(ctx context.Context, userID uint64, step int64, codeHashes []string) error
+	UseMFAStep(ctx context.Context, userID uint64, step int64) error
+	UseRecoveryCode(ctx context.Context, userID uint64, codeHash string) error
+	ReplaceRecoveryCodes(ctx context.Context, userID uint64, codeHashes []string) error
+	DeleteMFA(ctx context.Context, userID uint64) error
+}
+
+type TokenRevoker interface {
+	RevokeToken(ctx context.Context, jti string, ttl time.Duration) error
+	ConsumeToken(ctx context.Context, jti string, ttl time.Duration) (bool, error)
+	RevokeUserTokens(ctx context.Context, userID uint64, ttl time.Duration) error
+}
+
+const refreshTokenLen = 32
+
+// Register saves a user waiting for the verification of their email address
+// and sends them a verification mail.
 func (a *Auth) Register(ctx context.Context, email string, password string, firstName string, lastName string, age uint32) (uint64, error) {
 	const caller = "services.auth.Register"
 
@@ -70,9 +148,10 @@ func (a *Auth) Register(ctx context.Context, email string, password string, firs
 		FirstName: firstName,
 		LastName:  lastName,
 		Age:       age,
+		Status:    models.UserStatusPendingVerification,
 	}
 
-	newUserId, err := a.userSaver.SaveUser(ctx, newUser)
+	newUserId, err := a.userSaver.SaveUser(ctx, newUser, a.verificationMessages)
 	if err != nil {
 		if errors.Is(err, storage.ErrUserAlreadyExists) {
 			log.Warn("user already exists", sl.Error(err))
@@ -87,30 +166,196 @@ func (a *Auth) Register(ctx context.Context, email string, password string, firs
 	return newUserId, nil
 }
 
-func (a *Auth) Login(ctx context.Context, email string, password string) (string, error) {
+// Login checks the password of the user with email. Failed logins back off
+// and eventually lock out further ones to the account and from the client IP
+// of ctx.
+func (a *Auth) Login(ctx context.Context, email string, password string) (models.TokenPair, error) {
 	const caller = "services.auth.Login"
 
 	log := sl.AddCaller(a.log, caller)
 
 	log.Info("logging a user in")
 
+	ip := principal.ClientIPFromContext(ctx)
+	if err := a.checkLoginAllowed(ctx, log, email, ip); err != nil {
+		return models.TokenPair{}, fmt.Errorf("%s: %w", caller, err)
+	}
+
 	user, err := a.getUserAndCheckPassword(ctx, email, password)
 	if err != nil {
-		return "", fmt.Errorf("%s: %w", caller, err)
+		if errors.Is(err, auth.ErrInvalidCredentials) {
+			if err := a.recordLoginFailure(ctx, log, email, ip); err != nil {
+				log.Error("failed to record login failure", sl.Error(err))
+				return models.TokenPair{}, fmt.Errorf("%s: %w", caller, err)
+			}
+		}
+		return models.TokenPair{}, fmt.Errorf("%s: %w", caller, err)
+	}
+
+	if err := a.loginAttempts.ResetLoginFailures(ctx, accountKey(email)); err != nil {
+		log.Error("failed to reset login failures", sl.Error(err))
+		return models.TokenPair{}, fmt.Errorf("%s: %w", caller, err)
+	}
+
+	secret, err := a.mfaStore.MFASecret(ctx, user.ID)
+	if err != nil && !errors.Is(err, storage.ErrMFANotFound) {
+		log.Error("failed to get mfa secret", sl.Error(err))
+		return models.TokenPair{}, fmt.Errorf("%s: %w", caller, err)
+	}
+	if err == nil && secret.Confirmed() {
+		challenge, err := a.tokens.NewChallengeToken(user)
+		if err != nil {
+			log.Error("failed to generate mfa token", sl.Error(err))
+			return models.TokenPair{}, fmt.Errorf("%s: %w", caller, err)
+		}
+		log.Info("password verified, mfa required")
+		return models.TokenPair{MFAToken: challenge}, nil
 	}
 
 	log.Info("user logged in successfully")
 
-	jwt := jwt.New(a.tokenTTL)
+	tokens, err := a.issueTokens(ctx, log, user, a.tokens.NewToken)
+	if err != nil {
+		return models.TokenPair{}, fmt.Errorf("%s: %w", caller, err)
+	}
 
-	token, err := jwt.NewToken(user)
+	return tokens, nil
+}
+
+// issueTokens starts a session of user with an access token from newToken
+// and a refresh token.
+func (a *Auth) issueTokens(ctx context.Context, log *slog.Logger, user models.User, newToken func(models.User) (string, error)) (models.TokenPair, error) {
+	token, err := newToken(user)
 	if err != nil {
 		log.Error("failed to generate token", sl.Error(err))
-		return "", fmt.Errorf("%s: %w", caller, err)
+		return models.TokenPair{}, err
+	}
+
+	refreshToken, refreshTokenHash, err := newRefreshToken()
+	if err != nil {
+		log.Error("failed to generate refresh token", sl.Error(err))
+		return models.TokenPair{}, err
+	}
+
+	familyID, err := jwt.NewID()
+	if err != nil {
+		log.Error("failed to generate refresh token family", sl.Error(err))
+		return models.TokenPair{}, err
+	}
+
+	err = a.refreshTokenStore.SaveRefreshToken(ctx, models.RefreshToken{
+		UserID:    user.ID,
+		FamilyID:  familyID,
+		TokenHash: refreshTokenHash,
+		ExpiresAt: time.Now().Add(a.refreshTTL),
+	})
+	if err != nil {
+		log.Error("failed to save refresh token", sl.Error(err))
+		return models.TokenPair{}, err
 	}
 
 	log.Info("generated token successfully")
-	return token, nil
+	return models.TokenPair{AccessToken: token, RefreshToken: refreshToken}, nil
+}
+
+// Refresh exchanges a refresh token for a new access token and a new refresh
+// token. Each refresh token can be used once; using it again revokes every
+// token issued from the same login.
+func (a *Auth) Refresh(ctx context.Context, refreshToken string) (models.TokenPair, error) {
+	const caller = "services.auth.Refresh"
+
+	log := sl.AddCaller(a.log, caller)
+
+	log.Info("refreshing token")
+
+	nextToken, nextTokenHash, err := newRefreshToken()
+	if err != nil {
+		log.Error("failed to generate refresh token", sl.Error(err))
+		return models.TokenPair{}, fmt.Errorf("%s: %w", caller, err)
+	}
+
+	user, err := a.refreshTokenStore.RotateRefreshToken(ctx, hashRefreshToken(refreshToken), models.RefreshToken{
+		TokenHash: nextTokenHash,
+		ExpiresAt: time.Now().Add(a.refreshTTL),
+	})
+	if err != nil {
+		if errors.Is(err, storage.ErrRefreshTokenReused) {
+			log.Warn("refresh token reuse detected, session revoked", sl.Error(err))
+			return models.TokenPair{}, fmt.Errorf("%s: %w", caller, auth.ErrInvalidToken)
+		}
+		if errors.Is(err, storage.ErrRefreshTokenNotFound) || errors.Is(err, storage.ErrRefreshTokenExpired) || errors.Is(err, storage.ErrUserNotFound) {
+			log.Info("invalid refresh token", sl.Error(err))
+			return models.TokenPair{}, fmt.Errorf("%s: %w", caller, auth.ErrInvalidToken)
+		}
+		log.Error("failed to rotate refresh token", sl.Error(err))
+		return models.TokenPair{}, fmt.Errorf("%s: %w", caller, err)
+	}
+
+	token, err := a.tokens.NewToken(user)
+	if err != nil {
+		log.Error("failed to generate token", sl.Error(err))
+		return models.TokenPair{}, fmt.Errorf("%s: %w", caller, err)
+	}
+
+	log.Info("token refreshed")
+	return models.TokenPair{AccessToken: token, RefreshToken: nextToken}, nil
+}
+
+// Logout revokes the access token and, if given, the session of the refresh
+// token.
+func (a *Auth) Logout(ctx context.Context, accessToken string, refreshToken string) error {
+	const caller = "services.auth.Logout"
+
+	log := sl.AddCaller(a.log, caller)
+
+	log.Info("logging a user out")
+
+	parsedToken, err := a.tokens.CheckToken(accessToken)
+	if err != nil {
+		log.Info("invalid access token", sl.Error(err))
+		return fmt.Errorf("%s: %w", caller, auth.ErrInvalidToken)
+	}
+	claims := parsedToken.Claims.(*jwt.Claims)
+	uid, err := claims.UserID()
+	if err != nil {
+		return fmt.Errorf("%s: %w", caller, auth.ErrInvalidToken)
+	}
+
+	// the token only has to stay on the revocation list until it expires
+	if err := a.tokenRevoker.RevokeToken(ctx, claims.ID, a.tokens.Remaining(claims)); err != nil {
+		log.Error("failed to revoke access token", sl.Error(err))
+		return fmt.Errorf("%s: %w", caller, err)
+	}
+
+	if refreshToken == "" {
+		return nil
+	}
+
+	err = a.refreshTokenStore.RevokeRefreshToken(ctx, uid, hashRefreshToken(refreshToken))
+	if err != nil {
+		if errors.Is(err, storage.ErrRefreshTokenNotFound) {
+			log.Info("refresh token not found", sl.Error(err))
+			return fmt.Errorf("%s: %w", caller, auth.ErrInvalidToken)
+		}
+		log.Error("failed to revoke refresh token", sl.Error(err))
+		return fmt.Errorf("%s: %w", caller, err)
+	}
+
+	log.Info("user logged out")
+	return nil
+}
+
+// JWKS returns the public keys access tokens are verified with.
+func (a *Auth) JWKS(ctx context.Context) (jwt.JWKS, error) {
+	const caller = "services.auth.JWKS"
+
+	jwks, err := a.tokens.JWKS()
+	if err != nil {
+		sl.AddCaller(a.log, caller).Error("failed to encode keys", sl.Error(err))
+		return jwt.JWKS{}, fmt.Errorf("%s: %w", caller, err)
+	}
+
+	return jwks, nil
 }
 
 func (a *Auth) UpdatePassword(ctx context.Context, email string, oldPassword string, newPassword string) error {
@@ -141,25 +386,44 @@ func (a *Auth) UpdatePassword(ctx context.Context, email string, oldPassword str
 	return nil
 }
 
+// Unregister closes the account with email and password, which must not hold
+// any money, and revokes its tokens. The account is kept until the retention
+// job anonymizes it.
 func (a *Auth) Unregister(ctx context.Context, email string, password string) error {
 	const caller = "services.auth.Unregister"
 
 	log := sl.AddCaller(a.log, caller)
 
-	log.Info("deleting user")
+	log.Info("closing user")
 
-	_, err := a.getUserAndCheckPassword(ctx, email, password)
+	user, err := a.getUserAndCheckPassword(ctx, email, password)
 	if err != nil {
 		return fmt.Errorf("%s: %w", caller, err)
 	}
 
 	log.Info("user retrieved successfully")
 
-	err = a.userDeleter.DeleteUser(ctx, email)
+	err = a.userCloser.CloseUser(ctx, user.ID, time.Now())
 	if err != nil {
+		if errors.Is(err, storage.ErrUserHasFunds) {
+			log.Warn("user has a non-zero balance", sl.Error(err))
+			return fmt.Errorf("%s: %w", caller, auth.ErrUserHasFunds)
+		}
+		if errors.Is(err, storage.ErrUserNotFound) {
+			log.Warn("user not found", sl.Error(err))
+			return fmt.Errorf("%s: %w", caller, auth.ErrInvalidCredentials)
+		}
+		log.Error("failed to close user", sl.Error(err))
+		return fmt.Errorf("%s: %w", caller, err)
+	}
+
+	if err := a.tokenRevoker.RevokeUserTokens(ctx, user.ID, a.tokens.Lifetime()); err != nil {
+		log.Error("failed to revoke access tokens", sl.Error(err))
 		return fmt.Errorf("%s: %w", caller, err)
 	}
 
+	log.Info("user closed")
+
 	return nil
 }
 
@@ -208,3 +472,19 @@ func (a *Auth) getUserAndCheckPassword(ctx context.Context, email string, passwo
 
 	return user, nil
 }
+
+// newRefreshToken returns an opaque refresh token and the hash it is stored
+// by.
+func newRefreshToken() (string, string, error) {
+	value := make([]byte, refreshTokenLen)
+	if _, err := rand.Read(value); err != nil {
+		return "", "", err
+	}
+	token := base64.RawURLEncoding.EncodeToString(value)
+	return token, hashRefreshToken(token), nil
+}
+
+func hashRefreshToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
@@ -3,7 +3,18 @@ package auth
 import "errors"
 
 var (
-	ErrInvalidCredentials = errors.New("invalid credentials")
-	ErrUserAlreadyExists  = errors.New("user already exists")
-	ErrUserNotFound       = errors.New("user not found")
+	ErrInvalidCredentials   = errors.New("invalid credentials")
+	ErrUserAlreadyExists    = errors.New("user already exists")
+	ErrUserNotFound         = errors.New("user not found")
+	ErrUserHasFunds         = errors.New("user has a non-zero balance")
+	ErrInvalidToken         = errors.New("invalid token")
+	ErrMFAAlreadyEnabled    = errors.New("mfa is already enabled")
+	ErrMFANotEnabled        = errors.New("mfa is not enabled")
+	ErrInvalidMFACode       = errors.New("invalid mfa code")
+	ErrEmailAlreadyVerified = errors.New("email is already verified")
+	ErrTooManyRequests      = errors.New("too many requests, try again later")
+	ErrTooManyAttempts      = errors.New("too many failed login attempts, try again later")
+	ErrTooManyMFAAttempts   = errors.New("too many wrong mfa codes, try again later")
+	ErrEmptyProfileUpdate   = errors.New("nothing to update")
+	ErrEmailUnchanged       = errors.New("new email is the current one")
 )
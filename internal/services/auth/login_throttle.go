@@ -0,0 +1,222 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+
+	outboxModels "github.com/tizzhh/micro-banking/internal/domain/outbox/models"
+	"github.com/tizzhh/micro-banking/internal/services/auth/errors"
+	"github.com/tizzhh/micro-banking/internal/storage"
+	"github.com/tizzhh/micro-banking/pkg/logger/sl"
+)
+
+const LockoutMsgTemplate = "Your micro-banking account was locked for %s after too many failed logins.\nIf it was not you, reset your password."
+
+// LoginThrottle limits failed logins per account and per client IP. Every
+// failure blocks further logins to the account for a backoff doubling from
+// BaseBackoff up to MaxBackoff. IPs may be shared, so they are not backed
+// off. Accounts with MaxAccountFailures and IPs with MaxIPFailures within
+// FailureWindow are locked out for LockoutDuration, and so are the second
+// factor checks of users with MaxMFAFailures wrong codes.
+type LoginThrottle struct {
+	MaxAccountFailures int
+	MaxIPFailures      int
+	MaxMFAFailures     int
+	FailureWindow      time.Duration
+	BaseBackoff        time.Duration
+	MaxBackoff         time.Duration
+	LockoutDuration    time.Duration
+}
+
+// Backoff returns how long logins are blocked after the failures-th failed
+// one in a row.
+func (t LoginThrottle) Backoff(failures int64) time.Duration {
+	if failures <= 0 || t.BaseBackoff <= 0 {
+		return 0
+	}
+	backoff := t.BaseBackoff
+	for i := int64(1); i < failures && backoff < t.MaxBackoff; i++ {
+		backoff *= 2
+	}
+	return min(backoff, t.MaxBackoff)
+}
+
+type LoginAttempts interface {
+	AddLoginFailure(ctx context.Context, key string, window time.Duration) (int64, error)
+	BlockLogins(ctx context.Context, key string, d time.Duration) error
+	LoginsBlockedFor(ctx context.Context, keys ...string) (time.Duration, error)
+	ResetLoginFailures(ctx context.Context, key string) error
+}
+
+// UnlockAccount lifts the lockout of the account with email and forgets its
+// failed logins and second factor checks.
+func (a *Auth) UnlockAccount(ctx context.Context, email string) error {
+	const caller = "services.auth.UnlockAccount"
+
+	log := sl.AddCaller(a.log, caller)
+
+	log.Info("unlocking account")
+
+	user, err := a.user(ctx, log, email)
+	if err != nil {
+		return fmt.Errorf("%s: %w", caller, err)
+	}
+
+	if err := a.loginAttempts.ResetLoginFailures(ctx, accountKey(user.Email)); err != nil {
+		log.Error("failed to reset login failures", sl.Error(err))
+		return fmt.Errorf("%s: %w", caller, err)
+	}
+	if err := a.loginAttempts.ResetLoginFailures(ctx, mfaKey(user.ID)); err != nil {
+		log.Error("failed to reset mfa failures", sl.Error(err))
+		return fmt.Errorf("%s: %w", caller, err)
+	}
+
+	log.Info("account unlocked")
+
+	return nil
+}
+
+// checkLoginAllowed fails with ErrTooManyAttempts while logins to the account
+// with email or from ip are blocked.
+func (a *Auth) checkLoginAllowed(ctx context.Context, log *slog.Logger, email string, ip string) error {
+	keys := []string{accountKey(email)}
+	if ip != "" {
+		keys = append(keys, ipKey(ip))
+	}
+
+	blockedFor, err := a.loginAttempts.LoginsBlockedFor(ctx, keys...)
+	if err != nil {
+		log.Error("failed to check login block", sl.Error(err))
+		return err
+	}
+	if blockedFor > 0 {
+		log.Warn("login blocked", slog.Duration("blocked_for", blockedFor))
+		return auth.ErrTooManyAttempts
+	}
+
+	return nil
+}
+
+// recordLoginFailure backs off further logins to the account with email and
+// locks out the account or ip once they failed too often. The owner of an
+// account that gets locked out is mailed.
+func (a *Auth) recordLoginFailure(ctx context.Context, log *slog.Logger, email string, ip string) error {
+	lockedOut, err := a.addLoginFailure(ctx, accountKey(email), a.loginThrottle.MaxAccountFailures, true)
+	if err != nil {
+		return err
+	}
+	if lockedOut {
+		log.Warn("account locked out")
+		if err := a.notifyLockout(ctx, log, email); err != nil {
+			return err
+		}
+	}
+
+	if ip == "" {
+		return nil
+	}
+	lockedOut, err = a.addLoginFailure(ctx, ipKey(ip), a.loginThrottle.MaxIPFailures, false)
+	if err != nil {
+		return err
+	}
+	if lockedOut {
+		log.Warn("client ip locked out", slog.String("ip", ip))
+	}
+
+	return nil
+}
+
+// addLoginFailure counts a failed login for key and blocks the next ones for
+// the lockout duration from maxFailures on, before that for the backoff if
+// backoff is set. It reports whether this failure locked key out.
+func (a *Auth) addLoginFailure(ctx context.Context, key string, maxFailures int, backoff bool) (bool, error) {
+	failures, err := a.loginAttempts.AddLoginFailure(ctx, key, a.loginThrottle.FailureWindow)
+	if err != nil {
+		return false, err
+	}
+
+	lockedOut := maxFailures > 0 && failures >= int64(maxFailures)
+	var block time.Duration
+	if backoff {
+		block = a.loginThrottle.Backoff(failures)
+	}
+	if lockedOut {
+		block = a.loginThrottle.LockoutDuration
+	}
+	if block > 0 {
+		if err := a.loginAttempts.BlockLogins(ctx, key, block); err != nil {
+			return false, err
+		}
+	}
+
+	return lockedOut && failures == int64(maxFailures), nil
+}
+
+// checkMFAAllowed fails with ErrTooManyMFAAttempts while the second factor
+// checks of the user with id are locked out.
+func (a *Auth) checkMFAAllowed(ctx context.Context, log *slog.Logger, id uint64) error {
+	blockedFor, err := a.loginAttempts.LoginsBlockedFor(ctx, mfaKey(id))
+	if err != nil {
+		log.Error("failed to check mfa block", sl.Error(err))
+		return err
+	}
+	if blockedFor > 0 {
+		log.Warn("mfa blocked", slog.Duration("blocked_for", blockedFor))
+		return auth.ErrTooManyMFAAttempts
+	}
+
+	return nil
+}
+
+// recordMFAFailure counts a wrong second factor of the user with id and locks
+// out their second factor checks once they failed too often.
+func (a *Auth) recordMFAFailure(ctx context.Context, log *slog.Logger, id uint64) error {
+	lockedOut, err := a.addLoginFailure(ctx, mfaKey(id), a.loginThrottle.MaxMFAFailures, false)
+	if err != nil {
+		return err
+	}
+	if lockedOut {
+		log.Warn("mfa locked out")
+	}
+
+	return nil
+}
+
+func (a *Auth) notifyLockout(ctx context.Context, log *slog.Logger, email string) error {
+	user, err := a.userProvider.User(ctx, email)
+	if err != nil {
+		if errors.Is(err, storage.ErrUserNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	messages := []outboxModels.Message{{
+		EmailAddr: user.Email,
+		Message:   fmt.Sprintf(LockoutMsgTemplate, a.loginThrottle.LockoutDuration),
+	}}
+	if err := a.verificationStore.EnqueueOutbox(ctx, messages); err != nil {
+		return err
+	}
+
+	log.Info("lockout mail enqueued")
+
+	return nil
+}
+
+func accountKey(email string) string {
+	return "account:" + strings.ToLower(email)
+}
+
+func ipKey(ip string) string {
+	return "ip:" + ip
+}
+
+func mfaKey(id uint64) string {
+	return "mfa:" + strconv.FormatUint(id, 10)
+}
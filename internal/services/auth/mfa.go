@@ -0,0 +1,375 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/tizzhh/micro-banking/internal/domain/auth/models"
+	"github.com/tizzhh/micro-banking/internal/services/auth/errors"
+	"github.com/tizzhh/micro-banking/internal/storage"
+	"github.com/tizzhh/micro-banking/pkg/jwt"
+	"github.com/tizzhh/micro-banking/pkg/logger/sl"
+	"github.com/tizzhh/micro-banking/pkg/totp"
+)
+
+const (
+	recoveryCodesCount = 10
+	recoveryCodeLen    = 10
+)
+
+var recoveryCodeEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// EnrollMFA generates a new TOTP secret for the user. It protects logins
+// only once confirmed with ConfirmMFA.
+func (a *Auth) EnrollMFA(ctx context.Context, email string) (models.MFAEnrollment, error) {
+	const caller = "services.auth.EnrollMFA"
+
+	log := sl.AddCaller(a.log, caller)
+
+	log.Info("enrolling mfa")
+
+	user, err := a.user(ctx, log, email)
+	if err != nil {
+		return models.MFAEnrollment{}, fmt.Errorf("%s: %w", caller, err)
+	}
+
+	secret, err := totp.NewSecret()
+	if err != nil {
+		log.Error("failed to generate mfa secret", sl.Error(err))
+		return models.MFAEnrollment{}, fmt.Errorf("%s: %w", caller, err)
+	}
+
+	err = a.mfaStore.SaveMFASecret(ctx, models.MFASecret{UserID: user.ID, Secret: secret, CreatedAt: time.Now()})
+	if err != nil {
+		if errors.Is(err, storage.ErrMFAAlreadyConfirmed) {
+			log.Warn("mfa already enabled")
+			return models.MFAEnrollment{}, fmt.Errorf("%s: %w", caller, auth.ErrMFAAlreadyEnabled)
+		}
+		log.Error("failed to save mfa secret", sl.Error(err))
+		return models.MFAEnrollment{}, fmt.Errorf("%s: %w", caller, err)
+	}
+
+	log.Info("mfa enrolled")
+
+	return models.MFAEnrollment{
+		Secret: secret,
+		URI:    totp.URI(a.mfaIssuer, user.Email, secret),
+	}, nil
+}
+
+// ConfirmMFA enables MFA for the user with the first code of their
+// authenticator and returns their recovery codes.
+func (a *Auth) ConfirmMFA(ctx context.Context, email string, code string) ([]string, error) {
+	const caller = "services.auth.ConfirmMFA"
+
+	log := sl.AddCaller(a.log, caller)
+
+	log.Info("confirming mfa")
+
+	user, err := a.user(ctx, log, email)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", caller, err)
+	}
+
+	secret, err := a.mfaStore.MFASecret(ctx, user.ID)
+	if err != nil {
+		if errors.Is(err, storage.ErrMFANotFound) {
+			log.Warn("mfa not enrolled")
+			return nil, fmt.Errorf("%s: %w", caller, auth.ErrMFANotEnabled)
+		}
+		log.Error("failed to get mfa secret", sl.Error(err))
+		return nil, fmt.Errorf("%s: %w", caller, err)
+	}
+	if secret.Confirmed() {
+		log.Warn("mfa already enabled")
+		return nil, fmt.Errorf("%s: %w", caller, auth.ErrMFAAlreadyEnabled)
+	}
+
+	step, err := totp.Validate(secret.Secret, code, time.Now())
+	if err != nil {
+		log.Info("invalid mfa code", sl.Error(err))
+		return nil, fmt.Errorf("%s: %w", caller, auth.ErrInvalidMFACode)
+	}
+
+	codes, hashes, err := newRecoveryCodes()
+	if err != nil {
+		log.Error("failed to generate recovery codes", sl.Error(err))
+		return nil, fmt.Errorf("%s: %w", caller, err)
+	}
+
+	if err := a.mfaStore.ConfirmMFA(ctx, user.ID, step, hashes); err != nil {
+		if errors.Is(err, storage.ErrMFAAlreadyConfirmed) {
+			log.Warn("mfa already enabled or code reused")
+			return nil, fmt.Errorf("%s: %w", caller, auth.ErrInvalidMFACode)
+		}
+		log.Error("failed to confirm mfa", sl.Error(err))
+		return nil, fmt.Errorf("%s: %w", caller, err)
+	}
+
+	log.Info("mfa enabled")
+
+	return codes, nil
+}
+
+// DisableMFA turns MFA off for the user, who has to present both their
+// password and a second factor.
+func (a *Auth) DisableMFA(ctx context.Context, email string, password string, code string) error {
+	const caller = "services.auth.DisableMFA"
+
+	log := sl.AddCaller(a.log, caller)
+
+	log.Info("disabling mfa")
+
+	user, err := a.getUserAndCheckPassword(ctx, email, password)
+	if err != nil {
+		return fmt.Errorf("%s: %w", caller, err)
+	}
+
+	if err := a.verifySecondFactor(ctx, log, user, code); err != nil {
+		return fmt.Errorf("%s: %w", caller, err)
+	}
+
+	if err := a.mfaStore.DeleteMFA(ctx, user.ID); err != nil {
+		if errors.Is(err, storage.ErrMFANotFound) {
+			return fmt.Errorf("%s: %w", caller, auth.ErrMFANotEnabled)
+		}
+		log.Error("failed to delete mfa", sl.Error(err))
+		return fmt.Errorf("%s: %w", caller, err)
+	}
+
+	log.Info("mfa disabled")
+
+	return nil
+}
+
+// RegenerateRecoveryCodes replaces the recovery codes of the user, which
+// invalidates the old ones.
+func (a *Auth) RegenerateRecoveryCodes(ctx context.Context, email string, code string) ([]string, error) {
+	const caller = "services.auth.RegenerateRecoveryCodes"
+
+	log := sl.AddCaller(a.log, caller)
+
+	log.Info("regenerating recovery codes")
+
+	user, err := a.user(ctx, log, email)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", caller, err)
+	}
+
+	if err := a.verifySecondFactor(ctx, log, user, code); err != nil {
+		return nil, fmt.Errorf("%s: %w", caller, err)
+	}
+
+	codes, hashes, err := newRecoveryCodes()
+	if err != nil {
+		log.Error("failed to generate recovery codes", sl.Error(err))
+		return nil, fmt.Errorf("%s: %w", caller, err)
+	}
+
+	if err := a.mfaStore.ReplaceRecoveryCodes(ctx, user.ID, hashes); err != nil {
+		log.Error("failed to save recovery codes", sl.Error(err))
+		return nil, fmt.Errorf("%s: %w", caller, err)
+	}
+
+	log.Info("recovery codes regenerated")
+
+	return codes, nil
+}
+
+// VerifyMFA completes a login with the challenge token Login returned and a
+// TOTP or recovery code. A challenge token can be used once, so a wrong code
+// requires logging in again.
+func (a *Auth) VerifyMFA(ctx context.Context, mfaToken string, code string) (models.TokenPair, error) {
+	const caller = "services.auth.VerifyMFA"
+
+	log := sl.AddCaller(a.log, caller)
+
+	log.Info("verifying mfa")
+
+	parsedToken, err := a.tokens.CheckChallengeToken(mfaToken)
+	if err != nil {
+		log.Info("invalid mfa token", sl.Error(err))
+		return models.TokenPair{}, fmt.Errorf("%s: %w", caller, auth.ErrInvalidToken)
+	}
+	claims := parsedToken.Claims.(*jwt.Claims)
+
+	consumed, err := a.tokenRevoker.ConsumeToken(ctx, claims.ID, a.tokens.Remaining(claims))
+	if err != nil {
+		log.Error("failed to consume mfa token", sl.Error(err))
+		return models.TokenPair{}, fmt.Errorf("%s: %w", caller, err)
+	}
+	if !consumed {
+		log.Warn("mfa token reused")
+		return models.TokenPair{}, fmt.Errorf("%s: %w", caller, auth.ErrInvalidToken)
+	}
+
+	user, err := a.user(ctx, log, claims.Email)
+	if err != nil {
+		return models.TokenPair{}, fmt.Errorf("%s: %w", caller, err)
+	}
+
+	if err := a.verifySecondFactor(ctx, log, user, code); err != nil {
+		return models.TokenPair{}, fmt.Errorf("%s: %w", caller, err)
+	}
+
+	verifiedAt := time.Now()
+	tokens, err := a.issueTokens(ctx, log, user, func(user models.User) (string, error) {
+		return a.tokens.NewMFAToken(user, verifiedAt)
+	})
+	if err != nil {
+		return models.TokenPair{}, fmt.Errorf("%s: %w", caller, err)
+	}
+
+	log.Info("user logged in with mfa")
+
+	return tokens, nil
+}
+
+// StepUp returns a new access token for the user, recording that they just
+// verified a second factor. Large withdrawals and transfers require one.
+func (a *Auth) StepUp(ctx context.Context, email string, code string) (string, error) {
+	const caller = "services.auth.StepUp"
+
+	log := sl.AddCaller(a.log, caller)
+
+	log.Info("stepping up authentication")
+
+	user, err := a.user(ctx, log, email)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", caller, err)
+	}
+
+	if err := a.verifySecondFactor(ctx, log, user, code); err != nil {
+		return "", fmt.Errorf("%s: %w", caller, err)
+	}
+
+	token, err := a.tokens.NewMFAToken(user, time.Now())
+	if err != nil {
+		log.Error("failed to generate token", sl.Error(err))
+		return "", fmt.Errorf("%s: %w", caller, err)
+	}
+
+	log.Info("authentication stepped up")
+
+	return token, nil
+}
+
+// verifySecondFactor checks a TOTP or recovery code of user. Either can be
+// used only once. Users who got too many codes wrong are locked out like
+// failed logins are, whether or not the next code is right.
+func (a *Auth) verifySecondFactor(ctx context.Context, log *slog.Logger, user models.User, code string) error {
+	if err := a.checkMFAAllowed(ctx, log, user.ID); err != nil {
+		return err
+	}
+
+	err := a.checkSecondFactor(ctx, log, user, code)
+	if errors.Is(err, auth.ErrInvalidMFACode) {
+		if err := a.recordMFAFailure(ctx, log, user.ID); err != nil {
+			log.Error("failed to record mfa failure", sl.Error(err))
+			return err
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := a.loginAttempts.ResetLoginFailures(ctx, mfaKey(user.ID)); err != nil {
+		log.Error("failed to reset mfa failures", sl.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func (a *Auth) checkSecondFactor(ctx context.Context, log *slog.Logger, user models.User, code string) error {
+	secret, err := a.mfaStore.MFASecret(ctx, user.ID)
+	if err != nil {
+		if errors.Is(err, storage.ErrMFANotFound) {
+			log.Warn("mfa not enabled")
+			return auth.ErrMFANotEnabled
+		}
+		log.Error("failed to get mfa secret", sl.Error(err))
+		return err
+	}
+	if !secret.Confirmed() {
+		log.Warn("mfa not confirmed")
+		return auth.ErrMFANotEnabled
+	}
+
+	if !totp.IsCode(code) {
+		err := a.mfaStore.UseRecoveryCode(ctx, user.ID, hashRecoveryCode(code))
+		if err != nil {
+			if errors.Is(err, storage.ErrRecoveryCodeNotFound) {
+				log.Info("invalid recovery code")
+				return auth.ErrInvalidMFACode
+			}
+			log.Error("failed to use recovery code", sl.Error(err))
+			return err
+		}
+		log.Info("recovery code used")
+		return nil
+	}
+
+	step, err := totp.Validate(secret.Secret, code, time.Now())
+	if err != nil {
+		log.Info("invalid mfa code", sl.Error(err))
+		return auth.ErrInvalidMFACode
+	}
+	if err := a.mfaStore.UseMFAStep(ctx, user.ID, step); err != nil {
+		if errors.Is(err, storage.ErrMFACodeReused) {
+			log.Warn("mfa code reused")
+			return auth.ErrInvalidMFACode
+		}
+		log.Error("failed to record mfa code", sl.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func (a *Auth) user(ctx context.Context, log *slog.Logger, email string) (models.User, error) {
+	user, err := a.userProvider.User(ctx, email)
+	if err != nil {
+		if errors.Is(err, storage.ErrUserNotFound) {
+			log.Warn("user not found", sl.Error(err))
+			return models.User{}, auth.ErrInvalidCredentials
+		}
+		log.Error("failed to get user", sl.Error(err))
+		return models.User{}, err
+	}
+	return user, nil
+}
+
+// newRecoveryCodes returns recovery codes formatted as xxxxx-xxxxx, and the
+// hashes they are stored by.
+func newRecoveryCodes() ([]string, []string, error) {
+	codes := make([]string, 0, recoveryCodesCount)
+	hashes := make([]string, 0, recoveryCodesCount)
+	for i := 0; i < recoveryCodesCount; i++ {
+		value := make([]byte, recoveryCodeLen*5/8)
+		if _, err := rand.Read(value); err != nil {
+			return nil, nil, err
+		}
+		encoded := strings.ToLower(recoveryCodeEncoding.EncodeToString(value))
+		code := encoded[:recoveryCodeLen/2] + "-" + encoded[recoveryCodeLen/2:]
+		codes = append(codes, code)
+		hashes = append(hashes, hashRecoveryCode(code))
+	}
+	return codes, hashes, nil
+}
+
+// hashRecoveryCode hashes a recovery code regardless of case and dashes, as
+// users may type it either way.
+func hashRecoveryCode(code string) string {
+	normalized := strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+	hash := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(hash[:])
+}
@@ -0,0 +1,128 @@
+// Code generated by mockery v2.45.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	time "time"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// LoginAttempts is an autogenerated mock type for the LoginAttempts type
+type LoginAttempts struct {
+	mock.Mock
+}
+
+// AddLoginFailure provides a mock function with given fields: ctx, key, window
+func (_m *LoginAttempts) AddLoginFailure(ctx context.Context, key string, window time.Duration) (int64, error) {
+	ret := _m.Called(ctx, key, window)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddLoginFailure")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration) (int64, error)); ok {
+		return rf(ctx, key, window)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration) int64); ok {
+		r0 = rf(ctx, key, window)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Duration) error); ok {
+		r1 = rf(ctx, key, window)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BlockLogins provides a mock function with given fields: ctx, key, d
+func (_m *LoginAttempts) BlockLogins(ctx context.Context, key string, d time.Duration) error {
+	ret := _m.Called(ctx, key, d)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BlockLogins")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration) error); ok {
+		r0 = rf(ctx, key, d)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// LoginsBlockedFor provides a mock function with given fields: ctx, keys
+func (_m *LoginAttempts) LoginsBlockedFor(ctx context.Context, keys ...string) (time.Duration, error) {
+	_va := make([]interface{}, len(keys))
+	for _i := range keys {
+		_va[_i] = keys[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LoginsBlockedFor")
+	}
+
+	var r0 time.Duration
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, ...string) (time.Duration, error)); ok {
+		return rf(ctx, keys...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, ...string) time.Duration); ok {
+		r0 = rf(ctx, keys...)
+	} else {
+		r0 = ret.Get(0).(time.Duration)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, ...string) error); ok {
+		r1 = rf(ctx, keys...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ResetLoginFailures provides a mock function with given fields: ctx, key
+func (_m *LoginAttempts) ResetLoginFailures(ctx context.Context, key string) error {
+	ret := _m.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResetLoginFailures")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, key)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewLoginAttempts creates a new instance of LoginAttempts. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewLoginAttempts(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *LoginAttempts {
+	mock := &LoginAttempts{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,165 @@
+// Code generated by mockery v2.45.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+	models "github.com/tizzhh/micro-banking/internal/domain/auth/models"
+)
+
+// MFAStore is an autogenerated mock type for the MFAStore type
+type MFAStore struct {
+	mock.Mock
+}
+
+// ConfirmMFA provides a mock function with given fields: ctx, userID, step, codeHashes
+func (_m *MFAStore) ConfirmMFA(ctx context.Context, userID uint64, step int64, codeHashes []string) error {
+	ret := _m.Called(ctx, userID, step, codeHashes)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ConfirmMFA")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, int64, []string) error); ok {
+		r0 = rf(ctx, userID, step, codeHashes)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteMFA provides a mock function with given fields: ctx, userID
+func (_m *MFAStore) DeleteMFA(ctx context.Context, userID uint64) error {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteMFA")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) error); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MFASecret provides a mock function with given fields: ctx, userID
+func (_m *MFAStore) MFASecret(ctx context.Context, userID uint64) (models.MFASecret, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MFASecret")
+	}
+
+	var r0 models.MFASecret
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) (models.MFASecret, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) models.MFASecret); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(models.MFASecret)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReplaceRecoveryCodes provides a mock function with given fields: ctx, userID, codeHashes
+func (_m *MFAStore) ReplaceRecoveryCodes(ctx context.Context, userID uint64, codeHashes []string) error {
+	ret := _m.Called(ctx, userID, codeHashes)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReplaceRecoveryCodes")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, []string) error); ok {
+		r0 = rf(ctx, userID, codeHashes)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SaveMFASecret provides a mock function with given fields: ctx, secret
+func (_m *MFAStore) SaveMFASecret(ctx context.Context, secret models.MFASecret) error {
+	ret := _m.Called(ctx, secret)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveMFASecret")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.MFASecret) error); ok {
+		r0 = rf(ctx, secret)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UseMFAStep provides a mock function with given fields: ctx, userID, step
+func (_m *MFAStore) UseMFAStep(ctx context.Context, userID uint64, step int64) error {
+	ret := _m.Called(ctx, userID, step)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UseMFAStep")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, int64) error); ok {
+		r0 = rf(ctx, userID, step)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UseRecoveryCode provides a mock function with given fields: ctx, userID, codeHash
+func (_m *MFAStore) UseRecoveryCode(ctx context.Context, userID uint64, codeHash string) error {
+	ret := _m.Called(ctx, userID, codeHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UseRecoveryCode")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, string) error); ok {
+		r0 = rf(ctx, userID, codeHash)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewMFAStore creates a new instance of MFAStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMFAStore(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MFAStore {
+	mock := &MFAStore{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
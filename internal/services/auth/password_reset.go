@@ -0,0 +1,132 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/tizzhh/micro-banking/internal/domain/auth/models"
+	outboxModels "github.com/tizzhh/micro-banking/internal/domain/outbox/models"
+	"github.com/tizzhh/micro-banking/internal/services/auth/errors"
+	"github.com/tizzhh/micro-banking/internal/storage"
+	"github.com/tizzhh/micro-banking/pkg/logger/sl"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	PasswordResetLinkMsgTemplate  = "Reset your micro-banking password: %s\nIf you did not ask for it, ignore this mail."
+	PasswordResetTokenMsgTemplate = "Reset your micro-banking password with the token: %s\nIf you did not ask for it, ignore this mail."
+)
+
+// PasswordReset configures the mails that reset forgotten passwords.
+type PasswordReset struct {
+	TokenTTL time.Duration
+	// LinkURL is the page the token is sent to in the token query
+	// parameter. Mails carry the bare token without it.
+	LinkURL         string
+	RequestInterval time.Duration
+}
+
+type PasswordResetStore interface {
+	SavePasswordResetToken(ctx context.Context, token models.PasswordResetToken, messages []outboxModels.Message) error
+	ResetPassword(ctx context.Context, tokenHash string, passHash []byte) (models.User, error)
+}
+
+// RequestPasswordReset mails a password reset token to the user with email,
+// at most once per request interval. It succeeds whether or not the email is
+// registered, so that callers cannot tell.
+func (a *Auth) RequestPasswordReset(ctx context.Context, email string) error {
+	const caller = "services.auth.RequestPasswordReset"
+
+	log := sl.AddCaller(a.log, caller)
+
+	log.Info("requesting password reset")
+
+	user, err := a.userProvider.User(ctx, email)
+	if err != nil {
+		if errors.Is(err, storage.ErrUserNotFound) {
+			log.Info("password reset requested for unknown email")
+			return nil
+		}
+		log.Error("failed to get user", sl.Error(err))
+		return fmt.Errorf("%s: %w", caller, err)
+	}
+
+	allowed, err := a.throttler.Throttle(ctx, "password-reset:"+strconv.FormatUint(user.ID, 10), a.passwordReset.RequestInterval)
+	if err != nil {
+		log.Error("failed to throttle password reset", sl.Error(err))
+		return fmt.Errorf("%s: %w", caller, err)
+	}
+	if !allowed {
+		log.Warn("password reset requested too often")
+		return nil
+	}
+
+	// reset tokens are made like refresh tokens
+	token, tokenHash, err := newRefreshToken()
+	if err != nil {
+		log.Error("failed to generate password reset token", sl.Error(err))
+		return fmt.Errorf("%s: %w", caller, err)
+	}
+
+	message := fmt.Sprintf(PasswordResetTokenMsgTemplate, token)
+	if a.passwordReset.LinkURL != "" {
+		link, err := tokenLink(a.passwordReset.LinkURL, token)
+		if err != nil {
+			log.Error("failed to build password reset link", sl.Error(err))
+			return fmt.Errorf("%s: %w", caller, err)
+		}
+		message = fmt.Sprintf(PasswordResetLinkMsgTemplate, link)
+	}
+
+	err = a.passwordResetStore.SavePasswordResetToken(ctx, models.PasswordResetToken{
+		UserID:    user.ID,
+		TokenHash: tokenHash,
+		ExpiresAt: time.Now().Add(a.passwordReset.TokenTTL),
+	}, []outboxModels.Message{{EmailAddr: user.Email, Message: message}})
+	if err != nil {
+		log.Error("failed to save password reset token", sl.Error(err))
+		return fmt.Errorf("%s: %w", caller, err)
+	}
+
+	log.Info("password reset mail enqueued")
+
+	return nil
+}
+
+// ResetPassword sets a new password with a reset token and signs the user out
+// everywhere by revoking their refresh and access tokens.
+func (a *Auth) ResetPassword(ctx context.Context, token string, newPassword string) error {
+	const caller = "services.auth.ResetPassword"
+
+	log := sl.AddCaller(a.log, caller)
+
+	log.Info("resetting password")
+
+	passHash, err := bcrypt.GenerateFromPassword([]byte(newPassword), bcrypt.DefaultCost)
+	if err != nil {
+		log.Error("failed to generate password hash", sl.Error(err))
+		return fmt.Errorf("%s: %w", caller, err)
+	}
+
+	user, err := a.passwordResetStore.ResetPassword(ctx, hashRefreshToken(token), passHash)
+	if err != nil {
+		if errors.Is(err, storage.ErrPasswordResetTokenNotFound) || errors.Is(err, storage.ErrPasswordResetTokenExpired) || errors.Is(err, storage.ErrUserNotFound) {
+			log.Info("invalid password reset token", sl.Error(err))
+			return fmt.Errorf("%s: %w", caller, auth.ErrInvalidToken)
+		}
+		log.Error("failed to reset password", sl.Error(err))
+		return fmt.Errorf("%s: %w", caller, err)
+	}
+
+	if err := a.tokenRevoker.RevokeUserTokens(ctx, user.ID, a.tokens.Lifetime()); err != nil {
+		log.Error("failed to revoke access tokens", sl.Error(err))
+		return fmt.Errorf("%s: %w", caller, err)
+	}
+
+	log.Info("password reset")
+
+	return nil
+}
@@ -0,0 +1,101 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/tizzhh/micro-banking/internal/domain/auth/models"
+	outboxModels "github.com/tizzhh/micro-banking/internal/domain/outbox/models"
+	"github.com/tizzhh/micro-banking/internal/services/auth/errors"
+	"github.com/tizzhh/micro-banking/internal/storage"
+	"github.com/tizzhh/micro-banking/pkg/logger/sl"
+)
+
+const EmailChangedMsgTemplate = "The email address of your micro-banking account was changed to %s.\nIf it was not you, contact support."
+
+type ProfileStore interface {
+	UpdateProfile(ctx context.Context, id uint64, update models.ProfileUpdate) (models.User, error)
+	ChangeEmail(ctx context.Context, id uint64, newEmail string, notify outboxModels.NotifyUser) (models.User, error)
+}
+
+// UpdateProfile changes the profile fields set in update of the user with
+// email.
+func (a *Auth) UpdateProfile(ctx context.Context, email string, update models.ProfileUpdate) (models.User, error) {
+	const caller = "services.auth.UpdateProfile"
+
+	log := sl.AddCaller(a.log, caller)
+
+	log.Info("updating profile")
+
+	if update.Empty() {
+		log.Warn("empty profile update")
+		return models.User{}, fmt.Errorf("%s: %w", caller, auth.ErrEmptyProfileUpdate)
+	}
+
+	user, err := a.user(ctx, log, email)
+	if err != nil {
+		return models.User{}, fmt.Errorf("%s: %w", caller, err)
+	}
+
+	user, err = a.profileStore.UpdateProfile(ctx, user.ID, update)
+	if err != nil {
+		log.Error("failed to update profile", sl.Error(err))
+		return models.User{}, fmt.Errorf("%s: %w", caller, err)
+	}
+
+	log.Info("profile updated")
+
+	return user, nil
+}
+
+// ChangeEmail moves the account with email and password to newEmail, which
+// has to be verified again before the user can move money. The old address
+// is told about the change and the access tokens issued for it are revoked.
+// Verification tokens sent to the old address no longer verify the account.
+func (a *Auth) ChangeEmail(ctx context.Context, email string, password string, newEmail string) error {
+	const caller = "services.auth.ChangeEmail"
+
+	log := sl.AddCaller(a.log, caller)
+
+	log.Info("changing email")
+
+	user, err := a.getUserAndCheckPassword(ctx, email, password)
+	if err != nil {
+		return fmt.Errorf("%s: %w", caller, err)
+	}
+	if strings.EqualFold(user.Email, newEmail) {
+		log.Warn("new email is the current one")
+		return fmt.Errorf("%s: %w", caller, auth.ErrEmailUnchanged)
+	}
+
+	_, err = a.profileStore.ChangeEmail(ctx, user.ID, newEmail, func(updated models.User) ([]outboxModels.Message, error) {
+		messages, err := a.verificationMessages(updated)
+		if err != nil {
+			return nil, err
+		}
+		return append(messages, outboxModels.Message{
+			EmailAddr: user.Email,
+			Message:   fmt.Sprintf(EmailChangedMsgTemplate, newEmail),
+		}), nil
+	})
+	if err != nil {
+		if errors.Is(err, storage.ErrUserAlreadyExists) {
+			log.Warn("new email is taken")
+			return fmt.Errorf("%s: %w", caller, auth.ErrUserAlreadyExists)
+		}
+		log.Error("failed to change email", sl.Error(err))
+		return fmt.Errorf("%s: %w", caller, err)
+	}
+
+	// access tokens carry the email, so they are reissued by refreshing
+	if err := a.tokenRevoker.RevokeUserTokens(ctx, user.ID, a.tokens.Lifetime()); err != nil {
+		log.Error("failed to revoke access tokens", sl.Error(err))
+		return fmt.Errorf("%s: %w", caller, err)
+	}
+
+	log.Info("email changed")
+
+	return nil
+}
@@ -0,0 +1,165 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/tizzhh/micro-banking/internal/domain/auth/models"
+	outboxModels "github.com/tizzhh/micro-banking/internal/domain/outbox/models"
+	"github.com/tizzhh/micro-banking/internal/services/auth/errors"
+	"github.com/tizzhh/micro-banking/internal/storage"
+	"github.com/tizzhh/micro-banking/pkg/jwt"
+	"github.com/tizzhh/micro-banking/pkg/logger/sl"
+)
+
+const (
+	VerificationLinkMsgTemplate  = "Confirm your email address to start using micro-banking: %s"
+	VerificationTokenMsgTemplate = "Confirm your email address to start using micro-banking with the token: %s"
+)
+
+// Verification configures the mails that verify the email addresses of new
+// users.
+type Verification struct {
+	// LinkURL is the page the token is sent to in the token query
+	// parameter. Mails carry the bare token without it.
+	LinkURL        string
+	ResendInterval time.Duration
+}
+
+type VerificationStore interface {
+	VerifyUserEmail(ctx context.Context, id uint64, email string) error
+	EnqueueOutbox(ctx context.Context, messages []outboxModels.Message) error
+}
+
+type Throttler interface {
+	Throttle(ctx context.Context, key string, interval time.Duration) (bool, error)
+}
+
+// VerifyEmail activates the account the verification token was sent for,
+// if the account still has the address it was sent to. Each token can be
+// used once.
+func (a *Auth) VerifyEmail(ctx context.Context, token string) error {
+	const caller = "services.auth.VerifyEmail"
+
+	log := sl.AddCaller(a.log, caller)
+
+	log.Info("verifying email")
+
+	parsedToken, err := a.tokens.CheckVerificationToken(token)
+	if err != nil {
+		log.Info("invalid verification token", sl.Error(err))
+		return fmt.Errorf("%s: %w", caller, auth.ErrInvalidToken)
+	}
+	claims := parsedToken.Claims.(*jwt.Claims)
+	uid, err := claims.UserID()
+	if err != nil {
+		return fmt.Errorf("%s: %w", caller, auth.ErrInvalidToken)
+	}
+
+	consumed, err := a.tokenRevoker.ConsumeToken(ctx, claims.ID, a.tokens.Remaining(claims))
+	if err != nil {
+		log.Error("failed to consume verification token", sl.Error(err))
+		return fmt.Errorf("%s: %w", caller, err)
+	}
+	if !consumed {
+		log.Warn("verification token reused")
+		return fmt.Errorf("%s: %w", caller, auth.ErrInvalidToken)
+	}
+
+	err = a.verificationStore.VerifyUserEmail(ctx, uid, claims.Email)
+	if err != nil {
+		if errors.Is(err, storage.ErrEmailAlreadyVerified) {
+			log.Info("email already verified", sl.Error(err))
+			return fmt.Errorf("%s: %w", caller, auth.ErrEmailAlreadyVerified)
+		}
+		if errors.Is(err, storage.ErrUserNotFound) {
+			log.Info("user of verification token not found or email changed", sl.Error(err))
+			return fmt.Errorf("%s: %w", caller, auth.ErrInvalidToken)
+		}
+		log.Error("failed to verify email", sl.Error(err))
+		return fmt.Errorf("%s: %w", caller, err)
+	}
+
+	log.Info("email verified")
+
+	return nil
+}
+
+// ResendVerification sends another verification mail to a user whose email
+// is not verified yet, at most once per resend interval.
+func (a *Auth) ResendVerification(ctx context.Context, email string) error {
+	const caller = "services.auth.ResendVerification"
+
+	log := sl.AddCaller(a.log, caller)
+
+	log.Info("resending verification mail")
+
+	user, err := a.user(ctx, log, email)
+	if err != nil {
+		return fmt.Errorf("%s: %w", caller, err)
+	}
+	if user.Verified() {
+		log.Info("email already verified")
+		return fmt.Errorf("%s: %w", caller, auth.ErrEmailAlreadyVerified)
+	}
+
+	allowed, err := a.throttler.Throttle(ctx, "verification:"+strconv.FormatUint(user.ID, 10), a.verification.ResendInterval)
+	if err != nil {
+		log.Error("failed to throttle verification mail", sl.Error(err))
+		return fmt.Errorf("%s: %w", caller, err)
+	}
+	if !allowed {
+		log.Warn("verification mail requested too often")
+		return fmt.Errorf("%s: %w", caller, auth.ErrTooManyRequests)
+	}
+
+	messages, err := a.verificationMessages(user)
+	if err != nil {
+		log.Error("failed to build verification mail", sl.Error(err))
+		return fmt.Errorf("%s: %w", caller, err)
+	}
+	if err := a.verificationStore.EnqueueOutbox(ctx, messages); err != nil {
+		log.Error("failed to enqueue verification mail", sl.Error(err))
+		return fmt.Errorf("%s: %w", caller, err)
+	}
+
+	log.Info("verification mail enqueued")
+
+	return nil
+}
+
+// verificationMessages builds the mail with a new verification token for
+// user.
+func (a *Auth) verificationMessages(user models.User) ([]outboxModels.Message, error) {
+	token, err := a.tokens.NewVerificationToken(user)
+	if err != nil {
+		return nil, err
+	}
+
+	message := fmt.Sprintf(VerificationTokenMsgTemplate, token)
+	if a.verification.LinkURL != "" {
+		link, err := tokenLink(a.verification.LinkURL, token)
+		if err != nil {
+			return nil, err
+		}
+		message = fmt.Sprintf(VerificationLinkMsgTemplate, link)
+	}
+
+	return []outboxModels.Message{{EmailAddr: user.Email, Message: message}}, nil
+}
+
+// tokenLink returns linkURL with token in the token query parameter.
+func tokenLink(linkURL string, token string) (string, error) {
+	link, err := url.Parse(linkURL)
+	if err != nil {
+		return "", err
+	}
+	query := link.Query()
+	query.Set("token", token)
+	link.RawQuery = query.Encode()
+	return link.String(), nil
+}
@@ -5,19 +5,22 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"strings"
 
 	"github.com/tizzhh/micro-banking/internal/domain/auth/models"
+	outboxModels "github.com/tizzhh/micro-banking/internal/domain/outbox/models"
 	bankErrors "github.com/tizzhh/micro-banking/internal/services/bank/errors"
 	"github.com/tizzhh/micro-banking/internal/storage"
 	"github.com/tizzhh/micro-banking/pkg/logger/sl"
+	"github.com/tizzhh/micro-banking/pkg/money"
 )
 
-func New(log *slog.Logger, balanceOperator BalanceOperator, userProvider UserProvider, producer Producer) *Bank {
+func New(log *slog.Logger, balanceOperator BalanceOperator, userProvider UserProvider, stepUp StepUp) *Bank {
 	return &Bank{
 		log:             log,
 		balanceOperator: balanceOperator,
 		userProvider:    userProvider,
-		producer:        producer,
+		stepUp:          stepUp,
 	}
 }
 
@@ -25,21 +28,20 @@ type Bank struct {
 	log             *slog.Logger
 	balanceOperator BalanceOperator
 	userProvider    UserProvider
-	producer        Producer
+	stepUp          StepUp
 }
 
 const (
-	DepositMsgTemplate    = "Sucessfully made a deposit. New account balance: %f"
-	WithdrawalMsgTemplate = "Sucessfully made a withdrawal. New account balance: %f"
+	DepositMsgTemplate          = "Sucessfully made a deposit. New account balance: %s"
+	WithdrawalMsgTemplate       = "Sucessfully made a withdrawal. New account balance: %s"
+	TransferSentMsgTemplate     = "Sucessfully sent %s %s to %s. New balance: %s"
+	TransferReceivedMsgTemplate = "Received %s %s from %s"
 )
 
-type Producer interface {
-	Produce(emailAddr string, msg string) error
-}
-
 type BalanceOperator interface {
-	Deposit(ctx context.Context, user models.User, amount uint64) error
-	Withdraw(ctx context.Context, user models.User, amount uint64) error
+	Deposit(ctx context.Context, user models.User, amount money.Money, notify outboxModels.Notify) (money.Money, error)
+	Withdraw(ctx context.Context, user models.User, amount money.Money, notify outboxModels.Notify) (money.Money, error)
+	Transfer(ctx context.Context, sender, recipient models.User, amount money.Money, notify outboxModels.Notify) (money.Money, error)
 }
 
 type UserProvider interface {
@@ -47,74 +49,187 @@ type UserProvider interface {
 }
 
 const (
-	priceToCentsConversion = 100
+	baseCurrencyCode = "USD"
 )
 
-func (b *Bank) Deposit(ctx context.Context, email string, amount float32) (float32, error) {
+func (b *Bank) Deposit(ctx context.Context, email string, amount money.Money) (money.Money, error) {
 	const caller = "services.bank.Deposit"
 	log := sl.AddCaller(b.log, caller)
 	log.Info("making a deposit")
 
-	amountInUint := uint64(amount * priceToCentsConversion)
+	if !amount.IsPositive() || amount.Currency() != baseCurrencyCode {
+		log.Warn("invalid amount", slog.String("amount", amount.String()))
+		return money.Money{}, fmt.Errorf("%s: %w", caller, bankErrors.ErrInvalidAmount)
+	}
 
 	user, err := b.userProvider.User(ctx, email)
 	if err != nil {
 		if errors.Is(err, storage.ErrUserNotFound) {
 			log.Warn("user not found", sl.Error(err))
-			return 0, fmt.Errorf("%s: %w", caller, bankErrors.ErrUserNotFound)
+			return money.Money{}, fmt.Errorf("%s: %w", caller, bankErrors.ErrUserNotFound)
 		}
 		log.Error("failed to get user", sl.Error(err))
-		return 0, fmt.Errorf("%s: %w", caller, err)
+		return money.Money{}, fmt.Errorf("%s: %w", caller, err)
 	}
-	newAmount := user.Balance + amountInUint
-	err = b.balanceOperator.Deposit(ctx, user, amountInUint)
+	newBalance, err := b.balanceOperator.Deposit(ctx, user, amount, func(newBalance money.Money) []outboxModels.Message {
+		return []outboxModels.Message{{EmailAddr: email, Message: fmt.Sprintf(DepositMsgTemplate, newBalance)}}
+	})
 	if err != nil {
+		if errors.Is(err, storage.ErrAccountFrozen) {
+			log.Warn("account is frozen")
+			return money.Money{}, fmt.Errorf("%s: %w", caller, bankErrors.ErrAccountFrozen)
+		}
+		if errors.Is(err, storage.ErrEmailNotVerified) {
+			log.Warn("email is not verified")
+			return money.Money{}, fmt.Errorf("%s: %w", caller, bankErrors.ErrEmailNotVerified)
+		}
 		log.Error("failed to deposit", sl.Error(err))
-		return 0, fmt.Errorf("%s: %w", caller, err)
+		return money.Money{}, fmt.Errorf("%s: %w", caller, err)
 	}
 
 	log.Info("deposit made")
-	newBalanceAmount := float32(newAmount / priceToCentsConversion)
-	if err = b.producer.Produce(email, fmt.Sprintf(DepositMsgTemplate, newBalanceAmount)); err != nil {
-		log.Error("failed to produce", sl.Error(err))
-	}
 
-	return newBalanceAmount, nil
+	return newBalance, nil
 }
 
-func (b *Bank) Withdraw(ctx context.Context, email string, amount float32) (float32, error) {
+func (b *Bank) Withdraw(ctx context.Context, email string, amount money.Money) (money.Money, error) {
 	const caller = "services.bank.Withdraw"
 	log := sl.AddCaller(b.log, caller)
 	log.Info("making a withdrawal")
 
-	amountInUint := uint64(amount * priceToCentsConversion)
+	if !amount.IsPositive() || amount.Currency() != baseCurrencyCode {
+		log.Warn("invalid amount", slog.String("amount", amount.String()))
+		return money.Money{}, fmt.Errorf("%s: %w", caller, bankErrors.ErrInvalidAmount)
+	}
+
+	if !b.stepUp.satisfied(ctx, amount) {
+		log.Warn("mfa step-up required", slog.String("amount", amount.String()))
+		return money.Money{}, fmt.Errorf("%s: %w", caller, bankErrors.ErrStepUpRequired)
+	}
 
 	user, err := b.userProvider.User(ctx, email)
 	if err != nil {
 		if errors.Is(err, storage.ErrUserNotFound) {
 			log.Warn("user not found", sl.Error(err))
-			return 0, fmt.Errorf("%s: %w", caller, bankErrors.ErrUserNotFound)
+			return money.Money{}, fmt.Errorf("%s: %w", caller, bankErrors.ErrUserNotFound)
 		}
 		log.Error("failed to get user", sl.Error(err))
-		return 0, fmt.Errorf("%s: %w", caller, err)
+		return money.Money{}, fmt.Errorf("%s: %w", caller, err)
 	}
-	if amountInUint > user.Balance {
+	if uint64(amount.Amount()) > user.Balance {
 		log.Warn("not enough money on balance to withdraw")
-		return 0, fmt.Errorf("%s: %w", caller, bankErrors.ErrNotEnoughMoney)
+		return money.Money{}, fmt.Errorf("%s: %w", caller, bankErrors.ErrNotEnoughMoney)
 	}
 
-	newAmount := user.Balance - amountInUint
-	err = b.balanceOperator.Withdraw(ctx, user, amountInUint)
+	newBalance, err := b.balanceOperator.Withdraw(ctx, user, amount, func(newBalance money.Money) []outboxModels.Message {
+		return []outboxModels.Message{{EmailAddr: email, Message: fmt.Sprintf(WithdrawalMsgTemplate, newBalance)}}
+	})
 	if err != nil {
+		if errors.Is(err, storage.ErrNotEnoughMoney) {
+			log.Warn("not enough money on balance to withdraw")
+			return money.Money{}, fmt.Errorf("%s: %w", caller, bankErrors.ErrNotEnoughMoney)
+		}
+		if errors.Is(err, storage.ErrAccountFrozen) {
+			log.Warn("account is frozen")
+			return money.Money{}, fmt.Errorf("%s: %w", caller, bankErrors.ErrAccountFrozen)
+		}
+		if errors.Is(err, storage.ErrEmailNotVerified) {
+			log.Warn("email is not verified")
+			return money.Money{}, fmt.Errorf("%s: %w", caller, bankErrors.ErrEmailNotVerified)
+		}
 		log.Error("failed to withdraw", sl.Error(err))
-		return 0, fmt.Errorf("%s: %w", caller, err)
+		return money.Money{}, fmt.Errorf("%s: %w", caller, err)
 	}
 
 	log.Info("withdrawal made")
-	newBalanceAmount := float32(newAmount / priceToCentsConversion)
-	if err = b.producer.Produce(email, fmt.Sprintf(WithdrawalMsgTemplate, newBalanceAmount)); err != nil {
-		log.Error("failed to produce", sl.Error(err))
+
+	return newBalance, nil
+}
+
+// Transfer moves amount from the sender to the recipient. USD amounts are
+// transferred from the balance, others from the sender's wallet in that
+// currency. Large amounts require a step-up, as for withdrawals.
+func (b *Bank) Transfer(ctx context.Context, email string, recipientEmail string, amount money.Money) (money.Money, error) {
+	const caller = "services.bank.Transfer"
+	log := sl.AddCaller(b.log, caller)
+	log.Info("making a transfer")
+
+	if strings.EqualFold(email, recipientEmail) {
+		log.Warn("attempt to transfer to self")
+		return money.Money{}, fmt.Errorf("%s: %w", caller, bankErrors.ErrSelfTransfer)
+	}
+
+	if !amount.IsPositive() {
+		log.Warn("invalid amount", slog.String("amount", amount.String()))
+		return money.Money{}, fmt.Errorf("%s: %w", caller, bankErrors.ErrInvalidAmount)
+	}
+
+	if !b.stepUp.satisfied(ctx, amount) {
+		log.Warn("mfa step-up required", slog.String("amount", amount.String()))
+		return money.Money{}, fmt.Errorf("%s: %w", caller, bankErrors.ErrStepUpRequired)
+	}
+
+	sender, err := b.userProvider.User(ctx, email)
+	if err != nil {
+		if errors.Is(err, storage.ErrUserNotFound) {
+			log.Warn("user not found", sl.Error(err))
+			return money.Money{}, fmt.Errorf("%s: %w", caller, bankErrors.ErrUserNotFound)
+		}
+		log.Error("failed to get user", sl.Error(err))
+		return money.Money{}, fmt.Errorf("%s: %w", caller, err)
+	}
+
+	recipient, err := b.userProvider.User(ctx, recipientEmail)
+	if err != nil {
+		if errors.Is(err, storage.ErrUserNotFound) {
+			log.Warn("recipient not found", sl.Error(err))
+			return money.Money{}, fmt.Errorf("%s: %w", caller, bankErrors.ErrRecipientNotFound)
+		}
+		log.Error("failed to get recipient", sl.Error(err))
+		return money.Money{}, fmt.Errorf("%s: %w", caller, err)
+	}
+
+	if amount.Currency() == baseCurrencyCode && uint64(amount.Amount()) > sender.Balance {
+		log.Warn("not enough money on balance to transfer")
+		return money.Money{}, fmt.Errorf("%s: %w", caller, bankErrors.ErrNotEnoughMoney)
 	}
 
-	return newBalanceAmount, nil
+	newBalance, err := b.balanceOperator.Transfer(ctx, sender, recipient, amount, func(newBalance money.Money) []outboxModels.Message {
+		return []outboxModels.Message{
+			{EmailAddr: email, Message: fmt.Sprintf(TransferSentMsgTemplate, amount, amount.Currency(), recipientEmail, newBalance)},
+			{EmailAddr: recipientEmail, Message: fmt.Sprintf(TransferReceivedMsgTemplate, amount, amount.Currency(), email)},
+		}
+	})
+	if err != nil {
+		if errors.Is(err, storage.ErrNotEnoughMoney) {
+			log.Warn("not enough money to transfer")
+			return money.Money{}, fmt.Errorf("%s: %w", caller, bankErrors.ErrNotEnoughMoney)
+		}
+		if errors.Is(err, storage.ErrCurrencyCodeNotFound) {
+			log.Warn("currency code not found")
+			return money.Money{}, fmt.Errorf("%s: %w", caller, bankErrors.ErrCurrencyCodeNotFound)
+		}
+		if errors.Is(err, storage.ErrCurrencyDisabled) {
+			log.Warn("currency is disabled")
+			return money.Money{}, fmt.Errorf("%s: %w", caller, bankErrors.ErrCurrencyDisabled)
+		}
+		if errors.Is(err, storage.ErrWalletNotFound) {
+			log.Warn("wallet not found")
+			return money.Money{}, fmt.Errorf("%s: %w", caller, bankErrors.ErrWalletNotFound)
+		}
+		if errors.Is(err, storage.ErrAccountFrozen) {
+			log.Warn("account is frozen")
+			return money.Money{}, fmt.Errorf("%s: %w", caller, bankErrors.ErrAccountFrozen)
+		}
+		if errors.Is(err, storage.ErrEmailNotVerified) {
+			log.Warn("email is not verified")
+			return money.Money{}, fmt.Errorf("%s: %w", caller, bankErrors.ErrEmailNotVerified)
+		}
+		log.Error("failed to transfer", sl.Error(err))
+		return money.Money{}, fmt.Errorf("%s: %w", caller, err)
+	}
+
+	log.Info("transfer made")
+
+	return newBalance, nil
 }
@@ -3,6 +3,15 @@ package errors
 import "errors"
 
 var (
-	ErrNotEnoughMoney = errors.New("not enough money on balance")
-	ErrUserNotFound   = errors.New("user not found")
+	ErrNotEnoughMoney       = errors.New("not enough money on balance")
+	ErrUserNotFound         = errors.New("user not found")
+	ErrRecipientNotFound    = errors.New("recipient not found")
+	ErrSelfTransfer         = errors.New("cannot transfer to yourself")
+	ErrCurrencyCodeNotFound = errors.New("currency code not found")
+	ErrWalletNotFound       = errors.New("wallet not found")
+	ErrInvalidAmount        = errors.New("amount must be positive")
+	ErrAccountFrozen        = errors.New("account is frozen")
+	ErrEmailNotVerified     = errors.New("email is not verified")
+	ErrStepUpRequired       = errors.New("mfa step-up required")
+	ErrCurrencyDisabled     = errors.New("currency is disabled")
 )
@@ -0,0 +1,48 @@
+package bank
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/tizzhh/micro-banking/internal/api/principal"
+	"github.com/tizzhh/micro-banking/pkg/money"
+)
+
+// StepUp makes withdrawals and transfers of at least a threshold amount
+// require a second factor verified within MaxAge.
+type StepUp struct {
+	// Thresholds maps currency codes to the amount from which a step-up is
+	// required. Currencies without a threshold never require one.
+	Thresholds map[string]money.Money
+	MaxAge     time.Duration
+}
+
+// ParseStepUp returns the StepUp of thresholds given as decimal strings,
+// e.g. "1000.00".
+func ParseStepUp(thresholds map[string]string, maxAge time.Duration) (StepUp, error) {
+	const caller = "services.bank.ParseStepUp"
+
+	stepUp := StepUp{Thresholds: make(map[string]money.Money, len(thresholds)), MaxAge: maxAge}
+	for currency, value := range thresholds {
+		threshold, err := money.Parse(value, currency)
+		if err != nil {
+			return StepUp{}, fmt.Errorf("%s: threshold of %s: %w", caller, currency, err)
+		}
+		stepUp.Thresholds[currency] = threshold
+	}
+	return stepUp, nil
+}
+
+// satisfied reports whether the caller of ctx may move amount.
+func (s StepUp) satisfied(ctx context.Context, amount money.Money) bool {
+	threshold, ok := s.Thresholds[amount.Currency()]
+	if !ok || amount.Amount() < threshold.Amount() {
+		return true
+	}
+	p, ok := principal.FromContext(ctx)
+	if !ok || p.MFAAt.IsZero() {
+		return false
+	}
+	return time.Since(p.MFAAt) <= s.MaxAge
+}
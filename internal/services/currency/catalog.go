@@ -0,0 +1,119 @@
+package currency
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	currencyModels "github.com/tizzhh/micro-banking/internal/domain/currency/models"
+	currency "github.com/tizzhh/micro-banking/internal/services/currency/errors"
+	"github.com/tizzhh/micro-banking/internal/storage"
+	"github.com/tizzhh/micro-banking/pkg/logger/sl"
+	"github.com/tizzhh/micro-banking/pkg/money"
+)
+
+type Catalog interface {
+	Currencies(ctx context.Context) ([]currencyModels.Currency, error)
+	Currency(ctx context.Context, code string) (currencyModels.Currency, error)
+}
+
+// ListCurrencies returns the currency catalog.
+func (c *Currency) ListCurrencies(ctx context.Context) ([]currencyModels.Currency, error) {
+	const caller = "services.currency.ListCurrencies"
+
+	log := sl.AddCaller(c.log, caller)
+
+	log.Info("listing currencies")
+
+	currencies, err := c.catalog.Currencies(ctx)
+	if err != nil {
+		log.Error("failed to list currencies", sl.Error(err))
+		return nil, fmt.Errorf("%s: %w", caller, err)
+	}
+	RegisterExponents(currencies)
+
+	return currencies, nil
+}
+
+// checkTradable fails unless the currency of amount is in the catalog, can be
+// traded and amount is within its order limits. Disabled currencies can only
+// be sold, so that users can leave them.
+func (c *Currency) checkTradable(ctx context.Context, log *slog.Logger, amount money.Money, buy bool) error {
+	entry, err := c.tradableCurrency(ctx, log, amount.Currency(), buy)
+	if err != nil {
+		return err
+	}
+
+	return checkOrderLimits(log, entry, amount)
+}
+
+// tradableCurrency returns the catalog entry of code if it can be traded, and
+// bought from if buy is set.
+func (c *Currency) tradableCurrency(ctx context.Context, log *slog.Logger, code string, buy bool) (currencyModels.Currency, error) {
+	entry, err := c.catalog.Currency(ctx, code)
+	if err != nil {
+		if errors.Is(err, storage.ErrCurrencyCodeNotFound) {
+			log.Warn("currency code not found")
+			return currencyModels.Currency{}, currency.ErrCurrencyCodeNotFound
+		}
+		log.Error("failed to get currency", sl.Error(err))
+		return currencyModels.Currency{}, err
+	}
+	money.SetExponent(entry.Code, entry.Exponent)
+
+	if !entry.Tradable || (buy && !entry.Enabled) {
+		log.Warn("currency is not tradable")
+		return currencyModels.Currency{}, currency.ErrCurrencyNotTradable
+	}
+
+	return entry, nil
+}
+
+func checkOrderLimits(log *slog.Logger, entry currencyModels.Currency, amount money.Money) error {
+	units := uint64(amount.Amount())
+	if units < entry.MinOrder {
+		log.Warn("order below the minimum", slog.String("amount", amount.String()))
+		return currency.ErrOrderTooSmall
+	}
+	if entry.MaxOrder > 0 && units > entry.MaxOrder {
+		log.Warn("order above the maximum", slog.String("amount", amount.String()))
+		return currency.ErrOrderTooLarge
+	}
+
+	return nil
+}
+
+// RegisterExponents makes money format and parse the amounts of currencies
+// with their catalog exponents.
+func RegisterExponents(currencies []currencyModels.Currency) {
+	for _, entry := range currencies {
+		money.SetExponent(entry.Code, entry.Exponent)
+	}
+}
+
+// WatchExponents registers the exponents of the catalog every interval until
+// ctx is done, so that currencies added by admins are picked up.
+func WatchExponents(ctx context.Context, log *slog.Logger, catalog Catalog, interval time.Duration) {
+	const caller = "services.currency.WatchExponents"
+	log = sl.AddCaller(log, caller)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		currencies, err := catalog.Currencies(ctx)
+		if err != nil {
+			log.Error("failed to load currency catalog", sl.Error(err))
+			continue
+		}
+		RegisterExponents(currencies)
+	}
+}
@@ -0,0 +1,133 @@
+package currency
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	outboxModels "github.com/tizzhh/micro-banking/internal/domain/outbox/models"
+	currency "github.com/tizzhh/micro-banking/internal/services/currency/errors"
+	"github.com/tizzhh/micro-banking/internal/storage"
+	"github.com/tizzhh/micro-banking/pkg/logger/sl"
+	"github.com/tizzhh/micro-banking/pkg/money"
+)
+
+// Convert moves amount of a foreign currency from the user's wallet to the
+// wallet of the foreign currency to, at the cross rate of their USD rates.
+// The converted amount is rounded down. It returns the converted amount and
+// the applied rate.
+func (c *Currency) Convert(ctx context.Context, email string, amount money.Money, to string) (money.Money, money.Rate, error) {
+	const caller = "services.currency.Convert"
+
+	log := sl.AddCaller(c.log, caller).With(
+		slog.String("from", amount.Currency()),
+		slog.String("to", to),
+	)
+
+	log.Info("converting currency")
+
+	if !amount.IsPositive() {
+		log.Warn("invalid amount", slog.String("amount", amount.String()))
+		return money.Money{}, money.Rate{}, fmt.Errorf("%s: %w", caller, currency.ErrInvalidAmount)
+	}
+	if amount.Currency() == to {
+		log.Warn("same currency")
+		return money.Money{}, money.Rate{}, fmt.Errorf("%s: %w", caller, currency.ErrSameCurrency)
+	}
+	if amount.Currency() == baseCurrencyCode || to == baseCurrencyCode {
+		log.Warn("base currency conversion")
+		return money.Money{}, money.Rate{}, fmt.Errorf("%s: %w", caller, currency.ErrBaseCurrency)
+	}
+
+	if err := c.checkTradable(ctx, log, amount, false); err != nil {
+		return money.Money{}, money.Rate{}, fmt.Errorf("%s: %w", caller, err)
+	}
+	target, err := c.tradableCurrency(ctx, log, to, true)
+	if err != nil {
+		return money.Money{}, money.Rate{}, fmt.Errorf("%s: %w", caller, err)
+	}
+
+	user, err := c.getUser(ctx, email)
+	if err != nil {
+		return money.Money{}, money.Rate{}, fmt.Errorf("%s: %w", caller, err)
+	}
+
+	fromRate, err := c.getCurrencyRate(ctx, amount.Currency())
+	if err != nil {
+		log.Error("could not get currency rate", sl.Error(err))
+		return money.Money{}, money.Rate{}, fmt.Errorf("%s: %w", caller, err)
+	}
+	toRate, err := c.getCurrencyRate(ctx, to)
+	if err != nil {
+		log.Error("could not get currency rate", sl.Error(err))
+		return money.Money{}, money.Rate{}, fmt.Errorf("%s: %w", caller, err)
+	}
+
+	// both rates are per USD, so going through USD gives the cross rate
+	rate := fromRate.Inverse().Mul(toRate)
+
+	converted, err := money.Convert(amount, rate, to, money.RoundDown)
+	if err != nil {
+		log.Error("failed to convert amount", sl.Error(err))
+		return money.Money{}, money.Rate{}, fmt.Errorf("%s: %w", caller, err)
+	}
+	if !converted.IsPositive() {
+		log.Warn("converted amount rounds to zero", slog.String("amount", amount.String()))
+		return money.Money{}, money.Rate{}, fmt.Errorf("%s: %w", caller, currency.ErrOrderTooSmall)
+	}
+	if err := checkOrderLimits(log, target, converted); err != nil {
+		return money.Money{}, money.Rate{}, fmt.Errorf("%s: %w", caller, err)
+	}
+
+	log.Info("getting currency balance")
+
+	currencyBalance, err := c.currencyOperator.CurrencyBalance(ctx, user, amount.Currency())
+	if err != nil {
+		if errors.Is(err, storage.ErrCurrencyCodeNotFound) {
+			log.Warn("currency code not found")
+			return money.Money{}, money.Rate{}, fmt.Errorf("%s: %w", caller, currency.ErrCurrencyCodeNotFound)
+		}
+		log.Error("failed to get currency balance", sl.Error(err))
+		return money.Money{}, money.Rate{}, fmt.Errorf("%s: %w", caller, err)
+	}
+
+	if currencyBalance.Amount() < amount.Amount() {
+		log.Info("not enough money of currency to convert", sl.Error(currency.ErrNotEnoughCurrency))
+		return money.Money{}, money.Rate{}, fmt.Errorf("%s: %w", caller, currency.ErrNotEnoughCurrency)
+	}
+
+	log.Info("saving balances")
+
+	err = c.currencyOperator.Convert(ctx, user, amount, converted, rate, func(money.Money) []outboxModels.Message {
+		return []outboxModels.Message{{EmailAddr: email, Message: fmt.Sprintf(ConvertedMsgTemplate, amount, amount.Currency(), converted, to)}}
+	})
+	if err != nil {
+		if errors.Is(err, storage.ErrNotEnoughMoney) {
+			log.Info("not enough money to perform operation", sl.Error(currency.ErrNotEnoughCurrency))
+			return money.Money{}, money.Rate{}, fmt.Errorf("%s: %w", caller, currency.ErrNotEnoughCurrency)
+		}
+		if errors.Is(err, storage.ErrCurrencyCodeNotFound) {
+			log.Warn("currency code not found")
+			return money.Money{}, money.Rate{}, fmt.Errorf("%s: %w", caller, currency.ErrCurrencyCodeNotFound)
+		}
+		if errors.Is(err, storage.ErrCurrencyDisabled) {
+			log.Warn("currency is disabled")
+			return money.Money{}, money.Rate{}, fmt.Errorf("%s: %w", caller, currency.ErrCurrencyNotTradable)
+		}
+		if errors.Is(err, storage.ErrAccountFrozen) {
+			log.Warn("account is frozen")
+			return money.Money{}, money.Rate{}, fmt.Errorf("%s: %w", caller, currency.ErrAccountFrozen)
+		}
+		if errors.Is(err, storage.ErrEmailNotVerified) {
+			log.Warn("email is not verified")
+			return money.Money{}, money.Rate{}, fmt.Errorf("%s: %w", caller, currency.ErrEmailNotVerified)
+		}
+		log.Error("failed to update wallet balances", sl.Error(err))
+		return money.Money{}, money.Rate{}, fmt.Errorf("%s: %w", caller, err)
+	}
+
+	log.Info("currency converted")
+
+	return converted, rate, nil
+}
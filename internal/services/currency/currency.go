@@ -5,21 +5,32 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"time"
 
 	authModels "github.com/tizzhh/micro-banking/internal/domain/auth/models"
 	currencyModels "github.com/tizzhh/micro-banking/internal/domain/currency/models"
+	ledgerModels "github.com/tizzhh/micro-banking/internal/domain/ledger/models"
+	outboxModels "github.com/tizzhh/micro-banking/internal/domain/outbox/models"
 	currency "github.com/tizzhh/micro-banking/internal/services/currency/errors"
 	"github.com/tizzhh/micro-banking/internal/storage"
 	"github.com/tizzhh/micro-banking/pkg/logger/sl"
+	"github.com/tizzhh/micro-banking/pkg/money"
+	"github.com/tizzhh/micro-banking/pkg/pricing"
 )
 
-func New(log *slog.Logger, currencyOperator CurrencyOperator, userProvider UserProvider, ratesOperator RatesOperator, ratesQuerier RatesQuerier) *Currency {
+func New(log *slog.Logger, currencyOperator CurrencyOperator, userProvider UserProvider, ratesOperator RatesOperator, ratesQuerier RatesQuerier, historyProvider HistoryProvider, catalog Catalog, quoteStore QuoteStore, quoteTTL time.Duration, pricer Pricer, rateHistory RateHistory) *Currency {
 	return &Currency{
 		log:              log,
 		currencyOperator: currencyOperator,
 		userProvider:     userProvider,
 		ratesOperator:    ratesOperator,
 		ratesQuerier:     ratesQuerier,
+		historyProvider:  historyProvider,
+		catalog:          catalog,
+		quoteStore:       quoteStore,
+		quoteTTL:         quoteTTL,
+		pricer:           pricer,
+		rateHistory:      rateHistory,
 	}
 }
 
@@ -29,43 +40,59 @@ type Currency struct {
 	userProvider     UserProvider
 	ratesOperator    RatesOperator
 	ratesQuerier     RatesQuerier
+	historyProvider  HistoryProvider
+	catalog          Catalog
+	quoteStore       QuoteStore
+	quoteTTL         time.Duration
+	pricer           Pricer
+	rateHistory      RateHistory
 }
 
 type CurrencyOperator interface {
-	Buy(ctx context.Context, user authModels.User, currencyCode string, newUserBalance, newCurrencyBalance uint64) error
-	Sell(ctx context.Context, user authModels.User, currencyCode string, newUserBalance, newCurrencyBalance uint64) error
-	CurrencyBalance(ctx context.Context, user authModels.User, currencyCode string) (uint64, error)
+	Buy(ctx context.Context, user authModels.User, amount money.Money, price pricing.Breakdown, notify outboxModels.Notify) error
+	Sell(ctx context.Context, user authModels.User, amount money.Money, price pricing.Breakdown, notify outboxModels.Notify) error
+	Convert(ctx context.Context, user authModels.User, amount, converted money.Money, rate money.Rate, notify outboxModels.Notify) error
+	CurrencyBalance(ctx context.Context, user authModels.User, currencyCode string) (money.Money, error)
 	Wallets(ctx context.Context, user authModels.User) ([]currencyModels.UserWallet, error)
 }
 
 type RatesOperator interface {
-	SetCurrencyRate(ctx context.Context, currencyCode string, rate float32) error
-	GetCurrencyRate(ctx context.Context, currencyCode string) (float32, error)
+	SetCurrencyRate(ctx context.Context, currencyCode string, rate money.Rate) error
+	GetCurrencyRate(ctx context.Context, currencyCode string) (money.Rate, error)
 }
 
+// RatesQuerier returns how many units of the currency one base currency unit
+// buys. Source names where the rates come from.
 type RatesQuerier interface {
-	QueryRates(ctx context.Context, currencyCode string) (float32, error)
+	QueryRates(ctx context.Context, currencyCode string) (money.Rate, error)
+	Source() string
 }
 
 type UserProvider interface {
 	User(ctx context.Context, email string) (authModels.User, error)
 }
 
+type HistoryProvider interface {
+	History(ctx context.Context, user authModels.User, filter ledgerModels.HistoryFilter) ([]ledgerModels.HistoryEntry, error)
+}
+
 const (
-	priceToCentsConversion = 100
+	BoughtMsgTemplate    = "Sucessfully bought %s %s for %s USD"
+	SoldMsgTemplate      = "Sucessfully sold %s %s for %s USD"
+	ConvertedMsgTemplate = "Sucessfully converted %s %s to %s %s"
+)
+
+const (
+	baseCurrencyCode = "USD"
+
+	defaultHistoryLimit = 20
+	maxHistoryLimit     = 100
 )
 
 func userHasEnoughMoneyToPerformOperation(balance, totalCost uint64) bool {
 	return balance >= totalCost
 }
 
-func performOperation(balance, currencyBalance, totalCost uint64, isBuy bool) (uint64, uint64) {
-	if isBuy {
-		return balance - totalCost, currencyBalance + totalCost
-	}
-	return balance + totalCost, currencyBalance - totalCost
-}
-
 func (c *Currency) getUser(ctx context.Context, email string) (authModels.User, error) {
 	const caller = "services.currency.getUser"
 
@@ -89,119 +116,182 @@ func (c *Currency) getUser(ctx context.Context, email string) (authModels.User,
 	return user, nil
 }
 
-func (c *Currency) Buy(ctx context.Context, email string, currencyCode string, amount uint64) (float32, error) {
+// Buy charges the user for amount of a foreign currency at the ask price plus
+// the fee, or at the price of the quote with quoteID if it is set. The cost is
+// rounded up to the next cent so that the bank never sells below the rate. It
+// returns the price, whose total is the cost in USD.
+func (c *Currency) Buy(ctx context.Context, email string, amount money.Money, quoteID string) (pricing.Breakdown, error) {
 	const caller = "services.currency.Buy"
 
 	log := sl.AddCaller(c.log, caller)
 
 	log.Info("buying currency")
 
-	user, err := c.getUser(ctx, email)
-	if err != nil {
-		return 0, fmt.Errorf("%s: %w", caller, err)
+	if !amount.IsPositive() {
+		log.Warn("invalid amount", slog.String("amount", amount.String()))
+		return pricing.Breakdown{}, fmt.Errorf("%s: %w", caller, currency.ErrInvalidAmount)
 	}
 
-	log.Info("user found")
+	if err := c.checkTradable(ctx, log, amount, true); err != nil {
+		return pricing.Breakdown{}, fmt.Errorf("%s: %w", caller, err)
+	}
 
-	currencyPrice, err := c.getCurrencyRate(ctx, currencyCode)
+	var price pricing.Breakdown
+	var err error
+	if quoteID != "" {
+		price, err = c.quotedPrice(ctx, log, quoteID, email, currencyModels.QuoteBuy, amount)
+	} else {
+		price, err = c.price(ctx, log, currencyModels.QuoteBuy, amount)
+	}
 	if err != nil {
-		log.Error("could not get currency rate", sl.Error(err))
-		return 0, fmt.Errorf("%s: %w", caller, err)
+		return pricing.Breakdown{}, fmt.Errorf("%s: %w", caller, err)
 	}
 
-	totalCost := uint64(currencyPrice*priceToCentsConversion) * amount
-
-	if !userHasEnoughMoneyToPerformOperation(user.Balance, totalCost) {
-		log.Info("not enough money on balance", sl.Error(currency.ErrNotEnoughMoney))
-		return 0, fmt.Errorf("%s: %w", caller, currency.ErrNotEnoughMoney)
+	user, err := c.getUser(ctx, email)
+	if err != nil {
+		return pricing.Breakdown{}, fmt.Errorf("%s: %w", caller, err)
 	}
 
-	log.Info("getting currency balance")
+	log.Info("user found")
 
-	currencyBalance, err := c.currencyOperator.CurrencyBalance(ctx, user, currencyCode)
-	if err != nil {
-		log.Error("failed to get currency balance", sl.Error(err))
-		return 0, fmt.Errorf("%s: %w", caller, err)
+	cost := price.Total
+	if !userHasEnoughMoneyToPerformOperation(user.Balance, uint64(cost.Amount())) {
+		log.Info("not enough money on balance", sl.Error(currency.ErrNotEnoughMoney))
+		return pricing.Breakdown{}, fmt.Errorf("%s: %w", caller, currency.ErrNotEnoughMoney)
 	}
 
 	log.Info("saving balance")
 
-	newBalance, newCurrencyBalance := performOperation(user.Balance, currencyBalance, totalCost, true)
-	if err := c.currencyOperator.Buy(ctx, user, currencyCode, newBalance, newCurrencyBalance); err != nil {
+	err = c.currencyOperator.Buy(ctx, user, amount, price, func(money.Money) []outboxModels.Message {
+		return []outboxModels.Message{{EmailAddr: email, Message: fmt.Sprintf(BoughtMsgTemplate, amount, amount.Currency(), cost)}}
+	})
+	if err != nil {
+		if errors.Is(err, storage.ErrNotEnoughMoney) {
+			log.Info("not enough money to perform operation", sl.Error(currency.ErrNotEnoughMoney))
+			return pricing.Breakdown{}, fmt.Errorf("%s: %w", caller, currency.ErrNotEnoughMoney)
+		}
 		if errors.Is(err, storage.ErrCurrencyCodeNotFound) {
 			log.Warn("currency code not found")
-			return 0, fmt.Errorf("%s: %w", caller, currency.ErrCurrencyCodeNotFound)
+			return pricing.Breakdown{}, fmt.Errorf("%s: %w", caller, currency.ErrCurrencyCodeNotFound)
+		}
+		if errors.Is(err, storage.ErrCurrencyDisabled) {
+			log.Warn("currency is disabled")
+			return pricing.Breakdown{}, fmt.Errorf("%s: %w", caller, currency.ErrCurrencyNotTradable)
 		}
 		if errors.Is(err, storage.ErrWalletNotFound) {
 			log.Warn("wallet not found")
-			return 0, fmt.Errorf("%s: %w", caller, currency.ErrWalletNotFound)
+			return pricing.Breakdown{}, fmt.Errorf("%s: %w", caller, currency.ErrWalletNotFound)
+		}
+		if errors.Is(err, storage.ErrAccountFrozen) {
+			log.Warn("account is frozen")
+			return pricing.Breakdown{}, fmt.Errorf("%s: %w", caller, currency.ErrAccountFrozen)
+		}
+		if errors.Is(err, storage.ErrEmailNotVerified) {
+			log.Warn("email is not verified")
+			return pricing.Breakdown{}, fmt.Errorf("%s: %w", caller, currency.ErrEmailNotVerified)
 		}
 		log.Error("failed to update wallet and user balance", sl.Error(err))
-		return 0, fmt.Errorf("%s: %w", caller, err)
+		return pricing.Breakdown{}, fmt.Errorf("%s: %w", caller, err)
 	}
 
 	log.Info("currency bought")
 
-	currencyBought := float32(float32(newCurrencyBalance-currencyBalance) / priceToCentsConversion)
-	return currencyBought, nil
+	return price, nil
 }
 
-func (c *Currency) Sell(ctx context.Context, email string, currencyCode string, amount uint64) (float32, error) {
+// Sell takes amount of a foreign currency from the user's wallet at the bid
+// price minus the fee, or at the price of the quote with quoteID if it is set.
+// The proceeds are rounded down to the cent. It returns the price, whose total
+// is the proceeds in USD.
+func (c *Currency) Sell(ctx context.Context, email string, amount money.Money, quoteID string) (pricing.Breakdown, error) {
 	const caller = "services.currency.Sell"
 
 	log := sl.AddCaller(c.log, caller)
 
 	log.Info("selling currency")
 
-	log.Info("user found")
+	if !amount.IsPositive() {
+		log.Warn("invalid amount", slog.String("amount", amount.String()))
+		return pricing.Breakdown{}, fmt.Errorf("%s: %w", caller, currency.ErrInvalidAmount)
+	}
 
-	user, err := c.getUser(ctx, email)
+	if err := c.checkTradable(ctx, log, amount, false); err != nil {
+		return pricing.Breakdown{}, fmt.Errorf("%s: %w", caller, err)
+	}
+
+	var price pricing.Breakdown
+	var err error
+	if quoteID != "" {
+		price, err = c.quotedPrice(ctx, log, quoteID, email, currencyModels.QuoteSell, amount)
+	} else {
+		price, err = c.price(ctx, log, currencyModels.QuoteSell, amount)
+	}
 	if err != nil {
-		return 0, fmt.Errorf("%s: %w", caller, err)
+		return pricing.Breakdown{}, fmt.Errorf("%s: %w", caller, err)
 	}
+	proceeds := price.Total
 
-	currencyPrice, err := c.getCurrencyRate(ctx, currencyCode)
+	user, err := c.getUser(ctx, email)
 	if err != nil {
-		log.Error("could not get currency rate", sl.Error(err))
-		return 0, fmt.Errorf("%s: %w", caller, err)
+		return pricing.Breakdown{}, fmt.Errorf("%s: %w", caller, err)
 	}
 
-	totalCost := uint64(currencyPrice*priceToCentsConversion) * amount
+	log.Info("user found")
 
 	log.Info("getting currency balance")
 
-	currencyBalance, err := c.currencyOperator.CurrencyBalance(ctx, user, currencyCode)
+	currencyBalance, err := c.currencyOperator.CurrencyBalance(ctx, user, amount.Currency())
 	if err != nil {
+		if errors.Is(err, storage.ErrCurrencyCodeNotFound) {
+			log.Warn("currency code not found")
+			return pricing.Breakdown{}, fmt.Errorf("%s: %w", caller, currency.ErrCurrencyCodeNotFound)
+		}
 		log.Error("failed to get currency balance", sl.Error(err))
-		return 0, fmt.Errorf("%s: %w", caller, err)
+		return pricing.Breakdown{}, fmt.Errorf("%s: %w", caller, err)
 	}
 
-	if !userHasEnoughMoneyToPerformOperation(currencyBalance, totalCost) {
+	if currencyBalance.Amount() < amount.Amount() {
 		log.Info("not enough money of currency to sell", sl.Error(currency.ErrNotEnoughCurrency))
-		return 0, fmt.Errorf("%s: %w", caller, currency.ErrNotEnoughCurrency)
+		return pricing.Breakdown{}, fmt.Errorf("%s: %w", caller, currency.ErrNotEnoughCurrency)
 	}
 
 	log.Info("saving balance")
 
-	newBalance, newCurrencyBalance := performOperation(user.Balance, currencyBalance, totalCost, false)
-	if err := c.currencyOperator.Sell(ctx, user, currencyCode, newBalance, newCurrencyBalance); err != nil {
+	err = c.currencyOperator.Sell(ctx, user, amount, price, func(money.Money) []outboxModels.Message {
+		return []outboxModels.Message{{EmailAddr: email, Message: fmt.Sprintf(SoldMsgTemplate, amount, amount.Currency(), proceeds)}}
+	})
+	if err != nil {
+		if errors.Is(err, storage.ErrNotEnoughMoney) {
+			log.Info("not enough money to perform operation", sl.Error(currency.ErrNotEnoughCurrency))
+			return pricing.Breakdown{}, fmt.Errorf("%s: %w", caller, currency.ErrNotEnoughCurrency)
+		}
 		if errors.Is(err, storage.ErrCurrencyCodeNotFound) {
 			log.Warn("currency code not found")
-			return 0, fmt.Errorf("%s: %w", caller, currency.ErrCurrencyCodeNotFound)
+			return pricing.Breakdown{}, fmt.Errorf("%s: %w", caller, currency.ErrCurrencyCodeNotFound)
+		}
+		if errors.Is(err, storage.ErrCurrencyDisabled) {
+			log.Warn("currency is disabled")
+			return pricing.Breakdown{}, fmt.Errorf("%s: %w", caller, currency.ErrCurrencyNotTradable)
 		}
 		if errors.Is(err, storage.ErrWalletNotFound) {
 			log.Warn("wallet not found")
-			return 0, fmt.Errorf("%s: %w", caller, currency.ErrWalletNotFound)
+			return pricing.Breakdown{}, fmt.Errorf("%s: %w", caller, currency.ErrWalletNotFound)
+		}
+		if errors.Is(err, storage.ErrAccountFrozen) {
+			log.Warn("account is frozen")
+			return pricing.Breakdown{}, fmt.Errorf("%s: %w", caller, currency.ErrAccountFrozen)
+		}
+		if errors.Is(err, storage.ErrEmailNotVerified) {
+			log.Warn("email is not verified")
+			return pricing.Breakdown{}, fmt.Errorf("%s: %w", caller, currency.ErrEmailNotVerified)
 		}
 		log.Error("failed to update wallet and user balance", sl.Error(err))
-		return 0, fmt.Errorf("%s: %w", caller, err)
+		return pricing.Breakdown{}, fmt.Errorf("%s: %w", caller, err)
 	}
 
 	log.Info("currency sold")
 
-	currencySold := float32(float32(currencyBalance-newCurrencyBalance) / priceToCentsConversion)
-
-	return currencySold, nil
+	return price, nil
 }
 
 func (c *Currency) Wallets(ctx context.Context, email string) ([]currencyModels.UserWallet, error) {
@@ -231,7 +321,45 @@ func (c *Currency) Wallets(ctx context.Context, email string) ([]currencyModels.
 	return wallets, nil
 }
 
-func (c *Currency) getCurrencyRate(ctx context.Context, currencyCode string) (float32, error) {
+// Transactions returns a page of the user's ledger entries and the cursor of
+// the next page, which is zero when there are no more entries.
+func (c *Currency) Transactions(ctx context.Context, email string, filter ledgerModels.HistoryFilter) ([]ledgerModels.HistoryEntry, uint64, error) {
+	const caller = "services.currency.Transactions"
+
+	log := sl.AddCaller(c.log, caller)
+
+	log.Info("getting transactions")
+
+	user, err := c.getUser(ctx, email)
+	if err != nil {
+		return nil, 0, fmt.Errorf("%s: %w", caller, err)
+	}
+
+	if filter.Limit <= 0 {
+		filter.Limit = defaultHistoryLimit
+	}
+	if filter.Limit > maxHistoryLimit {
+		filter.Limit = maxHistoryLimit
+	}
+	pageSize := filter.Limit
+	filter.Limit++
+
+	entries, err := c.historyProvider.History(ctx, user, filter)
+	if err != nil {
+		log.Error("failed to get transactions", sl.Error(err))
+		return nil, 0, fmt.Errorf("%s: %w", caller, err)
+	}
+
+	var nextCursor uint64
+	if len(entries) > pageSize {
+		entries = entries[:pageSize]
+		nextCursor = entries[pageSize-1].ID
+	}
+
+	return entries, nextCursor, nil
+}
+
+func (c *Currency) getCurrencyRate(ctx context.Context, currencyCode string) (money.Rate, error) {
 	const caller = "services.currency.getCurrencyRate"
 
 	log := sl.AddCaller(c.log, caller)
@@ -242,23 +370,30 @@ func (c *Currency) getCurrencyRate(ctx context.Context, currencyCode string) (fl
 		currencyPrice, err = c.requestRatesAPI(ctx, currencyCode)
 		if err != nil {
 			log.Error("could not request rates", sl.Error(err))
-			return 0, fmt.Errorf("%s: %w", caller, err)
+			return money.Rate{}, fmt.Errorf("%s: %w", caller, err)
+		}
+		// the rate is kept before it is cached, so every rate traded at is in
+		// the history
+		err = c.rateHistory.SaveRate(ctx, currencyCode, currencyPrice, c.ratesQuerier.Source(), time.Now())
+		if err != nil {
+			log.Error("could not save rate history", sl.Error(err))
+			return money.Rate{}, fmt.Errorf("%s: %w", caller, err)
 		}
 		err := c.ratesOperator.SetCurrencyRate(ctx, currencyCode, currencyPrice)
 		if err != nil {
 			log.Error("could not save currency rates", sl.Error(err))
-			return 0, fmt.Errorf("%s: %w", caller, err)
+			return money.Rate{}, fmt.Errorf("%s: %w", caller, err)
 		}
 	}
 	if err != nil {
 		log.Error("internal error", sl.Error(err))
-		return 0, fmt.Errorf("%s: %w", caller, err)
+		return money.Rate{}, fmt.Errorf("%s: %w", caller, err)
 	}
 
 	return currencyPrice, nil
 }
 
-func (c *Currency) requestRatesAPI(ctx context.Context, currencyCode string) (float32, error) {
+func (c *Currency) requestRatesAPI(ctx context.Context, currencyCode string) (money.Rate, error) {
 	const caller = "services.currency.getCurrencyRate"
 
 	log := sl.AddCaller(c.log, caller)
@@ -266,7 +401,7 @@ func (c *Currency) requestRatesAPI(ctx context.Context, currencyCode string) (fl
 	rates, err := c.ratesQuerier.QueryRates(ctx, currencyCode)
 	if err != nil {
 		log.Error("failed to query rates", sl.Error(err))
-		return 0, fmt.Errorf("%s: %w", caller, err)
+		return money.Rate{}, fmt.Errorf("%s: %w", caller, err)
 	}
 	return rates, nil
 }
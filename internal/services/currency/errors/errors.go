@@ -10,4 +10,17 @@ var (
 	ErrWalletNotFound       = errors.New("wallet not found")
 	ErrInternal             = errors.New("internal error")
 	ErrCurrencyKeyNotFound  = errors.New("currency code not found")
+	ErrInvalidCursor        = errors.New("invalid cursor")
+	ErrInvalidAmount        = errors.New("amount must be positive")
+	ErrAccountFrozen        = errors.New("account is frozen")
+	ErrEmailNotVerified     = errors.New("email is not verified")
+	ErrCurrencyNotTradable  = errors.New("currency cannot be traded")
+	ErrOrderTooSmall        = errors.New("amount is below the minimum order")
+	ErrOrderTooLarge        = errors.New("amount is above the maximum order")
+	ErrSameCurrency         = errors.New("cannot convert a currency to itself")
+	ErrBaseCurrency         = errors.New("use buy and sell to trade the base currency")
+	ErrQuoteExpired         = errors.New("quote expired")
+	ErrQuoteMismatch        = errors.New("quote does not match the order")
+	ErrInvalidInterval      = errors.New("invalid interval")
+	ErrInvalidPeriod        = errors.New("invalid period")
 )
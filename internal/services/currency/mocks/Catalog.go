@@ -0,0 +1,88 @@
+// Code generated by mockery v2.45.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	models "github.com/tizzhh/micro-banking/internal/domain/currency/models"
+)
+
+// Catalog is an autogenerated mock type for the Catalog type
+type Catalog struct {
+	mock.Mock
+}
+
+// Currencies provides a mock function with given fields: ctx
+func (_m *Catalog) Currencies(ctx context.Context) ([]models.Currency, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Currencies")
+	}
+
+	var r0 []models.Currency
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]models.Currency, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []models.Currency); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Currency)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Currency provides a mock function with given fields: ctx, code
+func (_m *Catalog) Currency(ctx context.Context, code string) (models.Currency, error) {
+	ret := _m.Called(ctx, code)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Currency")
+	}
+
+	var r0 models.Currency
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (models.Currency, error)); ok {
+		return rf(ctx, code)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) models.Currency); ok {
+		r0 = rf(ctx, code)
+	} else {
+		r0 = ret.Get(0).(models.Currency)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, code)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewCatalog creates a new instance of Catalog. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewCatalog(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Catalog {
+	mock := &Catalog{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,76 @@
+// Code generated by mockery v2.45.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	models "github.com/tizzhh/micro-banking/internal/domain/currency/models"
+)
+
+// QuoteStore is an autogenerated mock type for the QuoteStore type
+type QuoteStore struct {
+	mock.Mock
+}
+
+// SaveQuote provides a mock function with given fields: ctx, quote
+func (_m *QuoteStore) SaveQuote(ctx context.Context, quote models.Quote) error {
+	ret := _m.Called(ctx, quote)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveQuote")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.Quote) error); ok {
+		r0 = rf(ctx, quote)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// TakeQuote provides a mock function with given fields: ctx, id
+func (_m *QuoteStore) TakeQuote(ctx context.Context, id string) (models.Quote, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TakeQuote")
+	}
+
+	var r0 models.Quote
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (models.Quote, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) models.Quote); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(models.Quote)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewQuoteStore creates a new instance of QuoteStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewQuoteStore(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *QuoteStore {
+	mock := &QuoteStore{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
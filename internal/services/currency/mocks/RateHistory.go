@@ -0,0 +1,82 @@
+// Code generated by mockery v2.45.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	models "github.com/tizzhh/micro-banking/internal/domain/currency/models"
+
+	money "github.com/tizzhh/micro-banking/pkg/money"
+
+	time "time"
+)
+
+// RateHistory is an autogenerated mock type for the RateHistory type
+type RateHistory struct {
+	mock.Mock
+}
+
+// Rates provides a mock function with given fields: ctx, currencyCode, from, to
+func (_m *RateHistory) Rates(ctx context.Context, currencyCode string, from time.Time, to time.Time) ([]models.ExchangeRate, error) {
+	ret := _m.Called(ctx, currencyCode, from, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Rates")
+	}
+
+	var r0 []models.ExchangeRate
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time, time.Time) ([]models.ExchangeRate, error)); ok {
+		return rf(ctx, currencyCode, from, to)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time, time.Time) []models.ExchangeRate); ok {
+		r0 = rf(ctx, currencyCode, from, to)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.ExchangeRate)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, currencyCode, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SaveRate provides a mock function with given fields: ctx, currencyCode, rate, source, fetchedAt
+func (_m *RateHistory) SaveRate(ctx context.Context, currencyCode string, rate money.Rate, source string, fetchedAt time.Time) error {
+	ret := _m.Called(ctx, currencyCode, rate, source, fetchedAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveRate")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, money.Rate, string, time.Time) error); ok {
+		r0 = rf(ctx, currencyCode, rate, source, fetchedAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewRateHistory creates a new instance of RateHistory. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewRateHistory(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *RateHistory {
+	mock := &RateHistory{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,46 @@
+package currency
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	currencyModels "github.com/tizzhh/micro-banking/internal/domain/currency/models"
+	currency "github.com/tizzhh/micro-banking/internal/services/currency/errors"
+	"github.com/tizzhh/micro-banking/pkg/logger/sl"
+	"github.com/tizzhh/micro-banking/pkg/money"
+	"github.com/tizzhh/micro-banking/pkg/pricing"
+)
+
+// Pricer applies the bank's spread and fees to the mid rate, the units of the
+// currency per USD.
+type Pricer interface {
+	Buy(amount money.Money, mid money.Rate) (pricing.Breakdown, error)
+	Sell(amount money.Money, mid money.Rate) (pricing.Breakdown, error)
+}
+
+// price returns what buying or selling amount costs at the current rate.
+func (c *Currency) price(ctx context.Context, log *slog.Logger, side currencyModels.QuoteSide, amount money.Money) (pricing.Breakdown, error) {
+	mid, err := c.getCurrencyRate(ctx, amount.Currency())
+	if err != nil {
+		log.Error("could not get currency rate", sl.Error(err))
+		return pricing.Breakdown{}, err
+	}
+
+	priceOf := c.pricer.Sell
+	if side == currencyModels.QuoteBuy {
+		priceOf = c.pricer.Buy
+	}
+
+	price, err := priceOf(amount, mid)
+	if err != nil {
+		if errors.Is(err, pricing.ErrFeeAboveProceeds) {
+			log.Warn("fee is not below the proceeds", slog.String("amount", amount.String()))
+			return pricing.Breakdown{}, currency.ErrOrderTooSmall
+		}
+		log.Error("failed to price amount", sl.Error(err))
+		return pricing.Breakdown{}, err
+	}
+
+	return price, nil
+}
@@ -0,0 +1,136 @@
+package currency
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	currencyModels "github.com/tizzhh/micro-banking/internal/domain/currency/models"
+	currency "github.com/tizzhh/micro-banking/internal/services/currency/errors"
+	"github.com/tizzhh/micro-banking/internal/storage"
+	"github.com/tizzhh/micro-banking/pkg/logger/sl"
+	"github.com/tizzhh/micro-banking/pkg/money"
+	"github.com/tizzhh/micro-banking/pkg/pricing"
+)
+
+const quoteIDLen = 16
+
+type QuoteStore interface {
+	SaveQuote(ctx context.Context, quote currencyModels.Quote) error
+	TakeQuote(ctx context.Context, id string) (currencyModels.Quote, error)
+}
+
+// Quote prices buying or selling amount of a foreign currency at the current
+// rate and holds the price for the quote TTL. Passing the quote id to Buy or
+// Sell executes the order at exactly that price.
+func (c *Currency) Quote(ctx context.Context, email string, amount money.Money, side currencyModels.QuoteSide) (currencyModels.Quote, error) {
+	const caller = "services.currency.Quote"
+
+	log := sl.AddCaller(c.log, caller).With(slog.String("side", string(side)))
+
+	log.Info("quoting currency")
+
+	if !amount.IsPositive() {
+		log.Warn("invalid amount", slog.String("amount", amount.String()))
+		return currencyModels.Quote{}, fmt.Errorf("%s: %w", caller, currency.ErrInvalidAmount)
+	}
+
+	if err := c.checkTradable(ctx, log, amount, side == currencyModels.QuoteBuy); err != nil {
+		return currencyModels.Quote{}, fmt.Errorf("%s: %w", caller, err)
+	}
+
+	if _, err := c.getUser(ctx, email); err != nil {
+		return currencyModels.Quote{}, fmt.Errorf("%s: %w", caller, err)
+	}
+
+	price, err := c.price(ctx, log, side, amount)
+	if err != nil {
+		return currencyModels.Quote{}, fmt.Errorf("%s: %w", caller, err)
+	}
+
+	id, err := newQuoteID()
+	if err != nil {
+		log.Error("failed to generate quote id", sl.Error(err))
+		return currencyModels.Quote{}, fmt.Errorf("%s: %w", caller, err)
+	}
+
+	quote := currencyModels.Quote{
+		ID:           id,
+		Email:        email,
+		Side:         side,
+		CurrencyCode: amount.Currency(),
+		Amount:       amount.Amount(),
+		Cost:         price.Total.Amount(),
+		Rate:         price.Rate.String(),
+		MidRate:      price.MidRate.String(),
+		Gross:        price.Gross.Amount(),
+		Spread:       price.Spread.Amount(),
+		Fee:          price.Fee.Amount(),
+		ExpiresAt:    time.Now().Add(c.quoteTTL),
+	}
+	if err := c.quoteStore.SaveQuote(ctx, quote); err != nil {
+		log.Error("failed to save quote", sl.Error(err))
+		return currencyModels.Quote{}, fmt.Errorf("%s: %w", caller, err)
+	}
+
+	log.Info("currency quoted")
+
+	return quote, nil
+}
+
+// quotedPrice takes the quote with id, which has to be of the user with email
+// for the same order, and returns its price. A quote is used at most once,
+// even if the order then fails.
+func (c *Currency) quotedPrice(ctx context.Context, log *slog.Logger, id string, email string, side currencyModels.QuoteSide, amount money.Money) (pricing.Breakdown, error) {
+	quote, err := c.quoteStore.TakeQuote(ctx, id)
+	if err != nil {
+		if errors.Is(err, storage.ErrQuoteNotFound) {
+			log.Warn("quote expired")
+			return pricing.Breakdown{}, currency.ErrQuoteExpired
+		}
+		log.Error("failed to get quote", sl.Error(err))
+		return pricing.Breakdown{}, err
+	}
+	if !time.Now().Before(quote.ExpiresAt) {
+		log.Warn("quote expired")
+		return pricing.Breakdown{}, currency.ErrQuoteExpired
+	}
+
+	if quote.Email != email || quote.Side != side ||
+		quote.CurrencyCode != amount.Currency() || quote.Amount != amount.Amount() {
+		log.Warn("quote does not match the order")
+		return pricing.Breakdown{}, currency.ErrQuoteMismatch
+	}
+
+	rate, err := money.ParseRate(quote.Rate)
+	if err != nil {
+		log.Error("failed to parse quote rate", sl.Error(err))
+		return pricing.Breakdown{}, err
+	}
+	midRate, err := money.ParseRate(quote.MidRate)
+	if err != nil {
+		log.Error("failed to parse quote mid rate", sl.Error(err))
+		return pricing.Breakdown{}, err
+	}
+
+	return pricing.Breakdown{
+		MidRate: midRate,
+		Rate:    rate,
+		Gross:   money.New(quote.Gross, baseCurrencyCode),
+		Spread:  money.New(quote.Spread, baseCurrencyCode),
+		Fee:     money.New(quote.Fee, baseCurrencyCode),
+		Total:   money.New(quote.Cost, baseCurrencyCode),
+	}, nil
+}
+
+func newQuoteID() (string, error) {
+	value := make([]byte, quoteIDLen)
+	if _, err := rand.Read(value); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(value), nil
+}
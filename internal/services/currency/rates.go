@@ -0,0 +1,140 @@
+package currency
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	currencyModels "github.com/tizzhh/micro-banking/internal/domain/currency/models"
+	currency "github.com/tizzhh/micro-banking/internal/services/currency/errors"
+	"github.com/tizzhh/micro-banking/internal/storage"
+	"github.com/tizzhh/micro-banking/pkg/logger/sl"
+	"github.com/tizzhh/micro-banking/pkg/money"
+)
+
+const (
+	defaultRateInterval = "1h"
+	defaultRateRange    = 24 * time.Hour
+	maxRateRange        = 31 * 24 * time.Hour
+)
+
+// rateIntervals are the candle intervals RateHistory aggregates by.
+var rateIntervals = map[string]time.Duration{
+	"1m":  time.Minute,
+	"5m":  5 * time.Minute,
+	"15m": 15 * time.Minute,
+	"1h":  time.Hour,
+	"4h":  4 * time.Hour,
+	"1d":  24 * time.Hour,
+}
+
+// RateHistory keeps every rate fetched from the rates API, in units of the
+// currency per USD.
+type RateHistory interface {
+	SaveRate(ctx context.Context, currencyCode string, rate money.Rate, source string, fetchedAt time.Time) error
+	Rates(ctx context.Context, currencyCode string, from, to time.Time) ([]currencyModels.ExchangeRate, error)
+}
+
+// RateHistory returns the rates of the currency with code fetched in
+// [from, to) and their candles by interval. The period defaults to the day
+// before to, which defaults to now, and the interval to an hour.
+func (c *Currency) RateHistory(ctx context.Context, code string, from, to time.Time, interval string) (currencyModels.RateHistory, error) {
+	const caller = "services.currency.RateHistory"
+
+	log := sl.AddCaller(c.log, caller).With(slog.String("currency", code))
+
+	log.Info("getting rate history")
+
+	if interval == "" {
+		interval = defaultRateInterval
+	}
+	step, ok := rateIntervals[interval]
+	if !ok {
+		log.Warn("invalid interval", slog.String("interval", interval))
+		return currencyModels.RateHistory{}, fmt.Errorf("%s: %w", caller, currency.ErrInvalidInterval)
+	}
+
+	if to.IsZero() {
+		to = time.Now()
+	}
+	if from.IsZero() {
+		from = to.Add(-defaultRateRange)
+	}
+	if !from.Before(to) || to.Sub(from) > maxRateRange {
+		log.Warn("invalid period", slog.Time("from", from), slog.Time("to", to))
+		return currencyModels.RateHistory{}, fmt.Errorf("%s: %w", caller, currency.ErrInvalidPeriod)
+	}
+
+	if code == baseCurrencyCode {
+		log.Warn("rates are against the base currency")
+		return currencyModels.RateHistory{}, fmt.Errorf("%s: %w", caller, currency.ErrBaseCurrency)
+	}
+	if _, err := c.catalog.Currency(ctx, code); err != nil {
+		if errors.Is(err, storage.ErrCurrencyCodeNotFound) {
+			log.Warn("currency code not found")
+			return currencyModels.RateHistory{}, fmt.Errorf("%s: %w", caller, currency.ErrCurrencyCodeNotFound)
+		}
+		log.Error("failed to get currency", sl.Error(err))
+		return currencyModels.RateHistory{}, fmt.Errorf("%s: %w", caller, err)
+	}
+
+	rates, err := c.rateHistory.Rates(ctx, code, from, to)
+	if err != nil {
+		log.Error("failed to get rates", sl.Error(err))
+		return currencyModels.RateHistory{}, fmt.Errorf("%s: %w", caller, err)
+	}
+
+	candles, err := rateCandles(rates, step)
+	if err != nil {
+		log.Error("failed to aggregate rates", sl.Error(err))
+		return currencyModels.RateHistory{}, fmt.Errorf("%s: %w", caller, err)
+	}
+
+	log.Info("rate history retrieved", slog.Int("rates", len(rates)))
+
+	return currencyModels.RateHistory{CurrencyCode: code, Rates: rates, Candles: candles}, nil
+}
+
+// rateCandles aggregates rates, oldest first, into a candle per interval
+// that has any. Intervals start at multiples of step since midnight UTC.
+// The rates are formatted like money.Rate does.
+func rateCandles(rates []currencyModels.ExchangeRate, step time.Duration) ([]currencyModels.RateCandle, error) {
+	var candles []currencyModels.RateCandle
+	var high, low money.Rate
+	for i := range rates {
+		rate, err := money.ParseRate(rates[i].Rate)
+		if err != nil {
+			return nil, err
+		}
+		rates[i].Rate = rate.String()
+
+		start := rates[i].FetchedAt.UTC().Truncate(step)
+		last := len(candles) - 1
+		if last < 0 || !candles[last].Start.Equal(start) {
+			candles = append(candles, currencyModels.RateCandle{
+				Start: start,
+				Open:  rate.String(),
+				High:  rate.String(),
+				Low:   rate.String(),
+			})
+			high, low = rate, rate
+			last++
+		}
+
+		candle := &candles[last]
+		if rate.Cmp(high) > 0 {
+			high = rate
+			candle.High = rate.String()
+		}
+		if rate.Cmp(low) < 0 {
+			low = rate
+			candle.Low = rate.String()
+		}
+		candle.Close = rate.String()
+		candle.Samples++
+	}
+
+	return candles, nil
+}
@@ -0,0 +1,42 @@
+// Code generated by mockery v2.45.0. DO NOT EDIT.
+
+package mocks
+
+import mock "github.com/stretchr/testify/mock"
+
+// Producer is an autogenerated mock type for the Producer type
+type Producer struct {
+	mock.Mock
+}
+
+// Produce provides a mock function with given fields: emailAddr, msg
+func (_m *Producer) Produce(emailAddr string, msg string) error {
+	ret := _m.Called(emailAddr, msg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Produce")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(emailAddr, msg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewProducer creates a new instance of Producer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewProducer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Producer {
+	mock := &Producer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,111 @@
+package outbox
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/tizzhh/micro-banking/internal/config"
+	"github.com/tizzhh/micro-banking/internal/domain/outbox/models"
+	"github.com/tizzhh/micro-banking/pkg/logger/sl"
+)
+
+type Store interface {
+	ClaimOutbox(ctx context.Context, limit int, lease time.Duration) ([]models.Message, error)
+	MarkOutboxSent(ctx context.Context, id uint64) error
+	RetryOutbox(ctx context.Context, id uint64, nextAttemptAt time.Time, lastError string) error
+}
+
+type Producer interface {
+	Produce(emailAddr string, msg string) error
+}
+
+// Relay publishes outbox messages to Kafka. A message is marked as sent only
+// after the producer accepted it, so delivery is at least once.
+type Relay struct {
+	log      *slog.Logger
+	store    Store
+	producer Producer
+	cfg      config.Outbox
+}
+
+func New(log *slog.Logger, store Store, producer Producer, cfg config.Outbox) *Relay {
+	return &Relay{
+		log:      log,
+		store:    store,
+		producer: producer,
+		cfg:      cfg,
+	}
+}
+
+// Run relays due messages every poll interval until ctx is done. Full batches
+// are followed by the next one right away.
+func (r *Relay) Run(ctx context.Context) {
+	const caller = "services.outbox.Run"
+	log := sl.AddCaller(r.log, caller)
+
+	ticker := time.NewTicker(r.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		claimed, err := r.RelayBatch(ctx)
+		if err != nil {
+			log.Error("failed to relay outbox", sl.Error(err))
+		}
+		if err == nil && claimed == r.cfg.BatchSize {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RelayBatch publishes one batch of due messages and returns how many were
+// claimed.
+func (r *Relay) RelayBatch(ctx context.Context) (int, error) {
+	const caller = "services.outbox.RelayBatch"
+	log := sl.AddCaller(r.log, caller)
+
+	messages, err := r.store.ClaimOutbox(ctx, r.cfg.BatchSize, r.cfg.Lease)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", caller, err)
+	}
+
+	for _, message := range messages {
+		log := log.With(slog.Uint64("message_id", message.ID))
+
+		if err := r.producer.Produce(message.EmailAddr, message.Message); err != nil {
+			nextAttemptAt := time.Now().Add(Backoff(message.Attempts, r.cfg.MinBackoff, r.cfg.MaxBackoff))
+			log.Warn("failed to publish message", slog.Int("attempts", message.Attempts+1), sl.Error(err))
+			if err := r.store.RetryOutbox(ctx, message.ID, nextAttemptAt, err.Error()); err != nil {
+				log.Error("failed to reschedule message", sl.Error(err))
+			}
+			continue
+		}
+
+		// if this fails the message is published again once the lease expires
+		if err := r.store.MarkOutboxSent(ctx, message.ID); err != nil {
+			log.Error("failed to mark message as sent", sl.Error(err))
+		}
+	}
+
+	return len(messages), nil
+}
+
+// Backoff doubles the delay with every failed attempt, starting at minBackoff
+// and never exceeding maxBackoff.
+func Backoff(attempts int, minBackoff, maxBackoff time.Duration) time.Duration {
+	backoff := minBackoff
+	for range attempts {
+		if backoff >= maxBackoff/2 {
+			return maxBackoff
+		}
+		backoff *= 2
+	}
+	return min(backoff, maxBackoff)
+}
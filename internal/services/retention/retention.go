@@ -0,0 +1,75 @@
+package retention
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/tizzhh/micro-banking/internal/config"
+	"github.com/tizzhh/micro-banking/pkg/logger/sl"
+)
+
+type Store interface {
+	AnonymizeClosedUsers(ctx context.Context, closedBefore time.Time, limit int) (int, error)
+}
+
+// Anonymizer erases the personal data of accounts that were closed longer
+// than the retention period ago. Their wallets and ledger are kept.
+type Anonymizer struct {
+	log   *slog.Logger
+	store Store
+	cfg   config.Retention
+}
+
+func New(log *slog.Logger, store Store, cfg config.Retention) *Anonymizer {
+	return &Anonymizer{
+		log:   log,
+		store: store,
+		cfg:   cfg,
+	}
+}
+
+// Run anonymizes due accounts every poll interval until ctx is done. Full
+// batches are followed by the next one right away.
+func (a *Anonymizer) Run(ctx context.Context) {
+	const caller = "services.retention.Run"
+	log := sl.AddCaller(a.log, caller)
+
+	ticker := time.NewTicker(a.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		anonymized, err := a.AnonymizeBatch(ctx, time.Now())
+		if err != nil {
+			log.Error("failed to anonymize closed accounts", sl.Error(err))
+		}
+		if err == nil && anonymized == a.cfg.BatchSize {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// AnonymizeBatch anonymizes one batch of accounts closed more than the
+// retention period before now and returns how many were anonymized.
+func (a *Anonymizer) AnonymizeBatch(ctx context.Context, now time.Time) (int, error) {
+	const caller = "services.retention.AnonymizeBatch"
+	log := sl.AddCaller(a.log, caller)
+
+	anonymized, err := a.store.AnonymizeClosedUsers(ctx, now.Add(-a.cfg.Period), a.cfg.BatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", caller, err)
+	}
+
+	if anonymized > 0 {
+		log.Info("closed accounts anonymized", slog.Int("count", anonymized))
+	}
+
+	return anonymized, nil
+}
@@ -6,7 +6,33 @@ var (
 	ErrUserAlreadyExists    = errors.New("user already exists")
 	ErrUserNotFound         = errors.New("user not found")
 	ErrCurrencyCodeNotFound = errors.New("currency code not found")
+	ErrCurrencyDisabled     = errors.New("currency is disabled")
+	ErrCurrencyExists       = errors.New("currency already exists")
+	ErrInvalidOrderLimits   = errors.New("max order is below min order")
 	ErrWalletNotFound       = errors.New("wallet not found")
+	ErrUserHasFunds         = errors.New("user has a non-zero balance")
+	ErrNotEnoughMoney       = errors.New("not enough money on balance")
+	ErrInvalidAmount        = errors.New("invalid amount")
+	ErrAccountFrozen        = errors.New("account is frozen")
+	ErrEmailNotVerified     = errors.New("email is not verified")
+	ErrEmailAlreadyVerified = errors.New("email is already verified")
+
+	ErrUnbalancedTransaction = errors.New("transaction debits and credits do not balance")
 
 	ErrCurrencyKeyNotFound = errors.New("currency code not found")
+	ErrQuoteNotFound       = errors.New("quote not found")
+
+	ErrIdempotencyKeyNotFound = errors.New("idempotency key not found")
+
+	ErrRefreshTokenNotFound = errors.New("refresh token not found")
+	ErrRefreshTokenExpired  = errors.New("refresh token expired")
+	ErrRefreshTokenReused   = errors.New("refresh token was already used")
+
+	ErrPasswordResetTokenNotFound = errors.New("password reset token not found")
+	ErrPasswordResetTokenExpired  = errors.New("password reset token expired")
+
+	ErrMFANotFound          = errors.New("mfa secret not found")
+	ErrMFAAlreadyConfirmed  = errors.New("mfa is already confirmed")
+	ErrMFACodeReused        = errors.New("mfa code was already used")
+	ErrRecoveryCodeNotFound = errors.New("recovery code not found")
 )
@@ -0,0 +1,214 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	adminModels "github.com/tizzhh/micro-banking/internal/domain/admin/models"
+	authModels "github.com/tizzhh/micro-banking/internal/domain/auth/models"
+	ledgerModels "github.com/tizzhh/micro-banking/internal/domain/ledger/models"
+	outboxModels "github.com/tizzhh/micro-banking/internal/domain/outbox/models"
+	"github.com/tizzhh/micro-banking/internal/storage"
+	"github.com/tizzhh/micro-banking/pkg/money"
+	"gorm.io/gorm"
+)
+
+// loadRoles fills in the roles granted to user.
+func loadRoles(db *gorm.DB, user *authModels.User) error {
+	const caller = "storage.postgres.loadRoles"
+
+	var roles []string
+	result := db.Model(&authModels.UserRole{}).Where("user_id = ?", user.ID).Order("role").Pluck("role", &roles)
+	if result.Error != nil {
+		return fmt.Errorf("%s: %w", caller, result.Error)
+	}
+	user.Roles = roles
+
+	return nil
+}
+
+// Users returns a page of users in id order, with their roles.
+func (s *Storage) Users(ctx context.Context, filter adminModels.UserFilter) ([]authModels.User, error) {
+	const caller = "storage.postgres.Users"
+
+	dbCtx := s.db.WithContext(ctx)
+
+	var users []authModels.User
+	result := dbCtx.Where("id > ?", filter.After).Order("id ASC").Limit(filter.Limit).Find(&users)
+	if result.Error != nil {
+		return nil, fmt.Errorf("%s: %w", caller, result.Error)
+	}
+	if len(users) == 0 {
+		return users, nil
+	}
+
+	ids := make([]uint64, 0, len(users))
+	for _, user := range users {
+		ids = append(ids, user.ID)
+	}
+
+	var roles []authModels.UserRole
+	result = dbCtx.Where("user_id IN ?", ids).Order("role").Find(&roles)
+	if result.Error != nil {
+		return nil, fmt.Errorf("%s: %w", caller, result.Error)
+	}
+
+	byUser := make(map[uint64][]string, len(users))
+	for _, role := range roles {
+		byUser[role.UserID] = append(byUser[role.UserID], role.Role)
+	}
+	for i := range users {
+		users[i].Roles = byUser[users[i].ID]
+	}
+
+	return users, nil
+}
+
+func (s *Storage) UserByID(ctx context.Context, id uint64) (authModels.User, error) {
+	const caller = "storage.postgres.UserByID"
+
+	var user authModels.User
+
+	dbCtx := s.db.WithContext(ctx)
+
+	result := dbCtx.Limit(1).Find(&user, id)
+	if result.Error != nil {
+		return authModels.User{}, fmt.Errorf("%s: %w", caller, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return authModels.User{}, fmt.Errorf("%s: %w", caller, storage.ErrUserNotFound)
+	}
+
+	if err := loadRoles(dbCtx, &user); err != nil {
+		return authModels.User{}, fmt.Errorf("%s: %w", caller, err)
+	}
+
+	return user, nil
+}
+
+// SetFrozen freezes or unfreezes the user's account.
+func (s *Storage) SetFrozen(ctx context.Context, userID uint64, frozen bool) error {
+	const caller = "storage.postgres.SetFrozen"
+
+	result := s.db.WithContext(ctx).Model(&authModels.User{}).Where("id = ?", userID).Update("frozen", frozen)
+	if result.Error != nil {
+		return fmt.Errorf("%s: %w", caller, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("%s: %w", caller, storage.ErrUserNotFound)
+	}
+
+	return nil
+}
+
+// AdjustBalance posts amount, a credit if positive and a debit if negative,
+// between the user's account in amount's currency and the external account,
+// and records the adjustment. Base currency amounts adjust cash, others the
+// wallet. Frozen accounts can be adjusted. It returns the new balance.
+func (s *Storage) AdjustBalance(ctx context.Context, user authModels.User, amount money.Money, adjustment adminModels.BalanceAdjustment, notify outboxModels.Notify) (money.Money, error) {
+	const caller = "storage.postgres.AdjustBalance"
+
+	if amount.IsZero() {
+		return money.Money{}, fmt.Errorf("%s: %w", caller, storage.ErrInvalidAmount)
+	}
+
+	ctxTx := s.db.WithContext(ctx).Begin()
+	defer func() {
+		if err := recover(); err != nil {
+			ctxTx.Rollback()
+		}
+	}()
+
+	if err := ctxTx.Error; err != nil {
+		return money.Money{}, fmt.Errorf("%s: %w", caller, err)
+	}
+
+	currencyCode := amount.Currency()
+	currency, err := getCurrency(ctxTx, currencyCode)
+	if err != nil {
+		ctxTx.Rollback()
+		return money.Money{}, fmt.Errorf("%s: %w", caller, err)
+	}
+
+	if err := lockUser(ctxTx, &user); err != nil {
+		ctxTx.Rollback()
+		return money.Money{}, fmt.Errorf("%s: %w", caller, err)
+	}
+
+	kind := ledgerModels.AccountUserWallet
+	if currencyCode == baseCurrencyCode {
+		kind = ledgerModels.AccountUserCash
+	}
+
+	userAccount, err := userLedgerAccount(ctxTx, user, currency, kind)
+	if err != nil {
+		ctxTx.Rollback()
+		return money.Money{}, fmt.Errorf("%s: %w", caller, err)
+	}
+
+	external, err := systemLedgerAccount(ctxTx, currency, ledgerModels.AccountExternal)
+	if err != nil {
+		ctxTx.Rollback()
+		return money.Money{}, fmt.Errorf("%s: %w", caller, err)
+	}
+
+	delta := amount.Amount()
+	units := uint64(delta)
+	postings := []ledgerModels.Posting{
+		{Account: external, Direction: ledgerModels.Debit, Amount: units},
+		{Account: userAccount, Direction: ledgerModels.Credit, Amount: units},
+	}
+	if amount.IsNegative() {
+		units = uint64(-delta)
+		postings = []ledgerModels.Posting{
+			{Account: userAccount, Direction: ledgerModels.Debit, Amount: units},
+			{Account: external, Direction: ledgerModels.Credit, Amount: units},
+		}
+	}
+
+	transaction, err := postTransaction(ctxTx, &user.ID, ledgerModels.TransactionAdjustment, postings)
+	if err != nil {
+		ctxTx.Rollback()
+		return money.Money{}, fmt.Errorf("%s: %w", caller, err)
+	}
+
+	var newBalance uint64
+	if kind == ledgerModels.AccountUserCash {
+		if err := addUserBalance(ctxTx, &user, delta); err != nil {
+			ctxTx.Rollback()
+			return money.Money{}, fmt.Errorf("%s: %w", caller, err)
+		}
+		newBalance = user.Balance
+	} else {
+		wallet, err := lockOrCreateWallet(ctxTx, user, currency)
+		if err != nil {
+			ctxTx.Rollback()
+			return money.Money{}, fmt.Errorf("%s: %w", caller, err)
+		}
+		if err := addWalletBalance(ctxTx, &wallet, delta); err != nil {
+			ctxTx.Rollback()
+			return money.Money{}, fmt.Errorf("%s: %w", caller, err)
+		}
+		newBalance = wallet.Balance
+	}
+
+	adjustment.TransactionID = transaction.ID
+	adjustment.UserID = user.ID
+	if err := ctxTx.Create(&adjustment).Error; err != nil {
+		ctxTx.Rollback()
+		return money.Money{}, fmt.Errorf("%s: %w", caller, err)
+	}
+
+	balance := money.New(int64(newBalance), currencyCode)
+	if err := enqueueOutbox(ctxTx, notify, balance); err != nil {
+		ctxTx.Rollback()
+		return money.Money{}, fmt.Errorf("%s: %w", caller, err)
+	}
+
+	if err := ctxTx.Commit().Error; err != nil {
+		ctxTx.Rollback()
+		return money.Money{}, fmt.Errorf("%s: %w", caller, err)
+	}
+
+	return balance, nil
+}
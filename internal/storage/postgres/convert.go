@@ -0,0 +1,162 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	authModels "github.com/tizzhh/micro-banking/internal/domain/auth/models"
+	currencyModels "github.com/tizzhh/micro-banking/internal/domain/currency/models"
+	ledgerModels "github.com/tizzhh/micro-banking/internal/domain/ledger/models"
+	outboxModels "github.com/tizzhh/micro-banking/internal/domain/outbox/models"
+	"github.com/tizzhh/micro-banking/internal/storage"
+	"github.com/tizzhh/micro-banking/pkg/money"
+	"gorm.io/gorm"
+)
+
+// Convert debits amount from the user's wallet of its currency and credits
+// converted to the wallet of converted's currency through the house FX
+// accounts of both, and records the applied rate. Like selling, a disabled
+// currency can be converted from but not to. Notify gets the new balance of
+// the target wallet.
+func (s *Storage) Convert(ctx context.Context, user authModels.User, amount, converted money.Money, rate money.Rate, notify outboxModels.Notify) error {
+	const caller = "storage.postgres.Convert"
+
+	amountUnits, err := minorUnits(amount, amount.Currency())
+	if err != nil {
+		return fmt.Errorf("%s: %w", caller, err)
+	}
+	convertedUnits, err := minorUnits(converted, converted.Currency())
+	if err != nil {
+		return fmt.Errorf("%s: %w", caller, err)
+	}
+	if amountUnits == 0 || convertedUnits == 0 || amount.Currency() == converted.Currency() || rate.IsZero() {
+		return fmt.Errorf("%s: %w", caller, storage.ErrInvalidAmount)
+	}
+
+	ctxTx := s.db.WithContext(ctx).Begin()
+	defer func() {
+		if err := recover(); err != nil {
+			ctxTx.Rollback()
+		}
+	}()
+
+	if err := ctxTx.Error; err != nil {
+		return fmt.Errorf("%s: %w", caller, err)
+	}
+
+	from, err := getCurrency(ctxTx, amount.Currency())
+	if err != nil {
+		ctxTx.Rollback()
+		return fmt.Errorf("%s: %w", caller, err)
+	}
+	to, err := getEnabledCurrency(ctxTx, converted.Currency())
+	if err != nil {
+		ctxTx.Rollback()
+		return fmt.Errorf("%s: %w", caller, err)
+	}
+
+	// the user lock serializes the wallet locks of the user
+	if err := lockActiveUser(ctxTx, &user); err != nil {
+		ctxTx.Rollback()
+		return fmt.Errorf("%s: %w", caller, err)
+	}
+
+	fromWallet, err := lockWallet(ctxTx, user, from)
+	if errors.Is(err, storage.ErrWalletNotFound) {
+		ctxTx.Rollback()
+		return fmt.Errorf("%s: %w", caller, storage.ErrNotEnoughMoney)
+	}
+	if err != nil {
+		ctxTx.Rollback()
+		return fmt.Errorf("%s: %w", caller, err)
+	}
+	if fromWallet.Balance < amountUnits {
+		ctxTx.Rollback()
+		return fmt.Errorf("%s: %w", caller, storage.ErrNotEnoughMoney)
+	}
+
+	toWallet, err := lockOrCreateWallet(ctxTx, user, to)
+	if err != nil {
+		ctxTx.Rollback()
+		return fmt.Errorf("%s: %w", caller, err)
+	}
+
+	postings, err := convertPostings(ctxTx, user, from, to, amountUnits, convertedUnits)
+	if err != nil {
+		ctxTx.Rollback()
+		return fmt.Errorf("%s: %w", caller, err)
+	}
+
+	transaction, err := postTransaction(ctxTx, &user.ID, ledgerModels.TransactionConvert, postings)
+	if err != nil {
+		ctxTx.Rollback()
+		return fmt.Errorf("%s: %w", caller, err)
+	}
+
+	if err := addWalletBalance(ctxTx, &fromWallet, -int64(amountUnits)); err != nil {
+		ctxTx.Rollback()
+		return fmt.Errorf("%s: %w", caller, err)
+	}
+	if err := addWalletBalance(ctxTx, &toWallet, int64(convertedUnits)); err != nil {
+		ctxTx.Rollback()
+		return fmt.Errorf("%s: %w", caller, err)
+	}
+
+	conversion := currencyModels.Conversion{
+		TransactionID:  transaction.ID,
+		UserID:         user.ID,
+		FromCurrencyID: from.ID,
+		ToCurrencyID:   to.ID,
+		FromAmount:     amountUnits,
+		ToAmount:       convertedUnits,
+		Rate:           rate.String(),
+	}
+	if err := ctxTx.Create(&conversion).Error; err != nil {
+		ctxTx.Rollback()
+		return fmt.Errorf("%s: %w", caller, err)
+	}
+
+	if err := enqueueOutbox(ctxTx, notify, money.New(int64(toWallet.Balance), to.Code)); err != nil {
+		ctxTx.Rollback()
+		return fmt.Errorf("%s: %w", caller, err)
+	}
+
+	if err := ctxTx.Commit().Error; err != nil {
+		ctxTx.Rollback()
+		return fmt.Errorf("%s: %w", caller, err)
+	}
+
+	return nil
+}
+
+// convertPostings moves amount from the user's wallet to the house FX account
+// in the source currency, and converted from the house FX account to the
+// user's wallet in the target currency.
+func convertPostings(ctxTx *gorm.DB, user authModels.User, from, to currencyModels.Currency, amount, converted uint64) ([]ledgerModels.Posting, error) {
+	const caller = "storage.postgres.convertPostings"
+
+	userFrom, err := userLedgerAccount(ctxTx, user, from, ledgerModels.AccountUserWallet)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", caller, err)
+	}
+	houseFrom, err := systemLedgerAccount(ctxTx, from, ledgerModels.AccountHouseFX)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", caller, err)
+	}
+	userTo, err := userLedgerAccount(ctxTx, user, to, ledgerModels.AccountUserWallet)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", caller, err)
+	}
+	houseTo, err := systemLedgerAccount(ctxTx, to, ledgerModels.AccountHouseFX)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", caller, err)
+	}
+
+	return []ledgerModels.Posting{
+		{Account: userFrom, Direction: ledgerModels.Debit, Amount: amount},
+		{Account: houseFrom, Direction: ledgerModels.Credit, Amount: amount},
+		{Account: houseTo, Direction: ledgerModels.Debit, Amount: converted},
+		{Account: userTo, Direction: ledgerModels.Credit, Amount: converted},
+	}, nil
+}
@@ -0,0 +1,137 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	authModels "github.com/tizzhh/micro-banking/internal/domain/auth/models"
+	currencyModels "github.com/tizzhh/micro-banking/internal/domain/currency/models"
+	"github.com/tizzhh/micro-banking/internal/storage"
+)
+
+// Currencies returns the currency catalog ordered by code.
+func (s *Storage) Currencies(ctx context.Context) ([]currencyModels.Currency, error) {
+	const caller = "storage.postgres.Currencies"
+
+	var currencies []currencyModels.Currency
+	if err := s.db.WithContext(ctx).Order("code").Find(&currencies).Error; err != nil {
+		return nil, fmt.Errorf("%s: %w", caller, err)
+	}
+
+	return currencies, nil
+}
+
+// Currency returns the catalog entry of code.
+func (s *Storage) Currency(ctx context.Context, code string) (currencyModels.Currency, error) {
+	const caller = "storage.postgres.Currency"
+
+	currency, err := getCurrency(s.db.WithContext(ctx), code)
+	if err != nil {
+		return currencyModels.Currency{}, fmt.Errorf("%s: %w", caller, err)
+	}
+
+	return currency, nil
+}
+
+// CreateCurrency adds currency to the catalog.
+func (s *Storage) CreateCurrency(ctx context.Context, currency currencyModels.Currency) (currencyModels.Currency, error) {
+	const caller = "storage.postgres.CreateCurrency"
+
+	result := s.db.WithContext(ctx).Create(&currency)
+	var psqlErr *pgconn.PgError
+	if errors.As(result.Error, &psqlErr) && psqlErr.Code == pgerrcode.UniqueViolation {
+		return currencyModels.Currency{}, fmt.Errorf("%s: %w", caller, storage.ErrCurrencyExists)
+	}
+	if result.Error != nil {
+		return currencyModels.Currency{}, fmt.Errorf("%s: %w", caller, result.Error)
+	}
+
+	return currency, nil
+}
+
+// UpdateCurrency changes the catalog fields set in update of the currency
+// with code and returns the updated entry.
+func (s *Storage) UpdateCurrency(ctx context.Context, code string, update currencyModels.CurrencyUpdate) (currencyModels.Currency, error) {
+	const caller = "storage.postgres.UpdateCurrency"
+
+	columns := map[string]any{}
+	if update.Name != nil {
+		columns["name"] = *update.Name
+	}
+	if update.Enabled != nil {
+		columns["enabled"] = *update.Enabled
+	}
+	if update.Tradable != nil {
+		columns["tradable"] = *update.Tradable
+	}
+	if update.MinOrder != nil {
+		columns["min_order"] = *update.MinOrder
+	}
+	if update.MaxOrder != nil {
+		columns["max_order"] = *update.MaxOrder
+	}
+
+	dbCtx := s.db.WithContext(ctx)
+	if len(columns) == 0 {
+		currency, err := getCurrency(dbCtx, code)
+		if err != nil {
+			return currencyModels.Currency{}, fmt.Errorf("%s: %w", caller, err)
+		}
+		return currency, nil
+	}
+
+	var currency currencyModels.Currency
+	result := dbCtx.Model(&currency).Clauses(clause.Returning{}).Where("code = ?", code).Updates(columns)
+	var psqlErr *pgconn.PgError
+	if errors.As(result.Error, &psqlErr) && psqlErr.Code == pgerrcode.CheckViolation {
+		return currencyModels.Currency{}, fmt.Errorf("%s: %w", caller, storage.ErrInvalidOrderLimits)
+	}
+	if result.Error != nil {
+		return currencyModels.Currency{}, fmt.Errorf("%s: %w", caller, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return currencyModels.Currency{}, fmt.Errorf("%s: %w", caller, storage.ErrCurrencyCodeNotFound)
+	}
+
+	return currency, nil
+}
+
+// getEnabledCurrency returns the catalog entry of code like getCurrency and
+// fails if the currency is disabled.
+func getEnabledCurrency(ctxTx *gorm.DB, code string) (currencyModels.Currency, error) {
+	const caller = "storage.postgres.getEnabledCurrency"
+
+	currency, err := getCurrency(ctxTx, code)
+	if err != nil {
+		return currencyModels.Currency{}, fmt.Errorf("%s: %w", caller, err)
+	}
+	if !currency.Enabled {
+		return currencyModels.Currency{}, fmt.Errorf("%s: %w", caller, storage.ErrCurrencyDisabled)
+	}
+
+	return currency, nil
+}
+
+// lockOrCreateWallet locks the user's wallet in currency like lockWallet and
+// creates it first if the user never held the currency.
+func lockOrCreateWallet(ctxTx *gorm.DB, user authModels.User, currency currencyModels.Currency) (currencyModels.UserWallet, error) {
+	const caller = "storage.postgres.lockOrCreateWallet"
+
+	wallet := currencyModels.UserWallet{UserID: user.ID, CurrencyID: currency.ID}
+	if err := ctxTx.Omit(clause.Associations).Clauses(clause.OnConflict{DoNothing: true}).Create(&wallet).Error; err != nil {
+		return currencyModels.UserWallet{}, fmt.Errorf("%s: %w", caller, err)
+	}
+
+	wallet, err := lockWallet(ctxTx, user, currency)
+	if err != nil {
+		return currencyModels.UserWallet{}, fmt.Errorf("%s: %w", caller, err)
+	}
+
+	return wallet, nil
+}
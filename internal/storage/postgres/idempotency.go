@@ -0,0 +1,95 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm/clause"
+
+	idempotencyModels "github.com/tizzhh/micro-banking/internal/domain/idempotency/models"
+	"github.com/tizzhh/micro-banking/internal/storage"
+)
+
+// ReserveIdempotencyKey claims key for a request with the given fingerprint.
+// If the key is already taken, the stored record is returned and reserved is
+// false. Records older than ttl are dropped and the key can be claimed again.
+func (s *Storage) ReserveIdempotencyKey(ctx context.Context, key, fingerprint string, ttl time.Duration) (idempotencyModels.Record, bool, error) {
+	const caller = "storage.postgres.ReserveIdempotencyKey"
+
+	ctxTx := s.db.WithContext(ctx).Begin()
+	defer func() {
+		if err := recover(); err != nil {
+			ctxTx.Rollback()
+		}
+	}()
+
+	if err := ctxTx.Error; err != nil {
+		return idempotencyModels.Record{}, false, fmt.Errorf("%s: %w", caller, err)
+	}
+
+	result := ctxTx.Where("key = ? AND created_at < ?", key, time.Now().Add(-ttl)).Delete(&idempotencyModels.Record{})
+	if result.Error != nil {
+		ctxTx.Rollback()
+		return idempotencyModels.Record{}, false, fmt.Errorf("%s: %w", caller, result.Error)
+	}
+
+	record := idempotencyModels.Record{Key: key, Fingerprint: fingerprint}
+	result = ctxTx.Clauses(clause.OnConflict{DoNothing: true}).Create(&record)
+	if result.Error != nil {
+		ctxTx.Rollback()
+		return idempotencyModels.Record{}, false, fmt.Errorf("%s: %w", caller, result.Error)
+	}
+	reserved := result.RowsAffected == 1
+
+	if !reserved {
+		record = idempotencyModels.Record{}
+		if result = ctxTx.First(&record, "key = ?", key); result.Error != nil {
+			ctxTx.Rollback()
+			return idempotencyModels.Record{}, false, fmt.Errorf("%s: %w", caller, result.Error)
+		}
+	}
+
+	if err := ctxTx.Commit().Error; err != nil {
+		ctxTx.Rollback()
+		return idempotencyModels.Record{}, false, fmt.Errorf("%s: %w", caller, err)
+	}
+
+	return record, reserved, nil
+}
+
+// CompleteIdempotencyKey stores the response of a reserved key so that
+// duplicates can replay it.
+func (s *Storage) CompleteIdempotencyKey(ctx context.Context, key string, statusCode int, response []byte) error {
+	const caller = "storage.postgres.CompleteIdempotencyKey"
+
+	result := s.db.WithContext(ctx).
+		Model(&idempotencyModels.Record{}).
+		Where("key = ? AND completed_at IS NULL", key).
+		Updates(map[string]any{
+			"status_code":  statusCode,
+			"response":     response,
+			"completed_at": time.Now(),
+		})
+	if result.Error != nil {
+		return fmt.Errorf("%s: %w", caller, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("%s: %w", caller, storage.ErrIdempotencyKeyNotFound)
+	}
+
+	return nil
+}
+
+// ReleaseIdempotencyKey drops an unfinished reservation, letting a retry with
+// the same key run the request again.
+func (s *Storage) ReleaseIdempotencyKey(ctx context.Context, key string) error {
+	const caller = "storage.postgres.ReleaseIdempotencyKey"
+
+	result := s.db.WithContext(ctx).Where("key = ? AND completed_at IS NULL", key).Delete(&idempotencyModels.Record{})
+	if result.Error != nil {
+		return fmt.Errorf("%s: %w", caller, result.Error)
+	}
+
+	return nil
+}
@@ -0,0 +1,223 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	authModels "github.com/tizzhh/micro-banking/internal/domain/auth/models"
+	currencyModels "github.com/tizzhh/micro-banking/internal/domain/currency/models"
+	ledgerModels "github.com/tizzhh/micro-banking/internal/domain/ledger/models"
+	"github.com/tizzhh/micro-banking/internal/storage"
+	"github.com/tizzhh/micro-banking/pkg/money"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	baseCurrencyCode = "USD"
+)
+
+// minorUnits returns amount as stored in the balance columns. It must not be
+// negative and must be in currencyCode.
+func minorUnits(amount money.Money, currencyCode string) (uint64, error) {
+	if amount.IsNegative() || amount.Currency() != currencyCode {
+		return 0, storage.ErrInvalidAmount
+	}
+	return uint64(amount.Amount()), nil
+}
+
+func ledgerAccount(ctxTx *gorm.DB, userID *uint64, currencyID uint64, kind ledgerModels.AccountKind) (ledgerModels.LedgerAccount, error) {
+	const caller = "storage.postgres.ledgerAccount"
+
+	account := ledgerModels.LedgerAccount{UserID: userID, CurrencyID: currencyID, Kind: kind}
+	if err := ctxTx.Omit(clause.Associations).Clauses(clause.OnConflict{DoNothing: true}).Create(&account).Error; err != nil {
+		return ledgerModels.LedgerAccount{}, fmt.Errorf("%s: %w", caller, err)
+	}
+	if account.ID != 0 {
+		return account, nil
+	}
+
+	query := ctxTx.Where("currency_id = ? AND kind = ?", currencyID, kind)
+	if userID == nil {
+		query = query.Where("user_id IS NULL")
+	} else {
+		query = query.Where("user_id = ?", *userID)
+	}
+
+	result := query.First(&account)
+	if result.Error != nil {
+		return ledgerModels.LedgerAccount{}, fmt.Errorf("%s: %w", caller, result.Error)
+	}
+
+	return account, nil
+}
+
+func userLedgerAccount(ctxTx *gorm.DB, user authModels.User, currency currencyModels.Currency, kind ledgerModels.AccountKind) (ledgerModels.LedgerAccount, error) {
+	return ledgerAccount(ctxTx, &user.ID, currency.ID, kind)
+}
+
+func systemLedgerAccount(ctxTx *gorm.DB, currency currencyModels.Currency, kind ledgerModels.AccountKind) (ledgerModels.LedgerAccount, error) {
+	return ledgerAccount(ctxTx, nil, currency.ID, kind)
+}
+
+// postTransaction writes a transaction and its entries. Debits and credits must
+// balance per currency, otherwise nothing is written.
+func postTransaction(ctxTx *gorm.DB, userID *uint64, txType ledgerModels.TransactionType, postings []ledgerModels.Posting) (ledgerModels.Transaction, error) {
+	const caller = "storage.postgres.postTransaction"
+
+	sums := make(map[uint64]int64)
+	entries := make([]ledgerModels.LedgerEntry, 0, len(postings))
+	for _, posting := range postings {
+		if posting.Amount == 0 {
+			continue
+		}
+		switch posting.Direction {
+		case ledgerModels.Debit:
+			sums[posting.Account.CurrencyID] -= int64(posting.Amount)
+		case ledgerModels.Credit:
+			sums[posting.Account.CurrencyID] += int64(posting.Amount)
+		default:
+			return ledgerModels.Transaction{}, fmt.Errorf("%s: %w", caller, storage.ErrUnbalancedTransaction)
+		}
+		entries = append(entries, ledgerModels.LedgerEntry{
+			AccountID: posting.Account.ID,
+			Direction: posting.Direction,
+			Amount:    posting.Amount,
+		})
+	}
+	for _, sum := range sums {
+		if sum != 0 {
+			return ledgerModels.Transaction{}, fmt.Errorf("%s: %w", caller, storage.ErrUnbalancedTransaction)
+		}
+	}
+
+	transaction := ledgerModels.Transaction{UserID: userID, Type: txType}
+	if err := ctxTx.Omit(clause.Associations).Create(&transaction).Error; err != nil {
+		return ledgerModels.Transaction{}, fmt.Errorf("%s: %w", caller, err)
+	}
+
+	if len(entries) == 0 {
+		return transaction, nil
+	}
+
+	for i := range entries {
+		entries[i].TransactionID = transaction.ID
+	}
+	if err := ctxTx.Omit(clause.Associations).Create(&entries).Error; err != nil {
+		return ledgerModels.Transaction{}, fmt.Errorf("%s: %w", caller, err)
+	}
+	transaction.Entries = entries
+
+	return transaction, nil
+}
+
+// addUserBalance applies delta to the stored balance. A negative delta is only
+// applied when the balance covers it.
+func addUserBalance(ctxTx *gorm.DB, user *authModels.User, delta int64) error {
+	const caller = "storage.postgres.addUserBalance"
+
+	query := ctxTx.Model(user)
+	if delta < 0 {
+		query = query.Where("balance >= ?", -delta)
+	}
+
+	result := query.
+		Clauses(clause.Returning{Columns: []clause.Column{{Name: "balance"}}}).
+		Update("balance", gorm.Expr("balance + ?", delta))
+	if result.Error != nil {
+		return fmt.Errorf("%s: %w", caller, result.Error)
+	}
+	if result.RowsAffected == 0 && delta < 0 {
+		return fmt.Errorf("%s: %w", caller, storage.ErrNotEnoughMoney)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("%s: %w", caller, storage.ErrUserNotFound)
+	}
+
+	return nil
+}
+
+// addWalletBalance applies delta to the wallet balance. A negative delta is only
+// applied when the balance covers it.
+func addWalletBalance(ctxTx *gorm.DB, wallet *currencyModels.UserWallet, delta int64) error {
+	const caller = "storage.postgres.addWalletBalance"
+
+	query := ctxTx.Model(wallet)
+	if delta < 0 {
+		query = query.Where("balance >= ?", -delta)
+	}
+
+	result := query.
+		Omit(clause.Associations).
+		Clauses(clause.Returning{Columns: []clause.Column{{Name: "balance"}}}).
+		Update("balance", gorm.Expr("balance + ?", delta))
+	if result.Error != nil {
+		return fmt.Errorf("%s: %w", caller, result.Error)
+	}
+	if result.RowsAffected == 0 && delta < 0 {
+		return fmt.Errorf("%s: %w", caller, storage.ErrNotEnoughMoney)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("%s: %w", caller, storage.ErrWalletNotFound)
+	}
+
+	return nil
+}
+
+func (s *Storage) Mismatches(ctx context.Context) ([]ledgerModels.Mismatch, error) {
+	const caller = "storage.postgres.Mismatches"
+
+	var mismatches []ledgerModels.Mismatch
+
+	result := s.db.WithContext(ctx).Table("ledger_mismatches").Find(&mismatches)
+	if result.Error != nil {
+		return nil, fmt.Errorf("%s: %w", caller, result.Error)
+	}
+
+	return mismatches, nil
+}
+
+func (s *Storage) History(ctx context.Context, user authModels.User, filter ledgerModels.HistoryFilter) ([]ledgerModels.HistoryEntry, error) {
+	const caller = "storage.postgres.History"
+
+	query := s.db.WithContext(ctx).
+		Table("ledger_entries e").
+		Select("e.id, e.transaction_id, t.type, c.code AS currency_code, e.direction, e.amount, e.created_at").
+		Joins("JOIN ledger_accounts a ON a.id = e.account_id").
+		Joins("JOIN transactions t ON t.id = e.transaction_id").
+		Joins("JOIN currencies c ON c.id = a.currency_id").
+		Where("a.user_id = ?", user.ID)
+
+	if len(filter.Types) > 0 {
+		query = query.Where("t.type IN ?", filter.Types)
+	}
+	if filter.CurrencyCode != "" {
+		query = query.Where("c.code = ?", filter.CurrencyCode)
+	}
+	if !filter.From.IsZero() {
+		query = query.Where("e.created_at >= ?", filter.From)
+	}
+	if !filter.To.IsZero() {
+		query = query.Where("e.created_at < ?", filter.To)
+	}
+
+	if filter.Ascending {
+		if filter.After != 0 {
+			query = query.Where("e.id > ?", filter.After)
+		}
+		query = query.Order("e.id ASC")
+	} else {
+		if filter.After != 0 {
+			query = query.Where("e.id < ?", filter.After)
+		}
+		query = query.Order("e.id DESC")
+	}
+
+	var entries []ledgerModels.HistoryEntry
+	result := query.Limit(filter.Limit).Scan(&entries)
+	if result.Error != nil {
+		return nil, fmt.Errorf("%s: %w", caller, result.Error)
+	}
+
+	return entries, nil
+}
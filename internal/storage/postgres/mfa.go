@@ -0,0 +1,216 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	authModels "github.com/tizzhh/micro-banking/internal/domain/auth/models"
+	"github.com/tizzhh/micro-banking/internal/storage"
+)
+
+// SaveMFASecret stores the unconfirmed secret of a user, replacing an
+// earlier unconfirmed one. A confirmed secret is not replaced.
+func (s *Storage) SaveMFASecret(ctx context.Context, secret authModels.MFASecret) error {
+	const caller = "storage.postgres.SaveMFASecret"
+
+	result := s.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"secret", "last_used_step", "created_at"}),
+		Where: clause.Where{Exprs: []clause.Expression{
+			clause.Expr{SQL: "mfa_secrets.confirmed_at IS NULL"},
+		}},
+	}).Create(&secret)
+	if result.Error != nil {
+		return fmt.Errorf("%s: %w", caller, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("%s: %w", caller, storage.ErrMFAAlreadyConfirmed)
+	}
+
+	return nil
+}
+
+func (s *Storage) MFASecret(ctx context.Context, userID uint64) (authModels.MFASecret, error) {
+	const caller = "storage.postgres.MFASecret"
+
+	var secret authModels.MFASecret
+	result := s.db.WithContext(ctx).First(&secret, "user_id = ?", userID)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return authModels.MFASecret{}, fmt.Errorf("%s: %w", caller, storage.ErrMFANotFound)
+	}
+	if result.Error != nil {
+		return authModels.MFASecret{}, fmt.Errorf("%s: %w", caller, result.Error)
+	}
+
+	return secret, nil
+}
+
+// ConfirmMFA confirms the secret of a user with the code of step and
+// replaces their recovery codes.
+func (s *Storage) ConfirmMFA(ctx context.Context, userID uint64, step int64, codeHashes []string) error {
+	const caller = "storage.postgres.ConfirmMFA"
+
+	ctxTx := s.db.WithContext(ctx).Begin()
+	defer func() {
+		if err := recover(); err != nil {
+			ctxTx.Rollback()
+		}
+	}()
+
+	if err := ctxTx.Error; err != nil {
+		return fmt.Errorf("%s: %w", caller, err)
+	}
+
+	result := ctxTx.Model(&authModels.MFASecret{}).
+		Where("user_id = ? AND confirmed_at IS NULL AND last_used_step < ?", userID, step).
+		Updates(map[string]any{
+			"confirmed_at":   time.Now(),
+			"last_used_step": step,
+		})
+	if result.Error != nil {
+		ctxTx.Rollback()
+		return fmt.Errorf("%s: %w", caller, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		ctxTx.Rollback()
+		return fmt.Errorf("%s: %w", caller, storage.ErrMFAAlreadyConfirmed)
+	}
+
+	if err := replaceRecoveryCodes(ctxTx, userID, codeHashes); err != nil {
+		ctxTx.Rollback()
+		return fmt.Errorf("%s: %w", caller, err)
+	}
+
+	if err := ctxTx.Commit().Error; err != nil {
+		ctxTx.Rollback()
+		return fmt.Errorf("%s: %w", caller, err)
+	}
+
+	return nil
+}
+
+// UseMFAStep records that the code of step was accepted for a user. Codes
+// of the same or an earlier step are rejected with ErrMFACodeReused.
+func (s *Storage) UseMFAStep(ctx context.Context, userID uint64, step int64) error {
+	const caller = "storage.postgres.UseMFAStep"
+
+	result := s.db.WithContext(ctx).Model(&authModels.MFASecret{}).
+		Where("user_id = ? AND last_used_step < ?", userID, step).
+		Update("last_used_step", step)
+	if result.Error != nil {
+		return fmt.Errorf("%s: %w", caller, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("%s: %w", caller, storage.ErrMFACodeReused)
+	}
+
+	return nil
+}
+
+// UseRecoveryCode marks an unused recovery code of a user as used.
+func (s *Storage) UseRecoveryCode(ctx context.Context, userID uint64, codeHash string) error {
+	const caller = "storage.postgres.UseRecoveryCode"
+
+	result := s.db.WithContext(ctx).Model(&authModels.RecoveryCode{}).
+		Where("user_id = ? AND code_hash = ? AND used_at IS NULL", userID, codeHash).
+		Update("used_at", time.Now())
+	if result.Error != nil {
+		return fmt.Errorf("%s: %w", caller, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("%s: %w", caller, storage.ErrRecoveryCodeNotFound)
+	}
+
+	return nil
+}
+
+func (s *Storage) ReplaceRecoveryCodes(ctx context.Context, userID uint64, codeHashes []string) error {
+	const caller = "storage.postgres.ReplaceRecoveryCodes"
+
+	ctxTx := s.db.WithContext(ctx).Begin()
+	defer func() {
+		if err := recover(); err != nil {
+			ctxTx.Rollback()
+		}
+	}()
+
+	if err := ctxTx.Error; err != nil {
+		return fmt.Errorf("%s: %w", caller, err)
+	}
+
+	if err := replaceRecoveryCodes(ctxTx, userID, codeHashes); err != nil {
+		ctxTx.Rollback()
+		return fmt.Errorf("%s: %w", caller, err)
+	}
+
+	if err := ctxTx.Commit().Error; err != nil {
+		ctxTx.Rollback()
+		return fmt.Errorf("%s: %w", caller, err)
+	}
+
+	return nil
+}
+
+// DeleteMFA removes the secret and the recovery codes of a user.
+func (s *Storage) DeleteMFA(ctx context.Context, userID uint64) error {
+	const caller = "storage.postgres.DeleteMFA"
+
+	ctxTx := s.db.WithContext(ctx).Begin()
+	defer func() {
+		if err := recover(); err != nil {
+			ctxTx.Rollback()
+		}
+	}()
+
+	if err := ctxTx.Error; err != nil {
+		return fmt.Errorf("%s: %w", caller, err)
+	}
+
+	if err := ctxTx.Where("user_id = ?", userID).Delete(&authModels.RecoveryCode{}).Error; err != nil {
+		ctxTx.Rollback()
+		return fmt.Errorf("%s: %w", caller, err)
+	}
+
+	result := ctxTx.Where("user_id = ?", userID).Delete(&authModels.MFASecret{})
+	if result.Error != nil {
+		ctxTx.Rollback()
+		return fmt.Errorf("%s: %w", caller, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		ctxTx.Rollback()
+		return fmt.Errorf("%s: %w", caller, storage.ErrMFANotFound)
+	}
+
+	if err := ctxTx.Commit().Error; err != nil {
+		ctxTx.Rollback()
+		return fmt.Errorf("%s: %w", caller, err)
+	}
+
+	return nil
+}
+
+func replaceRecoveryCodes(db *gorm.DB, userID uint64, codeHashes []string) error {
+	const caller = "storage.postgres.replaceRecoveryCodes"
+
+	if err := db.Where("user_id = ?", userID).Delete(&authModels.RecoveryCode{}).Error; err != nil {
+		return fmt.Errorf("%s: %w", caller, err)
+	}
+
+	codes := make([]authModels.RecoveryCode, 0, len(codeHashes))
+	for _, hash := range codeHashes {
+		codes = append(codes, authModels.RecoveryCode{UserID: userID, CodeHash: hash})
+	}
+	if len(codes) == 0 {
+		return nil
+	}
+	if err := db.Create(&codes).Error; err != nil {
+		return fmt.Errorf("%s: %w", caller, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,120 @@
+package postgres
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"gorm.io/gorm"
+
+	outboxModels "github.com/tizzhh/micro-banking/internal/domain/outbox/models"
+	"github.com/tizzhh/micro-banking/pkg/money"
+)
+
+// enqueueOutbox stores the messages built by notify in the transaction of the
+// balance change. A nil notify stores nothing.
+func enqueueOutbox(ctxTx *gorm.DB, notify outboxModels.Notify, newBalance money.Money) error {
+	const caller = "storage.postgres.enqueueOutbox"
+
+	if notify == nil {
+		return nil
+	}
+	if err := enqueueMessages(ctxTx, notify(newBalance)); err != nil {
+		return fmt.Errorf("%s: %w", caller, err)
+	}
+
+	return nil
+}
+
+// EnqueueOutbox stores messages for publishing outside of a balance change.
+func (s *Storage) EnqueueOutbox(ctx context.Context, messages []outboxModels.Message) error {
+	const caller = "storage.postgres.EnqueueOutbox"
+
+	if err := enqueueMessages(s.db.WithContext(ctx), messages); err != nil {
+		return fmt.Errorf("%s: %w", caller, err)
+	}
+
+	return nil
+}
+
+func enqueueMessages(db *gorm.DB, messages []outboxModels.Message) error {
+	const caller = "storage.postgres.enqueueMessages"
+
+	if len(messages) == 0 {
+		return nil
+	}
+	if err := db.Create(&messages).Error; err != nil {
+		return fmt.Errorf("%s: %w", caller, err)
+	}
+
+	return nil
+}
+
+// ClaimOutbox returns up to limit messages that are due for publishing and
+// hides them from other relays until lease passes. Claimed messages that are
+// neither marked as sent nor rescheduled become due again after the lease.
+func (s *Storage) ClaimOutbox(ctx context.Context, limit int, lease time.Duration) ([]outboxModels.Message, error) {
+	const caller = "storage.postgres.ClaimOutbox"
+
+	var messages []outboxModels.Message
+
+	result := s.db.WithContext(ctx).Raw(`
+		UPDATE outbox SET next_attempt_at = ?
+		WHERE id IN (
+			SELECT id FROM outbox
+			WHERE sent_at IS NULL AND next_attempt_at <= NOW()
+			ORDER BY id
+			LIMIT ?
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING *`,
+		time.Now().Add(lease), limit,
+	).Scan(&messages)
+	if result.Error != nil {
+		return nil, fmt.Errorf("%s: %w", caller, result.Error)
+	}
+
+	slices.SortFunc(messages, func(a, b outboxModels.Message) int {
+		return cmp.Compare(a.ID, b.ID)
+	})
+
+	return messages, nil
+}
+
+// MarkOutboxSent marks the message with id as sent and clears its body,
+// which may carry single-use tokens that must not outlive the delivery.
+func (s *Storage) MarkOutboxSent(ctx context.Context, id uint64) error {
+	const caller = "storage.postgres.MarkOutboxSent"
+
+	result := s.db.WithContext(ctx).
+		Model(&outboxModels.Message{ID: id}).
+		Updates(map[string]any{
+			"sent_at": time.Now(),
+			"message": "",
+		})
+	if result.Error != nil {
+		return fmt.Errorf("%s: %w", caller, result.Error)
+	}
+
+	return nil
+}
+
+// RetryOutbox records a failed publish and schedules the next attempt.
+func (s *Storage) RetryOutbox(ctx context.Context, id uint64, nextAttemptAt time.Time, lastError string) error {
+	const caller = "storage.postgres.RetryOutbox"
+
+	result := s.db.WithContext(ctx).
+		Model(&outboxModels.Message{ID: id}).
+		Updates(map[string]any{
+			"attempts":        gorm.Expr("attempts + 1"),
+			"next_attempt_at": nextAttemptAt,
+			"last_error":      lastError,
+		})
+	if result.Error != nil {
+		return fmt.Errorf("%s: %w", caller, result.Error)
+	}
+
+	return nil
+}
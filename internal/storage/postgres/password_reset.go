@@ -0,0 +1,120 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	authModels "github.com/tizzhh/micro-banking/internal/domain/auth/models"
+	outboxModels "github.com/tizzhh/micro-banking/internal/domain/outbox/models"
+	"github.com/tizzhh/micro-banking/internal/storage"
+)
+
+// SavePasswordResetToken stores the token in place of the unused tokens of
+// its user, together with the messages that deliver it.
+func (s *Storage) SavePasswordResetToken(ctx context.Context, token authModels.PasswordResetToken, messages []outboxModels.Message) error {
+	const caller = "storage.postgres.SavePasswordResetToken"
+
+	ctxTx := s.db.WithContext(ctx).Begin()
+	defer func() {
+		if err := recover(); err != nil {
+			ctxTx.Rollback()
+		}
+	}()
+
+	if err := ctxTx.Error; err != nil {
+		return fmt.Errorf("%s: %w", caller, err)
+	}
+
+	err := ctxTx.Where("user_id = ? AND used_at IS NULL", token.UserID).Delete(&authModels.PasswordResetToken{}).Error
+	if err != nil {
+		ctxTx.Rollback()
+		return fmt.Errorf("%s: %w", caller, err)
+	}
+
+	if err := ctxTx.Create(&token).Error; err != nil {
+		ctxTx.Rollback()
+		return fmt.Errorf("%s: %w", caller, err)
+	}
+
+	if err := enqueueMessages(ctxTx, messages); err != nil {
+		ctxTx.Rollback()
+		return fmt.Errorf("%s: %w", caller, err)
+	}
+
+	if err := ctxTx.Commit().Error; err != nil {
+		ctxTx.Rollback()
+		return fmt.Errorf("%s: %w", caller, err)
+	}
+
+	return nil
+}
+
+// ResetPassword uses the token with tokenHash to set the password of its
+// user and revokes all refresh tokens of the user. It returns the user.
+func (s *Storage) ResetPassword(ctx context.Context, tokenHash string, passHash []byte) (authModels.User, error) {
+	const caller = "storage.postgres.ResetPassword"
+
+	ctxTx := s.db.WithContext(ctx).Begin()
+	defer func() {
+		if err := recover(); err != nil {
+			ctxTx.Rollback()
+		}
+	}()
+
+	if err := ctxTx.Error; err != nil {
+		return authModels.User{}, fmt.Errorf("%s: %w", caller, err)
+	}
+
+	var token authModels.PasswordResetToken
+	result := ctxTx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		First(&token, "token_hash = ? AND used_at IS NULL", tokenHash)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		ctxTx.Rollback()
+		return authModels.User{}, fmt.Errorf("%s: %w", caller, storage.ErrPasswordResetTokenNotFound)
+	}
+	if result.Error != nil {
+		ctxTx.Rollback()
+		return authModels.User{}, fmt.Errorf("%s: %w", caller, result.Error)
+	}
+
+	if time.Now().After(token.ExpiresAt) {
+		ctxTx.Rollback()
+		return authModels.User{}, fmt.Errorf("%s: %w", caller, storage.ErrPasswordResetTokenExpired)
+	}
+
+	if err := ctxTx.Model(&token).Update("used_at", time.Now()).Error; err != nil {
+		ctxTx.Rollback()
+		return authModels.User{}, fmt.Errorf("%s: %w", caller, err)
+	}
+
+	user := authModels.User{ID: token.UserID}
+	if err := lockUser(ctxTx, &user); err != nil {
+		ctxTx.Rollback()
+		return authModels.User{}, fmt.Errorf("%s: %w", caller, err)
+	}
+
+	if err := ctxTx.Model(&user).Update("pass_hash", passHash).Error; err != nil {
+		ctxTx.Rollback()
+		return authModels.User{}, fmt.Errorf("%s: %w", caller, err)
+	}
+
+	result = ctxTx.Model(&authModels.RefreshToken{}).
+		Where("user_id = ? AND revoked_at IS NULL", user.ID).
+		Update("revoked_at", time.Now())
+	if result.Error != nil {
+		ctxTx.Rollback()
+		return authModels.User{}, fmt.Errorf("%s: %w", caller, result.Error)
+	}
+
+	if err := ctxTx.Commit().Error; err != nil {
+		ctxTx.Rollback()
+		return authModels.User{}, fmt.Errorf("%s: %w", caller, err)
+	}
+
+	return user, nil
+}
@@ -5,16 +5,22 @@ import (
 	"errors"
 	"fmt"
 	"sync"
+	"time"
 
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
 	"github.com/jackc/pgerrcode"
 	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/tizzhh/micro-banking/internal/config"
 	authModels "github.com/tizzhh/micro-banking/internal/domain/auth/models"
 	currencyModels "github.com/tizzhh/micro-banking/internal/domain/currency/models"
+	ledgerModels "github.com/tizzhh/micro-banking/internal/domain/ledger/models"
+	outboxModels "github.com/tizzhh/micro-banking/internal/domain/outbox/models"
 	"github.com/tizzhh/micro-banking/internal/storage"
+	"github.com/tizzhh/micro-banking/pkg/money"
+	"github.com/tizzhh/micro-banking/pkg/pricing"
 )
 
 type Storage struct {
@@ -47,7 +53,10 @@ func New() (*Storage, error) {
 	return &Storage{db: db}, nil
 }
 
-func (s *Storage) SaveUser(ctx context.Context, user authModels.User) (uint64, error) {
+// SaveUser creates the user and stores the messages built by notify in the
+// same transaction. A nil notify stores nothing. Wallets are created on first
+// use.
+func (s *Storage) SaveUser(ctx context.Context, user authModels.User, notify outboxModels.NotifyUser) (uint64, error) {
 	const caller = "storage.postgres.SaveUser"
 
 	ctxTx := s.db.WithContext(ctx).Begin()
@@ -73,9 +82,16 @@ func (s *Storage) SaveUser(ctx context.Context, user authModels.User) (uint64, e
 		return 0, fmt.Errorf("%s: %w", caller, result.Error)
 	}
 
-	if err := InitializeCurrency(ctxTx, user.ID); err != nil {
-		ctxTx.Rollback()
-		return 0, fmt.Errorf("%s: %w", caller, err)
+	if notify != nil {
+		messages, err := notify(user)
+		if err != nil {
+			ctxTx.Rollback()
+			return 0, fmt.Errorf("%s: %w", caller, err)
+		}
+		if err := enqueueMessages(ctxTx, messages); err != nil {
+			ctxTx.Rollback()
+			return 0, fmt.Errorf("%s: %w", caller, err)
+		}
 	}
 
 	if err := ctxTx.Commit().Error; err != nil {
@@ -93,7 +109,7 @@ func (s *Storage) User(ctx context.Context, email string) (authModels.User, erro
 
 	dbCtx := s.db.WithContext(ctx)
 
-	result := dbCtx.First(&user, "email = ?", email)
+	result := dbCtx.First(&user, "email = ? AND status <> ?", email, authModels.UserStatusClosed)
 	if result.RowsAffected == 0 {
 		return authModels.User{}, fmt.Errorf("%s: %w", caller, storage.ErrUserNotFound)
 	}
@@ -102,6 +118,10 @@ func (s *Storage) User(ctx context.Context, email string) (authModels.User, erro
 		return authModels.User{}, fmt.Errorf("%s: %w", caller, result.Error)
 	}
 
+	if err := loadRoles(dbCtx, &user); err != nil {
+		return authModels.User{}, fmt.Errorf("%s: %w", caller, err)
+	}
+
 	return user, nil
 }
 
@@ -121,7 +141,7 @@ func (s *Storage) UpdateUser(ctx context.Context, email string, newPassword []by
 		return fmt.Errorf("%s: %w", caller, err)
 	}
 
-	result := ctxTx.First(&user, "email = ?", email)
+	result := ctxTx.First(&user, "email = ? AND status <> ?", email, authModels.UserStatusClosed)
 	if result.RowsAffected == 0 {
 		ctxTx.Rollback()
 		return fmt.Errorf("%s: %w", caller, storage.ErrUserNotFound)
@@ -146,8 +166,10 @@ func (s *Storage) UpdateUser(ctx context.Context, email string, newPassword []by
 	return nil
 }
 
-func (s *Storage) DeleteUser(ctx context.Context, email string) error {
-	const caller = "storage.postgres.DeleteUser"
+// CloseUser closes the account of the user with id, which must not hold any
+// money, and revokes its sessions. The user, wallets and ledger are kept.
+func (s *Storage) CloseUser(ctx context.Context, id uint64, closedAt time.Time) error {
+	const caller = "storage.postgres.CloseUser"
 
 	var user authModels.User
 
@@ -162,18 +184,48 @@ func (s *Storage) DeleteUser(ctx context.Context, email string) error {
 		return fmt.Errorf("%s: %w", caller, err)
 	}
 
-	result := ctxTx.First(&user, "email = ?", email)
-	if result.RowsAffected == 0 {
+	result := ctxTx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		First(&user, "id = ? AND status <> ?", id, authModels.UserStatusClosed)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
 		ctxTx.Rollback()
 		return fmt.Errorf("%s: %w", caller, storage.ErrUserNotFound)
 	}
+	if result.Error != nil {
+		ctxTx.Rollback()
+		return fmt.Errorf("%s: %w", caller, result.Error)
+	}
+
+	var fundedWallets int64
+	result = ctxTx.Model(&currencyModels.UserWallet{}).
+		Where("user_id = ? AND balance > 0", user.ID).
+		Count(&fundedWallets)
+	if result.Error != nil {
+		ctxTx.Rollback()
+		return fmt.Errorf("%s: %w", caller, result.Error)
+	}
+	if user.Balance > 0 || fundedWallets > 0 {
+		ctxTx.Rollback()
+		return fmt.Errorf("%s: %w", caller, storage.ErrUserHasFunds)
+	}
+
+	result = ctxTx.Model(&user).Updates(map[string]any{
+		"status":    authModels.UserStatusClosed,
+		"closed_at": closedAt,
+	})
+	if result.Error != nil {
+		ctxTx.Rollback()
+		return fmt.Errorf("%s: %w", caller, result.Error)
+	}
 
+	result = ctxTx.Model(&authModels.RefreshToken{}).
+		Where("user_id = ? AND revoked_at IS NULL", user.ID).
+		Update("revoked_at", closedAt)
 	if result.Error != nil {
 		ctxTx.Rollback()
 		return fmt.Errorf("%s: %w", caller, result.Error)
 	}
 
-	if result = ctxTx.Delete(&user); result.Error != nil {
+	if result = ctxTx.Where("user_id = ?", user.ID).Delete(&authModels.PasswordResetToken{}); result.Error != nil {
 		ctxTx.Rollback()
 		return fmt.Errorf("%s: %w", caller, result.Error)
 	}
@@ -186,35 +238,34 @@ func (s *Storage) DeleteUser(ctx context.Context, email string) error {
 	return nil
 }
 
-func InitializeCurrency(ctxTx *gorm.DB, userId uint64) error {
-	const caller = "storage.postgres.InitializeCurrency"
-
-	var currencies []currencyModels.Currency
+// VerifyUserEmail activates the account of the user with id, which must
+// still have email and be waiting for its verification.
+func (s *Storage) VerifyUserEmail(ctx context.Context, id uint64, email string) error {
+	const caller = "storage.postgres.VerifyUserEmail"
 
-	result := ctxTx.Find(&currencies)
-	if result.RowsAffected == 0 {
-		return fmt.Errorf("%s: %w", caller, storage.ErrUserNotFound)
-	}
+	dbCtx := s.db.WithContext(ctx)
 
+	result := dbCtx.Model(&authModels.User{}).
+		Where("id = ? AND email = ? AND status = ?", id, email, authModels.UserStatusPendingVerification).
+		Update("status", authModels.UserStatusActive)
 	if result.Error != nil {
 		return fmt.Errorf("%s: %w", caller, result.Error)
 	}
-
-	userWallets := make([]*currencyModels.UserWallet, 0, len(currencies))
-	for _, currency := range currencies {
-		userWallet := &currencyModels.UserWallet{
-			UserID:     userId,
-			CurrencyID: currency.ID,
-		}
-		userWallets = append(userWallets, userWallet)
+	if result.RowsAffected > 0 {
+		return nil
 	}
 
-	result = ctxTx.Create(userWallets)
+	// the email has changed since the token was issued if it does not match
+	var user authModels.User
+	result = dbCtx.Select("id").Where("id = ? AND email = ?", id, email).First(&user)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return fmt.Errorf("%s: %w", caller, storage.ErrUserNotFound)
+	}
 	if result.Error != nil {
 		return fmt.Errorf("%s: %w", caller, result.Error)
 	}
 
-	return nil
+	return fmt.Errorf("%s: %w", caller, storage.ErrEmailAlreadyVerified)
 }
 
 func getCurrency(ctxTx *gorm.DB, currencyCode string) (currencyModels.Currency, error) {
@@ -248,7 +299,74 @@ func getWallet(ctxTx *gorm.DB, user authModels.User, currency currencyModels.Cur
 	return wallet, nil
 }
 
-func (s *Storage) performBuySellOperation(ctx context.Context, user authModels.User, currencyCode string, newUserBalance, newCurrencyBalance uint64) error {
+// lockUser reloads the user with FOR UPDATE so that concurrent balance
+// changes of the same user are serialized until the transaction ends. Users are
+// always locked before their wallets.
+func lockUser(ctxTx *gorm.DB, user *authModels.User) error {
+	const caller = "storage.postgres.lockUser"
+
+	result := ctxTx.Clauses(clause.Locking{Strength: "UPDATE"}).First(user, user.ID)
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("%s: %w", caller, storage.ErrUserNotFound)
+	}
+	if result.Error != nil {
+		return fmt.Errorf("%s: %w", caller, result.Error)
+	}
+
+	return nil
+}
+
+// lockActiveUser locks the user like lockUser and fails if the account is
+// frozen or its email is not verified.
+func lockActiveUser(ctxTx *gorm.DB, user *authModels.User) error {
+	const caller = "storage.postgres.lockActiveUser"
+
+	if err := lockUser(ctxTx, user); err != nil {
+		return fmt.Errorf("%s: %w", caller, err)
+	}
+	if user.Frozen {
+		return fmt.Errorf("%s: %w", caller, storage.ErrAccountFrozen)
+	}
+	if !user.Verified() {
+		return fmt.Errorf("%s: %w", caller, storage.ErrEmailNotVerified)
+	}
+
+	return nil
+}
+
+// lockUsers locks both users in id order so that opposite operations between
+// the same users cannot deadlock. Both accounts must be active like in
+// lockActiveUser.
+func lockUsers(ctxTx *gorm.DB, first, second *authModels.User) error {
+	const caller = "storage.postgres.lockUsers"
+
+	if second.ID < first.ID {
+		first, second = second, first
+	}
+	if err := lockActiveUser(ctxTx, first); err != nil {
+		return fmt.Errorf("%s: %w", caller, err)
+	}
+	if err := lockActiveUser(ctxTx, second); err != nil {
+		return fmt.Errorf("%s: %w", caller, err)
+	}
+
+	return nil
+}
+
+func lockWallet(ctxTx *gorm.DB, user authModels.User, currency currencyModels.Currency) (currencyModels.UserWallet, error) {
+	const caller = "storage.postgres.lockWallet"
+
+	wallet, err := getWallet(ctxTx.Clauses(clause.Locking{Strength: "UPDATE"}), user, currency)
+	if err != nil {
+		return currencyModels.UserWallet{}, fmt.Errorf("%s: %w", caller, err)
+	}
+
+	return wallet, nil
+}
+
+// performBuySellOperation books trade, whose Amount, Total and Fee are in
+// minor units, and records it with the rates it was priced at.
+func (s *Storage) performBuySellOperation(ctx context.Context, user authModels.User, currencyCode string, txType ledgerModels.TransactionType, trade currencyModels.Trade, notify outboxModels.Notify) error {
 	const caller = "storage.postgres.performBuySellOperation"
 
 	ctxTx := s.db.WithContext(ctx).Begin()
@@ -258,26 +376,89 @@ func (s *Storage) performBuySellOperation(ctx context.Context, user authModels.U
 		}
 	}()
 
-	currency, err := getCurrency(ctxTx, currencyCode)
+	// disabled currencies can still be sold
+	getCurrencyOf := getEnabledCurrency
+	if txType == ledgerModels.TransactionSell {
+		getCurrencyOf = getCurrency
+	}
+	currency, err := getCurrencyOf(ctxTx, currencyCode)
+	if err != nil {
+		ctxTx.Rollback()
+		return fmt.Errorf("%s: %w", caller, err)
+	}
+
+	if err := lockActiveUser(ctxTx, &user); err != nil {
+		ctxTx.Rollback()
+		return fmt.Errorf("%s: %w", caller, err)
+	}
+
+	var wallet currencyModels.UserWallet
+	if txType == ledgerModels.TransactionBuy {
+		wallet, err = lockOrCreateWallet(ctxTx, user, currency)
+	} else {
+		wallet, err = lockWallet(ctxTx, user, currency)
+	}
+	if errors.Is(err, storage.ErrWalletNotFound) {
+		ctxTx.Rollback()
+		return fmt.Errorf("%s: %w", caller, storage.ErrNotEnoughMoney)
+	}
+	if err != nil {
+		ctxTx.Rollback()
+		return fmt.Errorf("%s: %w", caller, err)
+	}
+
+	cost, fee, amount := trade.Total, trade.Fee, trade.Amount
+	if txType == ledgerModels.TransactionBuy && user.Balance < cost {
+		ctxTx.Rollback()
+		return fmt.Errorf("%s: %w", caller, storage.ErrNotEnoughMoney)
+	}
+	if txType == ledgerModels.TransactionSell && wallet.Balance < amount {
+		ctxTx.Rollback()
+		return fmt.Errorf("%s: %w", caller, storage.ErrNotEnoughMoney)
+	}
+
+	baseCurrency, err := getCurrency(ctxTx, baseCurrencyCode)
+	if err != nil {
+		ctxTx.Rollback()
+		return fmt.Errorf("%s: %w", caller, err)
+	}
+
+	postings, err := buySellPostings(ctxTx, user, baseCurrency, currency, txType, cost, fee, amount)
 	if err != nil {
 		ctxTx.Rollback()
 		return fmt.Errorf("%s: %w", caller, err)
 	}
 
-	wallet, err := getWallet(ctxTx, user, currency)
+	transaction, err := postTransaction(ctxTx, &user.ID, txType, postings)
 	if err != nil {
 		ctxTx.Rollback()
 		return fmt.Errorf("%s: %w", caller, err)
 	}
 
-	user.Balance = newUserBalance
-	if err := ctxTx.Save(&user).Error; err != nil {
+	trade.TransactionID = transaction.ID
+	trade.UserID = user.ID
+	trade.CurrencyID = currency.ID
+	if err := ctxTx.Create(&trade).Error; err != nil {
 		ctxTx.Rollback()
 		return fmt.Errorf("%s: %w", caller, err)
 	}
 
-	wallet.Balance = newCurrencyBalance
-	if err := ctxTx.Save(&wallet).Error; err != nil {
+	userDelta, walletDelta := -int64(cost), int64(amount)
+	if txType == ledgerModels.TransactionSell {
+		userDelta, walletDelta = int64(cost), -int64(amount)
+	}
+
+	if err := addUserBalance(ctxTx, &user, userDelta); err != nil {
+		ctxTx.Rollback()
+		return fmt.Errorf("%s: %w", caller, err)
+	}
+
+	if err := addWalletBalance(ctxTx, &wallet, walletDelta); err != nil {
+		ctxTx.Rollback()
+		return fmt.Errorf("%s: %w", caller, err)
+	}
+
+	if err := enqueueOutbox(ctxTx, notify, money.New(int64(user.Balance), baseCurrencyCode)); err != nil {
 		ctxTx.Rollback()
 		return fmt.Errorf("%s: %w", caller, err)
 	}
@@ -290,61 +471,181 @@ func (s *Storage) performBuySellOperation(ctx context.Context, user authModels.U
 	return nil
 }
 
-func (s *Storage) Buy(ctx context.Context, user authModels.User, currencyCode string, newUserBalance, newCurrencyBalance uint64) error {
+// buySellPostings moves cost between the user's cash and the house FX account
+// in the base currency, and amount between the house FX account and the user's
+// wallet in the traded currency. The fee goes to the house fees account: it is
+// part of the cost of a buy and taken from the proceeds of a sell on top of
+// cost.
+func buySellPostings(ctxTx *gorm.DB, user authModels.User, baseCurrency, currency currencyModels.Currency, txType ledgerModels.TransactionType, cost, fee, amount uint64) ([]ledgerModels.Posting, error) {
+	const caller = "storage.postgres.buySellPostings"
+
+	userCash, err := userLedgerAccount(ctxTx, user, baseCurrency, ledgerModels.AccountUserCash)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", caller, err)
+	}
+	houseCash, err := systemLedgerAccount(ctxTx, baseCurrency, ledgerModels.AccountHouseFX)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", caller, err)
+	}
+	userWallet, err := userLedgerAccount(ctxTx, user, currency, ledgerModels.AccountUserWallet)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", caller, err)
+	}
+	houseWallet, err := systemLedgerAccount(ctxTx, currency, ledgerModels.AccountHouseFX)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", caller, err)
+	}
+	houseFees, err := systemLedgerAccount(ctxTx, baseCurrency, ledgerModels.AccountHouseFees)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", caller, err)
+	}
+
+	if txType == ledgerModels.TransactionSell {
+		return []ledgerModels.Posting{
+			{Account: houseCash, Direction: ledgerModels.Debit, Amount: cost + fee},
+			{Account: userCash, Direction: ledgerModels.Credit, Amount: cost},
+			{Account: houseFees, Direction: ledgerModels.Credit, Amount: fee},
+			{Account: userWallet, Direction: ledgerModels.Debit, Amount: amount},
+			{Account: houseWallet, Direction: ledgerModels.Credit, Amount: amount},
+		}, nil
+	}
+
+	return []ledgerModels.Posting{
+		{Account: userCash, Direction: ledgerModels.Debit, Amount: cost},
+		{Account: houseCash, Direction: ledgerModels.Credit, Amount: cost - fee},
+		{Account: houseFees, Direction: ledgerModels.Credit, Amount: fee},
+		{Account: houseWallet, Direction: ledgerModels.Debit, Amount: amount},
+		{Account: userWallet, Direction: ledgerModels.Credit, Amount: amount},
+	}, nil
+}
+
+// Buy charges the total of price in the base currency, of which the fee goes
+// to the house fees account, and credits amount to the wallet of amount's
+// currency.
+func (s *Storage) Buy(ctx context.Context, user authModels.User, amount money.Money, price pricing.Breakdown, notify outboxModels.Notify) error {
 	const caller = "storage.postgres.Buy"
 
-	if err := s.performBuySellOperation(ctx, user, currencyCode, newUserBalance, newCurrencyBalance); err != nil {
+	if err := s.buySell(ctx, user, ledgerModels.TransactionBuy, amount, price, notify); err != nil {
 		return fmt.Errorf("%s: %w", caller, err)
 	}
 
 	return nil
 }
 
-func (s *Storage) Sell(ctx context.Context, user authModels.User, currencyCode string, newUserBalance, newCurrencyBalance uint64) error {
+// Sell debits amount from the wallet of amount's currency and credits the
+// total of price in the base currency. The fee withheld from the proceeds goes
+// to the house fees account.
+func (s *Storage) Sell(ctx context.Context, user authModels.User, amount money.Money, price pricing.Breakdown, notify outboxModels.Notify) error {
 	const caller = "storage.postgres.Sell"
 
-	if err := s.performBuySellOperation(ctx, user, currencyCode, newUserBalance, newCurrencyBalance); err != nil {
+	if err := s.buySell(ctx, user, ledgerModels.TransactionSell, amount, price, notify); err != nil {
+		return fmt.Errorf("%s: %w", caller, err)
+	}
+
+	return nil
+}
+
+func (s *Storage) buySell(ctx context.Context, user authModels.User, txType ledgerModels.TransactionType, amount money.Money, price pricing.Breakdown, notify outboxModels.Notify) error {
+	const caller = "storage.postgres.buySell"
+
+	costUnits, err := minorUnits(price.Total, baseCurrencyCode)
+	if err != nil {
+		return fmt.Errorf("%s: %w", caller, err)
+	}
+	feeUnits, err := minorUnits(price.Fee, baseCurrencyCode)
+	if err != nil {
+		return fmt.Errorf("%s: %w", caller, err)
+	}
+	if txType == ledgerModels.TransactionBuy && feeUnits > costUnits {
+		return fmt.Errorf("%s: %w", caller, storage.ErrInvalidAmount)
+	}
+	amountUnits, err := minorUnits(amount, amount.Currency())
+	if err != nil {
+		return fmt.Errorf("%s: %w", caller, err)
+	}
+
+	if costUnits == 0 || amountUnits == 0 || price.MidRate.IsZero() || price.Rate.IsZero() {
+		return fmt.Errorf("%s: %w", caller, storage.ErrInvalidAmount)
+	}
+
+	trade := currencyModels.Trade{
+		Side:    currencyModels.QuoteSell,
+		Amount:  amountUnits,
+		Total:   costUnits,
+		Fee:     feeUnits,
+		MidRate: price.MidRate.String(),
+		Rate:    price.Rate.String(),
+	}
+	if txType == ledgerModels.TransactionBuy {
+		trade.Side = currencyModels.QuoteBuy
+	}
+
+	if err := s.performBuySellOperation(ctx, user, amount.Currency(), txType, trade, notify); err != nil {
 		return fmt.Errorf("%s: %w", caller, err)
 	}
 
 	return nil
 }
 
-func (s *Storage) CurrencyBalance(ctx context.Context, user authModels.User, currencyCode string) (uint64, error) {
+func (s *Storage) CurrencyBalance(ctx context.Context, user authModels.User, currencyCode string) (money.Money, error) {
 	const caller = "storage.postgres.CurrencyBalance"
 
 	ctxDb := s.db.WithContext(ctx)
 	currency, err := getCurrency(ctxDb, currencyCode)
 	if err != nil {
-		return 0, fmt.Errorf("%s: %w", caller, err)
+		return money.Money{}, fmt.Errorf("%s: %w", caller, err)
 	}
 
 	wallet, err := getWallet(ctxDb, user, currency)
+	if errors.Is(err, storage.ErrWalletNotFound) {
+		return money.New(0, currencyCode), nil
+	}
 	if err != nil {
-		return 0, fmt.Errorf("%s: %w", caller, err)
+		return money.Money{}, fmt.Errorf("%s: %w", caller, err)
 	}
 
-	return wallet.Balance, nil
+	return money.New(int64(wallet.Balance), currencyCode), nil
 }
 
+// Wallets returns the user's wallets in every enabled currency but the base
+// one, with a zero balance where the user never held the currency, and the
+// wallets in disabled currencies that still hold money.
 func (s *Storage) Wallets(ctx context.Context, user authModels.User) ([]currencyModels.UserWallet, error) {
-	const caller = "storage.postgres.Wallet"
-
-	var userWallets []currencyModels.UserWallet
-
-	ctxDb := s.db.WithContext(ctx)
-	result := ctxDb.Preload("Currency").Find(&userWallets)
-	if result.RowsAffected == 0 {
-		return nil, fmt.Errorf("%s: %w", caller, storage.ErrWalletNotFound)
-	}
+	const caller = "storage.postgres.Wallets"
+
+	var rows []struct {
+		WalletID uint64
+		currencyModels.Currency
+		Balance uint64
+	}
+
+	result := s.db.WithContext(ctx).Raw(`
+		SELECT COALESCE(w.id, 0) AS wallet_id, c.*, COALESCE(w.balance, 0) AS balance
+		FROM currencies c
+		LEFT JOIN user_wallets w ON w.currency_id = c.id AND w.user_id = ?
+		WHERE c.code <> ? AND (c.enabled OR w.balance > 0)
+		ORDER BY c.code`,
+		user.ID, baseCurrencyCode,
+	).Scan(&rows)
 	if result.Error != nil {
 		return nil, fmt.Errorf("%s: %w", caller, result.Error)
 	}
 
+	userWallets := make([]currencyModels.UserWallet, 0, len(rows))
+	for _, row := range rows {
+		userWallets = append(userWallets, currencyModels.UserWallet{
+			ID:         row.WalletID,
+			UserID:     user.ID,
+			CurrencyID: row.Currency.ID,
+			Currency:   row.Currency,
+			Balance:    row.Balance,
+		})
+	}
+
 	return userWallets, nil
 }
 
-func (s *Storage) updateUserBalance(ctx context.Context, user authModels.User, newBalanceAmount uint64) error {
+func (s *Storage) updateUserBalance(ctx context.Context, user authModels.User, txType ledgerModels.TransactionType, amount uint64, notify outboxModels.Notify) (uint64, error) {
 	const caller = "storage.postgres.updateUserBalance"
 
 	ctxTx := s.db.WithContext(ctx).Begin()
@@ -354,34 +655,239 @@ func (s *Storage) updateUserBalance(ctx context.Context, user authModels.User, n
 		}
 	}()
 
-	user.Balance = newBalanceAmount
-	result := ctxTx.Save(&user)
-	if result.Error != nil {
+	baseCurrency, err := getCurrency(ctxTx, baseCurrencyCode)
+	if err != nil {
 		ctxTx.Rollback()
-		return fmt.Errorf("%s: %w", caller, result.Error)
+		return 0, fmt.Errorf("%s: %w", caller, err)
+	}
+
+	if err := lockActiveUser(ctxTx, &user); err != nil {
+		ctxTx.Rollback()
+		return 0, fmt.Errorf("%s: %w", caller, err)
+	}
+
+	if txType == ledgerModels.TransactionWithdrawal && user.Balance < amount {
+		ctxTx.Rollback()
+		return 0, fmt.Errorf("%s: %w", caller, storage.ErrNotEnoughMoney)
+	}
+
+	userCash, err := userLedgerAccount(ctxTx, user, baseCurrency, ledgerModels.AccountUserCash)
+	if err != nil {
+		ctxTx.Rollback()
+		return 0, fmt.Errorf("%s: %w", caller, err)
+	}
+
+	external, err := systemLedgerAccount(ctxTx, baseCurrency, ledgerModels.AccountExternal)
+	if err != nil {
+		ctxTx.Rollback()
+		return 0, fmt.Errorf("%s: %w", caller, err)
+	}
+
+	postings := []ledgerModels.Posting{
+		{Account: external, Direction: ledgerModels.Debit, Amount: amount},
+		{Account: userCash, Direction: ledgerModels.Credit, Amount: amount},
+	}
+	delta := int64(amount)
+	if txType == ledgerModels.TransactionWithdrawal {
+		postings = []ledgerModels.Posting{
+			{Account: userCash, Direction: ledgerModels.Debit, Amount: amount},
+			{Account: external, Direction: ledgerModels.Credit, Amount: amount},
+		}
+		delta = -delta
+	}
+
+	if _, err := postTransaction(ctxTx, &user.ID, txType, postings); err != nil {
+		ctxTx.Rollback()
+		return 0, fmt.Errorf("%s: %w", caller, err)
+	}
+
+	if err := addUserBalance(ctxTx, &user, delta); err != nil {
+		ctxTx.Rollback()
+		return 0, fmt.Errorf("%s: %w", caller, err)
+	}
+
+	if err := enqueueOutbox(ctxTx, notify, money.New(int64(user.Balance), baseCurrencyCode)); err != nil {
+		ctxTx.Rollback()
+		return 0, fmt.Errorf("%s: %w", caller, err)
 	}
 
 	if err := ctxTx.Commit().Error; err != nil {
 		ctxTx.Rollback()
-		return fmt.Errorf("%s: %w", caller, err)
+		return 0, fmt.Errorf("%s: %w", caller, err)
 	}
-	return nil
+	return user.Balance, nil
 }
 
-func (s *Storage) Deposit(ctx context.Context, user authModels.User, newBalanceAmount uint64) error {
+func (s *Storage) Deposit(ctx context.Context, user authModels.User, amount money.Money, notify outboxModels.Notify) (money.Money, error) {
 	const caller = "storage.postgres.Deposit"
-	if err := s.updateUserBalance(ctx, user, newBalanceAmount); err != nil {
-		return fmt.Errorf("%s: %w", caller, err)
+	units, err := minorUnits(amount, baseCurrencyCode)
+	if err != nil {
+		return money.Money{}, fmt.Errorf("%s: %w", caller, err)
 	}
-	return nil
+	newBalance, err := s.updateUserBalance(ctx, user, ledgerModels.TransactionDeposit, units, notify)
+	if err != nil {
+		return money.Money{}, fmt.Errorf("%s: %w", caller, err)
+	}
+	return money.New(int64(newBalance), baseCurrencyCode), nil
 }
 
-func (s *Storage) Withdraw(ctx context.Context, user authModels.User, newBalanceAmount uint64) error {
+func (s *Storage) Withdraw(ctx context.Context, user authModels.User, amount money.Money, notify outboxModels.Notify) (money.Money, error) {
 	const caller = "storage.postgres.Withdraw"
-	if err := s.updateUserBalance(ctx, user, newBalanceAmount); err != nil {
-		return fmt.Errorf("%s: %w", caller, err)
+	units, err := minorUnits(amount, baseCurrencyCode)
+	if err != nil {
+		return money.Money{}, fmt.Errorf("%s: %w", caller, err)
 	}
-	return nil
+	newBalance, err := s.updateUserBalance(ctx, user, ledgerModels.TransactionWithdrawal, units, notify)
+	if err != nil {
+		return money.Money{}, fmt.Errorf("%s: %w", caller, err)
+	}
+	return money.New(int64(newBalance), baseCurrencyCode), nil
+}
+
+// Transfer moves amount from sender to recipient. Base currency amounts move
+// cash, others move the wallet balance in that currency. It returns the
+// sender's new balance.
+func (s *Storage) Transfer(ctx context.Context, sender, recipient authModels.User, amount money.Money, notify outboxModels.Notify) (money.Money, error) {
+	const caller = "storage.postgres.Transfer"
+
+	currencyCode := amount.Currency()
+	units, err := minorUnits(amount, currencyCode)
+	if err != nil {
+		return money.Money{}, fmt.Errorf("%s: %w", caller, err)
+	}
+
+	newBalance, err := s.transfer(ctx, sender, recipient, currencyCode, units, notify)
+	if err != nil {
+		return money.Money{}, fmt.Errorf("%s: %w", caller, err)
+	}
+
+	return money.New(int64(newBalance), currencyCode), nil
+}
+
+func (s *Storage) transfer(ctx context.Context, sender, recipient authModels.User, currencyCode string, amount uint64, notify outboxModels.Notify) (uint64, error) {
+	const caller = "storage.postgres.transfer"
+
+	ctxTx := s.db.WithContext(ctx).Begin()
+	defer func() {
+		if err := recover(); err != nil {
+			ctxTx.Rollback()
+		}
+	}()
+
+	if err := lockUsers(ctxTx, &sender, &recipient); err != nil {
+		ctxTx.Rollback()
+		return 0, fmt.Errorf("%s: %w", caller, err)
+	}
+
+	kind := ledgerModels.AccountUserWallet
+	if currencyCode == baseCurrencyCode {
+		kind = ledgerModels.AccountUserCash
+	}
+
+	currency, err := getEnabledCurrency(ctxTx, currencyCode)
+	if err != nil {
+		ctxTx.Rollback()
+		return 0, fmt.Errorf("%s: %w", caller, err)
+	}
+
+	senderAccount, err := userLedgerAccount(ctxTx, sender, currency, kind)
+	if err != nil {
+		ctxTx.Rollback()
+		return 0, fmt.Errorf("%s: %w", caller, err)
+	}
+
+	recipientAccount, err := userLedgerAccount(ctxTx, recipient, currency, kind)
+	if err != nil {
+		ctxTx.Rollback()
+		return 0, fmt.Errorf("%s: %w", caller, err)
+	}
+
+	postings := []ledgerModels.Posting{
+		{Account: senderAccount, Direction: ledgerModels.Debit, Amount: amount},
+		{Account: recipientAccount, Direction: ledgerModels.Credit, Amount: amount},
+	}
+	if _, err := postTransaction(ctxTx, &sender.ID, ledgerModels.TransactionTransfer, postings); err != nil {
+		ctxTx.Rollback()
+		return 0, fmt.Errorf("%s: %w", caller, err)
+	}
+
+	var newBalance uint64
+	if kind == ledgerModels.AccountUserCash {
+		newBalance, err = transferCash(ctxTx, sender, recipient, amount)
+	} else {
+		newBalance, err = transferWallet(ctxTx, sender, recipient, currency, amount)
+	}
+	if err != nil {
+		ctxTx.Rollback()
+		return 0, fmt.Errorf("%s: %w", caller, err)
+	}
+
+	if err := enqueueOutbox(ctxTx, notify, money.New(int64(newBalance), currencyCode)); err != nil {
+		ctxTx.Rollback()
+		return 0, fmt.Errorf("%s: %w", caller, err)
+	}
+
+	if err := ctxTx.Commit().Error; err != nil {
+		ctxTx.Rollback()
+		return 0, fmt.Errorf("%s: %w", caller, err)
+	}
+
+	return newBalance, nil
+}
+
+// transferCash updates both users in id order so that opposite transfers
+// between the same users cannot deadlock.
+func transferCash(ctxTx *gorm.DB, sender, recipient authModels.User, amount uint64) (uint64, error) {
+	const caller = "storage.postgres.transferCash"
+
+	updates := []func() error{
+		func() error { return addUserBalance(ctxTx, &sender, -int64(amount)) },
+		func() error { return addUserBalance(ctxTx, &recipient, int64(amount)) },
+	}
+	if recipient.ID < sender.ID {
+		updates[0], updates[1] = updates[1], updates[0]
+	}
+
+	for _, update := range updates {
+		if err := update(); err != nil {
+			return 0, fmt.Errorf("%s: %w", caller, err)
+		}
+	}
+
+	return sender.Balance, nil
+}
+
+func transferWallet(ctxTx *gorm.DB, sender, recipient authModels.User, currency currencyModels.Currency, amount uint64) (uint64, error) {
+	const caller = "storage.postgres.transferWallet"
+
+	senderWallet, err := lockWallet(ctxTx, sender, currency)
+	if errors.Is(err, storage.ErrWalletNotFound) {
+		return 0, fmt.Errorf("%s: %w", caller, storage.ErrNotEnoughMoney)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", caller, err)
+	}
+
+	recipientWallet, err := lockOrCreateWallet(ctxTx, recipient, currency)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", caller, err)
+	}
+
+	updates := []func() error{
+		func() error { return addWalletBalance(ctxTx, &senderWallet, -int64(amount)) },
+		func() error { return addWalletBalance(ctxTx, &recipientWallet, int64(amount)) },
+	}
+	if recipientWallet.ID < senderWallet.ID {
+		updates[0], updates[1] = updates[1], updates[0]
+	}
+
+	for _, update := range updates {
+		if err := update(); err != nil {
+			return 0, fmt.Errorf("%s: %w", caller, err)
+		}
+	}
+
+	return senderWallet.Balance, nil
 }
 
 func (s *Storage) Stop() error {
@@ -0,0 +1,121 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	authModels "github.com/tizzhh/micro-banking/internal/domain/auth/models"
+	outboxModels "github.com/tizzhh/micro-banking/internal/domain/outbox/models"
+	"github.com/tizzhh/micro-banking/internal/storage"
+)
+
+// UpdateProfile changes the profile fields set in update of the user with id
+// and returns the updated user.
+func (s *Storage) UpdateProfile(ctx context.Context, id uint64, update authModels.ProfileUpdate) (authModels.User, error) {
+	const caller = "storage.postgres.UpdateProfile"
+
+	columns := map[string]any{}
+	if update.FirstName != nil {
+		columns["first_name"] = *update.FirstName
+	}
+	if update.LastName != nil {
+		columns["last_name"] = *update.LastName
+	}
+	if update.Age != nil {
+		columns["age"] = *update.Age
+	}
+	if update.PhoneNumber != nil {
+		columns["phone_number"] = *update.PhoneNumber
+	}
+
+	dbCtx := s.db.WithContext(ctx)
+
+	var user authModels.User
+	result := dbCtx.Model(&user).Clauses(clause.Returning{}).Where("id = ?", id).Updates(columns)
+	if result.Error != nil {
+		return authModels.User{}, fmt.Errorf("%s: %w", caller, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return authModels.User{}, fmt.Errorf("%s: %w", caller, storage.ErrUserNotFound)
+	}
+
+	if err := loadRoles(dbCtx, &user); err != nil {
+		return authModels.User{}, fmt.Errorf("%s: %w", caller, err)
+	}
+
+	return user, nil
+}
+
+// ChangeEmail sets the email of the user with id to newEmail, to be verified
+// again, and enqueues the messages from notify for the updated user.
+func (s *Storage) ChangeEmail(ctx context.Context, id uint64, newEmail string, notify outboxModels.NotifyUser) (authModels.User, error) {
+	const caller = "storage.postgres.ChangeEmail"
+
+	ctxTx := s.db.WithContext(ctx).Begin()
+	defer func() {
+		if err := recover(); err != nil {
+			ctxTx.Rollback()
+		}
+	}()
+
+	if err := ctxTx.Error; err != nil {
+		return authModels.User{}, fmt.Errorf("%s: %w", caller, err)
+	}
+
+	var user authModels.User
+	result := ctxTx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&user, id)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		ctxTx.Rollback()
+		return authModels.User{}, fmt.Errorf("%s: %w", caller, storage.ErrUserNotFound)
+	}
+	if result.Error != nil {
+		ctxTx.Rollback()
+		return authModels.User{}, fmt.Errorf("%s: %w", caller, result.Error)
+	}
+
+	result = ctxTx.Model(&user).Updates(map[string]any{
+		"email":  newEmail,
+		"status": authModels.UserStatusPendingVerification,
+	})
+	var psqlErr *pgconn.PgError
+	if errors.As(result.Error, &psqlErr) && psqlErr.Code == pgerrcode.UniqueViolation {
+		ctxTx.Rollback()
+		return authModels.User{}, fmt.Errorf("%s: %w", caller, storage.ErrUserAlreadyExists)
+	}
+	if result.Error != nil {
+		ctxTx.Rollback()
+		return authModels.User{}, fmt.Errorf("%s: %w", caller, result.Error)
+	}
+	user.Email = newEmail
+	user.Status = authModels.UserStatusPendingVerification
+
+	if err := loadRoles(ctxTx, &user); err != nil {
+		ctxTx.Rollback()
+		return authModels.User{}, fmt.Errorf("%s: %w", caller, err)
+	}
+
+	if notify != nil {
+		messages, err := notify(user)
+		if err != nil {
+			ctxTx.Rollback()
+			return authModels.User{}, fmt.Errorf("%s: %w", caller, err)
+		}
+		if err := enqueueMessages(ctxTx, messages); err != nil {
+			ctxTx.Rollback()
+			return authModels.User{}, fmt.Errorf("%s: %w", caller, err)
+		}
+	}
+
+	if err := ctxTx.Commit().Error; err != nil {
+		ctxTx.Rollback()
+		return authModels.User{}, fmt.Errorf("%s: %w", caller, err)
+	}
+
+	return user, nil
+}
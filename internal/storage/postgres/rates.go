@@ -0,0 +1,59 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	currencyModels "github.com/tizzhh/micro-banking/internal/domain/currency/models"
+	"github.com/tizzhh/micro-banking/pkg/money"
+)
+
+// SaveRate records rate, in units of the currency with currencyCode per USD,
+// as fetched from source at fetchedAt.
+func (s *Storage) SaveRate(ctx context.Context, currencyCode string, rate money.Rate, source string, fetchedAt time.Time) error {
+	const caller = "storage.postgres.SaveRate"
+
+	dbCtx := s.db.WithContext(ctx)
+
+	currency, err := getCurrency(dbCtx, currencyCode)
+	if err != nil {
+		return fmt.Errorf("%s: %w", caller, err)
+	}
+
+	exchangeRate := currencyModels.ExchangeRate{
+		CurrencyID: currency.ID,
+		Rate:       rate.String(),
+		Source:     source,
+		FetchedAt:  fetchedAt,
+	}
+	if err := dbCtx.Create(&exchangeRate).Error; err != nil {
+		return fmt.Errorf("%s: %w", caller, err)
+	}
+
+	return nil
+}
+
+// Rates returns the rates of the currency with currencyCode fetched in
+// [from, to), oldest first.
+func (s *Storage) Rates(ctx context.Context, currencyCode string, from, to time.Time) ([]currencyModels.ExchangeRate, error) {
+	const caller = "storage.postgres.Rates"
+
+	dbCtx := s.db.WithContext(ctx)
+
+	currency, err := getCurrency(dbCtx, currencyCode)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", caller, err)
+	}
+
+	var rates []currencyModels.ExchangeRate
+	result := dbCtx.
+		Where("currency_id = ? AND fetched_at >= ? AND fetched_at < ?", currency.ID, from, to).
+		Order("fetched_at, id").
+		Find(&rates)
+	if result.Error != nil {
+		return nil, fmt.Errorf("%s: %w", caller, result.Error)
+	}
+
+	return rates, nil
+}
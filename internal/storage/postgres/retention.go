@@ -0,0 +1,92 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	authModels "github.com/tizzhh/micro-banking/internal/domain/auth/models"
+	outboxModels "github.com/tizzhh/micro-banking/internal/domain/outbox/models"
+)
+
+// AnonymizeClosedUsers erases the personal data of up to limit accounts
+// closed before closedBefore, together with their second factors and mails,
+// and returns how many were anonymized. Ids, balances, wallets and the ledger
+// are kept.
+func (s *Storage) AnonymizeClosedUsers(ctx context.Context, closedBefore time.Time, limit int) (int, error) {
+	const caller = "storage.postgres.AnonymizeClosedUsers"
+
+	ctxTx := s.db.WithContext(ctx).Begin()
+	defer func() {
+		if err := recover(); err != nil {
+			ctxTx.Rollback()
+		}
+	}()
+
+	if err := ctxTx.Error; err != nil {
+		return 0, fmt.Errorf("%s: %w", caller, err)
+	}
+
+	var anonymized []struct {
+		ID       uint64
+		Email    string
+		ClosedAt time.Time
+	}
+	result := ctxTx.Raw(`
+		WITH due AS (
+			SELECT id, email, closed_at FROM users
+			WHERE status = ? AND anonymized_at IS NULL AND closed_at < ?
+			ORDER BY id
+			LIMIT ?
+			FOR UPDATE SKIP LOCKED
+		)
+		UPDATE users u SET
+			email = 'anonymized-' || u.id || '@anonymized.invalid',
+			pass_hash = '',
+			first_name = '',
+			last_name = '',
+			phone_number = '',
+			age = 0,
+			anonymized_at = NOW()
+		FROM due
+		WHERE u.id = due.id
+		RETURNING due.id, due.email, due.closed_at`,
+		authModels.UserStatusClosed, closedBefore, limit,
+	).Scan(&anonymized)
+	if result.Error != nil {
+		ctxTx.Rollback()
+		return 0, fmt.Errorf("%s: %w", caller, result.Error)
+	}
+	if len(anonymized) == 0 {
+		ctxTx.Rollback()
+		return 0, nil
+	}
+
+	ids := make([]uint64, 0, len(anonymized))
+	for _, user := range anonymized {
+		ids = append(ids, user.ID)
+	}
+
+	for _, model := range []any{&authModels.MFASecret{}, &authModels.RecoveryCode{}} {
+		if result = ctxTx.Where("user_id IN ?", ids).Delete(model); result.Error != nil {
+			ctxTx.Rollback()
+			return 0, fmt.Errorf("%s: %w", caller, result.Error)
+		}
+	}
+	// the email may belong to another account by now, which gets mails only
+	// after the closure
+	for _, user := range anonymized {
+		result = ctxTx.Where("email_addr = ? AND created_at <= ?", user.Email, user.ClosedAt).Delete(&outboxModels.Message{})
+		if result.Error != nil {
+			ctxTx.Rollback()
+			return 0, fmt.Errorf("%s: %w", caller, result.Error)
+		}
+	}
+
+	if err := ctxTx.Commit().Error; err != nil {
+		ctxTx.Rollback()
+		return 0, fmt.Errorf("%s: %w", caller, err)
+	}
+
+	return len(anonymized), nil
+}
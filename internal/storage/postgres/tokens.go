@@ -0,0 +1,142 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	authModels "github.com/tizzhh/micro-banking/internal/domain/auth/models"
+	"github.com/tizzhh/micro-banking/internal/storage"
+)
+
+func (s *Storage) SaveRefreshToken(ctx context.Context, token authModels.RefreshToken) error {
+	const caller = "storage.postgres.SaveRefreshToken"
+
+	if err := s.db.WithContext(ctx).Create(&token).Error; err != nil {
+		return fmt.Errorf("%s: %w", caller, err)
+	}
+
+	return nil
+}
+
+// RotateRefreshToken replaces the token with tokenHash by next, which joins
+// its family, and returns the owner. Presenting a token that was already
+// rotated or revoked revokes the whole family.
+func (s *Storage) RotateRefreshToken(ctx context.Context, tokenHash string, next authModels.RefreshToken) (authModels.User, error) {
+	const caller = "storage.postgres.RotateRefreshToken"
+
+	ctxTx := s.db.WithContext(ctx).Begin()
+	defer func() {
+		if err := recover(); err != nil {
+			ctxTx.Rollback()
+		}
+	}()
+
+	if err := ctxTx.Error; err != nil {
+		return authModels.User{}, fmt.Errorf("%s: %w", caller, err)
+	}
+
+	var current authModels.RefreshToken
+	result := ctxTx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&current, "token_hash = ?", tokenHash)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		ctxTx.Rollback()
+		return authModels.User{}, fmt.Errorf("%s: %w", caller, storage.ErrRefreshTokenNotFound)
+	}
+	if result.Error != nil {
+		ctxTx.Rollback()
+		return authModels.User{}, fmt.Errorf("%s: %w", caller, result.Error)
+	}
+
+	if current.RevokedAt != nil {
+		if err := revokeRefreshTokenFamily(ctxTx, current.FamilyID); err != nil {
+			ctxTx.Rollback()
+			return authModels.User{}, fmt.Errorf("%s: %w", caller, err)
+		}
+		if err := ctxTx.Commit().Error; err != nil {
+			ctxTx.Rollback()
+			return authModels.User{}, fmt.Errorf("%s: %w", caller, err)
+		}
+		return authModels.User{}, fmt.Errorf("%s: %w", caller, storage.ErrRefreshTokenReused)
+	}
+
+	if time.Now().After(current.ExpiresAt) {
+		ctxTx.Rollback()
+		return authModels.User{}, fmt.Errorf("%s: %w", caller, storage.ErrRefreshTokenExpired)
+	}
+
+	next.UserID = current.UserID
+	next.FamilyID = current.FamilyID
+	if err := ctxTx.Create(&next).Error; err != nil {
+		ctxTx.Rollback()
+		return authModels.User{}, fmt.Errorf("%s: %w", caller, err)
+	}
+
+	result = ctxTx.Model(&current).Updates(map[string]any{
+		"revoked_at":  time.Now(),
+		"replaced_by": next.ID,
+	})
+	if result.Error != nil {
+		ctxTx.Rollback()
+		return authModels.User{}, fmt.Errorf("%s: %w", caller, result.Error)
+	}
+
+	var user authModels.User
+	result = ctxTx.First(&user, current.UserID)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		ctxTx.Rollback()
+		return authModels.User{}, fmt.Errorf("%s: %w", caller, storage.ErrUserNotFound)
+	}
+	if result.Error != nil {
+		ctxTx.Rollback()
+		return authModels.User{}, fmt.Errorf("%s: %w", caller, result.Error)
+	}
+
+	if err := loadRoles(ctxTx, &user); err != nil {
+		ctxTx.Rollback()
+		return authModels.User{}, fmt.Errorf("%s: %w", caller, err)
+	}
+
+	if err := ctxTx.Commit().Error; err != nil {
+		ctxTx.Rollback()
+		return authModels.User{}, fmt.Errorf("%s: %w", caller, err)
+	}
+
+	return user, nil
+}
+
+// RevokeRefreshToken revokes the family of the user's token with tokenHash.
+func (s *Storage) RevokeRefreshToken(ctx context.Context, userID uint64, tokenHash string) error {
+	const caller = "storage.postgres.RevokeRefreshToken"
+
+	var token authModels.RefreshToken
+	result := s.db.WithContext(ctx).First(&token, "token_hash = ? AND user_id = ?", tokenHash, userID)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return fmt.Errorf("%s: %w", caller, storage.ErrRefreshTokenNotFound)
+	}
+	if result.Error != nil {
+		return fmt.Errorf("%s: %w", caller, result.Error)
+	}
+
+	if err := revokeRefreshTokenFamily(s.db.WithContext(ctx), token.FamilyID); err != nil {
+		return fmt.Errorf("%s: %w", caller, err)
+	}
+
+	return nil
+}
+
+func revokeRefreshTokenFamily(db *gorm.DB, familyID string) error {
+	const caller = "storage.postgres.revokeRefreshTokenFamily"
+
+	result := db.Model(&authModels.RefreshToken{}).
+		Where("family_id = ? AND revoked_at IS NULL", familyID).
+		Update("revoked_at", time.Now())
+	if result.Error != nil {
+		return fmt.Errorf("%s: %w", caller, result.Error)
+	}
+
+	return nil
+}
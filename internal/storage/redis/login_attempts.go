@@ -0,0 +1,73 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	loginFailuresPrefix = "login-failures:"
+	loginBlockPrefix    = "login-block:"
+)
+
+// AddLoginFailure counts a failed login for key, e.g. an account or a client
+// IP, and returns the number of failures since the first one within window.
+func (c *Cache) AddLoginFailure(ctx context.Context, key string, window time.Duration) (int64, error) {
+	const caller = "storage.redis.AddLoginFailure"
+
+	// both run in one transaction, so the counter never lacks an expiry
+	var failures *redis.IntCmd
+	_, err := c.rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		failures = pipe.Incr(ctx, loginFailuresPrefix+key)
+		pipe.ExpireNX(ctx, loginFailuresPrefix+key, window)
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", caller, err)
+	}
+
+	return failures.Val(), nil
+}
+
+// BlockLogins blocks logins for key for d.
+func (c *Cache) BlockLogins(ctx context.Context, key string, d time.Duration) error {
+	const caller = "storage.redis.BlockLogins"
+
+	if err := c.rdb.Set(ctx, loginBlockPrefix+key, 1, d).Err(); err != nil {
+		return fmt.Errorf("%s: %w", caller, err)
+	}
+
+	return nil
+}
+
+// LoginsBlockedFor returns how long logins stay blocked for any of keys, zero
+// if they are not.
+func (c *Cache) LoginsBlockedFor(ctx context.Context, keys ...string) (time.Duration, error) {
+	const caller = "storage.redis.LoginsBlockedFor"
+
+	var blockedFor time.Duration
+	for _, key := range keys {
+		ttl, err := c.rdb.PTTL(ctx, loginBlockPrefix+key).Result()
+		if err != nil {
+			return 0, fmt.Errorf("%s: %w", caller, err)
+		}
+		// missing keys have a negative ttl
+		blockedFor = max(blockedFor, ttl)
+	}
+
+	return blockedFor, nil
+}
+
+// ResetLoginFailures forgets the failed logins of key and lifts its block.
+func (c *Cache) ResetLoginFailures(ctx context.Context, key string) error {
+	const caller = "storage.redis.ResetLoginFailures"
+
+	if err := c.rdb.Del(ctx, loginFailuresPrefix+key, loginBlockPrefix+key).Err(); err != nil {
+		return fmt.Errorf("%s: %w", caller, err)
+	}
+
+	return nil
+}
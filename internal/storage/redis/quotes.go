@@ -0,0 +1,64 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/tizzhh/micro-banking/internal/domain/currency/models"
+	"github.com/tizzhh/micro-banking/internal/storage"
+	"github.com/tizzhh/micro-banking/pkg/logger/sl"
+)
+
+const quotePrefix = "quote:"
+
+// SaveQuote holds quote until it expires.
+func (c *Cache) SaveQuote(ctx context.Context, quote models.Quote) error {
+	const caller = "storage.redis.SaveQuote"
+
+	log := sl.AddCaller(c.log, caller)
+
+	ttl := time.Until(quote.ExpiresAt)
+	if ttl <= 0 {
+		return fmt.Errorf("%s: %w", caller, storage.ErrQuoteNotFound)
+	}
+
+	value, err := json.Marshal(quote)
+	if err != nil {
+		return fmt.Errorf("%s: %w", caller, err)
+	}
+
+	if err := c.rdb.Set(ctx, quotePrefix+quote.ID, value, ttl).Err(); err != nil {
+		log.Error("failed to save quote", sl.Error(err))
+		return fmt.Errorf("%s: %w", caller, err)
+	}
+
+	return nil
+}
+
+// TakeQuote returns the quote with id and removes it, so that it is used at
+// most once. Expired quotes are not found.
+func (c *Cache) TakeQuote(ctx context.Context, id string) (models.Quote, error) {
+	const caller = "storage.redis.TakeQuote"
+
+	log := sl.AddCaller(c.log, caller)
+
+	value, err := c.rdb.GetDel(ctx, quotePrefix+id).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return models.Quote{}, fmt.Errorf("%s: %w", caller, storage.ErrQuoteNotFound)
+	}
+	if err != nil {
+		log.Error("failed to take quote", sl.Error(err))
+		return models.Quote{}, fmt.Errorf("%s: %w", caller, err)
+	}
+
+	var quote models.Quote
+	if err := json.Unmarshal(value, &quote); err != nil {
+		return models.Quote{}, fmt.Errorf("%s: %w", caller, err)
+	}
+
+	return quote, nil
+}
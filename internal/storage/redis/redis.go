@@ -5,7 +5,6 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
-	"strconv"
 	"sync"
 	"time"
 
@@ -13,6 +12,7 @@ import (
 	"github.com/tizzhh/micro-banking/internal/config"
 	"github.com/tizzhh/micro-banking/internal/storage"
 	"github.com/tizzhh/micro-banking/pkg/logger/sl"
+	"github.com/tizzhh/micro-banking/pkg/money"
 )
 
 type Cache struct {
@@ -36,7 +36,8 @@ func New(log *slog.Logger) (*Cache, error) {
 	cfg := config.Get()
 
 	return &Cache{
-		log: log,
+		keyTTL: cfg.Redis.KeyTTL,
+		log:    log,
 		rdb: redis.NewClient(&redis.Options{
 			Addr:     fmt.Sprintf("%s:%d", cfg.Redis.Host, cfg.Redis.Port),
 			Password: cfg.Redis.Password,
@@ -48,7 +49,7 @@ func (c *Cache) MustPing(ctx context.Context) error {
 	return c.rdb.Ping(ctx).Err()
 }
 
-func (c *Cache) GetCurrencyRate(ctx context.Context, currencyCode string) (float32, error) {
+func (c *Cache) GetCurrencyRate(ctx context.Context, currencyCode string) (money.Rate, error) {
 	const caller = "storage.redis.GetCurrencyRate"
 
 	log := sl.AddCaller(c.log, caller)
@@ -59,27 +60,27 @@ func (c *Cache) GetCurrencyRate(ctx context.Context, currencyCode string) (float
 	if err != nil {
 		if errors.Is(err, redis.Nil) {
 			log.Warn("currency key not found", slog.String("currency", currencyCode))
-			return 0, fmt.Errorf("%s: %w", caller, storage.ErrCurrencyKeyNotFound)
+			return money.Rate{}, fmt.Errorf("%s: %w", caller, storage.ErrCurrencyKeyNotFound)
 		}
 		log.Error("failed to get currency rate", sl.Error(err))
-		return 0, fmt.Errorf("%s: %w", caller, err)
+		return money.Rate{}, fmt.Errorf("%s: %w", caller, err)
 	}
 
-	rate, err := strconv.ParseFloat(strRate, 32)
+	rate, err := money.ParseRate(strRate)
 	if err != nil {
-		log.Error("failed to convert rate to float", slog.String("currency", currencyCode), slog.String("value", strRate))
-		return 0, fmt.Errorf("%s: %w", caller, err)
+		log.Error("failed to parse rate", slog.String("currency", currencyCode), slog.String("value", strRate))
+		return money.Rate{}, fmt.Errorf("%s: %w", caller, err)
 	}
 
-	return float32(rate), nil
+	return rate, nil
 }
 
-func (c *Cache) SetCurrencyRate(ctx context.Context, currencyCode string, rate float32) error {
+func (c *Cache) SetCurrencyRate(ctx context.Context, currencyCode string, rate money.Rate) error {
 	const caller = "storage.redis.SetCurrencyRate"
 
 	log := sl.AddCaller(c.log, caller)
 
-	if err := c.rdb.Set(ctx, currencyCode, rate, c.keyTTL).Err(); err != nil {
+	if err := c.rdb.Set(ctx, currencyCode, rate.String(), c.keyTTL).Err(); err != nil {
 		log.Error("failed to set key", sl.Error(err))
 		return fmt.Errorf("%s: %w", caller, err)
 	}
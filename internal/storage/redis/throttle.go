@@ -0,0 +1,22 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+const throttlePrefix = "throttle:"
+
+// Throttle reports whether the action named by key may run now and, if so,
+// blocks it for interval.
+func (c *Cache) Throttle(ctx context.Context, key string, interval time.Duration) (bool, error) {
+	const caller = "storage.redis.Throttle"
+
+	allowed, err := c.rdb.SetNX(ctx, throttlePrefix+key, 1, interval).Result()
+	if err != nil {
+		return false, fmt.Errorf("%s: %w", caller, err)
+	}
+
+	return allowed, nil
+}
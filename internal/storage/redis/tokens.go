@@ -0,0 +1,90 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/tizzhh/micro-banking/pkg/jwt"
+	"github.com/tizzhh/micro-banking/pkg/logger/sl"
+)
+
+const (
+	revokedTokenPrefix = "revoked-jti:"
+	// revokedUserPrefix keys the unix time up to which the tokens issued to
+	// a user are revoked.
+	revokedUserPrefix = "revoked-user:"
+)
+
+// RevokeToken puts the token id on the revocation list for ttl, which should
+// cover the rest of the token's lifetime.
+func (c *Cache) RevokeToken(ctx context.Context, jti string, ttl time.Duration) error {
+	const caller = "storage.redis.RevokeToken"
+
+	log := sl.AddCaller(c.log, caller)
+
+	if err := c.rdb.Set(ctx, revokedTokenPrefix+jti, 1, ttl).Err(); err != nil {
+		log.Error("failed to revoke token", sl.Error(err))
+		return fmt.Errorf("%s: %w", caller, err)
+	}
+
+	return nil
+}
+
+// RevokeUserTokens revokes every token issued to the user up to now for ttl,
+// which should cover the lifetime of the tokens.
+func (c *Cache) RevokeUserTokens(ctx context.Context, userID uint64, ttl time.Duration) error {
+	const caller = "storage.redis.RevokeUserTokens"
+
+	log := sl.AddCaller(c.log, caller)
+
+	key := revokedUserPrefix + strconv.FormatUint(userID, 10)
+	if err := c.rdb.Set(ctx, key, time.Now().Unix(), ttl).Err(); err != nil {
+		log.Error("failed to revoke user tokens", sl.Error(err))
+		return fmt.Errorf("%s: %w", caller, err)
+	}
+
+	return nil
+}
+
+// IsTokenRevoked reports whether the token was revoked by its id or together
+// with the other tokens of its user.
+func (c *Cache) IsTokenRevoked(ctx context.Context, claims *jwt.Claims) (bool, error) {
+	const caller = "storage.redis.IsTokenRevoked"
+
+	revoked, err := c.rdb.Exists(ctx, revokedTokenPrefix+claims.ID).Result()
+	if err != nil {
+		return false, fmt.Errorf("%s: %w", caller, err)
+	}
+	if revoked > 0 {
+		return true, nil
+	}
+
+	revokedUntil, err := c.rdb.Get(ctx, revokedUserPrefix+claims.Subject).Int64()
+	if errors.Is(err, redis.Nil) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("%s: %w", caller, err)
+	}
+
+	// issue times have a precision of a second, so tokens issued in the
+	// second of the revocation are revoked as well
+	return claims.IssuedAt == nil || claims.IssuedAt.Unix() <= revokedUntil, nil
+}
+
+// ConsumeToken puts the token id on the revocation list for ttl and reports
+// whether it was not on it yet, which makes tokens usable only once.
+func (c *Cache) ConsumeToken(ctx context.Context, jti string, ttl time.Duration) (bool, error) {
+	const caller = "storage.redis.ConsumeToken"
+
+	consumed, err := c.rdb.SetNX(ctx, revokedTokenPrefix+jti, 1, ttl).Result()
+	if err != nil {
+		return false, fmt.Errorf("%s: %w", caller, err)
+	}
+
+	return consumed, nil
+}
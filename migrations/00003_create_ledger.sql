@@ -0,0 +1,122 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS ledger_accounts (
+    id BIGSERIAL PRIMARY KEY UNIQUE NOT NULL,
+    user_id BIGINT REFERENCES users (id) ON DELETE RESTRICT,
+    currency_id BIGINT NOT NULL REFERENCES currencies (id) ON DELETE RESTRICT,
+    kind VARCHAR(20) NOT NULL CHECK (kind IN ('user_cash', 'user_wallet', 'house_fx', 'external'))
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS unique_user_ledger_account
+    ON ledger_accounts (user_id, currency_id, kind) WHERE user_id IS NOT NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS unique_system_ledger_account
+    ON ledger_accounts (currency_id, kind) WHERE user_id IS NULL;
+
+CREATE TABLE IF NOT EXISTS transactions (
+    id BIGSERIAL PRIMARY KEY UNIQUE NOT NULL,
+    user_id BIGINT REFERENCES users (id) ON DELETE RESTRICT,
+    type VARCHAR(20) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS ledger_entries (
+    id BIGSERIAL PRIMARY KEY UNIQUE NOT NULL,
+    transaction_id BIGINT NOT NULL REFERENCES transactions (id) ON DELETE RESTRICT,
+    account_id BIGINT NOT NULL REFERENCES ledger_accounts (id) ON DELETE RESTRICT,
+    direction VARCHAR(6) NOT NULL CHECK (direction IN ('debit', 'credit')),
+    amount BIGINT NOT NULL CHECK (amount > 0),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS ledger_entries_transaction_id_idx ON ledger_entries (transaction_id);
+CREATE INDEX IF NOT EXISTS ledger_entries_account_id_idx ON ledger_entries (account_id);
+
+-- Balance of every ledger account: credits increase it, debits decrease it.
+CREATE OR REPLACE VIEW ledger_account_balances AS
+SELECT a.id AS account_id,
+       a.user_id,
+       a.currency_id,
+       a.kind,
+       COALESCE(SUM(CASE WHEN e.direction = 'credit' THEN e.amount ELSE -e.amount END), 0) AS balance
+FROM ledger_accounts a
+LEFT JOIN ledger_entries e ON e.account_id = a.id
+GROUP BY a.id;
+
+-- Users and wallets whose stored balance disagrees with the ledger.
+CREATE OR REPLACE VIEW ledger_mismatches AS
+SELECT u.id AS user_id,
+       c.id AS currency_id,
+       'user_cash' AS kind,
+       u.balance AS stored_balance,
+       COALESCE(b.balance, 0) AS ledger_balance
+FROM users u
+JOIN currencies c ON c.code = 'USD'
+LEFT JOIN ledger_account_balances b
+       ON b.user_id = u.id AND b.currency_id = c.id AND b.kind = 'user_cash'
+WHERE u.balance <> COALESCE(b.balance, 0)
+UNION ALL
+SELECT w.user_id,
+       w.currency_id,
+       'user_wallet' AS kind,
+       w.balance AS stored_balance,
+       COALESCE(b.balance, 0) AS ledger_balance
+FROM user_wallets w
+LEFT JOIN ledger_account_balances b
+       ON b.user_id = w.user_id AND b.currency_id = w.currency_id AND b.kind = 'user_wallet'
+WHERE w.balance <> COALESCE(b.balance, 0);
+
+-- Opening balances for rows that existed before the ledger.
+INSERT INTO ledger_accounts (user_id, currency_id, kind)
+SELECT NULL, id, 'external' FROM currencies
+ON CONFLICT DO NOTHING;
+
+INSERT INTO ledger_accounts (user_id, currency_id, kind)
+SELECT u.id, c.id, 'user_cash'
+FROM users u
+JOIN currencies c ON c.code = 'USD'
+ON CONFLICT DO NOTHING;
+
+INSERT INTO ledger_accounts (user_id, currency_id, kind)
+SELECT user_id, currency_id, 'user_wallet' FROM user_wallets
+ON CONFLICT DO NOTHING;
+
+INSERT INTO transactions (user_id, type)
+SELECT id, 'opening_balance' FROM users
+WHERE balance > 0 OR id IN (SELECT user_id FROM user_wallets WHERE balance > 0);
+
+INSERT INTO ledger_entries (transaction_id, account_id, direction, amount)
+SELECT t.id, ext.id, 'debit', u.balance
+FROM users u
+JOIN transactions t ON t.user_id = u.id AND t.type = 'opening_balance'
+JOIN currencies c ON c.code = 'USD'
+JOIN ledger_accounts ext ON ext.user_id IS NULL AND ext.currency_id = c.id AND ext.kind = 'external'
+WHERE u.balance > 0
+UNION ALL
+SELECT t.id, acc.id, 'credit', u.balance
+FROM users u
+JOIN transactions t ON t.user_id = u.id AND t.type = 'opening_balance'
+JOIN currencies c ON c.code = 'USD'
+JOIN ledger_accounts acc ON acc.user_id = u.id AND acc.currency_id = c.id AND acc.kind = 'user_cash'
+WHERE u.balance > 0
+UNION ALL
+SELECT t.id, ext.id, 'debit', w.balance
+FROM user_wallets w
+JOIN transactions t ON t.user_id = w.user_id AND t.type = 'opening_balance'
+JOIN ledger_accounts ext ON ext.user_id IS NULL AND ext.currency_id = w.currency_id AND ext.kind = 'external'
+WHERE w.balance > 0
+UNION ALL
+SELECT t.id, acc.id, 'credit', w.balance
+FROM user_wallets w
+JOIN transactions t ON t.user_id = w.user_id AND t.type = 'opening_balance'
+JOIN ledger_accounts acc ON acc.user_id = w.user_id AND acc.currency_id = w.currency_id AND acc.kind = 'user_wallet'
+WHERE w.balance > 0;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP VIEW IF EXISTS ledger_mismatches;
+DROP VIEW IF EXISTS ledger_account_balances;
+DROP TABLE ledger_entries CASCADE;
+DROP TABLE transactions CASCADE;
+DROP TABLE ledger_accounts CASCADE;
+-- +goose StatementEnd
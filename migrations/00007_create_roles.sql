@@ -0,0 +1,29 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS user_roles (
+    user_id BIGINT NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    role VARCHAR(20) NOT NULL CHECK (role IN ('admin', 'support')),
+    PRIMARY KEY (user_id, role)
+);
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS frozen BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE TABLE IF NOT EXISTS balance_adjustments (
+    id BIGSERIAL PRIMARY KEY UNIQUE NOT NULL,
+    transaction_id BIGINT NOT NULL REFERENCES transactions (id) ON DELETE RESTRICT,
+    user_id BIGINT NOT NULL REFERENCES users (id) ON DELETE RESTRICT,
+    admin_id BIGINT NOT NULL REFERENCES users (id) ON DELETE RESTRICT,
+    reason_code VARCHAR(20) NOT NULL CHECK (reason_code IN ('correction', 'chargeback', 'refund', 'goodwill', 'fraud')),
+    comment TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS balance_adjustments_user_id_idx ON balance_adjustments (user_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE balance_adjustments;
+ALTER TABLE users DROP COLUMN IF EXISTS frozen;
+DROP TABLE user_roles;
+-- +goose StatementEnd
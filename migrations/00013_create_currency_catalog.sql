@@ -0,0 +1,38 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE currencies ADD COLUMN IF NOT EXISTS name VARCHAR(100) NOT NULL DEFAULT '';
+ALTER TABLE currencies ADD COLUMN IF NOT EXISTS exponent SMALLINT NOT NULL DEFAULT 2
+    CHECK (exponent BETWEEN 0 AND 8);
+ALTER TABLE currencies ADD COLUMN IF NOT EXISTS enabled BOOLEAN NOT NULL DEFAULT TRUE;
+ALTER TABLE currencies ADD COLUMN IF NOT EXISTS tradable BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE currencies ADD COLUMN IF NOT EXISTS min_order BIGINT NOT NULL DEFAULT 0
+    CHECK (min_order >= 0);
+ALTER TABLE currencies ADD COLUMN IF NOT EXISTS max_order BIGINT NOT NULL DEFAULT 0
+    CHECK (max_order >= 0);
+ALTER TABLE currencies ADD CONSTRAINT currencies_order_limits
+    CHECK (max_order = 0 OR max_order >= min_order);
+
+UPDATE currencies SET name = 'US Dollar' WHERE code = 'USD';
+UPDATE currencies SET name = 'Euro', tradable = TRUE WHERE code = 'EUR';
+UPDATE currencies SET name = 'Russian Ruble', tradable = TRUE WHERE code = 'RUB';
+UPDATE currencies SET name = 'Chinese Yuan', tradable = TRUE WHERE code = 'CNY';
+
+-- wallets are created on first use, the base currency never had one in use
+DELETE FROM user_wallets w USING currencies c
+WHERE w.currency_id = c.id AND c.code = 'USD' AND w.balance = 0;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+INSERT INTO user_wallets (user_id, currency_id)
+SELECT u.id, c.id FROM users u CROSS JOIN currencies c
+ON CONFLICT DO NOTHING;
+
+ALTER TABLE currencies DROP CONSTRAINT IF EXISTS currencies_order_limits;
+ALTER TABLE currencies DROP COLUMN IF EXISTS max_order;
+ALTER TABLE currencies DROP COLUMN IF EXISTS min_order;
+ALTER TABLE currencies DROP COLUMN IF EXISTS tradable;
+ALTER TABLE currencies DROP COLUMN IF EXISTS enabled;
+ALTER TABLE currencies DROP COLUMN IF EXISTS exponent;
+ALTER TABLE currencies DROP COLUMN IF EXISTS name;
+-- +goose StatementEnd
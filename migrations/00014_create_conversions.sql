@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS conversions (
+    id BIGSERIAL PRIMARY KEY UNIQUE NOT NULL,
+    transaction_id BIGINT NOT NULL REFERENCES transactions (id) ON DELETE RESTRICT,
+    user_id BIGINT NOT NULL REFERENCES users (id) ON DELETE RESTRICT,
+    from_currency_id BIGINT NOT NULL REFERENCES currencies (id) ON DELETE RESTRICT,
+    to_currency_id BIGINT NOT NULL REFERENCES currencies (id) ON DELETE RESTRICT,
+    from_amount BIGINT NOT NULL CHECK (from_amount > 0),
+    to_amount BIGINT NOT NULL CHECK (to_amount > 0),
+    rate NUMERIC(30, 12) NOT NULL CHECK (rate > 0),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CHECK (from_currency_id <> to_currency_id)
+);
+
+CREATE INDEX IF NOT EXISTS conversions_user_id_idx ON conversions (user_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE conversions;
+-- +goose StatementEnd
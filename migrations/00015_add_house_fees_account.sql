@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+-- commissions on currency trades are credited to a house fees account
+ALTER TABLE ledger_accounts DROP CONSTRAINT IF EXISTS ledger_accounts_kind_check;
+ALTER TABLE ledger_accounts ADD CONSTRAINT ledger_accounts_kind_check
+    CHECK (kind IN ('user_cash', 'user_wallet', 'house_fx', 'house_fees', 'external'));
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE ledger_accounts DROP CONSTRAINT IF EXISTS ledger_accounts_kind_check;
+ALTER TABLE ledger_accounts ADD CONSTRAINT ledger_accounts_kind_check
+    CHECK (kind IN ('user_cash', 'user_wallet', 'house_fx', 'external'));
+-- +goose StatementEnd
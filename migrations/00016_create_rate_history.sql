@@ -0,0 +1,34 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS exchange_rates (
+    id BIGSERIAL PRIMARY KEY UNIQUE NOT NULL,
+    currency_id BIGINT NOT NULL REFERENCES currencies (id) ON DELETE RESTRICT,
+    rate NUMERIC(30, 12) NOT NULL CHECK (rate > 0),
+    source VARCHAR(32) NOT NULL,
+    fetched_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS exchange_rates_currency_id_fetched_at_idx ON exchange_rates (currency_id, fetched_at);
+
+CREATE TABLE IF NOT EXISTS trades (
+    id BIGSERIAL PRIMARY KEY UNIQUE NOT NULL,
+    transaction_id BIGINT NOT NULL REFERENCES transactions (id) ON DELETE RESTRICT,
+    user_id BIGINT NOT NULL REFERENCES users (id) ON DELETE RESTRICT,
+    currency_id BIGINT NOT NULL REFERENCES currencies (id) ON DELETE RESTRICT,
+    side VARCHAR(4) NOT NULL CHECK (side IN ('buy', 'sell')),
+    amount BIGINT NOT NULL CHECK (amount > 0),
+    total BIGINT NOT NULL CHECK (total > 0),
+    fee BIGINT NOT NULL CHECK (fee >= 0),
+    mid_rate NUMERIC(30, 12) NOT NULL CHECK (mid_rate > 0),
+    rate NUMERIC(30, 12) NOT NULL CHECK (rate > 0),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS trades_user_id_idx ON trades (user_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE trades;
+DROP TABLE exchange_rates;
+-- +goose StatementEnd
@@ -0,0 +1,10 @@
+-- +goose Up
+-- +goose StatementBegin
+-- sent messages are cleared by the relay, which did not clear earlier ones
+UPDATE outbox SET message = '' WHERE sent_at IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 1;
+-- +goose StatementEnd
@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+-- closed accounts keep their email until anonymized, but do not hold it
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_email_key;
+CREATE UNIQUE INDEX IF NOT EXISTS users_email_not_closed ON users (email)
+    WHERE status <> 'closed';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS users_email_not_closed;
+ALTER TABLE users ADD CONSTRAINT users_email_key UNIQUE (email);
+-- +goose StatementEnd
@@ -12,6 +12,7 @@ import (
 	"github.com/tizzhh/micro-banking/internal/config"
 	currencyapihttp "github.com/tizzhh/micro-banking/pkg/currencyapi/domain/http"
 	"github.com/tizzhh/micro-banking/pkg/logger/sl"
+	"github.com/tizzhh/micro-banking/pkg/money"
 )
 
 type Api struct {
@@ -28,9 +29,16 @@ func New(log *slog.Logger, timeout time.Duration) *Api {
 
 const (
 	urlTemplate = "%s?apikey=%s&currencies=%s"
+
+	source = "currencyapi"
 )
 
-func (a *Api) QueryRates(ctx context.Context, currencyCode string) (float32, error) {
+// Source names the API in the rate history.
+func (a *Api) Source() string {
+	return source
+}
+
+func (a *Api) QueryRates(ctx context.Context, currencyCode string) (money.Rate, error) {
 	const caller = "currencyapi.QueryRates"
 
 	log := sl.AddCaller(a.log, caller)
@@ -43,29 +51,35 @@ func (a *Api) QueryRates(ctx context.Context, currencyCode string) (float32, err
 	resp, err := a.HttpClient.Get(queryUrl)
 	if err != nil {
 		log.Error("failed to query rates", sl.Error(err))
-		return 0, fmt.Errorf("%s: %w", caller, err)
+		return money.Rate{}, fmt.Errorf("%s: %w", caller, err)
 	}
 	defer resp.Body.Close()
 
 	resBody, err := io.ReadAll(resp.Body)
 	if err != nil {
 		log.Error("failed to read response body", sl.Error(err))
-		return 0, fmt.Errorf("%s: %w", caller, err)
+		return money.Rate{}, fmt.Errorf("%s: %w", caller, err)
 	}
 
 	var response currencyapihttp.Response
 	if err = json.Unmarshal(resBody, &response); err != nil {
 		log.Error("failed to unmarshal response body", sl.Error(err))
-		return 0, fmt.Errorf("%s: %w", caller, err)
+		return money.Rate{}, fmt.Errorf("%s: %w", caller, err)
 	}
 
 	rates, exists := response.Data.Currencies[currencyCode]
 	if !exists {
 		log.Error("currency code missing in response body")
-		return 0, fmt.Errorf("%s: %w", caller, err)
+		return money.Rate{}, fmt.Errorf("%s: %w", caller, err)
+	}
+
+	rate, err := money.ParseRate(rates.Value.String())
+	if err != nil {
+		log.Error("failed to parse rate", sl.Error(err))
+		return money.Rate{}, fmt.Errorf("%s: %w", caller, err)
 	}
 
 	log.Info("queried rates", slog.String("currency", currencyCode), slog.String("last_updated", response.Meta.LastUpdated))
 
-	return rates.Value, nil
+	return rate, nil
 }
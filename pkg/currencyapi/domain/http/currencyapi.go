@@ -16,8 +16,8 @@ type Data struct {
 }
 
 type Currency struct {
-	Code  string  `json:"code"`
-	Value float32 `json:"value"`
+	Code  string      `json:"code"`
+	Value json.Number `json:"value"`
 }
 
 func (d *Data) UnmarshalJSON(data []byte) error {
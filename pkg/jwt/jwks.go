@@ -0,0 +1,191 @@
+package jwt
+
+import (
+	"context"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"math/big"
+	"sync"
+	"time"
+)
+
+const (
+	// minRefetchInterval bounds how often an unknown kid or a failed fetch
+	// makes RemoteKeySet fetch the key set again.
+	minRefetchInterval = 10 * time.Second
+	fetchTimeout       = 10 * time.Second
+)
+
+// JWK is a public key in the JSON Web Key format.
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+}
+
+// JWKS is a JSON Web Key Set.
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// PublicKey returns the key described by jwk.
+func (jwk JWK) PublicKey() (crypto.PublicKey, error) {
+	switch {
+	case jwk.Kty == "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(jwk.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := base64.RawURLEncoding.DecodeString(jwk.E)
+		if err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}, nil
+	case jwk.Kty == "OKP" && jwk.Crv == "Ed25519":
+		x, err := base64.RawURLEncoding.DecodeString(jwk.X)
+		if err != nil {
+			return nil, err
+		}
+		if len(x) != ed25519.PublicKeySize {
+			return nil, ErrUnsupportedKeyType
+		}
+		return ed25519.PublicKey(x), nil
+	default:
+		return nil, ErrUnsupportedKeyType
+	}
+}
+
+func publicJWK(kid string, key crypto.PublicKey) (JWK, error) {
+	method, err := signingMethod(key)
+	if err != nil {
+		return JWK{}, err
+	}
+
+	jwk := JWK{Kid: kid, Use: "sig", Alg: method.Alg()}
+	switch key := key.(type) {
+	case *rsa.PublicKey:
+		jwk.Kty = "RSA"
+		jwk.N = encodeInt(key.N)
+		jwk.E = encodeInt(big.NewInt(int64(key.E)))
+	case ed25519.PublicKey:
+		jwk.Kty = "OKP"
+		jwk.Crv = "Ed25519"
+		jwk.X = base64.RawURLEncoding.EncodeToString(key)
+	}
+	return jwk, nil
+}
+
+// KeyRingFromJWKS returns a verification-only key ring of the signing keys in
+// jwks, identified by their kid. Keys of unsupported types are skipped.
+func KeyRingFromJWKS(jwks JWKS) *KeyRing {
+	ring := &KeyRing{keys: make(map[string]crypto.PublicKey)}
+	for _, jwk := range jwks.Keys {
+		if jwk.Kid == "" || (jwk.Use != "" && jwk.Use != "sig") {
+			continue
+		}
+		key, err := jwk.PublicKey()
+		if err != nil {
+			continue
+		}
+		if _, ok := ring.keys[jwk.Kid]; !ok {
+			ring.keys[jwk.Kid] = key
+			ring.order = append(ring.order, jwk.Kid)
+		}
+	}
+	return ring
+}
+
+// RemoteKeySet verifies tokens with the public keys of another service. The
+// key set is fetched again once it is older than the refresh interval, or
+// when a token names a kid it does not know yet, so rotated keys are picked
+// up without a restart.
+type RemoteKeySet struct {
+	fetch      func(ctx context.Context) (JWKS, error)
+	refresh    time.Duration
+	minRefetch time.Duration
+
+	mu          sync.Mutex
+	ring        *KeyRing
+	jwks        JWKS
+	fetchedAt   time.Time
+	attemptedAt time.Time
+}
+
+func NewRemoteKeySet(fetch func(ctx context.Context) (JWKS, error), refresh time.Duration) *RemoteKeySet {
+	return &RemoteKeySet{fetch: fetch, refresh: refresh, minRefetch: min(refresh, minRefetchInterval)}
+}
+
+func (s *RemoteKeySet) SigningKey() (string, crypto.Signer, error) {
+	return "", nil, ErrNoSigningKey
+}
+
+func (s *RemoteKeySet) VerificationKey(kid string) (crypto.PublicKey, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.ensureFresh(); err != nil {
+		return nil, err
+	}
+
+	key, err := s.ring.VerificationKey(kid)
+	if err == nil || !s.canFetch() {
+		return key, err
+	}
+
+	if err := s.update(); err != nil {
+		return nil, err
+	}
+	return s.ring.VerificationKey(kid)
+}
+
+func (s *RemoteKeySet) JWKS() (JWKS, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.ensureFresh(); err != nil {
+		return JWKS{}, err
+	}
+	return s.jwks, nil
+}
+
+// ensureFresh fetches the key set if there is none yet or it is stale. A
+// failed refresh keeps the keys fetched before in use.
+func (s *RemoteKeySet) ensureFresh() error {
+	if s.ring == nil {
+		if !s.canFetch() {
+			return ErrUnknownKey
+		}
+		return s.update()
+	}
+	if time.Since(s.fetchedAt) > s.refresh && s.canFetch() {
+		_ = s.update()
+	}
+	return nil
+}
+
+func (s *RemoteKeySet) canFetch() bool {
+	return time.Since(s.attemptedAt) >= s.minRefetch
+}
+
+func (s *RemoteKeySet) update() error {
+	s.attemptedAt = time.Now()
+
+	ctx, cancel := context.WithTimeout(context.Background(), fetchTimeout)
+	defer cancel()
+
+	jwks, err := s.fetch(ctx)
+	if err != nil {
+		return err
+	}
+	s.ring = KeyRingFromJWKS(jwks)
+	s.jwks = jwks
+	s.fetchedAt = time.Now()
+	return nil
+}
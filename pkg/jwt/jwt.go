@@ -1,6 +1,10 @@
 package jwt
 
 import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"strconv"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
@@ -8,43 +12,268 @@ import (
 	"github.com/tizzhh/micro-banking/internal/domain/auth/models"
 )
 
+var (
+	ErrInvalidToken            = errors.New("invalid token")
+	ErrTokenExpired            = errors.New("token is expired")
+	ErrTokenNotValidYet        = errors.New("token is not valid yet")
+	ErrInvalidIssuer           = errors.New("token has invalid issuer")
+	ErrInvalidAudience         = errors.New("token has invalid audience")
+	ErrUnexpectedSigningMethod = errors.New("unexpected signing method")
+)
+
+const (
+	// PurposeMFA marks the challenge tokens a login with a second factor is
+	// completed with. They are not access tokens.
+	PurposeMFA = "mfa"
+	// PurposeEmailVerification marks the tokens sent to verify the email
+	// address of a new user.
+	PurposeEmailVerification = "email_verification"
+)
+
+var validMethods = []string{jwt.SigningMethodRS256.Alg(), jwt.SigningMethodEdDSA.Alg()}
+
+// Claims are the claims of the access tokens issued by the auth service. The
+// user id is carried in the subject.
+type Claims struct {
+	Email string   `json:"email"`
+	Roles []string `json:"roles,omitempty"`
+	// MFAAt is when the user last verified a second factor, if they did
+	// for this token.
+	MFAAt   *jwt.NumericDate `json:"mfa_at,omitempty"`
+	Purpose string           `json:"purpose,omitempty"`
+	jwt.RegisteredClaims
+}
+
+// MFATime returns when the user verified a second factor, zero if not for
+// this token.
+func (c *Claims) MFATime() time.Time {
+	if c.MFAAt == nil {
+		return time.Time{}
+	}
+	return c.MFAAt.Time
+}
+
+// UserID returns the id of the user the token was issued to.
+func (c *Claims) UserID() (uint64, error) {
+	uid, err := strconv.ParseUint(c.Subject, 10, 64)
+	if err != nil {
+		return 0, ErrInvalidToken
+	}
+	return uid, nil
+}
+
 type JWT struct {
-	tokenTTL  time.Duration
-	secretKey string
+	tokenTTL        time.Duration
+	challengeTTL    time.Duration
+	verificationTTL time.Duration
+	keys            Keys
+	issuer          string
+	audience        string
+	leeway          time.Duration
 }
 
-func New(tokenTTL time.Duration) *JWT {
+func New(tokenTTL time.Duration, keys Keys) *JWT {
 	cfg := config.Get()
 	return &JWT{
-		tokenTTL:  tokenTTL,
-		secretKey: cfg.SecretKey,
+		tokenTTL:        tokenTTL,
+		challengeTTL:    cfg.MFA.ChallengeTTL,
+		verificationTTL: cfg.EmailVerification.TokenTTL,
+		keys:            keys,
+		issuer:          cfg.JWT.Issuer,
+		audience:        cfg.JWT.Audience,
+		leeway:          cfg.JWT.Leeway,
 	}
 }
 
 func (j *JWT) NewToken(user models.User) (string, error) {
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256,
-		jwt.MapClaims{
-			"uid":   user.ID,
-			"email": user.Email,
-			"exp":   j.tokenTTL,
+	return j.newToken(user, j.tokenTTL, func(*Claims) {})
+}
+
+// NewMFAToken returns an access token for user, who verified a second factor
+// at verifiedAt.
+func (j *JWT) NewMFAToken(user models.User, verifiedAt time.Time) (string, error) {
+	return j.newToken(user, j.tokenTTL, func(claims *Claims) {
+		claims.MFAAt = jwt.NewNumericDate(verifiedAt)
+	})
+}
+
+// NewChallengeToken returns a short-lived token that is exchanged, together
+// with a second factor, for the tokens of user.
+func (j *JWT) NewChallengeToken(user models.User) (string, error) {
+	return j.newToken(user, j.challengeTTL, func(claims *Claims) {
+		claims.Roles = nil
+		claims.Purpose = PurposeMFA
+	})
+}
+
+// NewVerificationToken returns a token that verifies the email address of
+// user.
+func (j *JWT) NewVerificationToken(user models.User) (string, error) {
+	return j.newToken(user, j.verificationTTL, func(claims *Claims) {
+		claims.Roles = nil
+		claims.Purpose = PurposeEmailVerification
+	})
+}
+
+func (j *JWT) newToken(user models.User, ttl time.Duration, customize func(*Claims)) (string, error) {
+	kid, signingKey, err := j.keys.SigningKey()
+	if err != nil {
+		return "", err
+	}
+	method, err := signingMethod(signingKey.Public())
+	if err != nil {
+		return "", err
+	}
+
+	jti, err := NewID()
+	if err != nil {
+		return "", err
+	}
+
+	now := time.Now()
+	claims := &Claims{
+		Email: user.Email,
+		Roles: user.Roles,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    j.issuer,
+			Subject:   strconv.FormatUint(user.ID, 10),
+			Audience:  jwt.ClaimStrings{j.audience},
+			ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
+			NotBefore: jwt.NewNumericDate(now),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ID:        jti,
 		},
-	)
+	}
+	customize(claims)
+	token := jwt.NewWithClaims(method, claims)
+	token.Header["kid"] = kid
 
-	tokenString, err := token.SignedString([]byte(j.secretKey))
+	tokenString, err := token.SignedString(signingKey)
 	if err != nil {
 		return "", err
 	}
 	return tokenString, nil
 }
 
+// CheckToken parses the access token and validates its signature and
+// registered claims. The claims of the returned token are *Claims.
 func (j *JWT) CheckToken(token string) (*jwt.Token, error) {
-	parsedToken, err := jwt.Parse(token, func(t *jwt.Token) (interface{}, error) {
-		return []byte(j.secretKey), nil
-	})
+	return j.checkToken(token, "")
+}
+
+// CheckChallengeToken is CheckToken for the tokens of NewChallengeToken.
+func (j *JWT) CheckChallengeToken(token string) (*jwt.Token, error) {
+	return j.checkToken(token, PurposeMFA)
+}
+
+// CheckVerificationToken is CheckToken for the tokens of
+// NewVerificationToken.
+func (j *JWT) CheckVerificationToken(token string) (*jwt.Token, error) {
+	return j.checkToken(token, PurposeEmailVerification)
+}
 
+func (j *JWT) checkToken(token string, purpose string) (*jwt.Token, error) {
+	parsedToken, err := jwt.ParseWithClaims(
+		token,
+		&Claims{},
+		j.verificationKey,
+		jwt.WithValidMethods(validMethods),
+		jwt.WithIssuer(j.issuer),
+		jwt.WithAudience(j.audience),
+		jwt.WithLeeway(j.leeway),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+	)
 	if err != nil {
+		return nil, validationError(parsedToken, err)
+	}
+
+	claims, ok := parsedToken.Claims.(*Claims)
+	if !ok || claims.ID == "" || claims.Purpose != purpose {
+		return nil, ErrInvalidToken
+	}
+	if _, err := claims.UserID(); err != nil {
 		return nil, err
 	}
 
 	return parsedToken, nil
 }
+
+// JWKS returns the public keys tokens are verified with.
+func (j *JWT) JWKS() (JWKS, error) {
+	return j.keys.JWKS()
+}
+
+func (j *JWT) verificationKey(token *jwt.Token) (interface{}, error) {
+	kid, ok := token.Header["kid"].(string)
+	if !ok {
+		return nil, ErrUnknownKey
+	}
+	key, err := j.keys.VerificationKey(kid)
+	if err != nil {
+		return nil, err
+	}
+	// a key is only used with the algorithm it was issued for
+	method, err := signingMethod(key)
+	if err != nil {
+		return nil, err
+	}
+	if token.Method.Alg() != method.Alg() {
+		return nil, ErrUnexpectedSigningMethod
+	}
+	return key, nil
+}
+
+// Lifetime returns how long access tokens are accepted after they are
+// issued.
+func (j *JWT) Lifetime() time.Duration {
+	return j.tokenTTL + j.leeway
+}
+
+// Remaining returns how long a token with claims is still accepted by
+// CheckToken.
+func (j *JWT) Remaining(claims *Claims) time.Duration {
+	if claims.ExpiresAt == nil {
+		return 0
+	}
+	return time.Until(claims.ExpiresAt.Time) + j.leeway
+}
+
+func validationError(token *jwt.Token, err error) error {
+	switch {
+	case token != nil && token.Method != nil && !isValidMethod(token.Method.Alg()),
+		errors.Is(err, ErrUnexpectedSigningMethod):
+		return ErrUnexpectedSigningMethod
+	case errors.Is(err, ErrUnknownKey):
+		return ErrUnknownKey
+	case errors.Is(err, jwt.ErrTokenExpired):
+		return ErrTokenExpired
+	case errors.Is(err, jwt.ErrTokenNotValidYet), errors.Is(err, jwt.ErrTokenUsedBeforeIssued):
+		return ErrTokenNotValidYet
+	case errors.Is(err, jwt.ErrTokenInvalidIssuer):
+		return ErrInvalidIssuer
+	case errors.Is(err, jwt.ErrTokenInvalidAudience):
+		return ErrInvalidAudience
+	default:
+		return ErrInvalidToken
+	}
+}
+
+func isValidMethod(alg string) bool {
+	for _, method := range validMethods {
+		if method == alg {
+			return true
+		}
+	}
+	return false
+}
+
+// NewID returns a random identifier, used for token ids and refresh token
+// families.
+func NewID() (string, error) {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(id), nil
+}
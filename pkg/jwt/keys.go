@@ -0,0 +1,208 @@
+package jwt
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+var (
+	ErrUnknownKey         = errors.New("unknown signing key")
+	ErrNoSigningKey       = errors.New("no signing key")
+	ErrUnsupportedKeyType = errors.New("unsupported key type")
+)
+
+const minRSAKeyBits = 2048
+
+// Keys provides the keys tokens are signed and verified with.
+type Keys interface {
+	SigningKey() (string, crypto.Signer, error)
+	VerificationKey(kid string) (crypto.PublicKey, error)
+	JWKS() (JWKS, error)
+}
+
+// KeyRing holds the signing key of the auth service and every public key
+// tokens are still accepted from. Keys are identified by their RFC 7638
+// thumbprint, so a key keeps its kid wherever it is loaded.
+type KeyRing struct {
+	signingKID string
+	signingKey crypto.Signer
+	keys       map[string]crypto.PublicKey
+	order      []string
+}
+
+// NewKeyRing returns a key ring that signs with signingKey, which may be nil
+// for a verification-only ring, and verifies with its public key and
+// verificationKeys.
+func NewKeyRing(signingKey crypto.Signer, verificationKeys ...crypto.PublicKey) (*KeyRing, error) {
+	ring := &KeyRing{keys: make(map[string]crypto.PublicKey)}
+
+	if signingKey != nil {
+		kid, err := ring.add(signingKey.Public())
+		if err != nil {
+			return nil, err
+		}
+		ring.signingKID = kid
+		ring.signingKey = signingKey
+	}
+
+	for _, key := range verificationKeys {
+		if _, err := ring.add(key); err != nil {
+			return nil, err
+		}
+	}
+
+	return ring, nil
+}
+
+// LoadKeyRing reads a PEM encoded private signing key and PEM encoded public
+// verification keys. Any path may be empty.
+func LoadKeyRing(signingKeyPath string, verificationKeyPaths []string) (*KeyRing, error) {
+	const caller = "jwt.LoadKeyRing"
+
+	var signingKey crypto.Signer
+	if signingKeyPath != "" {
+		key, err := readPEM(signingKeyPath)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", caller, err)
+		}
+		parsed, err := x509.ParsePKCS8PrivateKey(key)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s: %w", caller, signingKeyPath, err)
+		}
+		signer, ok := parsed.(crypto.Signer)
+		if !ok {
+			return nil, fmt.Errorf("%s: %s: %w", caller, signingKeyPath, ErrUnsupportedKeyType)
+		}
+		signingKey = signer
+	}
+
+	verificationKeys := make([]crypto.PublicKey, 0, len(verificationKeyPaths))
+	for _, path := range verificationKeyPaths {
+		key, err := readPEM(path)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", caller, err)
+		}
+		parsed, err := x509.ParsePKIXPublicKey(key)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s: %w", caller, path, err)
+		}
+		verificationKeys = append(verificationKeys, parsed)
+	}
+
+	ring, err := NewKeyRing(signingKey, verificationKeys...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", caller, err)
+	}
+	return ring, nil
+}
+
+func (r *KeyRing) SigningKey() (string, crypto.Signer, error) {
+	if r.signingKey == nil {
+		return "", nil, ErrNoSigningKey
+	}
+	return r.signingKID, r.signingKey, nil
+}
+
+func (r *KeyRing) VerificationKey(kid string) (crypto.PublicKey, error) {
+	key, ok := r.keys[kid]
+	if !ok {
+		return nil, ErrUnknownKey
+	}
+	return key, nil
+}
+
+func (r *KeyRing) JWKS() (JWKS, error) {
+	jwks := JWKS{Keys: make([]JWK, 0, len(r.order))}
+	for _, kid := range r.order {
+		jwk, err := publicJWK(kid, r.keys[kid])
+		if err != nil {
+			return JWKS{}, err
+		}
+		jwks.Keys = append(jwks.Keys, jwk)
+	}
+	return jwks, nil
+}
+
+func (r *KeyRing) add(key crypto.PublicKey) (string, error) {
+	kid, err := Thumbprint(key)
+	if err != nil {
+		return "", err
+	}
+	if _, ok := r.keys[kid]; !ok {
+		r.keys[kid] = key
+		r.order = append(r.order, kid)
+	}
+	return kid, nil
+}
+
+// Thumbprint returns the RFC 7638 thumbprint of key.
+func Thumbprint(key crypto.PublicKey) (string, error) {
+	var members interface{}
+	switch key := key.(type) {
+	case *rsa.PublicKey:
+		if key.N.BitLen() < minRSAKeyBits {
+			return "", ErrUnsupportedKeyType
+		}
+		members = struct {
+			E   string `json:"e"`
+			Kty string `json:"kty"`
+			N   string `json:"n"`
+		}{E: encodeInt(big.NewInt(int64(key.E))), Kty: "RSA", N: encodeInt(key.N)}
+	case ed25519.PublicKey:
+		members = struct {
+			Crv string `json:"crv"`
+			Kty string `json:"kty"`
+			X   string `json:"x"`
+		}{Crv: "Ed25519", Kty: "OKP", X: base64.RawURLEncoding.EncodeToString(key)}
+	default:
+		return "", ErrUnsupportedKeyType
+	}
+
+	// members are declared in lexicographic order without whitespace, as the
+	// thumbprint requires
+	canonical, err := json.Marshal(members)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(canonical)
+	return base64.RawURLEncoding.EncodeToString(sum[:]), nil
+}
+
+func signingMethod(key crypto.PublicKey) (jwt.SigningMethod, error) {
+	switch key.(type) {
+	case *rsa.PublicKey:
+		return jwt.SigningMethodRS256, nil
+	case ed25519.PublicKey:
+		return jwt.SigningMethodEdDSA, nil
+	default:
+		return nil, ErrUnsupportedKeyType
+	}
+}
+
+func readPEM(path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("%s: no PEM data", path)
+	}
+	return block.Bytes, nil
+}
+
+func encodeInt(n *big.Int) string {
+	return base64.RawURLEncoding.EncodeToString(n.Bytes())
+}
@@ -0,0 +1,218 @@
+// Package money keeps amounts as integer minor units of a currency, so that
+// balances never pass through floating point.
+package money
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+var (
+	ErrInvalidAmount    = errors.New("invalid amount")
+	ErrTooPrecise       = errors.New("amount has more decimal places than the currency allows")
+	ErrOverflow         = errors.New("amount is too large")
+	ErrCurrencyMismatch = errors.New("currency mismatch")
+	ErrInvalidRate      = errors.New("invalid rate")
+)
+
+const defaultExponent = 2
+
+var (
+	exponentsMu sync.RWMutex
+	// exponents is the number of minor unit digits of a currency, per ISO
+	// 4217, extended by SetExponent.
+	exponents = map[string]int{
+		"USD": 2,
+		"EUR": 2,
+		"RUB": 2,
+		"CNY": 2,
+		"JPY": 0,
+	}
+)
+
+var amountPattern = regexp.MustCompile(`^-?[0-9]+(\.[0-9]+)?$`)
+
+// Exponent returns the number of minor unit digits of currency.
+func Exponent(currency string) int {
+	exponentsMu.RLock()
+	defer exponentsMu.RUnlock()
+
+	if exponent, ok := exponents[currency]; ok {
+		return exponent
+	}
+	return defaultExponent
+}
+
+// SetExponent sets the number of minor unit digits of currency, e.g. for the
+// currencies of a catalog.
+func SetExponent(currency string, exponent int) {
+	exponentsMu.Lock()
+	defer exponentsMu.Unlock()
+
+	exponents[currency] = exponent
+}
+
+type Money struct {
+	amount   int64
+	currency string
+}
+
+// New returns amount minor units of currency.
+func New(amount int64, currency string) Money {
+	return Money{amount: amount, currency: currency}
+}
+
+// Parse reads a decimal string such as "12.34". Digits beyond the currency
+// exponent are rejected rather than rounded.
+func Parse(value string, currency string) (Money, error) {
+	return ParseExponent(value, currency, Exponent(currency))
+}
+
+// ParseExponent is Parse for a currency with exponent minor unit digits, e.g.
+// one that is not registered yet.
+func ParseExponent(value string, currency string, exponent int) (Money, error) {
+	if !amountPattern.MatchString(value) {
+		return Money{}, fmt.Errorf("%w: %q", ErrInvalidAmount, value)
+	}
+
+	whole, fraction, _ := strings.Cut(value, ".")
+	if len(fraction) > exponent {
+		return Money{}, fmt.Errorf("%w: %q", ErrTooPrecise, value)
+	}
+	fraction += strings.Repeat("0", exponent-len(fraction))
+
+	amount, err := strconv.ParseInt(whole+fraction, 10, 64)
+	if err != nil {
+		return Money{}, fmt.Errorf("%w: %q", ErrOverflow, value)
+	}
+
+	return New(amount, currency), nil
+}
+
+// Amount returns the amount in minor units.
+func (m Money) Amount() int64 {
+	return m.amount
+}
+
+func (m Money) Currency() string {
+	return m.currency
+}
+
+func (m Money) IsZero() bool {
+	return m.amount == 0
+}
+
+func (m Money) IsPositive() bool {
+	return m.amount > 0
+}
+
+func (m Money) IsNegative() bool {
+	return m.amount < 0
+}
+
+func (m Money) Add(other Money) (Money, error) {
+	if m.currency != other.currency {
+		return Money{}, ErrCurrencyMismatch
+	}
+	sum := m.amount + other.amount
+	if (other.amount > 0 && sum < m.amount) || (other.amount < 0 && sum > m.amount) {
+		return Money{}, ErrOverflow
+	}
+	return New(sum, m.currency), nil
+}
+
+func (m Money) Sub(other Money) (Money, error) {
+	if other.amount == math.MinInt64 {
+		return Money{}, ErrOverflow
+	}
+	return m.Add(New(-other.amount, other.currency))
+}
+
+// String formats the amount with exactly as many decimal places as the
+// currency has, without the currency code.
+func (m Money) String() string {
+	exponent := Exponent(m.currency)
+
+	sign := ""
+	digits := strconv.FormatInt(m.amount, 10)
+	if m.amount < 0 {
+		sign, digits = "-", digits[1:]
+	}
+	if exponent == 0 {
+		return sign + digits
+	}
+	if len(digits) <= exponent {
+		digits = strings.Repeat("0", exponent-len(digits)+1) + digits
+	}
+
+	return sign + digits[:len(digits)-exponent] + "." + digits[len(digits)-exponent:]
+}
+
+// RoundingMode decides what happens to the part of a converted amount that is
+// smaller than the minor unit.
+type RoundingMode int
+
+const (
+	// RoundHalfEven rounds to the nearest minor unit, ties to even.
+	RoundHalfEven RoundingMode = iota
+	// RoundDown drops the remainder, towards zero.
+	RoundDown
+	// RoundUp rounds away from zero whenever there is a remainder.
+	RoundUp
+)
+
+// Convert expresses m in currency at rate, the number of units of currency
+// for one unit of m's currency, rounded to a minor unit of currency with mode.
+func Convert(m Money, rate Rate, currency string, mode RoundingMode) (Money, error) {
+	if rate.value == nil {
+		return Money{}, ErrInvalidRate
+	}
+
+	result := new(big.Rat).SetInt64(m.amount)
+	result.Mul(result, rate.value)
+	result.Mul(result, pow10(Exponent(currency)))
+	result.Quo(result, pow10(Exponent(m.currency)))
+
+	amount := round(result, mode)
+	if !amount.IsInt64() {
+		return Money{}, ErrOverflow
+	}
+
+	return New(amount.Int64(), currency), nil
+}
+
+func round(value *big.Rat, mode RoundingMode) *big.Int {
+	quotient, remainder := new(big.Int).QuoRem(value.Num(), value.Denom(), new(big.Int))
+	if remainder.Sign() == 0 {
+		return quotient
+	}
+
+	away := big.NewInt(int64(value.Sign()))
+	switch mode {
+	case RoundDown:
+		return quotient
+	case RoundUp:
+		return quotient.Add(quotient, away)
+	default:
+		twice := new(big.Int).Mul(new(big.Int).Abs(remainder), big.NewInt(2))
+		switch twice.Cmp(value.Denom()) {
+		case 1:
+			return quotient.Add(quotient, away)
+		case 0:
+			if quotient.Bit(0) == 1 {
+				return quotient.Add(quotient, away)
+			}
+		}
+		return quotient
+	}
+}
+
+func pow10(exponent int) *big.Rat {
+	return new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(exponent)), nil))
+}
@@ -0,0 +1,81 @@
+package money
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// rateScale is the number of decimal places a rate keeps when formatted.
+const rateScale = 12
+
+// Rate is an exact, positive exchange rate.
+type Rate struct {
+	value *big.Rat
+}
+
+// ParseRate reads a decimal string such as "0.9215".
+func ParseRate(value string) (Rate, error) {
+	if !amountPattern.MatchString(value) {
+		return Rate{}, fmt.Errorf("%w: %q", ErrInvalidRate, value)
+	}
+
+	rate, ok := new(big.Rat).SetString(value)
+	if !ok || rate.Sign() <= 0 {
+		return Rate{}, fmt.Errorf("%w: %q", ErrInvalidRate, value)
+	}
+
+	return Rate{value: rate}, nil
+}
+
+// NewRate returns the rate numerator/denominator, e.g. NewRate(25, 10000) for
+// 25 basis points. It is zero unless both are positive.
+func NewRate(numerator, denominator int64) Rate {
+	if numerator <= 0 || denominator <= 0 {
+		return Rate{}
+	}
+	return Rate{value: big.NewRat(numerator, denominator)}
+}
+
+// Inverse returns the rate of the opposite direction.
+func (r Rate) Inverse() Rate {
+	if r.value == nil {
+		return Rate{}
+	}
+	return Rate{value: new(big.Rat).Inv(r.value)}
+}
+
+// Mul returns the rate of converting at r and then at other, e.g. the cross
+// rate of two rates against the same currency.
+func (r Rate) Mul(other Rate) Rate {
+	if r.value == nil || other.value == nil {
+		return Rate{}
+	}
+	return Rate{value: new(big.Rat).Mul(r.value, other.value)}
+}
+
+// Cmp returns -1, 0 or 1 if r is less than, equal to or greater than other.
+func (r Rate) Cmp(other Rate) int {
+	a, b := r.value, other.value
+	if a == nil {
+		a = new(big.Rat)
+	}
+	if b == nil {
+		b = new(big.Rat)
+	}
+	return a.Cmp(b)
+}
+
+func (r Rate) IsZero() bool {
+	return r.value == nil || r.value.Sign() == 0
+}
+
+// String formats the rate with up to rateScale decimal places.
+func (r Rate) String() string {
+	if r.value == nil {
+		return "0"
+	}
+	formatted := r.value.FloatString(rateScale)
+	formatted = strings.TrimRight(formatted, "0")
+	return strings.TrimSuffix(formatted, ".")
+}
@@ -0,0 +1,149 @@
+package mtls
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Files written by GenerateDevCA.
+const (
+	CAFile        = "ca.pem"
+	CAKeyFile     = "ca-key.pem"
+	ServerFile    = "server.pem"
+	ServerKeyFile = "server-key.pem"
+	ClientFile    = "client.pem"
+	ClientKeyFile = "client-key.pem"
+)
+
+// GenerateDevCA writes a self-signed CA and a server and a client certificate
+// issued by it to dir, for running the services with mTLS locally. The server
+// certificate is valid for hosts, which may be DNS names or IP addresses.
+// It is not meant for production use.
+func GenerateDevCA(dir string, hosts []string, validity time.Duration) error {
+	const caller = "mtls.GenerateDevCA"
+
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return fmt.Errorf("%s: %w", caller, err)
+	}
+
+	now := time.Now()
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return fmt.Errorf("%s: %w", caller, err)
+	}
+	caTemplate := &x509.Certificate{
+		Subject:               pkix.Name{CommonName: "micro-banking dev CA"},
+		NotBefore:             now.Add(-time.Minute),
+		NotAfter:              now.Add(validity),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	caDER, err := issue(caTemplate, caTemplate, caKey, caKey)
+	if err != nil {
+		return fmt.Errorf("%s: %w", caller, err)
+	}
+	ca, err := x509.ParseCertificate(caDER)
+	if err != nil {
+		return fmt.Errorf("%s: %w", caller, err)
+	}
+	if err := writeKeyPair(dir, CAFile, CAKeyFile, caDER, caKey); err != nil {
+		return fmt.Errorf("%s: %w", caller, err)
+	}
+
+	server := leafTemplate("micro-banking server", now, validity, x509.ExtKeyUsageServerAuth)
+	for _, host := range hosts {
+		if ip := net.ParseIP(host); ip != nil {
+			server.IPAddresses = append(server.IPAddresses, ip)
+		} else {
+			server.DNSNames = append(server.DNSNames, host)
+		}
+	}
+	client := leafTemplate("micro-banking client", now, validity, x509.ExtKeyUsageClientAuth)
+
+	for _, leaf := range []struct {
+		template      *x509.Certificate
+		cert, keyFile string
+	}{
+		{server, ServerFile, ServerKeyFile},
+		{client, ClientFile, ClientKeyFile},
+	} {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		if err != nil {
+			return fmt.Errorf("%s: %w", caller, err)
+		}
+		der, err := issue(leaf.template, ca, key, caKey)
+		if err != nil {
+			return fmt.Errorf("%s: %w", caller, err)
+		}
+		if err := writeKeyPair(dir, leaf.cert, leaf.keyFile, der, key); err != nil {
+			return fmt.Errorf("%s: %w", caller, err)
+		}
+	}
+
+	return nil
+}
+
+func leafTemplate(name string, now time.Time, validity time.Duration, usage x509.ExtKeyUsage) *x509.Certificate {
+	return &x509.Certificate{
+		Subject:     pkix.Name{CommonName: name},
+		NotBefore:   now.Add(-time.Minute),
+		NotAfter:    now.Add(validity),
+		KeyUsage:    x509.KeyUsageDigitalSignature,
+		ExtKeyUsage: []x509.ExtKeyUsage{usage},
+	}
+}
+
+func issue(template, parent *x509.Certificate, key *ecdsa.PrivateKey, parentKey crypto.Signer) ([]byte, error) {
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, err
+	}
+	template.SerialNumber = serial
+	return x509.CreateCertificate(rand.Reader, template, parent, key.Public(), parentKey)
+}
+
+func writeKeyPair(dir, certFile, keyFile string, der []byte, key *ecdsa.PrivateKey) error {
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return err
+	}
+	if err := writePEM(filepath.Join(dir, keyFile), "PRIVATE KEY", keyDER, 0o600); err != nil {
+		return err
+	}
+	return writePEM(filepath.Join(dir, certFile), "CERTIFICATE", der, 0o644)
+}
+
+// writePEM replaces path atomically, so that a reloading service never reads
+// a partly written file.
+func writePEM(path, blockType string, der []byte, perm os.FileMode) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := pem.Encode(tmp, &pem.Block{Type: blockType, Bytes: der}); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Chmod(perm); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
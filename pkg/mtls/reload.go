@@ -0,0 +1,102 @@
+package mtls
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"os"
+	"sync"
+	"time"
+)
+
+const defaultReloadInterval = 30 * time.Second
+
+// reloader holds a certificate and a CA bundle read from disk. On use it
+// checks, at most once per interval, whether the files changed and reads
+// them again if so. A failed reload keeps the files read before in use.
+type reloader struct {
+	certFile string
+	keyFile  string
+	caFile   string
+	interval time.Duration
+
+	mu        sync.Mutex
+	cert      *tls.Certificate
+	pool      *x509.CertPool
+	modTimes  [3]time.Time
+	checkedAt time.Time
+}
+
+func newReloader(certFile, keyFile, caFile string, interval time.Duration) (*reloader, error) {
+	if interval <= 0 {
+		interval = defaultReloadInterval
+	}
+	r := &reloader{certFile: certFile, keyFile: keyFile, caFile: caFile, interval: interval}
+
+	modTimes, err := r.stat()
+	if err != nil {
+		return nil, err
+	}
+	if err := r.load(modTimes); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// current returns the certificate, nil if there is none, and the CA pool,
+// nil for the system roots.
+func (r *reloader) current() (*tls.Certificate, *x509.CertPool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if time.Since(r.checkedAt) >= r.interval {
+		r.checkedAt = time.Now()
+		if modTimes, err := r.stat(); err == nil && modTimes != r.modTimes {
+			_ = r.load(modTimes)
+		}
+	}
+	return r.cert, r.pool, nil
+}
+
+func (r *reloader) stat() ([3]time.Time, error) {
+	var modTimes [3]time.Time
+	for i, path := range []string{r.certFile, r.keyFile, r.caFile} {
+		if path == "" {
+			continue
+		}
+		info, err := os.Stat(path)
+		if err != nil {
+			return modTimes, err
+		}
+		modTimes[i] = info.ModTime()
+	}
+	return modTimes, nil
+}
+
+func (r *reloader) load(modTimes [3]time.Time) error {
+	var cert *tls.Certificate
+	if r.certFile != "" {
+		loaded, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+		if err != nil {
+			return err
+		}
+		cert = &loaded
+	}
+
+	var pool *x509.CertPool
+	if r.caFile != "" {
+		data, err := os.ReadFile(r.caFile)
+		if err != nil {
+			return err
+		}
+		pool = x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(data) {
+			return ErrNoCertificates
+		}
+	}
+
+	r.cert = cert
+	r.pool = pool
+	r.modTimes = modTimes
+	r.checkedAt = time.Now()
+	return nil
+}
@@ -0,0 +1,182 @@
+// Package pricing turns the mid rate of a currency into the price the bank
+// trades it at: a bid/ask spread around the mid rate plus a percentage
+// commission that depends on the size of the order, with a minimum.
+package pricing
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/tizzhh/micro-banking/pkg/money"
+)
+
+const (
+	// BaseCurrency is the currency prices and fees are in.
+	BaseCurrency = "USD"
+
+	bpsDenominator = 10000
+)
+
+var (
+	ErrInvalidConfig    = errors.New("invalid pricing config")
+	ErrFeeAboveProceeds = errors.New("fee is not below the proceeds")
+)
+
+// FeeTier charges Bps basis points of the gross amount of orders up to UpTo
+// minor units of the base currency. A zero UpTo does not limit the tier.
+type FeeTier struct {
+	UpTo int64
+	Bps  int64
+}
+
+// Config holds the spreads in basis points per side of the mid rate, by
+// currency code with DefaultSpreadBps for the others, the fee tiers in
+// ascending order and the minimum fee in minor units of the base currency.
+type Config struct {
+	DefaultSpreadBps int64
+	SpreadBps        map[string]int64
+	FeeTiers         []FeeTier
+	MinFee           int64
+}
+
+// Breakdown is the price of an order. MidRate and Rate are in the base
+// currency per unit of the traded one, before and after the spread. Gross is
+// the order's value at Rate and Spread what the bank earns on the rate. Total
+// is Gross plus Fee for a buy and Gross minus Fee for a sell.
+type Breakdown struct {
+	MidRate money.Rate
+	Rate    money.Rate
+	Gross   money.Money
+	Spread  money.Money
+	Fee     money.Money
+	Total   money.Money
+}
+
+type Engine struct {
+	cfg Config
+}
+
+func New(cfg Config) (*Engine, error) {
+	if cfg.DefaultSpreadBps < 0 || cfg.DefaultSpreadBps >= bpsDenominator {
+		return nil, fmt.Errorf("%w: default spread must be in [0, %d) bps", ErrInvalidConfig, bpsDenominator)
+	}
+	for code, spread := range cfg.SpreadBps {
+		if spread < 0 || spread >= bpsDenominator {
+			return nil, fmt.Errorf("%w: spread of %s must be in [0, %d) bps", ErrInvalidConfig, code, bpsDenominator)
+		}
+	}
+	for i, tier := range cfg.FeeTiers {
+		if tier.Bps < 0 || tier.Bps > bpsDenominator || tier.UpTo < 0 {
+			return nil, fmt.Errorf("%w: fee tier %d", ErrInvalidConfig, i)
+		}
+		last := i == len(cfg.FeeTiers)-1
+		if (tier.UpTo == 0 && !last) || (i > 0 && tier.UpTo != 0 && tier.UpTo <= cfg.FeeTiers[i-1].UpTo) {
+			return nil, fmt.Errorf("%w: fee tiers must be ascending with only the last unlimited", ErrInvalidConfig)
+		}
+	}
+	if cfg.MinFee < 0 {
+		return nil, fmt.Errorf("%w: negative minimum fee", ErrInvalidConfig)
+	}
+
+	return &Engine{cfg: cfg}, nil
+}
+
+// Buy prices selling amount to the user at mid, the units of amount's
+// currency per unit of the base currency. The ask side of the spread applies
+// and the order's value is rounded up.
+func (e *Engine) Buy(amount money.Money, mid money.Rate) (Breakdown, error) {
+	return e.price(amount, mid, true)
+}
+
+// Sell prices buying amount from the user at mid. The bid side of the spread
+// applies and the order's value is rounded down.
+func (e *Engine) Sell(amount money.Money, mid money.Rate) (Breakdown, error) {
+	return e.price(amount, mid, false)
+}
+
+func (e *Engine) price(amount money.Money, mid money.Rate, buy bool) (Breakdown, error) {
+	if mid.IsZero() {
+		return Breakdown{}, money.ErrInvalidRate
+	}
+
+	midRate := mid.Inverse()
+	spread := e.spreadBps(amount.Currency())
+	mode := money.RoundDown
+	factor := money.NewRate(bpsDenominator-spread, bpsDenominator)
+	if buy {
+		mode = money.RoundUp
+		factor = money.NewRate(bpsDenominator+spread, bpsDenominator)
+	}
+	rate := midRate.Mul(factor)
+
+	midValue, err := money.Convert(amount, midRate, BaseCurrency, mode)
+	if err != nil {
+		return Breakdown{}, err
+	}
+	gross, err := money.Convert(amount, rate, BaseCurrency, mode)
+	if err != nil {
+		return Breakdown{}, err
+	}
+	fee, err := e.fee(gross)
+	if err != nil {
+		return Breakdown{}, err
+	}
+
+	var earned, total money.Money
+	if buy {
+		earned, err = gross.Sub(midValue)
+		if err == nil {
+			total, err = gross.Add(fee)
+		}
+	} else {
+		earned, err = midValue.Sub(gross)
+		if err == nil {
+			total, err = gross.Sub(fee)
+		}
+	}
+	if err != nil {
+		return Breakdown{}, err
+	}
+	if !buy && !total.IsPositive() {
+		return Breakdown{}, ErrFeeAboveProceeds
+	}
+
+	return Breakdown{
+		MidRate: midRate,
+		Rate:    rate,
+		Gross:   gross,
+		Spread:  earned,
+		Fee:     fee,
+		Total:   total,
+	}, nil
+}
+
+func (e *Engine) spreadBps(currency string) int64 {
+	if spread, ok := e.cfg.SpreadBps[currency]; ok {
+		return spread
+	}
+	return e.cfg.DefaultSpreadBps
+}
+
+// fee returns the commission on gross: the percentage of the first tier gross
+// falls in, rounded up, but at least the minimum fee.
+func (e *Engine) fee(gross money.Money) (money.Money, error) {
+	fee := money.New(0, BaseCurrency)
+	for _, tier := range e.cfg.FeeTiers {
+		if tier.UpTo != 0 && gross.Amount() > tier.UpTo {
+			continue
+		}
+		if tier.Bps > 0 {
+			var err error
+			fee, err = money.Convert(gross, money.NewRate(tier.Bps, bpsDenominator), BaseCurrency, money.RoundUp)
+			if err != nil {
+				return money.Money{}, err
+			}
+		}
+		break
+	}
+	if fee.Amount() < e.cfg.MinFee {
+		fee = money.New(e.cfg.MinFee, BaseCurrency)
+	}
+	return fee, nil
+}
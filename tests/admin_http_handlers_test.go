@@ -0,0 +1,589 @@
+package tests
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/tizzhh/micro-banking/internal/api/permissions"
+	"github.com/tizzhh/micro-banking/internal/api/principal"
+	adminApi "github.com/tizzhh/micro-banking/internal/delivery/http/bank/resource/admin"
+	adminMocks "github.com/tizzhh/micro-banking/internal/delivery/http/bank/resource/admin/mocks"
+	authentication "github.com/tizzhh/micro-banking/internal/delivery/http/bank/router/middleware/auth"
+	adminModels "github.com/tizzhh/micro-banking/internal/domain/admin/models"
+	"github.com/tizzhh/micro-banking/internal/domain/auth/models"
+	currencyModels "github.com/tizzhh/micro-banking/internal/domain/currency/models"
+	adminErrors "github.com/tizzhh/micro-banking/internal/services/admin/errors"
+	"github.com/tizzhh/micro-banking/pkg/money"
+)
+
+const (
+	adjustmentRequestTemplate  = `{"amount": "%s","currency_code": "%s","reason_code": "%s","comment": "%s"}`
+	adjustmentResponseTemplate = `{"new_balance_amount":"%s","currency_code":"%s"}`
+
+	usersResponseTemplate   = `{"users":[{"id":%d,"email":"%s","first_name":"%s","last_name":"%s","age":%d,"roles":[],"frozen":false}],"next_after":%d}`
+	balanceResponseTemplate = `{"user_id":%d,"frozen":true,"balance":"%s","wallets":[{"currency_code":"%s","balance":"%s"}]}`
+
+	adminCurrencyResponseTemplate = `{"code":"%s","name":"%s","exponent":%d,"enabled":%t,"tradable":%t,"min_order":"%s","max_order":"%s"}`
+)
+
+// adminRouter serves the admin routes the way the bank router does, for a
+// caller with roles.
+func adminRouter(admin adminApi.Administrator, roles ...string) http.Handler {
+	api := adminApi.New(log, validation, admin)
+
+	router := chi.NewRouter()
+	router.Use(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := principal.WithPrincipal(r.Context(), principal.Principal{
+				UserID: testPrincipalUserID,
+				Email:  "test-admin@gmail.com",
+				Roles:  roles,
+			})
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	})
+	router.Route("/v1/admin", func(r chi.Router) {
+		r.Group(func(r chi.Router) {
+			r.Use(authentication.Authorize(log, permissions.New(nil, permissions.DefaultPolicy)))
+
+			r.Method(http.MethodGet, "/users", api.Users())
+			r.Method(http.MethodGet, "/users/{id}", api.User())
+			r.Method(http.MethodGet, "/users/{id}/balance", api.Balance())
+			r.Method(http.MethodPost, "/users/{id}/freeze", api.Freeze())
+			r.Method(http.MethodPost, "/users/{id}/unfreeze", api.Unfreeze())
+			r.Method(http.MethodPost, "/users/{id}/unlock", api.Unlock())
+			r.Method(http.MethodPost, "/users/{id}/adjustments", api.Adjust())
+			r.Method(http.MethodPost, "/currencies", api.CreateCurrency())
+			r.Method(http.MethodPatch, "/currencies/{code}", api.UpdateCurrency())
+		})
+	})
+	return router
+}
+
+func TestPermissions_Allowed(t *testing.T) {
+	checker := permissions.New(nil, permissions.DefaultPolicy)
+
+	assert.True(t, checker.Allowed([]string{permissions.RoleAdmin}, "POST /v1/admin/users/{id}/adjustments"))
+	assert.True(t, checker.Allowed([]string{permissions.RoleSupport}, "GET /v1/admin/users/{id}/balance"))
+	assert.True(t, checker.Allowed([]string{"unknown", permissions.RoleSupport}, "GET /v1/admin/users"))
+	assert.True(t, checker.Allowed([]string{permissions.RoleAdmin}, "PATCH /v1/admin/currencies/{code}"))
+	assert.False(t, checker.Allowed([]string{permissions.RoleSupport}, "POST /v1/admin/users/{id}/freeze"))
+	assert.False(t, checker.Allowed([]string{permissions.RoleSupport}, "POST /v1/admin/currencies"))
+	assert.False(t, checker.Allowed(nil, "GET /v1/admin/users"))
+	assert.False(t, checker.Allowed([]string{permissions.RoleAdmin}, "DELETE /v1/admin/users/{id}"))
+	assert.False(t, checker.Allowed([]string{permissions.RoleAdmin}, ""))
+}
+
+func TestAdminAuthorization_Cases(t *testing.T) {
+	tests := []struct {
+		name           string
+		roles          []string
+		method         string
+		path           string
+		expectedStatus int
+	}{
+		{
+			name:           "Customer listing users",
+			method:         http.MethodGet,
+			path:           "/v1/admin/users",
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "Support listing users",
+			roles:          []string{permissions.RoleSupport},
+			method:         http.MethodGet,
+			path:           "/v1/admin/users",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Support viewing a balance",
+			roles:          []string{permissions.RoleSupport},
+			method:         http.MethodGet,
+			path:           "/v1/admin/users/7/balance",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Support freezing an account",
+			roles:          []string{permissions.RoleSupport},
+			method:         http.MethodPost,
+			path:           "/v1/admin/users/7/freeze",
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "Admin freezing an account",
+			roles:          []string{permissions.RoleAdmin},
+			method:         http.MethodPost,
+			path:           "/v1/admin/users/7/freeze",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Admin unfreezing an account",
+			roles:          []string{permissions.RoleAdmin},
+			method:         http.MethodPost,
+			path:           "/v1/admin/users/7/unfreeze",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Support unlocking an account",
+			roles:          []string{permissions.RoleSupport},
+			method:         http.MethodPost,
+			path:           "/v1/admin/users/7/unlock",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Support creating a currency",
+			roles:          []string{permissions.RoleSupport},
+			method:         http.MethodPost,
+			path:           "/v1/admin/currencies",
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "User unlocking an account",
+			roles:          nil,
+			method:         http.MethodPost,
+			path:           "/v1/admin/users/7/unlock",
+			expectedStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAdmin := adminMocks.NewAdministrator(t)
+			mockAdmin.On("Users", mock.Anything, uint64(0), 0).Return([]models.User{}, nil).Maybe()
+			mockAdmin.On("Balance", mock.Anything, uint64(7)).Return(adminModels.Balance{UserID: 7, Cash: money.New(0, "USD")}, nil).Maybe()
+			mockAdmin.On("SetFrozen", mock.Anything, uint64(7), mock.Anything).Return(nil).Maybe()
+			mockAdmin.On("Unlock", mock.Anything, uint64(7)).Return(nil).Maybe()
+
+			req, err := http.NewRequest(tt.method, tt.path, nil)
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+			adminRouter(mockAdmin, tt.roles...).ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			if tt.expectedStatus == http.StatusForbidden {
+				assert.Equal(t, fmt.Sprintf(
+					errorResponseTemplate,
+					"permission denied",
+				), strings.TrimRight(rr.Body.String(), "\n"))
+			}
+		})
+	}
+}
+
+func TestAdminUsers_HappyPath(t *testing.T) {
+	user := models.User{ID: 7, Email: "test-user0@gmail.com", FirstName: "Test", LastName: "User", Age: 20}
+
+	mockAdmin := adminMocks.NewAdministrator(t)
+	mockAdmin.On("Users", mock.Anything, uint64(3), 1).Return([]models.User{user}, nil)
+
+	req, err := http.NewRequest(http.MethodGet, "/v1/admin/users?after=3&limit=1", nil)
+	require.NoError(t, err)
+
+	rr := httptest.NewRecorder()
+	adminRouter(mockAdmin, permissions.RoleSupport).ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, fmt.Sprintf(
+		usersResponseTemplate,
+		user.ID,
+		user.Email,
+		user.FirstName,
+		user.LastName,
+		user.Age,
+		user.ID,
+	), strings.TrimRight(rr.Body.String(), "\n"))
+}
+
+func TestAdminBalance_HappyPath(t *testing.T) {
+	mockAdmin := adminMocks.NewAdministrator(t)
+	mockAdmin.On("Balance", mock.Anything, uint64(7)).Return(adminModels.Balance{
+		UserID:  7,
+		Frozen:  true,
+		Cash:    money.New(1234, "USD"),
+		Wallets: []money.Money{money.New(50, "EUR")},
+	}, nil)
+
+	req, err := http.NewRequest(http.MethodGet, "/v1/admin/users/7/balance", nil)
+	require.NoError(t, err)
+
+	rr := httptest.NewRecorder()
+	adminRouter(mockAdmin, permissions.RoleAdmin).ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, fmt.Sprintf(
+		balanceResponseTemplate,
+		7,
+		"12.34",
+		"EUR",
+		"0.50",
+	), strings.TrimRight(rr.Body.String(), "\n"))
+}
+
+func TestAdminUnlockHttp_Cases(t *testing.T) {
+	tests := []struct {
+		name            string
+		path            string
+		adminErr        error
+		expectedStatus  int
+		expectedMessage string
+	}{
+		{
+			name:            "Unlock an account",
+			path:            "/v1/admin/users/7/unlock",
+			expectedStatus:  http.StatusOK,
+			expectedMessage: `{"message":"account unlocked"}`,
+		},
+		{
+			name:            "Unlock an unknown user",
+			path:            "/v1/admin/users/7/unlock",
+			adminErr:        adminErrors.ErrUserNotFound,
+			expectedStatus:  http.StatusNotFound,
+			expectedMessage: fmt.Sprintf(errorResponseTemplate, "user not found"),
+		},
+		{
+			name:            "Unlock with an invalid user id",
+			path:            "/v1/admin/users/abc/unlock",
+			expectedStatus:  http.StatusBadRequest,
+			expectedMessage: fmt.Sprintf(errorResponseTemplate, "invalid user id"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAdmin := adminMocks.NewAdministrator(t)
+			if tt.expectedStatus != http.StatusBadRequest {
+				mockAdmin.On("Unlock", mock.Anything, uint64(7)).Return(tt.adminErr)
+			}
+
+			req, err := http.NewRequest(http.MethodPost, tt.path, nil)
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+			adminRouter(mockAdmin, permissions.RoleAdmin).ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			assert.Equal(t, tt.expectedMessage, strings.TrimRight(rr.Body.String(), "\n"))
+		})
+	}
+}
+
+func TestAdminAdjust_HappyPath(t *testing.T) {
+	reqBody := []byte(fmt.Sprintf(
+		adjustmentRequestTemplate,
+		"-5.00",
+		"USD",
+		"chargeback",
+		"disputed card payment",
+	))
+
+	mockAdmin := adminMocks.NewAdministrator(t)
+	mockAdmin.On(
+		"Adjust",
+		mock.Anything,
+		testPrincipalUserID,
+		uint64(7),
+		money.New(-500, "USD"),
+		adminModels.ReasonChargeback,
+		"disputed card payment",
+	).Return(money.New(1500, "USD"), nil)
+
+	req, err := http.NewRequest(http.MethodPost, "/v1/admin/users/7/adjustments", bytes.NewBuffer(reqBody))
+	require.NoError(t, err)
+
+	rr := httptest.NewRecorder()
+	adminRouter(mockAdmin, permissions.RoleAdmin).ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, fmt.Sprintf(
+		adjustmentResponseTemplate,
+		"15.00",
+		"USD",
+	), strings.TrimRight(rr.Body.String(), "\n"))
+}
+
+func TestAdminAdjustHttp_FailCases(t *testing.T) {
+	tests := []struct {
+		name           string
+		path           string
+		amount         string
+		reasonCode     string
+		serviceErr     error
+		expectedErr    string
+		expectedStatus int
+	}{
+		{
+			name:           "Missing reason code",
+			path:           "/v1/admin/users/7/adjustments",
+			amount:         "5.00",
+			reasonCode:     "",
+			expectedErr:    "field ReasonCode is a required field",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Unknown reason code",
+			path:           "/v1/admin/users/7/adjustments",
+			amount:         "5.00",
+			reasonCode:     "because",
+			expectedErr:    "field ReasonCode is not valid",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Invalid user id",
+			path:           "/v1/admin/users/abc/adjustments",
+			amount:         "5.00",
+			reasonCode:     "correction",
+			expectedErr:    "invalid user id",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Fraction of a cent",
+			path:           "/v1/admin/users/7/adjustments",
+			amount:         "5.001",
+			reasonCode:     "correction",
+			expectedErr:    "amount has more decimal places than the currency allows",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Own balance",
+			path:           "/v1/admin/users/7/adjustments",
+			amount:         "5.00",
+			reasonCode:     "goodwill",
+			serviceErr:     adminErrors.ErrSelfAdjustment,
+			expectedErr:    "cannot adjust your own balance",
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "Debit above balance",
+			path:           "/v1/admin/users/7/adjustments",
+			amount:         "-5.00",
+			reasonCode:     "fraud",
+			serviceErr:     adminErrors.ErrNotEnoughMoney,
+			expectedErr:    "not enough money on balance",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "User not found",
+			path:           "/v1/admin/users/7/adjustments",
+			amount:         "5.00",
+			reasonCode:     "refund",
+			serviceErr:     adminErrors.ErrUserNotFound,
+			expectedErr:    "user not found",
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reqBody := []byte(fmt.Sprintf(
+				adjustmentRequestTemplate,
+				tt.amount,
+				"USD",
+				tt.reasonCode,
+				"",
+			))
+
+			mockAdmin := adminMocks.NewAdministrator(t)
+			if tt.serviceErr != nil {
+				mockAdmin.On(
+					"Adjust",
+					mock.Anything,
+					testPrincipalUserID,
+					uint64(7),
+					mock.Anything,
+					adminModels.ReasonCode(tt.reasonCode),
+					"",
+				).Return(money.Money{}, tt.serviceErr)
+			}
+
+			req, err := http.NewRequest(http.MethodPost, tt.path, bytes.NewBuffer(reqBody))
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+			adminRouter(mockAdmin, permissions.RoleAdmin).ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			assert.Equal(t, fmt.Sprintf(
+				errorResponseTemplate,
+				tt.expectedErr,
+			), strings.TrimRight(rr.Body.String(), "\n"))
+		})
+	}
+}
+
+func TestAdminCreateCurrency_HappyPath(t *testing.T) {
+	reqBody := `{"code":"KWD","name":"Kuwaiti Dinar","exponent":3,"tradable":true,"min_order":"0.5","max_order":"1000"}`
+	currency := currencyModels.Currency{
+		Code:     "KWD",
+		Name:     "Kuwaiti Dinar",
+		Exponent: 3,
+		Enabled:  true,
+		Tradable: true,
+		MinOrder: 500,
+		MaxOrder: 1000000,
+	}
+
+	mockAdmin := adminMocks.NewAdministrator(t)
+	mockAdmin.On("CreateCurrency", mock.Anything, currency).Return(currency, nil)
+
+	req, err := http.NewRequest(http.MethodPost, "/v1/admin/currencies", strings.NewReader(reqBody))
+	require.NoError(t, err)
+
+	// the service registers the exponent of created currencies
+	money.SetExponent("KWD", 3)
+
+	rr := httptest.NewRecorder()
+	adminRouter(mockAdmin, permissions.RoleAdmin).ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusCreated, rr.Code)
+	assert.Equal(t, fmt.Sprintf(
+		adminCurrencyResponseTemplate,
+		"KWD",
+		"Kuwaiti Dinar",
+		3,
+		true,
+		true,
+		"0.500",
+		"1000.000",
+	), strings.TrimRight(rr.Body.String(), "\n"))
+}
+
+func TestAdminUpdateCurrency_HappyPath(t *testing.T) {
+	reqBody := `{"tradable":false,"max_order":"500.00"}`
+	tradable := false
+	maxOrder := uint64(50000)
+
+	mockAdmin := adminMocks.NewAdministrator(t)
+	mockAdmin.On("UpdateCurrency", mock.Anything, "EUR", currencyModels.CurrencyUpdate{
+		Tradable: &tradable,
+		MaxOrder: &maxOrder,
+	}).Return(currencyModels.Currency{
+		Code:     "EUR",
+		Name:     "Euro",
+		Exponent: 2,
+		Enabled:  true,
+		MinOrder: 100,
+		MaxOrder: maxOrder,
+	}, nil)
+
+	req, err := http.NewRequest(http.MethodPatch, "/v1/admin/currencies/EUR", strings.NewReader(reqBody))
+	require.NoError(t, err)
+
+	rr := httptest.NewRecorder()
+	adminRouter(mockAdmin, permissions.RoleAdmin).ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, fmt.Sprintf(
+		adminCurrencyResponseTemplate,
+		"EUR",
+		"Euro",
+		2,
+		true,
+		false,
+		"1.00",
+		"500.00",
+	), strings.TrimRight(rr.Body.String(), "\n"))
+}
+
+func TestAdminCurrencyHttp_FailCases(t *testing.T) {
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		body           string
+		serviceErr     error
+		expectedErr    string
+		expectedStatus int
+	}{
+		{
+			name:           "Create with lowercase code",
+			method:         http.MethodPost,
+			path:           "/v1/admin/currencies",
+			body:           `{"code":"kwd","name":"Kuwaiti Dinar","exponent":3}`,
+			expectedErr:    "field Code is not valid",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Create without exponent",
+			method:         http.MethodPost,
+			path:           "/v1/admin/currencies",
+			body:           `{"code":"KWD","name":"Kuwaiti Dinar"}`,
+			expectedErr:    "field Exponent is a required field",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Create with negative min order",
+			method:         http.MethodPost,
+			path:           "/v1/admin/currencies",
+			body:           `{"code":"KWD","name":"Kuwaiti Dinar","exponent":3,"min_order":"-1"}`,
+			expectedErr:    "order limits must be non-negative amounts of the currency",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Create existing currency",
+			method:         http.MethodPost,
+			path:           "/v1/admin/currencies",
+			body:           `{"code":"EUR","name":"Euro","exponent":2}`,
+			serviceErr:     adminErrors.ErrCurrencyExists,
+			expectedErr:    "currency already exists",
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name:           "Max order below min order",
+			method:         http.MethodPatch,
+			path:           "/v1/admin/currencies/EUR",
+			body:           `{"max_order":"0.50"}`,
+			serviceErr:     adminErrors.ErrInvalidOrderLimits,
+			expectedErr:    "max order is below min order",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Trade the base currency",
+			method:         http.MethodPatch,
+			path:           "/v1/admin/currencies/USD",
+			body:           `{"tradable":true}`,
+			serviceErr:     adminErrors.ErrBaseCurrency,
+			expectedErr:    "the base currency cannot be disabled or traded",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Update unknown currency",
+			method:         http.MethodPatch,
+			path:           "/v1/admin/currencies/XYZ",
+			body:           `{"enabled":false}`,
+			serviceErr:     adminErrors.ErrCurrencyCodeNotFound,
+			expectedErr:    "currency code not found",
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAdmin := adminMocks.NewAdministrator(t)
+			if tt.serviceErr != nil {
+				mockAdmin.On("CreateCurrency", mock.Anything, mock.Anything).Return(currencyModels.Currency{}, tt.serviceErr).Maybe()
+				mockAdmin.On("UpdateCurrency", mock.Anything, mock.Anything, mock.Anything).Return(currencyModels.Currency{}, tt.serviceErr).Maybe()
+			}
+
+			req, err := http.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+			adminRouter(mockAdmin, permissions.RoleAdmin).ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			assert.Equal(t, fmt.Sprintf(
+				errorResponseTemplate,
+				tt.expectedErr,
+			), strings.TrimRight(rr.Body.String(), "\n"))
+		})
+	}
+}
@@ -3,11 +3,15 @@ package tests
 import (
 	"bytes"
 	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/brianvoe/gofakeit/v6"
 	"github.com/go-playground/validator/v10"
@@ -16,8 +20,10 @@ import (
 	"google.golang.org/grpc/status"
 
 	"github.com/stretchr/testify/require"
+	"github.com/tizzhh/micro-banking/internal/api/principal"
 	authApi "github.com/tizzhh/micro-banking/internal/delivery/http/bank/resource/auth"
 	authMocks "github.com/tizzhh/micro-banking/internal/delivery/http/bank/resource/auth/mocks"
+	jwtpkg "github.com/tizzhh/micro-banking/pkg/jwt"
 	"github.com/tizzhh/micro-banking/pkg/logger/sl"
 )
 
@@ -28,19 +34,23 @@ const (
 		"last_name": "%s",
 		"age": %d
 	}`
-	registerResponseTemplate = `{"user_id":%d,"email":"%s","first_name":"%s","last_name":"%s","balance":%d,"age":%d}`
+	registerResponseTemplate = `{"user_id":%d,"email":"%s","first_name":"%s","last_name":"%s","balance":%d,"age":%d,"email_verified":false,"phone_number":"","status":"pending_verification"}`
 
 	loginRequestTemplate  = `{"email": "%s","password": "%s"}`
-	loginResponseTemplate = `{"token":"%s"}`
+	loginResponseTemplate = `{"token":"%s","refresh_token":"%s"}`
 
-	updatePassRequestTemplate  = `{"email": "%s","new_password": "%s","old_password": "%s"}`
+	refreshRequestTemplate = `{"refresh_token": "%s"}`
+
+	logoutRequestTemplate  = `{"refresh_token": "%s"}`
+	logoutResponseTemplate = `{"message":"%s"}`
+
+	updatePassRequestTemplate  = `{"new_password": "%s","old_password": "%s"}`
 	updatePassResponseTemplate = `{"message":"%s"}`
 
-	deleteRequestTemplate  = `{"email": "%s","password": "%s"}`
+	deleteRequestTemplate  = `{"password": "%s"}`
 	deleteResponseTemplate = `{"message":"%s"}`
 
-	userRequestTemplate  = `{"email": "%s"}`
-	userResponseTemplate = `{"user_id":%d,"email":"%s","first_name":"%s","last_name":"%s","balance":%d,"age":%d}`
+	userResponseTemplate = `{"user_id":%d,"email":"%s","first_name":"%s","last_name":"%s","balance":%d,"age":%d,"email_verified":%t,"phone_number":"%s","status":"%s","created_at":"%s","updated_at":"%s"}`
 
 	errorResponseTemplate = `{"error":"%s"}`
 )
@@ -262,6 +272,7 @@ func TestLogin_HappyPath(t *testing.T) {
 	testUserEmail := "test-user0@gmail.com"
 	testUserName := "testt"
 	testToken := "token"
+	testRefreshToken := "refresh-token"
 
 	reqBody := []byte(fmt.Sprintf(
 		loginRequestTemplate,
@@ -279,7 +290,7 @@ func TestLogin_HappyPath(t *testing.T) {
 		context.Background(),
 		testUserEmail,
 		testUserName,
-	).Return(testToken, nil)
+	).Return(authApi.LoginResponse{Token: testToken, RefreshToken: testRefreshToken}, nil)
 	auth := authApi.New(log, validation, mockClient)
 
 	rr := httptest.NewRecorder()
@@ -291,6 +302,7 @@ func TestLogin_HappyPath(t *testing.T) {
 	assert.Equal(t, fmt.Sprintf(
 		loginResponseTemplate,
 		testToken,
+		testRefreshToken,
 	), strings.TrimRight(rr.Body.String(), "\n"))
 }
 
@@ -362,6 +374,43 @@ func TestLoginHttp_FailCases(t *testing.T) {
 	}
 }
 
+func TestLoginTooManyAttempts_Fail(t *testing.T) {
+	testingEmail := "test@gmail.com"
+	testingPass := randomFakePassword()
+
+	excpectedErr := "too many failed login attempts, try again later"
+
+	reqBody := []byte(fmt.Sprintf(
+		loginRequestTemplate,
+		testingEmail,
+		testingPass,
+	))
+	bodyReader := bytes.NewBuffer(reqBody)
+
+	req, err := http.NewRequest(http.MethodPost, "/auth/login", bodyReader)
+	require.NoError(t, err)
+
+	mockClient := authMocks.NewAuthClient(t)
+	mockClient.On(
+		"Login",
+		context.Background(),
+		testingEmail,
+		testingPass,
+	).Return(authApi.LoginResponse{}, status.Error(codes.ResourceExhausted, excpectedErr))
+	auth := authApi.New(log, validation, mockClient)
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(auth.LoginUser())
+
+	handler.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusTooManyRequests, rr.Code)
+	assert.Equal(t, fmt.Sprintf(
+		errorResponseTemplate,
+		excpectedErr,
+	), strings.TrimRight(rr.Body.String(), "\n"))
+}
+
 func TestLoginNonMatchingPassword_Fail(t *testing.T) {
 	testingEmail := "test@gmail.com"
 	testingPass := randomFakePassword()
@@ -384,7 +433,7 @@ func TestLoginNonMatchingPassword_Fail(t *testing.T) {
 		context.Background(),
 		testingEmail,
 		testingPass,
-	).Return("", status.Error(codes.InvalidArgument, "invalid credentials"))
+	).Return(authApi.LoginResponse{}, status.Error(codes.InvalidArgument, "invalid credentials"))
 	auth := authApi.New(log, validation, mockClient)
 
 	rr := httptest.NewRecorder()
@@ -399,6 +448,129 @@ func TestLoginNonMatchingPassword_Fail(t *testing.T) {
 	), strings.TrimRight(rr.Body.String(), "\n"))
 }
 
+func TestRefresh_HappyPath(t *testing.T) {
+	testRefreshToken := "refresh-token"
+	testNewToken := "new-token"
+	testNewRefreshToken := "new-refresh-token"
+
+	reqBody := []byte(fmt.Sprintf(refreshRequestTemplate, testRefreshToken))
+	bodyReader := bytes.NewBuffer(reqBody)
+
+	req, err := http.NewRequest(http.MethodPost, "/auth/refresh", bodyReader)
+	require.NoError(t, err)
+
+	mockClient := authMocks.NewAuthClient(t)
+	mockClient.On(
+		"Refresh",
+		context.Background(),
+		testRefreshToken,
+	).Return(authApi.LoginResponse{Token: testNewToken, RefreshToken: testNewRefreshToken}, nil)
+	auth := authApi.New(log, validation, mockClient)
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(auth.Refresh())
+
+	handler.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, fmt.Sprintf(
+		loginResponseTemplate,
+		testNewToken,
+		testNewRefreshToken,
+	), strings.TrimRight(rr.Body.String(), "\n"))
+}
+
+func TestRefreshHttp_FailCases(t *testing.T) {
+	tests := []struct {
+		name           string
+		refreshToken   string
+		grpcErr        error
+		expectedErr    string
+		expectedStatus int
+	}{
+		{
+			name:           "Refresh with empty token",
+			refreshToken:   "",
+			expectedErr:    "field RefreshToken is a required field",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Refresh with revoked token",
+			refreshToken:   "revoked",
+			grpcErr:        status.Error(codes.Unauthenticated, "invalid refresh token"),
+			expectedErr:    "invalid refresh token",
+			expectedStatus: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			reqBody := []byte(fmt.Sprintf(refreshRequestTemplate, tt.refreshToken))
+			bodyReader := bytes.NewBuffer(reqBody)
+
+			req, err := http.NewRequest(http.MethodPost, "/auth/refresh", bodyReader)
+			require.NoError(t, err)
+
+			mockClient := authMocks.NewAuthClient(t)
+			if tt.grpcErr != nil {
+				mockClient.On(
+					"Refresh",
+					context.Background(),
+					tt.refreshToken,
+				).Return(authApi.LoginResponse{}, tt.grpcErr)
+			}
+			auth := authApi.New(log, validation, mockClient)
+
+			rr := httptest.NewRecorder()
+			handler := http.HandlerFunc(auth.Refresh())
+
+			handler.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			assert.Equal(t, fmt.Sprintf(
+				errorResponseTemplate,
+				tt.expectedErr,
+			), strings.TrimRight(rr.Body.String(), "\n"))
+		})
+	}
+}
+
+func TestLogout_HappyPath(t *testing.T) {
+	testUserEmail := "test-user0@gmail.com"
+	testToken := "token"
+	testRefreshToken := "refresh-token"
+
+	testResponse := "Logged out successfully"
+
+	reqBody := []byte(fmt.Sprintf(logoutRequestTemplate, testRefreshToken))
+	bodyReader := bytes.NewBuffer(reqBody)
+
+	ctx := principal.WithToken(principalContext(testUserEmail), testToken)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "/auth/logout", bodyReader)
+	require.NoError(t, err)
+
+	mockClient := authMocks.NewAuthClient(t)
+	mockClient.On(
+		"Logout",
+		ctx,
+		testToken,
+		testRefreshToken,
+	).Return(nil)
+	auth := authApi.New(log, validation, mockClient)
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(auth.Logout())
+
+	handler.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, fmt.Sprintf(
+		logoutResponseTemplate,
+		testResponse,
+	), strings.TrimRight(rr.Body.String(), "\n"))
+}
+
 func TestUpdatePassword_HappyPath(t *testing.T) {
 	testUserEmail := "test-user0@gmail.com"
 	testUserOldPassword := "test"
@@ -408,19 +580,19 @@ func TestUpdatePassword_HappyPath(t *testing.T) {
 
 	reqBody := []byte(fmt.Sprintf(
 		updatePassRequestTemplate,
-		testUserEmail,
 		testUserOldPassword,
 		testUserNewPassword,
 	))
 	bodyReader := bytes.NewBuffer(reqBody)
 
-	req, err := http.NewRequest(http.MethodPut, "/auth/change-password", bodyReader)
+	ctx := principalContext(testUserEmail)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, "/auth/change-password", bodyReader)
 	require.NoError(t, err)
 
 	mockClient := authMocks.NewAuthClient(t)
 	mockClient.On(
 		"UpdatePassword",
-		context.Background(),
+		ctx,
 		testUserEmail,
 		testUserNewPassword,
 		testUserOldPassword,
@@ -442,7 +614,6 @@ func TestUpdatePassword_HappyPath(t *testing.T) {
 func TestUpdatePasswordHttp_FailCases(t *testing.T) {
 	tests := []struct {
 		name           string
-		email          string
 		oldPassword    string
 		newPassword    string
 		expectedErr    string
@@ -450,36 +621,18 @@ func TestUpdatePasswordHttp_FailCases(t *testing.T) {
 	}{
 		{
 			name:           "Update password with empty old password",
-			email:          gofakeit.Email(),
 			oldPassword:    "",
 			newPassword:    randomFakePassword(),
 			expectedErr:    "field OldPassword is a required field",
 			expectedStatus: 400,
 		},
-		{
-			name:           "Update password with empty email",
-			email:          "",
-			oldPassword:    randomFakePassword(),
-			newPassword:    randomFakePassword(),
-			expectedErr:    "field Email is a required field",
-			expectedStatus: 400,
-		},
 		{
 			name:           "Update password with empty new password",
-			email:          gofakeit.Email(),
 			oldPassword:    randomFakePassword(),
 			newPassword:    "",
 			expectedErr:    "field NewPassword is a required field",
 			expectedStatus: 400,
 		},
-		{
-			name:           "Update password with empty email and password",
-			email:          "",
-			oldPassword:    "",
-			newPassword:    randomFakePassword(),
-			expectedErr:    "field Email is a required field field OldPassword is a required field",
-			expectedStatus: 400,
-		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -487,13 +640,13 @@ func TestUpdatePasswordHttp_FailCases(t *testing.T) {
 
 			reqBody := []byte(fmt.Sprintf(
 				updatePassRequestTemplate,
-				tt.email,
 				tt.newPassword,
 				tt.oldPassword,
 			))
 			bodyReader := bytes.NewBuffer(reqBody)
 
-			req, err := http.NewRequest(http.MethodPut, "/auth/change-password", bodyReader)
+			ctx := principalContext(gofakeit.Email())
+			req, err := http.NewRequestWithContext(ctx, http.MethodPut, "/auth/change-password", bodyReader)
 			require.NoError(t, err)
 
 			mockClient := authMocks.NewAuthClient(t)
@@ -522,19 +675,19 @@ func TestUpdateNonMatchingOldPassword_Fail(t *testing.T) {
 
 	reqBody := []byte(fmt.Sprintf(
 		updatePassRequestTemplate,
-		testingEmail,
 		testingOldPass,
 		testingNewPass,
 	))
 	bodyReader := bytes.NewBuffer(reqBody)
 
-	req, err := http.NewRequest(http.MethodPost, "/auth/change-password", bodyReader)
+	ctx := principalContext(testingEmail)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "/auth/change-password", bodyReader)
 	require.NoError(t, err)
 
 	mockClient := authMocks.NewAuthClient(t)
 	mockClient.On(
 		"UpdatePassword",
-		context.Background(),
+		ctx,
 		testingEmail,
 		testingNewPass,
 		testingOldPass,
@@ -561,18 +714,18 @@ func TestDeletePassword_HappyPath(t *testing.T) {
 
 	reqBody := []byte(fmt.Sprintf(
 		deleteRequestTemplate,
-		testUserEmail,
 		testUserPassword,
 	))
 	bodyReader := bytes.NewBuffer(reqBody)
 
-	req, err := http.NewRequest(http.MethodDelete, "/auth/unregister", bodyReader)
+	ctx := principalContext(testUserEmail)
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, "/auth/unregister", bodyReader)
 	require.NoError(t, err)
 
 	mockClient := authMocks.NewAuthClient(t)
 	mockClient.On(
 		"Unregister",
-		context.Background(),
+		ctx,
 		testUserEmail,
 		testUserPassword,
 	).Return(nil)
@@ -593,44 +746,28 @@ func TestDeletePassword_HappyPath(t *testing.T) {
 func TestDeletePasswordHttp_FailCases(t *testing.T) {
 	tests := []struct {
 		name           string
-		email          string
 		password       string
 		expectedErr    string
 		expectedStatus int
 	}{
 		{
 			name:           "Delete user with empty old password",
-			email:          gofakeit.Email(),
 			password:       "",
 			expectedErr:    "field Password is a required field",
 			expectedStatus: 400,
 		},
-		{
-			name:           "Delete user with empty email",
-			email:          "",
-			password:       randomFakePassword(),
-			expectedErr:    "field Email is a required field",
-			expectedStatus: 400,
-		},
-		{
-			name:           "Delete user with empty email and password",
-			email:          "",
-			password:       "",
-			expectedErr:    "field Email is a required field field Password is a required field",
-			expectedStatus: 400,
-		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			reqBody := []byte(fmt.Sprintf(
 				deleteRequestTemplate,
-				tt.email,
 				tt.password,
 			))
 			bodyReader := bytes.NewBuffer(reqBody)
 
-			req, err := http.NewRequest(http.MethodDelete, "/auth/unregister", bodyReader)
+			ctx := principalContext(gofakeit.Email())
+			req, err := http.NewRequestWithContext(ctx, http.MethodDelete, "/auth/unregister", bodyReader)
 			require.NoError(t, err)
 
 			mockClient := authMocks.NewAuthClient(t)
@@ -660,18 +797,18 @@ func TestDeletePasswordNonMatchingPassword_HappyPath(t *testing.T) {
 
 	reqBody := []byte(fmt.Sprintf(
 		deleteRequestTemplate,
-		testUserEmail,
 		testUserPassword,
 	))
 	bodyReader := bytes.NewBuffer(reqBody)
 
-	req, err := http.NewRequest(http.MethodDelete, "/auth/unregister", bodyReader)
+	ctx := principalContext(testUserEmail)
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, "/auth/unregister", bodyReader)
 	require.NoError(t, err)
 
 	mockClient := authMocks.NewAuthClient(t)
 	mockClient.On(
 		"Unregister",
-		context.Background(),
+		ctx,
 		testUserEmail,
 		testUserPassword,
 	).Return(status.Error(codes.NotFound, "user not found"))
@@ -689,35 +826,74 @@ func TestDeletePasswordNonMatchingPassword_HappyPath(t *testing.T) {
 	), strings.TrimRight(rr.Body.String(), "\n"))
 }
 
-func TestUser_HappyPath(t *testing.T) {
+func TestDeleteUserWithFunds_Fail(t *testing.T) {
 	testUserEmail := "test-user0@gmail.com"
-	testUserName := "testt"
-	var testUserAge uint32 = 18
-	testUserBalance := 0
-	var testUserId uint64 = 0
+	testUserPassword := "admin"
+
+	excpectedErr := "account has a non-zero balance, withdraw or sell it first"
 
 	reqBody := []byte(fmt.Sprintf(
-		userRequestTemplate,
-		testUserEmail,
+		deleteRequestTemplate,
+		testUserPassword,
 	))
 	bodyReader := bytes.NewBuffer(reqBody)
 
-	req, err := http.NewRequest(http.MethodGet, "/auth/user", bodyReader)
+	ctx := principalContext(testUserEmail)
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, "/auth/unregister", bodyReader)
+	require.NoError(t, err)
+
+	mockClient := authMocks.NewAuthClient(t)
+	mockClient.On(
+		"Unregister",
+		ctx,
+		testUserEmail,
+		testUserPassword,
+	).Return(status.Error(codes.FailedPrecondition, excpectedErr))
+	auth := authApi.New(log, validation, mockClient)
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(auth.DeleteUser())
+
+	handler.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Equal(t, fmt.Sprintf(
+		errorResponseTemplate,
+		excpectedErr,
+	), strings.TrimRight(rr.Body.String(), "\n"))
+}
+
+func TestUser_HappyPath(t *testing.T) {
+	testUserEmail := "test-user0@gmail.com"
+	testUserName := "testt"
+	var testUserAge uint32 = 18
+	testUserBalance := 0
+	var testUserId uint64 = 1
+	testUserPhone := "+14155550100"
+	testUserCreatedAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	ctx := principalContext(testUserEmail)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "/auth/user", nil)
 	require.NoError(t, err)
 
 	exceptedResponse := authApi.UserResponse{
-		ID:        testUserId,
-		Email:     testUserEmail,
-		FirstName: testUserName,
-		LastName:  testUserName,
-		Balance:   uint64(testUserBalance),
-		Age:       testUserAge,
+		ID:            testUserId,
+		Email:         testUserEmail,
+		FirstName:     testUserName,
+		LastName:      testUserName,
+		Balance:       uint64(testUserBalance),
+		Age:           testUserAge,
+		EmailVerified: true,
+		PhoneNumber:   testUserPhone,
+		Status:        "active",
+		CreatedAt:     &testUserCreatedAt,
+		UpdatedAt:     &testUserCreatedAt,
 	}
 
 	mockClient := authMocks.NewAuthClient(t)
 	mockClient.On(
 		"User",
-		context.Background(),
+		ctx,
 		testUserEmail,
 	).Return(exceptedResponse, nil)
 	auth := authApi.New(log, validation, mockClient)
@@ -736,5 +912,38 @@ func TestUser_HappyPath(t *testing.T) {
 		testUserName,
 		testUserBalance,
 		testUserAge,
+		true,
+		testUserPhone,
+		"active",
+		"2024-05-01T12:00:00Z",
+		"2024-05-01T12:00:00Z",
+	), strings.TrimRight(rr.Body.String(), "\n"))
+}
+
+func TestJWKS_HappyPath(t *testing.T) {
+	_, signingKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	keys, err := jwtpkg.NewKeyRing(signingKey)
+	require.NoError(t, err)
+	kid, err := jwtpkg.Thumbprint(signingKey.Public())
+	require.NoError(t, err)
+
+	req, err := http.NewRequest(http.MethodGet, "/.well-known/jwks.json", nil)
+	require.NoError(t, err)
+
+	mockClient := authMocks.NewAuthClient(t)
+	auth := authApi.New(log, validation, mockClient)
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(auth.JWKS(keys))
+
+	handler.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "public, max-age=300", rr.Header().Get("Cache-Control"))
+	assert.Equal(t, fmt.Sprintf(
+		`{"keys":[{"kty":"OKP","kid":"%s","use":"sig","alg":"EdDSA","crv":"Ed25519","x":"%s"}]}`,
+		kid,
+		base64.RawURLEncoding.EncodeToString(signingKey.Public().(ed25519.PublicKey)),
 	), strings.TrimRight(rr.Body.String(), "\n"))
 }
@@ -0,0 +1,143 @@
+package tests
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/tizzhh/micro-banking/internal/api/permissions"
+	"github.com/tizzhh/micro-banking/internal/api/principal"
+	authentication "github.com/tizzhh/micro-banking/internal/delivery/http/bank/router/middleware/auth"
+	authenticationMocks "github.com/tizzhh/micro-banking/internal/delivery/http/bank/router/middleware/auth/mocks"
+	"github.com/tizzhh/micro-banking/internal/domain/auth/models"
+	jwtpkg "github.com/tizzhh/micro-banking/pkg/jwt"
+)
+
+func TestAuthenticateUser_RevocationCases(t *testing.T) {
+	testUserEmail := "test-user0@gmail.com"
+
+	_, signingKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	keys, err := jwtpkg.NewKeyRing(signingKey)
+	require.NoError(t, err)
+
+	tokens := jwtpkg.New(time.Hour, keys)
+	token, err := tokens.NewToken(models.User{ID: 1, Email: testUserEmail})
+	require.NoError(t, err)
+
+	parsedToken, err := tokens.CheckToken(token)
+	require.NoError(t, err)
+	jti := parsedToken.Claims.(*jwtpkg.Claims).ID
+
+	tests := []struct {
+		name           string
+		revoked        bool
+		checkErr       error
+		expectedBody   string
+		expectedStatus int
+	}{
+		{
+			name:           "Token not revoked",
+			expectedBody:   token,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Token revoked",
+			revoked:        true,
+			expectedBody:   fmt.Sprintf(errorResponseTemplate, "token revoked"),
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "Revocation list unavailable",
+			checkErr:       errors.New("connection refused"),
+			expectedBody:   fmt.Sprintf(errorResponseTemplate, "internal error"),
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			req, err := http.NewRequest(http.MethodGet, "/auth/user", nil)
+			require.NoError(t, err)
+			req.Header.Set("Authorization", "Bearer "+token)
+
+			revocationChecker := authenticationMocks.NewTokenRevocationChecker(t)
+			revocationChecker.On("IsTokenRevoked", mock.Anything, mock.MatchedBy(func(claims *jwtpkg.Claims) bool { return claims.ID == jti })).Return(tt.revoked, tt.checkErr)
+
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(principal.TokenFromContext(r.Context())))
+			})
+			handler := authentication.AuthenticateUser(log, permissions.New(tokens, permissions.DefaultPolicy), revocationChecker)(next)
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			assert.Equal(t, tt.expectedBody, strings.TrimRight(rr.Body.String(), "\n"))
+		})
+	}
+}
+
+func TestAuthenticateUser_SetsPrincipal(t *testing.T) {
+	testUserEmail := "test-user0@gmail.com"
+	var testUserID uint64 = 7
+
+	_, signingKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	keys, err := jwtpkg.NewKeyRing(signingKey)
+	require.NoError(t, err)
+
+	tokens := jwtpkg.New(time.Hour, keys)
+	token, err := tokens.NewToken(models.User{ID: testUserID, Email: testUserEmail})
+	require.NoError(t, err)
+
+	req, err := http.NewRequest(http.MethodGet, "/auth/user", nil)
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	revocationChecker := authenticationMocks.NewTokenRevocationChecker(t)
+	revocationChecker.On("IsTokenRevoked", mock.Anything, mock.Anything).Return(false, nil)
+
+	var got principal.Principal
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		p, ok := principal.FromContext(r.Context())
+		require.True(t, ok)
+		got = p
+	})
+	handler := authentication.AuthenticateUser(log, permissions.New(tokens, permissions.DefaultPolicy), revocationChecker)(next)
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, principal.Principal{UserID: testUserID, Email: testUserEmail}, got)
+}
+
+func TestAuthenticateUser_MissingToken(t *testing.T) {
+	req, err := http.NewRequest(http.MethodGet, "/bank/my-wallet", nil)
+	require.NoError(t, err)
+
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Fatal("request without a token reached the handler")
+	})
+	handler := authentication.AuthenticateUser(
+		log,
+		permissions.New(jwtpkg.New(time.Hour, &jwtpkg.KeyRing{}), permissions.DefaultPolicy),
+		authenticationMocks.NewTokenRevocationChecker(t),
+	)(next)
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusUnauthorized, rr.Code)
+}
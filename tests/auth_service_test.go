@@ -2,19 +2,23 @@ package tests
 
 import (
 	"testing"
+	"time"
 
 	"github.com/brianvoe/gofakeit/v6"
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	authv1 "github.com/tizzhh/micro-banking/gen/go/protos/proto/auth"
+	"github.com/tizzhh/micro-banking/internal/domain/auth/models"
+	jwtpkg "github.com/tizzhh/micro-banking/pkg/jwt"
+	"github.com/tizzhh/micro-banking/pkg/totp"
 	suite "github.com/tizzhh/micro-banking/tests/suite/auth"
 )
 
 const (
-	jwtSecret = "test-secret"
-
 	namesLen = 5
 
 	passwordDefaultLen = 10
@@ -52,18 +56,39 @@ func TestRegisterLogin_HappyPath(t *testing.T) {
 	token := respLogin.GetToken()
 	require.NotEmpty(t, token)
 
-	tokenParsed, err := jwt.Parse(token, func(t *jwt.Token) (interface{}, error) {
-		return []byte(jwtSecret), nil
-	})
+	respKeys, err := st.AuthClient.JWKS(ctx, &authv1.JWKSRequest{})
+	require.NoError(t, err)
+	require.NotEmpty(t, respKeys.GetKeys())
+
+	jwks := jwtpkg.JWKS{}
+	for _, key := range respKeys.GetKeys() {
+		jwks.Keys = append(jwks.Keys, jwtpkg.JWK{
+			Kty: key.GetKty(),
+			Kid: key.GetKid(),
+			Use: key.GetUse(),
+			Alg: key.GetAlg(),
+			N:   key.GetN(),
+			E:   key.GetE(),
+			Crv: key.GetCrv(),
+			X:   key.GetX(),
+		})
+	}
+
+	tokenParsed, err := jwtpkg.New(st.Cfg.TokenTTL, jwtpkg.KeyRingFromJWKS(jwks)).CheckToken(token)
 	require.NoError(t, err)
 
-	claims, ok := tokenParsed.Claims.(jwt.MapClaims)
+	claims, ok := tokenParsed.Claims.(*jwtpkg.Claims)
 	assert.True(t, ok)
 
-	assert.Equal(t, respReg.GetUserId(), uint64(claims["uid"].(float64)))
-	assert.Equal(t, email, claims["email"].(string))
+	uid, err := claims.UserID()
+	require.NoError(t, err)
+	assert.Equal(t, respReg.GetUserId(), uid)
+	assert.Equal(t, email, claims.Email)
+	assert.Equal(t, st.Cfg.JWT.Issuer, claims.Issuer)
+	assert.Equal(t, jwt.ClaimStrings{st.Cfg.JWT.Audience}, claims.Audience)
+	assert.NotEmpty(t, claims.ID)
 
-	assert.Equal(t, float64(st.Cfg.TokenTTL), claims["exp"].(float64))
+	assert.Equal(t, st.Cfg.TokenTTL, claims.ExpiresAt.Sub(claims.IssuedAt.Time))
 
 	respUser, err := st.AuthClient.User(ctx, &authv1.UserRequest{
 		Email: email,
@@ -283,6 +308,58 @@ func TestUpdatePasswordDelete_HappyPath(t *testing.T) {
 		Email: email,
 	})
 	assert.ErrorContains(t, err, "user not found")
+
+	// the closed account is kept, but cannot log in anymore
+	_, err = st.AuthClient.Login(ctx, &authv1.LoginRequest{
+		Email:    email,
+		Password: newPassord,
+	})
+	require.Error(t, err)
+}
+
+func TestUnregisterRegisterAgain_HappyPath(t *testing.T) {
+	ctx, st := suite.New(t)
+
+	email := gofakeit.Email()
+	password := randomFakePassword()
+
+	respReg, err := st.AuthClient.Register(ctx, &authv1.RegisterRequest{
+		Email:     email,
+		Password:  password,
+		FirstName: gofakeit.LetterN(namesLen),
+		LastName:  gofakeit.LetterN(namesLen),
+		Age:       uint32(gofakeit.Number(18, 100)),
+	})
+	require.NoError(t, err)
+
+	_, err = st.AuthClient.Unregister(ctx, &authv1.UnregisterRequest{
+		Email:    email,
+		Password: password,
+	})
+	require.NoError(t, err)
+
+	// the closed account does not hold its email
+	newPassword := randomFakePassword()
+	respRegAgain, err := st.AuthClient.Register(ctx, &authv1.RegisterRequest{
+		Email:     email,
+		Password:  newPassword,
+		FirstName: gofakeit.LetterN(namesLen),
+		LastName:  gofakeit.LetterN(namesLen),
+		Age:       uint32(gofakeit.Number(18, 100)),
+	})
+	require.NoError(t, err)
+	assert.NotEqual(t, respReg.GetUserId(), respRegAgain.GetUserId())
+
+	respLogin, err := st.AuthClient.Login(ctx, &authv1.LoginRequest{
+		Email:    email,
+		Password: newPassword,
+	})
+	require.NoError(t, err)
+	assert.NotEmpty(t, respLogin.GetToken())
+
+	respUser, err := st.AuthClient.User(ctx, &authv1.UserRequest{Email: email})
+	require.NoError(t, err)
+	assert.Equal(t, respRegAgain.GetUserId(), respUser.GetUserId())
 }
 
 func TestUpdatePassword_FailCases(t *testing.T) {
@@ -426,6 +503,330 @@ func TestDelete_FailCases(t *testing.T) {
 	}
 }
 
+func TestRefreshRotation_ReuseRevokesSession(t *testing.T) {
+	ctx, st := suite.New(t)
+
+	email := gofakeit.Email()
+	password := randomFakePassword()
+
+	_, err := st.AuthClient.Register(ctx, &authv1.RegisterRequest{
+		Email:     email,
+		Password:  password,
+		FirstName: gofakeit.LetterN(namesLen),
+		LastName:  gofakeit.LetterN(namesLen),
+		Age:       uint32(gofakeit.Number(18, 100)),
+	})
+	require.NoError(t, err)
+
+	respLogin, err := st.AuthClient.Login(ctx, &authv1.LoginRequest{
+		Email:    email,
+		Password: password,
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, respLogin.GetRefreshToken())
+
+	respRefresh, err := st.AuthClient.Refresh(ctx, &authv1.RefreshRequest{
+		RefreshToken: respLogin.GetRefreshToken(),
+	})
+	require.NoError(t, err)
+	assert.NotEmpty(t, respRefresh.GetToken())
+	assert.NotEqual(t, respLogin.GetRefreshToken(), respRefresh.GetRefreshToken())
+
+	_, err = st.AuthClient.Refresh(ctx, &authv1.RefreshRequest{
+		RefreshToken: respLogin.GetRefreshToken(),
+	})
+	require.Error(t, err)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	_, err = st.AuthClient.Refresh(ctx, &authv1.RefreshRequest{
+		RefreshToken: respRefresh.GetRefreshToken(),
+	})
+	require.Error(t, err)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
+
+func TestLogout_RevokesRefreshToken(t *testing.T) {
+	ctx, st := suite.New(t)
+
+	email := gofakeit.Email()
+	password := randomFakePassword()
+
+	_, err := st.AuthClient.Register(ctx, &authv1.RegisterRequest{
+		Email:     email,
+		Password:  password,
+		FirstName: gofakeit.LetterN(namesLen),
+		LastName:  gofakeit.LetterN(namesLen),
+		Age:       uint32(gofakeit.Number(18, 100)),
+	})
+	require.NoError(t, err)
+
+	respLogin, err := st.AuthClient.Login(ctx, &authv1.LoginRequest{
+		Email:    email,
+		Password: password,
+	})
+	require.NoError(t, err)
+
+	_, err = st.AuthClient.Logout(ctx, &authv1.LogoutRequest{
+		Token:        respLogin.GetToken(),
+		RefreshToken: respLogin.GetRefreshToken(),
+	})
+	require.NoError(t, err)
+
+	_, err = st.AuthClient.Refresh(ctx, &authv1.RefreshRequest{
+		RefreshToken: respLogin.GetRefreshToken(),
+	})
+	require.Error(t, err)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
+
+func TestMFA_HappyPath(t *testing.T) {
+	ctx, st := suite.New(t)
+
+	email := gofakeit.Email()
+	password := randomFakePassword()
+
+	_, err := st.AuthClient.Register(ctx, &authv1.RegisterRequest{
+		Email:     email,
+		Password:  password,
+		FirstName: gofakeit.LetterN(namesLen),
+		LastName:  gofakeit.LetterN(namesLen),
+		Age:       uint32(gofakeit.Number(18, 100)),
+	})
+	require.NoError(t, err)
+
+	respEnroll, err := st.AuthClient.EnrollMFA(ctx, &authv1.EnrollMFARequest{Email: email})
+	require.NoError(t, err)
+	require.NotEmpty(t, respEnroll.GetSecret())
+
+	now := time.Now()
+	code, err := totp.Code(respEnroll.GetSecret(), now)
+	require.NoError(t, err)
+
+	respConfirm, err := st.AuthClient.ConfirmMFA(ctx, &authv1.ConfirmMFARequest{Email: email, Code: code})
+	require.NoError(t, err)
+	require.NotEmpty(t, respConfirm.GetRecoveryCodes())
+
+	respLogin, err := st.AuthClient.Login(ctx, &authv1.LoginRequest{
+		Email:    email,
+		Password: password,
+	})
+	require.NoError(t, err)
+	assert.Empty(t, respLogin.GetToken())
+	require.NotEmpty(t, respLogin.GetMfaToken())
+
+	respVerify, err := st.AuthClient.VerifyMFA(ctx, &authv1.VerifyMFARequest{
+		MfaToken: respLogin.GetMfaToken(),
+		Code:     respConfirm.GetRecoveryCodes()[0],
+	})
+	require.NoError(t, err)
+	assert.NotEmpty(t, respVerify.GetToken())
+	assert.NotEmpty(t, respVerify.GetRefreshToken())
+
+	_, err = st.AuthClient.VerifyMFA(ctx, &authv1.VerifyMFARequest{
+		MfaToken: respLogin.GetMfaToken(),
+		Code:     respConfirm.GetRecoveryCodes()[1],
+	})
+	require.Error(t, err)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	code, err = totp.Code(respEnroll.GetSecret(), now.Add(totp.Period))
+	require.NoError(t, err)
+
+	respStepUp, err := st.AuthClient.StepUp(ctx, &authv1.StepUpRequest{Email: email, Code: code})
+	require.NoError(t, err)
+	assert.NotEmpty(t, respStepUp.GetToken())
+}
+
+func TestRegisterVerifyEmail_HappyPath(t *testing.T) {
+	ctx, st := suite.New(t)
+
+	email := gofakeit.Email()
+
+	respReg, err := st.AuthClient.Register(ctx, &authv1.RegisterRequest{
+		Email:     email,
+		Password:  randomFakePassword(),
+		FirstName: gofakeit.LetterN(namesLen),
+		LastName:  gofakeit.LetterN(namesLen),
+		Age:       uint32(gofakeit.Number(18, 100)),
+	})
+	require.NoError(t, err)
+
+	respUser, err := st.AuthClient.User(ctx, &authv1.UserRequest{Email: email})
+	require.NoError(t, err)
+	assert.False(t, respUser.GetEmailVerified())
+
+	// the verification mail goes out through the outbox, so the token is
+	// signed here with the key of the auth service
+	keys, err := jwtpkg.LoadKeyRing(st.Cfg.JWT.SigningKey, st.Cfg.JWT.VerificationKeys)
+	require.NoError(t, err)
+	token, err := jwtpkg.New(st.Cfg.TokenTTL, keys).NewVerificationToken(models.User{
+		ID:    respReg.GetUserId(),
+		Email: email,
+	})
+	require.NoError(t, err)
+
+	_, err = st.AuthClient.VerifyEmail(ctx, &authv1.VerifyEmailRequest{Token: token})
+	require.NoError(t, err)
+
+	_, err = st.AuthClient.VerifyEmail(ctx, &authv1.VerifyEmailRequest{Token: token})
+	require.Error(t, err)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	respUser, err = st.AuthClient.User(ctx, &authv1.UserRequest{Email: email})
+	require.NoError(t, err)
+	assert.True(t, respUser.GetEmailVerified())
+
+	_, err = st.AuthClient.ResendVerification(ctx, &authv1.ResendVerificationRequest{Email: email})
+	require.Error(t, err)
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+}
+
+func TestVerifyEmailOtherAddress_Fail(t *testing.T) {
+	ctx, st := suite.New(t)
+
+	email := gofakeit.Email()
+
+	respReg, err := st.AuthClient.Register(ctx, &authv1.RegisterRequest{
+		Email:     email,
+		Password:  randomFakePassword(),
+		FirstName: gofakeit.LetterN(namesLen),
+		LastName:  gofakeit.LetterN(namesLen),
+		Age:       uint32(gofakeit.Number(18, 100)),
+	})
+	require.NoError(t, err)
+
+	// a token sent to an address the account no longer has
+	keys, err := jwtpkg.LoadKeyRing(st.Cfg.JWT.SigningKey, st.Cfg.JWT.VerificationKeys)
+	require.NoError(t, err)
+	token, err := jwtpkg.New(st.Cfg.TokenTTL, keys).NewVerificationToken(models.User{
+		ID:    respReg.GetUserId(),
+		Email: gofakeit.Email(),
+	})
+	require.NoError(t, err)
+
+	_, err = st.AuthClient.VerifyEmail(ctx, &authv1.VerifyEmailRequest{Token: token})
+	require.Error(t, err)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	respUser, err := st.AuthClient.User(ctx, &authv1.UserRequest{Email: email})
+	require.NoError(t, err)
+	assert.False(t, respUser.GetEmailVerified())
+}
+
+func TestPasswordReset_FailCases(t *testing.T) {
+	ctx, st := suite.New(t)
+
+	// unknown emails are not revealed
+	_, err := st.AuthClient.RequestPasswordReset(ctx, &authv1.RequestPasswordResetRequest{
+		Email: gofakeit.Email(),
+	})
+	require.NoError(t, err)
+
+	_, err = st.AuthClient.ResetPassword(ctx, &authv1.ResetPasswordRequest{
+		Token:       gofakeit.LetterN(43),
+		NewPassword: randomFakePassword(),
+	})
+	require.Error(t, err)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestLoginBackoffUnlock_HappyPath(t *testing.T) {
+	ctx, st := suite.New(t)
+
+	email := gofakeit.Email()
+	password := randomFakePassword()
+
+	_, err := st.AuthClient.Register(ctx, &authv1.RegisterRequest{
+		Email:     email,
+		Password:  password,
+		FirstName: gofakeit.LetterN(namesLen),
+		LastName:  gofakeit.LetterN(namesLen),
+		Age:       uint32(gofakeit.Number(18, 100)),
+	})
+	require.NoError(t, err)
+
+	_, err = st.AuthClient.Login(ctx, &authv1.LoginRequest{Email: email, Password: randomFakePassword()})
+	require.Error(t, err)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	// the failure backs off the next login, even with the right password
+	_, err = st.AuthClient.Login(ctx, &authv1.LoginRequest{Email: email, Password: password})
+	require.Error(t, err)
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	_, err = st.AuthClient.UnlockAccount(ctx, &authv1.UnlockAccountRequest{Email: email})
+	require.NoError(t, err)
+
+	respLogin, err := st.AuthClient.Login(ctx, &authv1.LoginRequest{Email: email, Password: password})
+	require.NoError(t, err)
+	assert.NotEmpty(t, respLogin.GetToken())
+}
+
+func TestUpdateProfileChangeEmail_HappyPath(t *testing.T) {
+	ctx, st := suite.New(t)
+
+	email := gofakeit.Email()
+	password := randomFakePassword()
+
+	_, err := st.AuthClient.Register(ctx, &authv1.RegisterRequest{
+		Email:     email,
+		Password:  password,
+		FirstName: gofakeit.LetterN(namesLen),
+		LastName:  gofakeit.LetterN(namesLen),
+		Age:       uint32(gofakeit.Number(18, 100)),
+	})
+	require.NoError(t, err)
+
+	firstName := gofakeit.LetterN(namesLen)
+	phoneNumber := "+14155550100"
+	respUpdate, err := st.AuthClient.UpdateProfile(ctx, &authv1.UpdateProfileRequest{
+		Email:       email,
+		FirstName:   &firstName,
+		PhoneNumber: &phoneNumber,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, firstName, respUpdate.GetFirstName())
+	assert.Equal(t, phoneNumber, respUpdate.GetPhoneNumber())
+	assert.NotZero(t, respUpdate.GetUserId())
+	assert.False(t, respUpdate.GetUpdatedAt().AsTime().Before(respUpdate.GetCreatedAt().AsTime()))
+
+	// a verification mail sent before the change must not verify the new
+	// address
+	keys, err := jwtpkg.LoadKeyRing(st.Cfg.JWT.SigningKey, st.Cfg.JWT.VerificationKeys)
+	require.NoError(t, err)
+	oldToken, err := jwtpkg.New(st.Cfg.TokenTTL, keys).NewVerificationToken(models.User{
+		ID:    respUpdate.GetUserId(),
+		Email: email,
+	})
+	require.NoError(t, err)
+
+	newEmail := gofakeit.Email()
+	_, err = st.AuthClient.ChangeEmail(ctx, &authv1.ChangeEmailRequest{
+		Email:    email,
+		Password: password,
+		NewEmail: newEmail,
+	})
+	require.NoError(t, err)
+
+	respUser, err := st.AuthClient.User(ctx, &authv1.UserRequest{Email: newEmail})
+	require.NoError(t, err)
+	assert.Equal(t, newEmail, respUser.GetEmail())
+	assert.Equal(t, firstName, respUser.GetFirstName())
+	assert.False(t, respUser.GetEmailVerified())
+
+	_, err = st.AuthClient.VerifyEmail(ctx, &authv1.VerifyEmailRequest{Token: oldToken})
+	require.Error(t, err)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	respUser, err = st.AuthClient.User(ctx, &authv1.UserRequest{Email: newEmail})
+	require.NoError(t, err)
+	assert.False(t, respUser.GetEmailVerified())
+
+	_, err = st.AuthClient.User(ctx, &authv1.UserRequest{Email: email})
+	require.Error(t, err)
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
 func randomFakePassword() string {
 	return gofakeit.Password(true, true, true, true, false, passwordDefaultLen)
 }
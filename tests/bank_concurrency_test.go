@@ -0,0 +1,89 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/brianvoe/gofakeit/v6"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	authModels "github.com/tizzhh/micro-banking/internal/domain/auth/models"
+	"github.com/tizzhh/micro-banking/internal/services/bank"
+	bankErrors "github.com/tizzhh/micro-banking/internal/services/bank/errors"
+	"github.com/tizzhh/micro-banking/internal/storage/postgres"
+	"github.com/tizzhh/micro-banking/pkg/money"
+)
+
+const (
+	concurrentDeposit     = 1000
+	concurrentWithdrawal  = 100
+	concurrentWithdrawers = 50
+)
+
+func TestConcurrentWithdrawals_NoLostUpdates(t *testing.T) {
+	ctx := context.Background()
+
+	storage, err := postgres.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = storage.Stop() })
+
+	email := gofakeit.Email()
+	_, err = storage.SaveUser(ctx, authModels.User{
+		Email:     email,
+		PassHash:  []byte(gofakeit.Password(true, true, true, true, false, 10)),
+		FirstName: gofakeit.FirstName(),
+		LastName:  gofakeit.LastName(),
+		Age:       20,
+		Status:    authModels.UserStatusActive,
+	}, nil)
+	require.NoError(t, err)
+
+	bankService := bank.New(log, storage, storage, bank.StepUp{})
+
+	_, err = bankService.Deposit(ctx, email, money.New(concurrentDeposit, "USD"))
+	require.NoError(t, err)
+
+	var (
+		wg        sync.WaitGroup
+		mu        sync.Mutex
+		succeeded int
+		rejected  int
+	)
+	for range concurrentWithdrawers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			_, err := bankService.Withdraw(ctx, email, money.New(concurrentWithdrawal, "USD"))
+
+			mu.Lock()
+			defer mu.Unlock()
+			switch {
+			case err == nil:
+				succeeded++
+			case errors.Is(err, bankErrors.ErrNotEnoughMoney):
+				rejected++
+			default:
+				t.Errorf("unexpected withdrawal error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	expectedWithdrawals := concurrentDeposit / concurrentWithdrawal
+	assert.Equal(t, expectedWithdrawals, succeeded)
+	assert.Equal(t, concurrentWithdrawers-expectedWithdrawals, rejected)
+
+	user, err := storage.User(ctx, email)
+	require.NoError(t, err)
+	assert.Zero(t, user.Balance)
+
+	mismatches, err := storage.Mismatches(ctx)
+	require.NoError(t, err)
+	for _, mismatch := range mismatches {
+		assert.NotEqual(t, user.ID, mismatch.UserID, "ledger and stored balance drifted")
+	}
+}
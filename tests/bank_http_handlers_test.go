@@ -2,7 +2,6 @@ package tests
 
 import (
 	"bytes"
-	"context"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -18,19 +17,22 @@ import (
 	currencyMocks "github.com/tizzhh/micro-banking/internal/delivery/http/bank/resource/currency/mocks"
 	bankErrors "github.com/tizzhh/micro-banking/internal/services/bank/errors"
 	currency "github.com/tizzhh/micro-banking/internal/services/currency/errors"
+	"github.com/tizzhh/micro-banking/pkg/money"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
 const (
-	myWalletRequestTemplate  = `{"email": "%s"}`
-	myWalletResponseTemplate = `{"wallet":[{"currency_code":"%s","balance":%d}]}`
+	myWalletResponseTemplate = `{"wallet":[{"currency_code":"%s","balance":"%s"}]}`
 
-	depositRequestTemplate  = `{"amount": %f,"email": "%s"}`
-	depositResponseTemplate = `{"new_balance_amount":%.1f}`
+	depositRequestTemplate  = `{"amount": "%s"}`
+	depositResponseTemplate = `{"new_balance_amount":"%s"}`
 
-	withdrawRequestTemplate  = `{"amount": %f,"email": "%s"}`
-	withdrawResponseTemplate = `{"new_balance_amount":%.1f}`
+	withdrawRequestTemplate  = `{"amount": "%s"}`
+	withdrawResponseTemplate = `{"new_balance_amount":"%s"}`
+
+	transferRequestTemplate  = `{"amount": "%s","recipient_email": "%s","currency_code": "%s"}`
+	transferResponseTemplate = `{"new_balance_amount":"%s"}`
 )
 
 func TestHealthCheck_HappyPath(t *testing.T) {
@@ -51,15 +53,10 @@ func TestHealthCheck_HappyPath(t *testing.T) {
 func TestWallet_HappyPath(t *testing.T) {
 	testUserEmail := "test-user0@gmail.com"
 	testCurrencyCode := "EUR"
-	var testBalance uint64 = 90
-
-	reqBody := []byte(fmt.Sprintf(
-		myWalletRequestTemplate,
-		testUserEmail,
-	))
-	bodyReader := bytes.NewBuffer(reqBody)
+	testBalance := "0.90"
 
-	req, err := http.NewRequest(http.MethodGet, "/bank/my-wallet", bodyReader)
+	ctx := principalContext(testUserEmail)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "/bank/my-wallet", nil)
 	require.NoError(t, err)
 
 	expectedResponse := currencyApi.WalletResponse{Wallets: []currencyApi.Wallet{
@@ -71,7 +68,7 @@ func TestWallet_HappyPath(t *testing.T) {
 	mockClient := currencyMocks.NewCurrencyClient(t)
 	mockClient.On(
 		"Wallets",
-		context.Background(),
+		ctx,
 		testUserEmail,
 	).Return(expectedResponse, nil)
 	currency := currencyApi.New(log, validation, mockClient)
@@ -89,49 +86,23 @@ func TestWallet_HappyPath(t *testing.T) {
 	), strings.TrimRight(rr.Body.String(), "\n"))
 }
 
-func TestWalletHttp_FailCases(t *testing.T) {
-	tests := []struct {
-		name           string
-		email          string
-		expectedErr    string
-		expectedStatus int
-	}{
-		{
-			name:           "Wallets with empty email",
-			email:          "",
-			expectedErr:    "field Email is a required field",
-			expectedStatus: 400,
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			t.Parallel()
-			reqBody := []byte(fmt.Sprintf(
-				myWalletRequestTemplate,
-				tt.email,
-			))
-			bodyReader := bytes.NewBuffer(reqBody)
-
-			req, err := http.NewRequest(http.MethodGet, "/bank/my-wallet", bodyReader)
-			require.NoError(t, err)
-
-			mockClient := currencyMocks.NewCurrencyClient(t)
-			currency := currencyApi.New(log, validation, mockClient)
+func TestWalletUnauthenticated_Fail(t *testing.T) {
+	req, err := http.NewRequest(http.MethodGet, "/bank/my-wallet", nil)
+	require.NoError(t, err)
 
-			rr := httptest.NewRecorder()
-			handler := http.HandlerFunc(currency.MyWallet())
+	mockClient := currencyMocks.NewCurrencyClient(t)
+	currency := currencyApi.New(log, validation, mockClient)
 
-			handler.ServeHTTP(rr, req)
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(currency.MyWallet())
 
-			assert.Equal(t, tt.expectedStatus, rr.Code)
-			assert.Equal(t, fmt.Sprintf(
-				errorResponseTemplate,
-				tt.expectedErr,
-			), strings.TrimRight(rr.Body.String(), "\n"))
+	handler.ServeHTTP(rr, req)
 
-		})
-	}
+	assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	assert.Equal(t, fmt.Sprintf(
+		errorResponseTemplate,
+		"unauthenticated",
+	), strings.TrimRight(rr.Body.String(), "\n"))
 }
 
 func TestWalletUserNotFound_Fail(t *testing.T) {
@@ -139,19 +110,14 @@ func TestWalletUserNotFound_Fail(t *testing.T) {
 
 	expectedErr := "user not found"
 
-	reqBody := []byte(fmt.Sprintf(
-		myWalletRequestTemplate,
-		testUserEmail,
-	))
-	bodyReader := bytes.NewBuffer(reqBody)
-
-	req, err := http.NewRequest(http.MethodGet, "/bank/my-wallet", bodyReader)
+	ctx := principalContext(testUserEmail)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "/bank/my-wallet", nil)
 	require.NoError(t, err)
 
 	mockClient := currencyMocks.NewCurrencyClient(t)
 	mockClient.On(
 		"Wallets",
-		context.Background(),
+		ctx,
 		testUserEmail,
 	).Return(currencyApi.WalletResponse{}, status.Error(codes.NotFound, currency.ErrUserNotFound.Error()))
 	currency := currencyApi.New(log, validation, mockClient)
@@ -170,25 +136,26 @@ func TestWalletUserNotFound_Fail(t *testing.T) {
 
 func TestDeposit_HappyPath(t *testing.T) {
 	testUserEmail := "test-user0@gmail.com"
-	var testAmount float32 = 1.8
+	testAmount := "1.80"
+	testMoney := money.New(180, "USD")
 
 	reqBody := []byte(fmt.Sprintf(
 		depositRequestTemplate,
 		testAmount,
-		testUserEmail,
 	))
 	bodyReader := bytes.NewBuffer(reqBody)
 
-	req, err := http.NewRequest(http.MethodPost, "/bank/deposit", bodyReader)
+	ctx := principalContext(testUserEmail)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "/bank/deposit", bodyReader)
 	require.NoError(t, err)
 
 	mockClient := bankMocks.NewBalancer(t)
 	mockClient.On(
 		"Deposit",
-		context.Background(),
+		ctx,
 		testUserEmail,
-		testAmount,
-	).Return(testAmount, nil)
+		testMoney,
+	).Return(testMoney, nil)
 	bank := bankApi.New(log, validation, mockClient)
 
 	rr := httptest.NewRecorder()
@@ -204,34 +171,28 @@ func TestDeposit_HappyPath(t *testing.T) {
 }
 
 func TestDepositHttp_FailCases(t *testing.T) {
-	var testAmount float32 = 1.8
-
 	tests := []struct {
 		name           string
-		email          string
-		amount         float32
+		amount         string
 		expectedErr    string
 		expectedStatus int
 	}{
 		{
-			name:           "Empty email",
-			email:          "",
-			amount:         testAmount,
-			expectedErr:    "field Email is a required field",
+			name:           "Empty amount",
+			amount:         "",
+			expectedErr:    "field Amount is a required field",
 			expectedStatus: 400,
 		},
 		{
-			name:           "Empty amount",
-			email:          gofakeit.Email(),
-			amount:         0,
-			expectedErr:    "field Amount is a required field",
+			name:           "Malformed amount",
+			amount:         "1e5",
+			expectedErr:    "field Amount is not valid",
 			expectedStatus: 400,
 		},
 		{
-			name:           "Invalid email",
-			email:          "askdlaskd",
-			amount:         testAmount,
-			expectedErr:    "field Email is not a valid email",
+			name:           "Fraction of a cent",
+			amount:         "1.005",
+			expectedErr:    "amount has more decimal places than the currency allows",
 			expectedStatus: 400,
 		},
 	}
@@ -243,11 +204,11 @@ func TestDepositHttp_FailCases(t *testing.T) {
 			reqBody := []byte(fmt.Sprintf(
 				depositRequestTemplate,
 				tt.amount,
-				tt.email,
 			))
 			bodyReader := bytes.NewBuffer(reqBody)
 
-			req, err := http.NewRequest(http.MethodPost, "/bank/deposit", bodyReader)
+			ctx := principalContext(gofakeit.Email())
+			req, err := http.NewRequestWithContext(ctx, http.MethodPost, "/bank/deposit", bodyReader)
 			require.NoError(t, err)
 
 			mockClient := bankMocks.NewBalancer(t)
@@ -271,27 +232,28 @@ func TestDepositHttp_FailCases(t *testing.T) {
 
 func TestDepositUserNotFound_Fail(t *testing.T) {
 	testUserEmail := "test-user0@gmail.com"
-	var testAmount float32 = 1.8
+	testAmount := "1.80"
+	testMoney := money.New(180, "USD")
 
 	expectedErr := "user not found"
 
 	reqBody := []byte(fmt.Sprintf(
 		depositRequestTemplate,
 		testAmount,
-		testUserEmail,
 	))
 	bodyReader := bytes.NewBuffer(reqBody)
 
-	req, err := http.NewRequest(http.MethodPost, "/bank/deposit", bodyReader)
+	ctx := principalContext(testUserEmail)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "/bank/deposit", bodyReader)
 	require.NoError(t, err)
 
 	mockClient := bankMocks.NewBalancer(t)
 	mockClient.On(
 		"Deposit",
-		context.Background(),
+		ctx,
 		testUserEmail,
-		testAmount,
-	).Return(testAmount, bankErrors.ErrUserNotFound)
+		testMoney,
+	).Return(testMoney, bankErrors.ErrUserNotFound)
 	bank := bankApi.New(log, validation, mockClient)
 
 	rr := httptest.NewRecorder()
@@ -308,25 +270,26 @@ func TestDepositUserNotFound_Fail(t *testing.T) {
 
 func TestWithdraw_HappyPath(t *testing.T) {
 	testUserEmail := "test-user0@gmail.com"
-	var testAmount float32 = 1.8
+	testAmount := "1.80"
+	testMoney := money.New(180, "USD")
 
 	reqBody := []byte(fmt.Sprintf(
 		withdrawRequestTemplate,
 		testAmount,
-		testUserEmail,
 	))
 	bodyReader := bytes.NewBuffer(reqBody)
 
-	req, err := http.NewRequest(http.MethodPost, "/bank/withdraw", bodyReader)
+	ctx := principalContext(testUserEmail)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "/bank/withdraw", bodyReader)
 	require.NoError(t, err)
 
 	mockClient := bankMocks.NewBalancer(t)
 	mockClient.On(
 		"Withdraw",
-		context.Background(),
+		ctx,
 		testUserEmail,
-		testAmount,
-	).Return(testAmount, nil)
+		testMoney,
+	).Return(testMoney, nil)
 	bank := bankApi.New(log, validation, mockClient)
 
 	rr := httptest.NewRecorder()
@@ -342,34 +305,28 @@ func TestWithdraw_HappyPath(t *testing.T) {
 }
 
 func TestWithdrawHttp_FailCases(t *testing.T) {
-	var testAmount float32 = 1.8
-
 	tests := []struct {
 		name           string
-		email          string
-		amount         float32
+		amount         string
 		expectedErr    string
 		expectedStatus int
 	}{
 		{
-			name:           "Empty email",
-			email:          "",
-			amount:         testAmount,
-			expectedErr:    "field Email is a required field",
+			name:           "Empty amount",
+			amount:         "",
+			expectedErr:    "field Amount is a required field",
 			expectedStatus: 400,
 		},
 		{
-			name:           "Empty amount",
-			email:          gofakeit.Email(),
-			amount:         0,
-			expectedErr:    "field Amount is a required field",
+			name:           "Malformed amount",
+			amount:         "1e5",
+			expectedErr:    "field Amount is not valid",
 			expectedStatus: 400,
 		},
 		{
-			name:           "Invalid email",
-			email:          "askdlaskd",
-			amount:         testAmount,
-			expectedErr:    "field Email is not a valid email",
+			name:           "Fraction of a cent",
+			amount:         "1.005",
+			expectedErr:    "amount has more decimal places than the currency allows",
 			expectedStatus: 400,
 		},
 	}
@@ -381,11 +338,11 @@ func TestWithdrawHttp_FailCases(t *testing.T) {
 			reqBody := []byte(fmt.Sprintf(
 				withdrawRequestTemplate,
 				tt.amount,
-				tt.email,
 			))
 			bodyReader := bytes.NewBuffer(reqBody)
 
-			req, err := http.NewRequest(http.MethodPost, "/bank/withdraw", bodyReader)
+			ctx := principalContext(gofakeit.Email())
+			req, err := http.NewRequestWithContext(ctx, http.MethodPost, "/bank/withdraw", bodyReader)
 			require.NoError(t, err)
 
 			mockClient := bankMocks.NewBalancer(t)
@@ -409,27 +366,28 @@ func TestWithdrawHttp_FailCases(t *testing.T) {
 
 func TestWithdrawUserNotFound_Fail(t *testing.T) {
 	testUserEmail := "test-user0@gmail.com"
-	var testAmount float32 = 1.8
+	testAmount := "1.80"
+	testMoney := money.New(180, "USD")
 
 	expectedErr := "user not found"
 
 	reqBody := []byte(fmt.Sprintf(
 		withdrawRequestTemplate,
 		testAmount,
-		testUserEmail,
 	))
 	bodyReader := bytes.NewBuffer(reqBody)
 
-	req, err := http.NewRequest(http.MethodPost, "/bank/withdraw", bodyReader)
+	ctx := principalContext(testUserEmail)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "/bank/withdraw", bodyReader)
 	require.NoError(t, err)
 
 	mockClient := bankMocks.NewBalancer(t)
 	mockClient.On(
 		"Withdraw",
-		context.Background(),
+		ctx,
 		testUserEmail,
-		testAmount,
-	).Return(testAmount, bankErrors.ErrUserNotFound)
+		testMoney,
+	).Return(testMoney, bankErrors.ErrUserNotFound)
 	bank := bankApi.New(log, validation, mockClient)
 
 	rr := httptest.NewRecorder()
@@ -446,27 +404,28 @@ func TestWithdrawUserNotFound_Fail(t *testing.T) {
 
 func TestWithdrawNotEnoughMoney_Fail(t *testing.T) {
 	testUserEmail := "test-user0@gmail.com"
-	var testAmount float32 = 1.8
+	testAmount := "1.80"
+	testMoney := money.New(180, "USD")
 
 	expectedErr := "not enough money on balance"
 
 	reqBody := []byte(fmt.Sprintf(
 		withdrawRequestTemplate,
 		testAmount,
-		testUserEmail,
 	))
 	bodyReader := bytes.NewBuffer(reqBody)
 
-	req, err := http.NewRequest(http.MethodPost, "/bank/withdraw", bodyReader)
+	ctx := principalContext(testUserEmail)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "/bank/withdraw", bodyReader)
 	require.NoError(t, err)
 
 	mockClient := bankMocks.NewBalancer(t)
 	mockClient.On(
 		"Withdraw",
-		context.Background(),
+		ctx,
 		testUserEmail,
-		testAmount,
-	).Return(testAmount, bankErrors.ErrNotEnoughMoney)
+		testMoney,
+	).Return(testMoney, bankErrors.ErrNotEnoughMoney)
 	bank := bankApi.New(log, validation, mockClient)
 
 	rr := httptest.NewRecorder()
@@ -480,3 +439,196 @@ func TestWithdrawNotEnoughMoney_Fail(t *testing.T) {
 		expectedErr,
 	), strings.TrimRight(rr.Body.String(), "\n"))
 }
+
+func TestTransfer_HappyPath(t *testing.T) {
+	testUserEmail := "test-user0@gmail.com"
+	testRecipientEmail := "test-user1@gmail.com"
+	testAmount := "1.80"
+	testMoney := money.New(180, "USD")
+
+	reqBody := []byte(fmt.Sprintf(
+		transferRequestTemplate,
+		testAmount,
+		testRecipientEmail,
+		"",
+	))
+	bodyReader := bytes.NewBuffer(reqBody)
+
+	ctx := principalContext(testUserEmail)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "/bank/transfer", bodyReader)
+	require.NoError(t, err)
+
+	mockClient := bankMocks.NewBalancer(t)
+	mockClient.On(
+		"Transfer",
+		ctx,
+		testUserEmail,
+		testRecipientEmail,
+		testMoney,
+	).Return(testMoney, nil)
+	bank := bankApi.New(log, validation, mockClient)
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(bank.Transfer())
+
+	handler.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, fmt.Sprintf(
+		transferResponseTemplate,
+		testAmount,
+	), strings.TrimRight(rr.Body.String(), "\n"))
+}
+
+func TestTransferHttp_FailCases(t *testing.T) {
+	testAmount := "1.80"
+
+	tests := []struct {
+		name           string
+		recipientEmail string
+		currencyCode   string
+		amount         string
+		expectedErr    string
+		expectedStatus int
+	}{
+		{
+			name:           "Empty recipient email",
+			recipientEmail: "",
+			amount:         testAmount,
+			expectedErr:    "field RecipientEmail is a required field",
+			expectedStatus: 400,
+		},
+		{
+			name:           "Empty amount",
+			recipientEmail: gofakeit.Email(),
+			amount:         "",
+			expectedErr:    "field Amount is a required field",
+			expectedStatus: 400,
+		},
+		{
+			name:           "Invalid recipient email",
+			recipientEmail: "askdlaskd",
+			amount:         testAmount,
+			expectedErr:    "field RecipientEmail is not a valid email",
+			expectedStatus: 400,
+		},
+		{
+			name:           "Malformed currency code",
+			recipientEmail: gofakeit.Email(),
+			currencyCode:   "abc",
+			amount:         testAmount,
+			expectedErr:    "field CurrencyCode is not valid",
+			expectedStatus: 400,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			reqBody := []byte(fmt.Sprintf(
+				transferRequestTemplate,
+				tt.amount,
+				tt.recipientEmail,
+				tt.currencyCode,
+			))
+			bodyReader := bytes.NewBuffer(reqBody)
+
+			ctx := principalContext(gofakeit.Email())
+			req, err := http.NewRequestWithContext(ctx, http.MethodPost, "/bank/transfer", bodyReader)
+			require.NoError(t, err)
+
+			mockClient := bankMocks.NewBalancer(t)
+			bank := bankApi.New(log, validation, mockClient)
+
+			rr := httptest.NewRecorder()
+			handler := http.HandlerFunc(bank.Transfer())
+
+			handler.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			assert.Equal(t, fmt.Sprintf(
+				errorResponseTemplate,
+				tt.expectedErr,
+			), strings.TrimRight(rr.Body.String(), "\n"))
+		})
+	}
+}
+
+func TestTransferServiceErrors_Fail(t *testing.T) {
+	testUserEmail := "test-user0@gmail.com"
+	testRecipientEmail := "test-user1@gmail.com"
+	testAmount := "1.80"
+	testMoney := money.New(180, "USD")
+
+	tests := []struct {
+		name           string
+		serviceErr     error
+		expectedErr    string
+		expectedStatus int
+	}{
+		{
+			name:           "Transfer to self",
+			serviceErr:     bankErrors.ErrSelfTransfer,
+			expectedErr:    "cannot transfer to yourself",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Recipient not found",
+			serviceErr:     bankErrors.ErrRecipientNotFound,
+			expectedErr:    "recipient not found",
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "Not enough money",
+			serviceErr:     bankErrors.ErrNotEnoughMoney,
+			expectedErr:    "not enough money on balance",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Email not verified",
+			serviceErr:     bankErrors.ErrEmailNotVerified,
+			expectedErr:    "email is not verified",
+			expectedStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			reqBody := []byte(fmt.Sprintf(
+				transferRequestTemplate,
+				testAmount,
+				testRecipientEmail,
+				"",
+			))
+			bodyReader := bytes.NewBuffer(reqBody)
+
+			ctx := principalContext(testUserEmail)
+			req, err := http.NewRequestWithContext(ctx, http.MethodPost, "/bank/transfer", bodyReader)
+			require.NoError(t, err)
+
+			mockClient := bankMocks.NewBalancer(t)
+			mockClient.On(
+				"Transfer",
+				ctx,
+				testUserEmail,
+				testRecipientEmail,
+				testMoney,
+			).Return(money.Money{}, tt.serviceErr)
+			bank := bankApi.New(log, validation, mockClient)
+
+			rr := httptest.NewRecorder()
+			handler := http.HandlerFunc(bank.Transfer())
+
+			handler.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			assert.Equal(t, fmt.Sprintf(
+				errorResponseTemplate,
+				tt.expectedErr,
+			), strings.TrimRight(rr.Body.String(), "\n"))
+		})
+	}
+}
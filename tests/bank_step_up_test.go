@@ -0,0 +1,111 @@
+package tests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tizzhh/micro-banking/internal/api/principal"
+	"github.com/tizzhh/micro-banking/internal/domain/auth/models"
+	outboxModels "github.com/tizzhh/micro-banking/internal/domain/outbox/models"
+	"github.com/tizzhh/micro-banking/internal/services/bank"
+	bankErrors "github.com/tizzhh/micro-banking/internal/services/bank/errors"
+	"github.com/tizzhh/micro-banking/pkg/money"
+)
+
+// stepUpLedger is a balance operator and user provider for users with
+// enough money for any amount.
+type stepUpLedger struct{}
+
+func (stepUpLedger) User(ctx context.Context, email string) (models.User, error) {
+	return models.User{ID: 1, Email: email, Balance: 1_000_000_00}, nil
+}
+
+func (stepUpLedger) Deposit(ctx context.Context, user models.User, amount money.Money, notify outboxModels.Notify) (money.Money, error) {
+	return money.New(int64(user.Balance)+amount.Amount(), amount.Currency()), nil
+}
+
+func (stepUpLedger) Withdraw(ctx context.Context, user models.User, amount money.Money, notify outboxModels.Notify) (money.Money, error) {
+	return money.New(int64(user.Balance)-amount.Amount(), amount.Currency()), nil
+}
+
+func (stepUpLedger) Transfer(ctx context.Context, sender, recipient models.User, amount money.Money, notify outboxModels.Notify) (money.Money, error) {
+	return money.New(int64(sender.Balance)-amount.Amount(), amount.Currency()), nil
+}
+
+func TestBankStepUp_Cases(t *testing.T) {
+	stepUp, err := bank.ParseStepUp(map[string]string{"USD": "1000.00"}, 5*time.Minute)
+	require.NoError(t, err)
+	bankService := bank.New(log, stepUpLedger{}, stepUpLedger{}, stepUp)
+
+	withMFA := func(mfaAt time.Time) context.Context {
+		return principal.WithPrincipal(context.Background(), principal.Principal{
+			UserID: testPrincipalUserID,
+			Email:  "test-user0@gmail.com",
+			MFAAt:  mfaAt,
+		})
+	}
+
+	tests := []struct {
+		name        string
+		ctx         context.Context
+		amount      money.Money
+		expectedErr error
+	}{
+		{
+			name:   "Below the threshold",
+			ctx:    withMFA(time.Time{}),
+			amount: money.New(999_99, "USD"),
+		},
+		{
+			name:        "At the threshold without mfa",
+			ctx:         withMFA(time.Time{}),
+			amount:      money.New(1000_00, "USD"),
+			expectedErr: bankErrors.ErrStepUpRequired,
+		},
+		{
+			name:   "Above the threshold with a fresh step-up",
+			ctx:    withMFA(time.Now().Add(-time.Minute)),
+			amount: money.New(5000_00, "USD"),
+		},
+		{
+			name:        "Above the threshold with a stale step-up",
+			ctx:         withMFA(time.Now().Add(-10 * time.Minute)),
+			amount:      money.New(5000_00, "USD"),
+			expectedErr: bankErrors.ErrStepUpRequired,
+		},
+		{
+			name:   "Currency without a threshold",
+			ctx:    withMFA(time.Time{}),
+			amount: money.New(5000_00, "EUR"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := bankService.Transfer(tt.ctx, "test-user0@gmail.com", "test-user1@gmail.com", tt.amount)
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			if tt.amount.Currency() != "USD" {
+				return
+			}
+			_, err = bankService.Withdraw(tt.ctx, "test-user0@gmail.com", tt.amount)
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestBankParseStepUp_InvalidThreshold(t *testing.T) {
+	_, err := bank.ParseStepUp(map[string]string{"USD": "1000.001"}, time.Minute)
+	assert.ErrorIs(t, err, money.ErrTooPrecise)
+}
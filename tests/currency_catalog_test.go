@@ -0,0 +1,235 @@
+package tests
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	currencyApi "github.com/tizzhh/micro-banking/internal/delivery/http/bank/resource/currency"
+	currencyMocks "github.com/tizzhh/micro-banking/internal/delivery/http/bank/resource/currency/mocks"
+	currencyModels "github.com/tizzhh/micro-banking/internal/domain/currency/models"
+	currencyService "github.com/tizzhh/micro-banking/internal/services/currency"
+	currency "github.com/tizzhh/micro-banking/internal/services/currency/errors"
+	catalogMocks "github.com/tizzhh/micro-banking/internal/services/currency/mocks"
+	"github.com/tizzhh/micro-banking/internal/storage"
+	"github.com/tizzhh/micro-banking/pkg/money"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	currenciesResponseTemplate = `{"currencies":[{"code":"EUR","name":"Euro","exponent":2,"enabled":true,"tradable":true,"min_order":"1.00","max_order":"10000.00"},{"code":"USD","name":"US Dollar","exponent":2,"enabled":true,"tradable":false,"min_order":"0.00"}]}`
+)
+
+func TestCurrencies_HappyPath(t *testing.T) {
+	req, err := http.NewRequest(http.MethodGet, "/currencies", nil)
+	require.NoError(t, err)
+
+	mockClient := currencyMocks.NewCurrencyClient(t)
+	mockClient.On("ListCurrencies", req.Context()).Return(currencyApi.CurrenciesResponse{Currencies: []currencyApi.Currency{
+		{Code: "EUR", Name: "Euro", Exponent: 2, Enabled: true, Tradable: true, MinOrder: "1.00", MaxOrder: "10000.00"},
+		{Code: "USD", Name: "US Dollar", Exponent: 2, Enabled: true, MinOrder: "0.00"},
+	}}, nil)
+	currency := currencyApi.New(log, validation, mockClient)
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(currency.Currencies())
+
+	handler.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, currenciesResponseTemplate, strings.TrimRight(rr.Body.String(), "\n"))
+}
+
+func TestBuyNotTradable_Fail(t *testing.T) {
+	testUserEmail := "test-user0@gmail.com"
+
+	expectedError := "currency cannot be traded"
+
+	ctx := principalContext(testUserEmail)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "/currency/buy", strings.NewReader(fmt.Sprintf(
+		buyRequestTemplate,
+		"1.00",
+		"GBP",
+	)))
+	require.NoError(t, err)
+
+	mockClient := currencyMocks.NewCurrencyClient(t)
+	mockClient.On(
+		"Buy",
+		ctx,
+		testUserEmail,
+		money.New(100, "GBP"),
+		"",
+	).Return(money.Money{}, currencyApi.Fees{}, status.Error(codes.FailedPrecondition, currency.ErrCurrencyNotTradable.Error()))
+	currency := currencyApi.New(log, validation, mockClient)
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(currency.BuyCurrency())
+
+	handler.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Equal(t, fmt.Sprintf(
+		errorResponseTemplate,
+		expectedError,
+	), strings.TrimRight(rr.Body.String(), "\n"))
+}
+
+func TestCatalogOrderChecks_FailCases(t *testing.T) {
+	catalog := []currencyModels.Currency{
+		{Code: "EUR", Exponent: 2, Enabled: true, Tradable: true, MinOrder: 100, MaxOrder: 100000},
+		{Code: "GBP", Exponent: 2, Enabled: true},
+		{Code: "CNY", Exponent: 2, Tradable: true},
+	}
+
+	tests := []struct {
+		name        string
+		buy         bool
+		amount      money.Money
+		expectedErr error
+	}{
+		{
+			name:        "Buy unknown currency",
+			buy:         true,
+			amount:      money.New(500, "XYZ"),
+			expectedErr: currency.ErrCurrencyCodeNotFound,
+		},
+		{
+			name:        "Buy currency that is not tradable",
+			buy:         true,
+			amount:      money.New(500, "GBP"),
+			expectedErr: currency.ErrCurrencyNotTradable,
+		},
+		{
+			name:        "Buy disabled currency",
+			buy:         true,
+			amount:      money.New(500, "CNY"),
+			expectedErr: currency.ErrCurrencyNotTradable,
+		},
+		{
+			name:        "Buy below the minimum order",
+			buy:         true,
+			amount:      money.New(99, "EUR"),
+			expectedErr: currency.ErrOrderTooSmall,
+		},
+		{
+			name:        "Sell above the maximum order",
+			amount:      money.New(100001, "EUR"),
+			expectedErr: currency.ErrOrderTooLarge,
+		},
+		{
+			name:        "Sell currency that is not tradable",
+			amount:      money.New(500, "GBP"),
+			expectedErr: currency.ErrCurrencyNotTradable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			mockCatalog := catalogMocks.NewCatalog(t)
+			mockCatalog.On("Currency", ctx, tt.amount.Currency()).Return(catalogEntry(catalog, tt.amount.Currency()))
+			service := currencyService.New(log, nil, nil, nil, nil, nil, mockCatalog, nil, 0, nil, nil)
+
+			var err error
+			if tt.buy {
+				_, err = service.Buy(ctx, testUserEmail, tt.amount, "")
+			} else {
+				_, err = service.Sell(ctx, testUserEmail, tt.amount, "")
+			}
+			require.ErrorIs(t, err, tt.expectedErr)
+		})
+	}
+}
+
+func TestConvertCatalogChecks_FailCases(t *testing.T) {
+	catalog := []currencyModels.Currency{
+		{Code: "EUR", Exponent: 2, Enabled: true, Tradable: true, MinOrder: 100},
+		{Code: "GBP", Exponent: 2, Enabled: true},
+		{Code: "CNY", Exponent: 2, Tradable: true},
+	}
+
+	tests := []struct {
+		name        string
+		amount      money.Money
+		to          string
+		expectedErr error
+	}{
+		{
+			name:        "Convert to the same currency",
+			amount:      money.New(500, "EUR"),
+			to:          "EUR",
+			expectedErr: currency.ErrSameCurrency,
+		},
+		{
+			name:        "Convert from the base currency",
+			amount:      money.New(500, "USD"),
+			to:          "EUR",
+			expectedErr: currency.ErrBaseCurrency,
+		},
+		{
+			name:        "Convert from currency that is not tradable",
+			amount:      money.New(500, "GBP"),
+			to:          "EUR",
+			expectedErr: currency.ErrCurrencyNotTradable,
+		},
+		{
+			name:        "Convert below the minimum order",
+			amount:      money.New(99, "EUR"),
+			to:          "CNY",
+			expectedErr: currency.ErrOrderTooSmall,
+		},
+		{
+			name:        "Convert to disabled currency",
+			amount:      money.New(500, "EUR"),
+			to:          "CNY",
+			expectedErr: currency.ErrCurrencyNotTradable,
+		},
+		{
+			name:        "Convert to unknown currency",
+			amount:      money.New(500, "EUR"),
+			to:          "XYZ",
+			expectedErr: currency.ErrCurrencyCodeNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			mockCatalog := catalogMocks.NewCatalog(t)
+			for _, code := range []string{tt.amount.Currency(), tt.to} {
+				mockCatalog.On("Currency", ctx, code).Return(catalogEntry(catalog, code)).Maybe()
+			}
+			service := currencyService.New(log, nil, nil, nil, nil, nil, mockCatalog, nil, 0, nil, nil)
+
+			_, _, err := service.Convert(ctx, testUserEmail, tt.amount, tt.to)
+			require.ErrorIs(t, err, tt.expectedErr)
+		})
+	}
+}
+
+func TestRegisterExponents_HappyPath(t *testing.T) {
+	currencyService.RegisterExponents([]currencyModels.Currency{{Code: "KWD", Exponent: 3}})
+
+	amount, err := money.Parse("1.234", "KWD")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1234), amount.Amount())
+	assert.Equal(t, "1.234", amount.String())
+}
+
+func catalogEntry(catalog []currencyModels.Currency, code string) (currencyModels.Currency, error) {
+	for _, entry := range catalog {
+		if entry.Code == code {
+			return entry, nil
+		}
+	}
+	return currencyModels.Currency{}, storage.ErrCurrencyCodeNotFound
+}
@@ -2,12 +2,12 @@ package tests
 
 import (
 	"bytes"
-	"context"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/brianvoe/gofakeit/v6"
 	"github.com/stretchr/testify/assert"
@@ -15,43 +15,59 @@ import (
 	currencyApi "github.com/tizzhh/micro-banking/internal/delivery/http/bank/resource/currency"
 	currencyMocks "github.com/tizzhh/micro-banking/internal/delivery/http/bank/resource/currency/mocks"
 	currency "github.com/tizzhh/micro-banking/internal/services/currency/errors"
+	"github.com/tizzhh/micro-banking/pkg/money"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
 const (
-	buyRequestTemplate  = `{"amount": %d,"currency_code": "%s","email": "%s"}`
-	buyResponseTemplate = `{"bought_amount":%.1f,"currency_code":"%s"}`
+	buyRequestTemplate  = `{"amount": "%s","currency_code": "%s"}`
+	buyResponseTemplate = `{"bought_amount":"%s","currency_code":"%s","cost":"%s","fees":%s}`
 
-	sellRequestTemplate  = `{"amount": %d,"currency_code": "%s","email": "%s"}`
-	sellResponseTemplate = `{"sold_amount":%.1f,"currency_code":"%s"}`
+	sellRequestTemplate  = `{"amount": "%s","currency_code": "%s"}`
+	sellResponseTemplate = `{"sold_amount":"%s","currency_code":"%s","proceeds":"%s","fees":%s}`
+
+	testFeesResponse = `{"mid_rate":"0.917431","rate":"1.09","gross":"1.09","spread":"0.01","fee":"0.50"}`
+
+	convertRequestTemplate  = `{"amount": "%s","from_currency_code": "%s","to_currency_code": "%s"}`
+	convertResponseTemplate = `{"from_amount":"%s","from_currency_code":"%s","to_amount":"%s","to_currency_code":"%s","rate":"%s"}`
+
+	transactionsResponseTemplate = `{"transactions":[{"transaction_id":%d,"type":"%s","currency_code":"%s","direction":"%s","amount":"%s","created_at":"%s"}],"next_cursor":"%s"}`
 )
 
+var testFees = currencyApi.Fees{
+	MidRate: "0.917431",
+	Rate:    "1.09",
+	Gross:   "1.09",
+	Spread:  "0.01",
+	Fee:     "0.50",
+}
+
 func TestBuy_HappyPath(t *testing.T) {
 	testUserEmail := "test-user0@gmail.com"
 	testCurrencyCode := "EUR"
-	var testAmount uint64 = 1
-	var testBought float32 = 0.9
+	testAmount := "1.00"
+	testCost := money.New(109, "USD")
 
 	reqBody := []byte(fmt.Sprintf(
 		buyRequestTemplate,
 		testAmount,
 		testCurrencyCode,
-		testUserEmail,
 	))
 	bodyReader := bytes.NewBuffer(reqBody)
 
-	req, err := http.NewRequest(http.MethodPost, "/currency/buy", bodyReader)
+	ctx := principalContext(testUserEmail)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "/currency/buy", bodyReader)
 	require.NoError(t, err)
 
 	mockClient := currencyMocks.NewCurrencyClient(t)
 	mockClient.On(
 		"Buy",
-		context.Background(),
+		ctx,
 		testUserEmail,
-		testCurrencyCode,
-		testAmount,
-	).Return(testBought, nil)
+		money.New(100, testCurrencyCode),
+		"",
+	).Return(testCost, testFees, nil)
 	currency := currencyApi.New(log, validation, mockClient)
 
 	rr := httptest.NewRecorder()
@@ -62,49 +78,39 @@ func TestBuy_HappyPath(t *testing.T) {
 	assert.Equal(t, http.StatusOK, rr.Code)
 	assert.Equal(t, fmt.Sprintf(
 		buyResponseTemplate,
-		testBought,
+		testAmount,
 		testCurrencyCode,
+		testCost.String(),
+		testFeesResponse,
 	), strings.TrimRight(rr.Body.String(), "\n"))
 }
 
 func TestBuyHttp_FailCases(t *testing.T) {
 	tests := []struct {
 		name           string
-		email          string
 		currencyCode   string
-		amount         uint64
+		amount         string
 		expectedErr    string
 		expectedStatus int
 	}{
-		{
-			name:           "Buy with empty email",
-			email:          "",
-			currencyCode:   testCurrencyCode,
-			amount:         testAmountBuy,
-			expectedErr:    "field Email is a required field",
-			expectedStatus: 400,
-		},
 		{
 			name:           "Buy with empty currency code",
-			email:          gofakeit.Email(),
 			currencyCode:   "",
-			amount:         testAmountBuy,
+			amount:         "2",
 			expectedErr:    "field CurrencyCode is a required field",
 			expectedStatus: 400,
 		},
 		{
 			name:           "Buy with empty amount",
-			email:          testUserEmail,
 			currencyCode:   testCurrencyCode,
-			amount:         0,
+			amount:         "",
 			expectedErr:    "field Amount is a required field",
 			expectedStatus: 400,
 		},
 		{
-			name:           "Buy with fake currency code",
-			email:          gofakeit.Email(),
-			currencyCode:   gofakeit.LetterN(currencyCodeLen),
-			amount:         testAmountBuy,
+			name:           "Buy with malformed currency code",
+			currencyCode:   "eur",
+			amount:         "2",
 			expectedErr:    "field CurrencyCode is not valid",
 			expectedStatus: 400,
 		},
@@ -118,11 +124,11 @@ func TestBuyHttp_FailCases(t *testing.T) {
 				buyRequestTemplate,
 				tt.amount,
 				tt.currencyCode,
-				tt.email,
 			))
 			bodyReader := bytes.NewBuffer(reqBody)
 
-			req, err := http.NewRequest(http.MethodPost, "/currency/buy", bodyReader)
+			ctx := principalContext(gofakeit.Email())
+			req, err := http.NewRequestWithContext(ctx, http.MethodPost, "/currency/buy", bodyReader)
 			require.NoError(t, err)
 
 			mockClient := currencyMocks.NewCurrencyClient(t)
@@ -147,8 +153,8 @@ func TestBuyHttp_FailCases(t *testing.T) {
 func TestBuyUserDoesNotExist_Fail(t *testing.T) {
 	testUserEmail := "test-user0@gmail.com"
 	testCurrencyCode := "EUR"
-	var testAmount uint64 = 1
-	var testBought float32 = 0.9
+	testAmount := "1.00"
+	testCost := money.New(109, "USD")
 
 	expectedError := "not enough money on balance"
 
@@ -156,21 +162,21 @@ func TestBuyUserDoesNotExist_Fail(t *testing.T) {
 		buyRequestTemplate,
 		testAmount,
 		testCurrencyCode,
-		testUserEmail,
 	))
 	bodyReader := bytes.NewBuffer(reqBody)
 
-	req, err := http.NewRequest(http.MethodPost, "/currency/buy", bodyReader)
+	ctx := principalContext(testUserEmail)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "/currency/buy", bodyReader)
 	require.NoError(t, err)
 
 	mockClient := currencyMocks.NewCurrencyClient(t)
 	mockClient.On(
 		"Buy",
-		context.Background(),
+		ctx,
 		testUserEmail,
-		testCurrencyCode,
-		testAmount,
-	).Return(testBought, status.Error(codes.FailedPrecondition, currency.ErrNotEnoughMoney.Error()))
+		money.New(100, testCurrencyCode),
+		"",
+	).Return(testCost, currencyApi.Fees{}, status.Error(codes.FailedPrecondition, currency.ErrNotEnoughMoney.Error()))
 	currency := currencyApi.New(log, validation, mockClient)
 
 	rr := httptest.NewRecorder()
@@ -188,8 +194,8 @@ func TestBuyUserDoesNotExist_Fail(t *testing.T) {
 func TestBuyNotEnoughMoney_Fail(t *testing.T) {
 	testUserEmail := "test-user0@gmail.com"
 	testCurrencyCode := "EUR"
-	var testAmount uint64 = 1
-	var testBought float32 = 0.9
+	testAmount := "1.00"
+	testCost := money.New(109, "USD")
 
 	expectedError := "user not found"
 
@@ -197,21 +203,21 @@ func TestBuyNotEnoughMoney_Fail(t *testing.T) {
 		buyRequestTemplate,
 		testAmount,
 		testCurrencyCode,
-		testUserEmail,
 	))
 	bodyReader := bytes.NewBuffer(reqBody)
 
-	req, err := http.NewRequest(http.MethodPost, "/currency/buy", bodyReader)
+	ctx := principalContext(testUserEmail)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "/currency/buy", bodyReader)
 	require.NoError(t, err)
 
 	mockClient := currencyMocks.NewCurrencyClient(t)
 	mockClient.On(
 		"Buy",
-		context.Background(),
+		ctx,
 		testUserEmail,
-		testCurrencyCode,
-		testAmount,
-	).Return(testBought, status.Error(codes.NotFound, currency.ErrUserNotFound.Error()))
+		money.New(100, testCurrencyCode),
+		"",
+	).Return(testCost, currencyApi.Fees{}, status.Error(codes.NotFound, currency.ErrUserNotFound.Error()))
 	currency := currencyApi.New(log, validation, mockClient)
 
 	rr := httptest.NewRecorder()
@@ -229,28 +235,28 @@ func TestBuyNotEnoughMoney_Fail(t *testing.T) {
 func TestSell_HappyPath(t *testing.T) {
 	testUserEmail := "test-user0@gmail.com"
 	testCurrencyCode := "EUR"
-	var testAmount uint64 = 1
-	var testBought float32 = 0.9
+	testAmount := "1.00"
+	testCost := money.New(109, "USD")
 
 	reqBody := []byte(fmt.Sprintf(
 		sellRequestTemplate,
 		testAmount,
 		testCurrencyCode,
-		testUserEmail,
 	))
 	bodyReader := bytes.NewBuffer(reqBody)
 
-	req, err := http.NewRequest(http.MethodPost, "/currency/sell", bodyReader)
+	ctx := principalContext(testUserEmail)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "/currency/sell", bodyReader)
 	require.NoError(t, err)
 
 	mockClient := currencyMocks.NewCurrencyClient(t)
 	mockClient.On(
 		"Sell",
-		context.Background(),
+		ctx,
 		testUserEmail,
-		testCurrencyCode,
-		testAmount,
-	).Return(testBought, nil)
+		money.New(100, testCurrencyCode),
+		"",
+	).Return(testCost, testFees, nil)
 	currency := currencyApi.New(log, validation, mockClient)
 
 	rr := httptest.NewRecorder()
@@ -261,49 +267,39 @@ func TestSell_HappyPath(t *testing.T) {
 	assert.Equal(t, http.StatusOK, rr.Code)
 	assert.Equal(t, fmt.Sprintf(
 		sellResponseTemplate,
-		testBought,
+		testAmount,
 		testCurrencyCode,
+		testCost.String(),
+		testFeesResponse,
 	), strings.TrimRight(rr.Body.String(), "\n"))
 }
 
 func TestSellHttp_FailCases(t *testing.T) {
 	tests := []struct {
 		name           string
-		email          string
 		currencyCode   string
-		amount         uint64
+		amount         string
 		expectedErr    string
 		expectedStatus int
 	}{
-		{
-			name:           "Buy with empty email",
-			email:          "",
-			currencyCode:   testCurrencyCode,
-			amount:         testAmountBuy,
-			expectedErr:    "field Email is a required field",
-			expectedStatus: 400,
-		},
 		{
 			name:           "Buy with empty currency code",
-			email:          gofakeit.Email(),
 			currencyCode:   "",
-			amount:         testAmountBuy,
+			amount:         "2",
 			expectedErr:    "field CurrencyCode is a required field",
 			expectedStatus: 400,
 		},
 		{
 			name:           "Buy with empty amount",
-			email:          testUserEmail,
 			currencyCode:   testCurrencyCode,
-			amount:         0,
+			amount:         "",
 			expectedErr:    "field Amount is a required field",
 			expectedStatus: 400,
 		},
 		{
-			name:           "Buy with fake currency code",
-			email:          gofakeit.Email(),
-			currencyCode:   gofakeit.LetterN(currencyCodeLen),
-			amount:         testAmountBuy,
+			name:           "Buy with malformed currency code",
+			currencyCode:   "eur",
+			amount:         "2",
 			expectedErr:    "field CurrencyCode is not valid",
 			expectedStatus: 400,
 		},
@@ -317,11 +313,11 @@ func TestSellHttp_FailCases(t *testing.T) {
 				buyRequestTemplate,
 				tt.amount,
 				tt.currencyCode,
-				tt.email,
 			))
 			bodyReader := bytes.NewBuffer(reqBody)
 
-			req, err := http.NewRequest(http.MethodPost, "/currency/sell", bodyReader)
+			ctx := principalContext(gofakeit.Email())
+			req, err := http.NewRequestWithContext(ctx, http.MethodPost, "/currency/sell", bodyReader)
 			require.NoError(t, err)
 
 			mockClient := currencyMocks.NewCurrencyClient(t)
@@ -346,8 +342,8 @@ func TestSellHttp_FailCases(t *testing.T) {
 func TestSellNotEnoughMoneyOnBalance_Fail(t *testing.T) {
 	testUserEmail := "test-user0@gmail.com"
 	testCurrencyCode := "EUR"
-	var testAmount uint64 = 1
-	var testSold float32 = 0.9
+	testAmount := "1.00"
+	testProceeds := money.New(108, "USD")
 
 	expectedError := "not enough currency on wallet"
 
@@ -355,21 +351,21 @@ func TestSellNotEnoughMoneyOnBalance_Fail(t *testing.T) {
 		buyRequestTemplate,
 		testAmount,
 		testCurrencyCode,
-		testUserEmail,
 	))
 	bodyReader := bytes.NewBuffer(reqBody)
 
-	req, err := http.NewRequest(http.MethodPost, "/currency/sell", bodyReader)
+	ctx := principalContext(testUserEmail)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "/currency/sell", bodyReader)
 	require.NoError(t, err)
 
 	mockClient := currencyMocks.NewCurrencyClient(t)
 	mockClient.On(
 		"Sell",
-		context.Background(),
+		ctx,
 		testUserEmail,
-		testCurrencyCode,
-		testAmount,
-	).Return(testSold, status.Error(codes.FailedPrecondition, currency.ErrNotEnoughCurrency.Error()))
+		money.New(100, testCurrencyCode),
+		"",
+	).Return(testProceeds, currencyApi.Fees{}, status.Error(codes.FailedPrecondition, currency.ErrNotEnoughCurrency.Error()))
 	currency := currencyApi.New(log, validation, mockClient)
 
 	rr := httptest.NewRecorder()
@@ -387,8 +383,8 @@ func TestSellNotEnoughMoneyOnBalance_Fail(t *testing.T) {
 func TestSellUserNotFound_Fail(t *testing.T) {
 	testUserEmail := "test-user0@gmail.com"
 	testCurrencyCode := "EUR"
-	var testAmount uint64 = 1
-	var testSold float32 = 0.9
+	testAmount := "1.00"
+	testProceeds := money.New(108, "USD")
 
 	expectedError := "user not found"
 
@@ -396,21 +392,21 @@ func TestSellUserNotFound_Fail(t *testing.T) {
 		buyRequestTemplate,
 		testAmount,
 		testCurrencyCode,
-		testUserEmail,
 	))
 	bodyReader := bytes.NewBuffer(reqBody)
 
-	req, err := http.NewRequest(http.MethodPost, "/currency/sell", bodyReader)
+	ctx := principalContext(testUserEmail)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "/currency/sell", bodyReader)
 	require.NoError(t, err)
 
 	mockClient := currencyMocks.NewCurrencyClient(t)
 	mockClient.On(
 		"Sell",
-		context.Background(),
+		ctx,
 		testUserEmail,
-		testCurrencyCode,
-		testAmount,
-	).Return(testSold, status.Error(codes.NotFound, currency.ErrUserNotFound.Error()))
+		money.New(100, testCurrencyCode),
+		"",
+	).Return(testProceeds, currencyApi.Fees{}, status.Error(codes.NotFound, currency.ErrUserNotFound.Error()))
 	currency := currencyApi.New(log, validation, mockClient)
 
 	rr := httptest.NewRecorder()
@@ -424,3 +420,318 @@ func TestSellUserNotFound_Fail(t *testing.T) {
 		expectedError,
 	), strings.TrimRight(rr.Body.String(), "\n"))
 }
+
+func TestConvert_HappyPath(t *testing.T) {
+	testUserEmail := "test-user0@gmail.com"
+	testAmount := "10.50"
+	testConverted := money.New(8400, "CNY")
+	testRate, err := money.ParseRate("8")
+	require.NoError(t, err)
+
+	reqBody := []byte(fmt.Sprintf(
+		convertRequestTemplate,
+		testAmount,
+		testCurrencyCode,
+		"CNY",
+	))
+	bodyReader := bytes.NewBuffer(reqBody)
+
+	ctx := principalContext(testUserEmail)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "/currency/convert", bodyReader)
+	require.NoError(t, err)
+
+	mockClient := currencyMocks.NewCurrencyClient(t)
+	mockClient.On(
+		"Convert",
+		ctx,
+		testUserEmail,
+		money.New(1050, testCurrencyCode),
+		"CNY",
+	).Return(testConverted, testRate, nil)
+	currency := currencyApi.New(log, validation, mockClient)
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(currency.ConvertCurrency())
+
+	handler.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, fmt.Sprintf(
+		convertResponseTemplate,
+		testAmount,
+		testCurrencyCode,
+		testConverted.String(),
+		"CNY",
+		"8",
+	), strings.TrimRight(rr.Body.String(), "\n"))
+}
+
+func TestConvertHttp_FailCases(t *testing.T) {
+	tests := []struct {
+		name           string
+		from           string
+		to             string
+		amount         string
+		expectedErr    string
+		expectedStatus int
+	}{
+		{
+			name:           "Convert with empty from currency code",
+			from:           "",
+			to:             "CNY",
+			amount:         "2",
+			expectedErr:    "field FromCurrencyCode is a required field",
+			expectedStatus: 400,
+		},
+		{
+			name:           "Convert with empty amount",
+			from:           testCurrencyCode,
+			to:             "CNY",
+			amount:         "",
+			expectedErr:    "field Amount is a required field",
+			expectedStatus: 400,
+		},
+		{
+			name:           "Convert to the same currency",
+			from:           testCurrencyCode,
+			to:             testCurrencyCode,
+			amount:         "2",
+			expectedErr:    "field ToCurrencyCode is not valid",
+			expectedStatus: 400,
+		},
+		{
+			name:           "Convert to malformed currency code",
+			from:           testCurrencyCode,
+			to:             "cny",
+			amount:         "2",
+			expectedErr:    "field ToCurrencyCode is not valid",
+			expectedStatus: 400,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			reqBody := []byte(fmt.Sprintf(
+				convertRequestTemplate,
+				tt.amount,
+				tt.from,
+				tt.to,
+			))
+			bodyReader := bytes.NewBuffer(reqBody)
+
+			ctx := principalContext(gofakeit.Email())
+			req, err := http.NewRequestWithContext(ctx, http.MethodPost, "/currency/convert", bodyReader)
+			require.NoError(t, err)
+
+			mockClient := currencyMocks.NewCurrencyClient(t)
+
+			currency := currencyApi.New(log, validation, mockClient)
+
+			rr := httptest.NewRecorder()
+			handler := http.HandlerFunc(currency.ConvertCurrency())
+
+			handler.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			assert.Equal(t, fmt.Sprintf(
+				errorResponseTemplate,
+				tt.expectedErr,
+			), strings.TrimRight(rr.Body.String(), "\n"))
+		})
+	}
+}
+
+func TestConvertNotEnoughCurrency_Fail(t *testing.T) {
+	testUserEmail := "test-user0@gmail.com"
+
+	expectedError := "not enough currency on wallet"
+
+	reqBody := []byte(fmt.Sprintf(
+		convertRequestTemplate,
+		"1.00",
+		testCurrencyCode,
+		"CNY",
+	))
+	bodyReader := bytes.NewBuffer(reqBody)
+
+	ctx := principalContext(testUserEmail)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "/currency/convert", bodyReader)
+	require.NoError(t, err)
+
+	mockClient := currencyMocks.NewCurrencyClient(t)
+	mockClient.On(
+		"Convert",
+		ctx,
+		testUserEmail,
+		money.New(100, testCurrencyCode),
+		"CNY",
+	).Return(money.Money{}, money.Rate{}, status.Error(codes.FailedPrecondition, currency.ErrNotEnoughCurrency.Error()))
+	currency := currencyApi.New(log, validation, mockClient)
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(currency.ConvertCurrency())
+
+	handler.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Equal(t, fmt.Sprintf(
+		errorResponseTemplate,
+		expectedError,
+	), strings.TrimRight(rr.Body.String(), "\n"))
+}
+
+func TestTransactions_HappyPath(t *testing.T) {
+	testUserEmail := "test-user0@gmail.com"
+	testCreatedAt := time.Date(2024, 8, 1, 12, 0, 0, 0, time.UTC)
+	testFrom := time.Date(2024, 8, 1, 0, 0, 0, 0, time.UTC)
+	testCursor := "MTA"
+
+	ctx := principalContext(testUserEmail)
+	req, err := http.NewRequestWithContext(
+		ctx,
+		http.MethodGet,
+		"/bank/transactions?type=deposit,withdrawal&type=transfer&currency_code=USD&from=2024-08-01T00:00:00Z&order=asc&limit=1",
+		nil,
+	)
+	require.NoError(t, err)
+
+	mockClient := currencyMocks.NewCurrencyClient(t)
+	mockClient.On(
+		"Transactions",
+		ctx,
+		testUserEmail,
+		currencyApi.TransactionsFilter{
+			Types:        []string{"deposit", "withdrawal", "transfer"},
+			CurrencyCode: "USD",
+			From:         testFrom,
+			Order:        "asc",
+			Limit:        1,
+		},
+	).Return(currencyApi.TransactionsResponse{
+		Transactions: []currencyApi.Transaction{{
+			TransactionID: 10,
+			Type:          "deposit",
+			CurrencyCode:  "USD",
+			Direction:     "credit",
+			Amount:        "5.00",
+			CreatedAt:     testCreatedAt,
+		}},
+		NextCursor: testCursor,
+	}, nil)
+	currency := currencyApi.New(log, validation, mockClient)
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(currency.Transactions())
+
+	handler.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, fmt.Sprintf(
+		transactionsResponseTemplate,
+		10,
+		"deposit",
+		"USD",
+		"credit",
+		"5.00",
+		testCreatedAt.Format(time.RFC3339),
+		testCursor,
+	), strings.TrimRight(rr.Body.String(), "\n"))
+}
+
+func TestTransactionsHttp_FailCases(t *testing.T) {
+	tests := []struct {
+		name           string
+		query          string
+		expectedErr    string
+		expectedStatus int
+	}{
+		{
+			name:           "Transactions with unknown type",
+			query:          "type=refund",
+			expectedErr:    "field Types[0] is not valid",
+			expectedStatus: 400,
+		},
+		{
+			name:           "Transactions with malformed currency code",
+			query:          "currency_code=eur",
+			expectedErr:    "field CurrencyCode is not valid",
+			expectedStatus: 400,
+		},
+		{
+			name:           "Transactions with malformed from",
+			query:          "from=yesterday",
+			expectedErr:    "field From is not valid",
+			expectedStatus: 400,
+		},
+		{
+			name:           "Transactions with unknown order",
+			query:          "order=random",
+			expectedErr:    "field Order is not valid",
+			expectedStatus: 400,
+		},
+		{
+			name:           "Transactions with limit above maximum",
+			query:          "limit=101",
+			expectedErr:    "invalid query",
+			expectedStatus: 400,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := principalContext(gofakeit.Email())
+			req, err := http.NewRequestWithContext(ctx, http.MethodGet, "/bank/transactions?"+tt.query, nil)
+			require.NoError(t, err)
+
+			mockClient := currencyMocks.NewCurrencyClient(t)
+
+			currency := currencyApi.New(log, validation, mockClient)
+
+			rr := httptest.NewRecorder()
+			handler := http.HandlerFunc(currency.Transactions())
+
+			handler.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			assert.Equal(t, fmt.Sprintf(
+				errorResponseTemplate,
+				tt.expectedErr,
+			), strings.TrimRight(rr.Body.String(), "\n"))
+		})
+	}
+}
+
+func TestTransactionsInvalidCursor_Fail(t *testing.T) {
+	testUserEmail := "test-user0@gmail.com"
+	testCursor := "not-a-cursor"
+
+	expectedError := "invalid cursor"
+
+	ctx := principalContext(testUserEmail)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "/bank/transactions?cursor="+testCursor, nil)
+	require.NoError(t, err)
+
+	mockClient := currencyMocks.NewCurrencyClient(t)
+	mockClient.On(
+		"Transactions",
+		ctx,
+		testUserEmail,
+		currencyApi.TransactionsFilter{Cursor: testCursor},
+	).Return(currencyApi.TransactionsResponse{}, status.Error(codes.InvalidArgument, currency.ErrInvalidCursor.Error()))
+	currency := currencyApi.New(log, validation, mockClient)
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(currency.Transactions())
+
+	handler.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Equal(t, fmt.Sprintf(
+		errorResponseTemplate,
+		expectedError,
+	), strings.TrimRight(rr.Body.String(), "\n"))
+}
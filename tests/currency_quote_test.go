@@ -0,0 +1,343 @@
+package tests
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/brianvoe/gofakeit/v6"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	currencyApi "github.com/tizzhh/micro-banking/internal/delivery/http/bank/resource/currency"
+	currencyMocks "github.com/tizzhh/micro-banking/internal/delivery/http/bank/resource/currency/mocks"
+	currencyModels "github.com/tizzhh/micro-banking/internal/domain/currency/models"
+	currencyService "github.com/tizzhh/micro-banking/internal/services/currency"
+	currency "github.com/tizzhh/micro-banking/internal/services/currency/errors"
+	catalogMocks "github.com/tizzhh/micro-banking/internal/services/currency/mocks"
+	"github.com/tizzhh/micro-banking/internal/storage"
+	"github.com/tizzhh/micro-banking/pkg/money"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	testQuoteID = "0123456789abcdef0123456789abcdef"
+
+	quoteRequestTemplate     = `{"amount": "%s","currency_code": "%s","side": "%s"}`
+	quoteResponseTemplate    = `{"quote_id":"%s","side":"%s","amount":"%s","currency_code":"%s","cost":"%s","rate":"%s","expires_at":"%s","fees":%s}`
+	quotedBuyRequestTemplate = `{"amount": "%s","currency_code": "%s","quote_id": "%s"}`
+)
+
+func TestQuote_HappyPath(t *testing.T) {
+	testUserEmail := "test-user0@gmail.com"
+	expiresAt := time.Date(2024, 5, 1, 12, 0, 30, 0, time.UTC)
+	quote := currencyApi.QuoteResponse{
+		QuoteID:      testQuoteID,
+		Side:         "buy",
+		Amount:       "1.00",
+		CurrencyCode: testCurrencyCode,
+		Cost:         "1.09",
+		Rate:         "1.0869",
+		ExpiresAt:    expiresAt,
+		Fees:         testFees,
+	}
+
+	ctx := principalContext(testUserEmail)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "/currency/quote", strings.NewReader(fmt.Sprintf(
+		quoteRequestTemplate,
+		"1.00",
+		testCurrencyCode,
+		"buy",
+	)))
+	require.NoError(t, err)
+
+	mockClient := currencyMocks.NewCurrencyClient(t)
+	mockClient.On(
+		"Quote",
+		ctx,
+		testUserEmail,
+		money.New(100, testCurrencyCode),
+		"buy",
+	).Return(quote, nil)
+	currency := currencyApi.New(log, validation, mockClient)
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(currency.QuoteCurrency())
+
+	handler.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, fmt.Sprintf(
+		quoteResponseTemplate,
+		testQuoteID,
+		"buy",
+		"1.00",
+		testCurrencyCode,
+		"1.09",
+		"1.0869",
+		expiresAt.Format(time.RFC3339),
+		testFeesResponse,
+	), strings.TrimRight(rr.Body.String(), "\n"))
+}
+
+func TestQuoteHttp_FailCases(t *testing.T) {
+	tests := []struct {
+		name           string
+		currencyCode   string
+		amount         string
+		side           string
+		expectedErr    string
+		expectedStatus int
+	}{
+		{
+			name:           "Quote with empty side",
+			currencyCode:   testCurrencyCode,
+			amount:         "2",
+			side:           "",
+			expectedErr:    "field Side is a required field",
+			expectedStatus: 400,
+		},
+		{
+			name:           "Quote with unknown side",
+			currencyCode:   testCurrencyCode,
+			amount:         "2",
+			side:           "hold",
+			expectedErr:    "field Side is not valid",
+			expectedStatus: 400,
+		},
+		{
+			name:           "Quote with malformed currency code",
+			currencyCode:   "eur",
+			amount:         "2",
+			side:           "sell",
+			expectedErr:    "field CurrencyCode is not valid",
+			expectedStatus: 400,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := principalContext(gofakeit.Email())
+			req, err := http.NewRequestWithContext(ctx, http.MethodPost, "/currency/quote", strings.NewReader(fmt.Sprintf(
+				quoteRequestTemplate,
+				tt.amount,
+				tt.currencyCode,
+				tt.side,
+			)))
+			require.NoError(t, err)
+
+			mockClient := currencyMocks.NewCurrencyClient(t)
+
+			currency := currencyApi.New(log, validation, mockClient)
+
+			rr := httptest.NewRecorder()
+			handler := http.HandlerFunc(currency.QuoteCurrency())
+
+			handler.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			assert.Equal(t, fmt.Sprintf(
+				errorResponseTemplate,
+				tt.expectedErr,
+			), strings.TrimRight(rr.Body.String(), "\n"))
+		})
+	}
+}
+
+func TestBuyWithQuote_HappyPath(t *testing.T) {
+	testUserEmail := "test-user0@gmail.com"
+	testCost := money.New(109, "USD")
+
+	ctx := principalContext(testUserEmail)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "/currency/buy", strings.NewReader(fmt.Sprintf(
+		quotedBuyRequestTemplate,
+		"1.00",
+		testCurrencyCode,
+		testQuoteID,
+	)))
+	require.NoError(t, err)
+
+	mockClient := currencyMocks.NewCurrencyClient(t)
+	mockClient.On(
+		"Buy",
+		ctx,
+		testUserEmail,
+		money.New(100, testCurrencyCode),
+		testQuoteID,
+	).Return(testCost, testFees, nil)
+	currency := currencyApi.New(log, validation, mockClient)
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(currency.BuyCurrency())
+
+	handler.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, fmt.Sprintf(
+		buyResponseTemplate,
+		"1.00",
+		testCurrencyCode,
+		testCost.String(),
+		testFeesResponse,
+	), strings.TrimRight(rr.Body.String(), "\n"))
+}
+
+func TestBuyQuoteExpired_Fail(t *testing.T) {
+	testUserEmail := "test-user0@gmail.com"
+
+	expectedError := "quote expired"
+
+	ctx := principalContext(testUserEmail)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "/currency/buy", strings.NewReader(fmt.Sprintf(
+		quotedBuyRequestTemplate,
+		"1.00",
+		testCurrencyCode,
+		testQuoteID,
+	)))
+	require.NoError(t, err)
+
+	mockClient := currencyMocks.NewCurrencyClient(t)
+	mockClient.On(
+		"Buy",
+		ctx,
+		testUserEmail,
+		money.New(100, testCurrencyCode),
+		testQuoteID,
+	).Return(money.Money{}, currencyApi.Fees{}, status.Error(codes.FailedPrecondition, currency.ErrQuoteExpired.Error()))
+	currency := currencyApi.New(log, validation, mockClient)
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(currency.BuyCurrency())
+
+	handler.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Equal(t, fmt.Sprintf(
+		errorResponseTemplate,
+		expectedError,
+	), strings.TrimRight(rr.Body.String(), "\n"))
+}
+
+func TestBuyMalformedQuoteID_Fail(t *testing.T) {
+	ctx := principalContext(gofakeit.Email())
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "/currency/buy", strings.NewReader(fmt.Sprintf(
+		quotedBuyRequestTemplate,
+		"1.00",
+		testCurrencyCode,
+		"not-a-quote",
+	)))
+	require.NoError(t, err)
+
+	mockClient := currencyMocks.NewCurrencyClient(t)
+	currency := currencyApi.New(log, validation, mockClient)
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(currency.BuyCurrency())
+
+	handler.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Equal(t, fmt.Sprintf(
+		errorResponseTemplate,
+		"field QuoteID is not valid",
+	), strings.TrimRight(rr.Body.String(), "\n"))
+}
+
+func TestQuotedOrder_FailCases(t *testing.T) {
+	catalog := []currencyModels.Currency{
+		{Code: "EUR", Exponent: 2, Enabled: true, Tradable: true},
+		{Code: "CNY", Exponent: 2, Enabled: true, Tradable: true},
+	}
+	quote := currencyModels.Quote{
+		ID:           testQuoteID,
+		Email:        testUserEmail,
+		Side:         currencyModels.QuoteBuy,
+		CurrencyCode: "EUR",
+		Amount:       500,
+		Cost:         545,
+		Rate:         "1.09",
+		ExpiresAt:    time.Now().Add(time.Minute),
+	}
+	expired := quote
+	expired.ExpiresAt = time.Now().Add(-time.Second)
+
+	tests := []struct {
+		name        string
+		sell        bool
+		email       string
+		amount      money.Money
+		stored      currencyModels.Quote
+		storeErr    error
+		expectedErr error
+	}{
+		{
+			name:        "Buy with unknown or expired quote",
+			email:       testUserEmail,
+			amount:      money.New(500, "EUR"),
+			storeErr:    storage.ErrQuoteNotFound,
+			expectedErr: currency.ErrQuoteExpired,
+		},
+		{
+			name:        "Buy with quote past its expiry",
+			email:       testUserEmail,
+			amount:      money.New(500, "EUR"),
+			stored:      expired,
+			expectedErr: currency.ErrQuoteExpired,
+		},
+		{
+			name:        "Buy with quote of another user",
+			email:       "other@gmail.com",
+			amount:      money.New(500, "EUR"),
+			stored:      quote,
+			expectedErr: currency.ErrQuoteMismatch,
+		},
+		{
+			name:        "Buy another amount than quoted",
+			email:       testUserEmail,
+			amount:      money.New(501, "EUR"),
+			stored:      quote,
+			expectedErr: currency.ErrQuoteMismatch,
+		},
+		{
+			name:        "Buy another currency than quoted",
+			email:       testUserEmail,
+			amount:      money.New(500, "CNY"),
+			stored:      quote,
+			expectedErr: currency.ErrQuoteMismatch,
+		},
+		{
+			name:        "Sell with buy quote",
+			sell:        true,
+			email:       testUserEmail,
+			amount:      money.New(500, "EUR"),
+			stored:      quote,
+			expectedErr: currency.ErrQuoteMismatch,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			mockCatalog := catalogMocks.NewCatalog(t)
+			mockCatalog.On("Currency", ctx, tt.amount.Currency()).Return(catalogEntry(catalog, tt.amount.Currency()))
+			mockQuotes := catalogMocks.NewQuoteStore(t)
+			mockQuotes.On("TakeQuote", ctx, testQuoteID).Return(tt.stored, tt.storeErr)
+			service := currencyService.New(log, nil, nil, nil, nil, nil, mockCatalog, mockQuotes, time.Minute, nil, nil)
+
+			var err error
+			if tt.sell {
+				_, err = service.Sell(ctx, tt.email, tt.amount, testQuoteID)
+			} else {
+				_, err = service.Buy(ctx, tt.email, tt.amount, testQuoteID)
+			}
+			require.ErrorIs(t, err, tt.expectedErr)
+		})
+	}
+}
@@ -2,26 +2,24 @@ package tests
 
 import (
 	"testing"
+	"time"
 
 	"github.com/brianvoe/gofakeit/v6"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
 
 	currencyv1 "github.com/tizzhh/micro-banking/gen/go/protos/proto/currency"
+	"github.com/tizzhh/micro-banking/internal/api/idempotency"
 	suite "github.com/tizzhh/micro-banking/tests/suite/currency"
 )
 
 const (
-	testUserEmail            = "test@gmail.com"
-	testUserPassword         = "admin"
-	testCurrencyCode         = "EUR"
-	testAmountBuy            = 2
-	boughtEuros      float32 = 1.8
-
-	testAmountSell         = 1
-	soldEuros      float32 = 0.9
-
-	currencyCodeLen = 3
+	testUserEmail    = "test@gmail.com"
+	testUserPassword = "admin"
+	testCurrencyCode = "EUR"
+	testAmountBuy    = 200
+	testAmountSell   = 100
 )
 
 func TestBuySellWallets_HappyPath(t *testing.T) {
@@ -35,7 +33,9 @@ func TestBuySellWallets_HappyPath(t *testing.T) {
 
 	require.NoError(t, err)
 	assert.Equal(t, testUserEmail, respBuy.GetEmail())
-	assert.Equal(t, respBuy.GetBought(), boughtEuros)
+	assert.Equal(t, int64(testAmountBuy), respBuy.GetBought())
+	assert.Positive(t, respBuy.GetCost())
+	assert.Equal(t, respBuy.GetCost(), respBuy.GetFees().GetGross()+respBuy.GetFees().GetFee())
 
 	respSell, err := st.CurrencyClient.Sell(ctx, &currencyv1.SellRequest{
 		Email:        testUserEmail,
@@ -45,7 +45,9 @@ func TestBuySellWallets_HappyPath(t *testing.T) {
 
 	require.NoError(t, err)
 	assert.Equal(t, testUserEmail, respSell.GetEmail())
-	assert.Equal(t, respSell.GetSold(), soldEuros)
+	assert.Equal(t, int64(testAmountSell), respSell.GetSold())
+	assert.Positive(t, respSell.GetProceeds())
+	assert.Equal(t, respSell.GetProceeds(), respSell.GetFees().GetGross()-respSell.GetFees().GetFee())
 
 	respWallet, err := st.CurrencyClient.Wallets(ctx, &currencyv1.WalletRequest{
 		Email: testUserEmail,
@@ -55,6 +57,32 @@ func TestBuySellWallets_HappyPath(t *testing.T) {
 	assert.NotEmpty(t, respWallet)
 }
 
+func TestBuyIdempotencyKey_Replay(t *testing.T) {
+	ctx, st := suite.New(t)
+
+	ctx = metadata.AppendToOutgoingContext(ctx, idempotency.MetadataKey, gofakeit.UUID())
+	req := &currencyv1.BuyRequest{
+		Email:        testUserEmail,
+		CurrencyCode: testCurrencyCode,
+		Amount:       testAmountBuy,
+	}
+
+	first, err := st.CurrencyClient.Buy(ctx, req)
+	require.NoError(t, err)
+
+	replayed, err := st.CurrencyClient.Buy(ctx, req)
+	require.NoError(t, err)
+	assert.Equal(t, first.GetCost(), replayed.GetCost())
+
+	_, err = st.CurrencyClient.Buy(ctx, &currencyv1.BuyRequest{
+		Email:        testUserEmail,
+		CurrencyCode: testCurrencyCode,
+		Amount:       testAmountBuy + 1,
+	})
+	require.Error(t, err)
+	assert.ErrorContains(t, err, idempotency.ErrKeyReused.Error())
+}
+
 func TestBuy_FailCases(t *testing.T) {
 	ctx, st := suite.New(t)
 
@@ -62,7 +90,7 @@ func TestBuy_FailCases(t *testing.T) {
 		name         string
 		email        string
 		currencyCode string
-		amount       uint64
+		amount       int64
 		expectedErr  string
 	}{
 		{
@@ -77,7 +105,7 @@ func TestBuy_FailCases(t *testing.T) {
 			email:        gofakeit.Email(),
 			currencyCode: "",
 			amount:       testAmountBuy,
-			expectedErr:  "value must be in list",
+			expectedErr:  "value does not match regex pattern",
 		},
 		{
 			name:         "Buy with empty amount",
@@ -94,11 +122,11 @@ func TestBuy_FailCases(t *testing.T) {
 			expectedErr:  "user not found",
 		},
 		{
-			name:         "Buy with fake currency code",
+			name:         "Buy with unknown currency code",
 			email:        gofakeit.Email(),
-			currencyCode: gofakeit.LetterN(currencyCodeLen),
+			currencyCode: "XYZ",
 			amount:       testAmountBuy,
-			expectedErr:  "value must be in list",
+			expectedErr:  "currency code not found",
 		},
 	}
 
@@ -124,7 +152,7 @@ func TestSell_FailCases(t *testing.T) {
 		name         string
 		email        string
 		currencyCode string
-		amount       uint64
+		amount       int64
 		expectedErr  string
 	}{
 		{
@@ -139,7 +167,7 @@ func TestSell_FailCases(t *testing.T) {
 			email:        gofakeit.Email(),
 			currencyCode: "",
 			amount:       testAmountBuy,
-			expectedErr:  "value must be in list",
+			expectedErr:  "value does not match regex pattern",
 		},
 		{
 			name:         "Sell with empty amount",
@@ -156,11 +184,11 @@ func TestSell_FailCases(t *testing.T) {
 			expectedErr:  "user not found",
 		},
 		{
-			name:         "Sell with fake currency code",
+			name:         "Sell with unknown currency code",
 			email:        gofakeit.Email(),
-			currencyCode: gofakeit.LetterN(currencyCodeLen),
+			currencyCode: "XYZ",
 			amount:       testAmountBuy,
-			expectedErr:  "value must be in list",
+			expectedErr:  "currency code not found",
 		},
 	}
 
@@ -211,3 +239,213 @@ func TestWallet_FailCases(t *testing.T) {
 		})
 	}
 }
+
+func TestTransactionsPagination_HappyPath(t *testing.T) {
+	ctx, st := suite.New(t)
+
+	_, err := st.CurrencyClient.Buy(ctx, &currencyv1.BuyRequest{
+		Email:        testUserEmail,
+		CurrencyCode: testCurrencyCode,
+		Amount:       testAmountBuy,
+	})
+	require.NoError(t, err)
+
+	firstPage, err := st.CurrencyClient.Transactions(ctx, &currencyv1.TransactionsRequest{
+		Email: testUserEmail,
+		Limit: 1,
+	})
+	require.NoError(t, err)
+	require.Len(t, firstPage.GetTransactions(), 1)
+	assert.NotEmpty(t, firstPage.GetNextCursor())
+
+	secondPage, err := st.CurrencyClient.Transactions(ctx, &currencyv1.TransactionsRequest{
+		Email:  testUserEmail,
+		Cursor: firstPage.GetNextCursor(),
+		Limit:  1,
+	})
+	require.NoError(t, err)
+	require.Len(t, secondPage.GetTransactions(), 1)
+}
+
+func TestTransactionsService_FailCases(t *testing.T) {
+	ctx, st := suite.New(t)
+
+	tests := []struct {
+		name        string
+		email       string
+		types       []string
+		cursor      string
+		limit       uint32
+		expectedErr string
+	}{
+		{
+			name:        "Transactions with empty email",
+			email:       "",
+			expectedErr: "email: value is empty, which is not a valid email address",
+		},
+		{
+			name:        "Transactions with unknown type",
+			email:       testUserEmail,
+			types:       []string{"refund"},
+			expectedErr: "value must be in list",
+		},
+		{
+			name:        "Transactions with limit above maximum",
+			email:       testUserEmail,
+			limit:       101,
+			expectedErr: "value must be less than or equal to 100",
+		},
+		{
+			name:        "Transactions with invalid cursor",
+			email:       testUserEmail,
+			cursor:      "not-a-cursor",
+			expectedErr: "invalid cursor",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := st.CurrencyClient.Transactions(ctx, &currencyv1.TransactionsRequest{
+				Email:  tt.email,
+				Types:  tt.types,
+				Cursor: tt.cursor,
+				Limit:  tt.limit,
+			})
+			require.Error(t, err)
+			assert.ErrorContains(t, err, tt.expectedErr)
+		})
+	}
+}
+
+func TestConvertService_HappyPath(t *testing.T) {
+	ctx, st := suite.New(t)
+
+	_, err := st.CurrencyClient.Buy(ctx, &currencyv1.BuyRequest{
+		Email:        testUserEmail,
+		CurrencyCode: testCurrencyCode,
+		Amount:       testAmountBuy,
+	})
+	require.NoError(t, err)
+
+	resp, err := st.CurrencyClient.Convert(ctx, &currencyv1.ConvertRequest{
+		Email:            testUserEmail,
+		FromCurrencyCode: testCurrencyCode,
+		ToCurrencyCode:   "CNY",
+		Amount:           testAmountSell,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, testUserEmail, resp.GetEmail())
+	assert.Positive(t, resp.GetConverted())
+	assert.NotEmpty(t, resp.GetRate())
+}
+
+func TestConvertService_FailCases(t *testing.T) {
+	ctx, st := suite.New(t)
+
+	tests := []struct {
+		name        string
+		from        string
+		to          string
+		amount      int64
+		expectedErr string
+	}{
+		{
+			name:        "Convert to the same currency",
+			from:        testCurrencyCode,
+			to:          testCurrencyCode,
+			amount:      testAmountSell,
+			expectedErr: "cannot convert a currency to itself",
+		},
+		{
+			name:        "Convert to the base currency",
+			from:        testCurrencyCode,
+			to:          "USD",
+			amount:      testAmountSell,
+			expectedErr: "use buy and sell to trade the base currency",
+		},
+		{
+			name:        "Convert with malformed currency code",
+			from:        "eur",
+			to:          "CNY",
+			amount:      testAmountSell,
+			expectedErr: "value does not match regex pattern",
+		},
+		{
+			name:        "Convert non-positive amount",
+			from:        testCurrencyCode,
+			to:          "CNY",
+			amount:      0,
+			expectedErr: "value must be greater than 0",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := st.CurrencyClient.Convert(ctx, &currencyv1.ConvertRequest{
+				Email:            testUserEmail,
+				FromCurrencyCode: tt.from,
+				ToCurrencyCode:   tt.to,
+				Amount:           tt.amount,
+			})
+			require.Error(t, err)
+			assert.ErrorContains(t, err, tt.expectedErr)
+		})
+	}
+}
+
+func TestQuoteBuy_HappyPath(t *testing.T) {
+	ctx, st := suite.New(t)
+
+	quote, err := st.CurrencyClient.Quote(ctx, &currencyv1.QuoteRequest{
+		Email:        testUserEmail,
+		CurrencyCode: testCurrencyCode,
+		Amount:       testAmountBuy,
+		Side:         "buy",
+	})
+	require.NoError(t, err)
+	assert.NotEmpty(t, quote.GetQuoteId())
+	assert.Positive(t, quote.GetCost())
+	assert.True(t, quote.GetExpiresAt().AsTime().After(time.Now()))
+
+	req := &currencyv1.BuyRequest{
+		Email:        testUserEmail,
+		CurrencyCode: testCurrencyCode,
+		Amount:       testAmountBuy,
+		QuoteId:      quote.GetQuoteId(),
+	}
+	bought, err := st.CurrencyClient.Buy(ctx, req)
+	require.NoError(t, err)
+	assert.Equal(t, quote.GetCost(), bought.GetCost())
+
+	// quotes are single use
+	_, err = st.CurrencyClient.Buy(ctx, req)
+	require.Error(t, err)
+	assert.ErrorContains(t, err, "quote expired")
+}
+
+func TestRateHistoryGrpc_HappyPath(t *testing.T) {
+	ctx, st := suite.New(t)
+
+	// quoting fetches the rate if it is not cached
+	_, err := st.CurrencyClient.Quote(ctx, &currencyv1.QuoteRequest{
+		Email:        testUserEmail,
+		CurrencyCode: testCurrencyCode,
+		Amount:       testAmountBuy,
+		Side:         "buy",
+	})
+	require.NoError(t, err)
+
+	resp, err := st.CurrencyClient.RateHistory(ctx, &currencyv1.RateHistoryRequest{
+		CurrencyCode: testCurrencyCode,
+		Interval:     "1m",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, testCurrencyCode, resp.GetCurrencyCode())
+	require.NotEmpty(t, resp.GetRates())
+
+	var samples uint32
+	for _, candle := range resp.GetCandles() {
+		samples += candle.GetSamples()
+	}
+	assert.Equal(t, uint32(len(resp.GetRates())), samples)
+}
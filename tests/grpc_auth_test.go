@@ -0,0 +1,207 @@
+package tests
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	authv1 "github.com/tizzhh/micro-banking/gen/go/protos/proto/auth"
+	"github.com/tizzhh/micro-banking/internal/api/principal"
+	"github.com/tizzhh/micro-banking/internal/delivery/grpc/interceptors"
+	authenticationMocks "github.com/tizzhh/micro-banking/internal/delivery/http/bank/router/middleware/auth/mocks"
+	"github.com/tizzhh/micro-banking/internal/domain/auth/models"
+	jwtpkg "github.com/tizzhh/micro-banking/pkg/jwt"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	testPublicMethod   = "/test.Test/Public"
+	testOwnerMethod    = "/test.Test/Owner"
+	testInternalMethod = "/test.Test/Internal"
+
+	testServiceName  = "bank"
+	testServiceToken = "service-token"
+)
+
+var testPolicy = interceptors.Policy{
+	testPublicMethod:   interceptors.Public,
+	testOwnerMethod:    interceptors.Owner,
+	testInternalMethod: interceptors.Internal,
+}
+
+func TestGRPCAuth_Cases(t *testing.T) {
+	testUserEmail := "test-user0@gmail.com"
+	var testUserID uint64 = 7
+
+	_, signingKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	keys, err := jwtpkg.NewKeyRing(signingKey)
+	require.NoError(t, err)
+	tokens := jwtpkg.New(time.Hour, keys)
+
+	token, err := tokens.NewToken(models.User{ID: testUserID, Email: testUserEmail})
+	require.NoError(t, err)
+	revokedToken, err := tokens.NewToken(models.User{ID: testUserID, Email: testUserEmail})
+	require.NoError(t, err)
+	parsed, err := tokens.CheckToken(revokedToken)
+	require.NoError(t, err)
+	revokedJTI := parsed.Claims.(*jwtpkg.Claims).ID
+
+	bearer := []string{principal.MetadataAuthorization, "Bearer " + token}
+	service := []string{principal.MetadataServiceName, testServiceName, principal.MetadataServiceToken, testServiceToken}
+	forwarded := []string{principal.MetadataUserID, "8", principal.MetadataEmail, "test-user1@gmail.com"}
+
+	tests := []struct {
+		name              string
+		method            string
+		md                []string
+		email             string
+		expectedCode      codes.Code
+		expectedPrincipal *principal.Principal
+		expectedService   string
+	}{
+		{
+			name:         "Public method without credentials",
+			method:       testPublicMethod,
+			expectedCode: codes.OK,
+		},
+		{
+			name:         "Owner method without credentials",
+			method:       testOwnerMethod,
+			email:        testUserEmail,
+			expectedCode: codes.Unauthenticated,
+		},
+		{
+			name:              "User acting on own account",
+			method:            testOwnerMethod,
+			md:                bearer,
+			email:             testUserEmail,
+			expectedCode:      codes.OK,
+			expectedPrincipal: &principal.Principal{UserID: testUserID, Email: testUserEmail},
+		},
+		{
+			name:         "User acting on another account",
+			method:       testOwnerMethod,
+			md:           bearer,
+			email:        "test-user1@gmail.com",
+			expectedCode: codes.PermissionDenied,
+		},
+		{
+			name:         "User forging forwarded principal",
+			method:       testOwnerMethod,
+			md:           forwarded,
+			email:        "test-user1@gmail.com",
+			expectedCode: codes.Unauthenticated,
+		},
+		{
+			name:         "Invalid token",
+			method:       testOwnerMethod,
+			md:           []string{principal.MetadataAuthorization, "Bearer not-a-token"},
+			email:        testUserEmail,
+			expectedCode: codes.Unauthenticated,
+		},
+		{
+			name:         "Revoked token",
+			method:       testOwnerMethod,
+			md:           []string{principal.MetadataAuthorization, "Bearer " + revokedToken},
+			email:        testUserEmail,
+			expectedCode: codes.Unauthenticated,
+		},
+		{
+			name:              "Service acting for forwarded user",
+			method:            testOwnerMethod,
+			md:                append(append([]string{}, service...), forwarded...),
+			email:             "test-user1@gmail.com",
+			expectedCode:      codes.OK,
+			expectedPrincipal: &principal.Principal{UserID: 8, Email: "test-user1@gmail.com"},
+			expectedService:   testServiceName,
+		},
+		{
+			name:         "Service forwarding user token for another account",
+			method:       testOwnerMethod,
+			md:           append(append([]string{}, service...), bearer...),
+			email:        "test-user1@gmail.com",
+			expectedCode: codes.PermissionDenied,
+		},
+		{
+			name:         "Invalid service token",
+			method:       testOwnerMethod,
+			md:           []string{principal.MetadataServiceName, testServiceName, principal.MetadataServiceToken, "guess"},
+			email:        testUserEmail,
+			expectedCode: codes.Unauthenticated,
+		},
+		{
+			name:            "Service calling internal method",
+			method:          testInternalMethod,
+			md:              service,
+			expectedCode:    codes.OK,
+			expectedService: testServiceName,
+		},
+		{
+			name:         "User calling internal method",
+			method:       testInternalMethod,
+			md:           bearer,
+			expectedCode: codes.PermissionDenied,
+		},
+		{
+			name:         "Method without policy",
+			method:       "/test.Test/Unknown",
+			md:           service,
+			expectedCode: codes.PermissionDenied,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			revocationChecker := authenticationMocks.NewTokenRevocationChecker(t)
+			revocationChecker.On("IsTokenRevoked", mock.Anything, mock.MatchedBy(func(claims *jwtpkg.Claims) bool { return claims.ID == revokedJTI })).Return(true, nil).Maybe()
+			revocationChecker.On("IsTokenRevoked", mock.Anything, mock.Anything).Return(false, nil).Maybe()
+
+			interceptor := interceptors.Auth(
+				log,
+				tokens,
+				revocationChecker,
+				map[string]string{testServiceName: testServiceToken},
+				testPolicy,
+			)
+
+			ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(tt.md...))
+			var handlerCtx context.Context
+			_, err := interceptor(
+				ctx,
+				&authv1.UserRequest{Email: tt.email},
+				&grpc.UnaryServerInfo{FullMethod: tt.method},
+				func(ctx context.Context, req any) (any, error) {
+					handlerCtx = ctx
+					return nil, nil
+				},
+			)
+
+			require.Equal(t, tt.expectedCode, status.Code(err))
+			if tt.expectedCode != codes.OK {
+				assert.Nil(t, handlerCtx)
+				return
+			}
+
+			p, ok := principal.FromContext(handlerCtx)
+			if tt.expectedPrincipal != nil {
+				require.True(t, ok)
+				assert.Equal(t, *tt.expectedPrincipal, p)
+			} else {
+				assert.False(t, ok)
+			}
+			service, _ := principal.ServiceFromContext(handlerCtx)
+			assert.Equal(t, tt.expectedService, service)
+		})
+	}
+}
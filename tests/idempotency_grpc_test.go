@@ -0,0 +1,58 @@
+package tests
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	currencyv1 "github.com/tizzhh/micro-banking/gen/go/protos/proto/currency"
+	"github.com/tizzhh/micro-banking/internal/api/idempotency"
+	"github.com/tizzhh/micro-banking/internal/api/principal"
+	"github.com/tizzhh/micro-banking/internal/delivery/grpc/interceptors"
+	idempotencyMocks "github.com/tizzhh/micro-banking/internal/delivery/http/bank/router/middleware/idempotency/mocks"
+	"github.com/tizzhh/micro-banking/internal/domain/idempotency/models"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestGRPCIdempotency_KeyScopedToPrincipal(t *testing.T) {
+	method := currencyv1.Currency_Buy_FullMethodName
+	users := []principal.Principal{
+		{UserID: 7, Email: "test-user0@gmail.com"},
+		{UserID: 8, Email: "test-user1@gmail.com"},
+	}
+
+	mockStore := idempotencyMocks.NewStore(t)
+	for _, user := range users {
+		storeKey := "grpc:" + strconv.FormatUint(user.UserID, 10) + ":" + method + ":" + testIdempotencyKey
+		mockStore.On("ReserveIdempotencyKey", mock.Anything, storeKey, mock.Anything, testIdempotencyKeyTTL).
+			Return(models.Record{}, true, nil).Once()
+		mockStore.On("CompleteIdempotencyKey", mock.Anything, storeKey, int(codes.OK), mock.Anything).
+			Return(nil).Once()
+	}
+
+	interceptor := interceptors.Idempotency(log, mockStore, testIdempotencyKeyTTL, method)
+
+	handled := 0
+	handler := func(ctx context.Context, req any) (any, error) {
+		handled++
+		return &currencyv1.BuyResponse{}, nil
+	}
+
+	for _, user := range users {
+		ctx := principal.WithPrincipal(
+			metadata.NewIncomingContext(context.Background(), metadata.Pairs(idempotency.MetadataKey, testIdempotencyKey)),
+			user,
+		)
+		req := &currencyv1.BuyRequest{Email: user.Email, CurrencyCode: "EUR", Amount: 100}
+
+		_, err := interceptor(ctx, req, &grpc.UnaryServerInfo{FullMethod: method}, handler)
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, len(users), handled)
+}
@@ -0,0 +1,192 @@
+package tests
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/tizzhh/micro-banking/internal/api/idempotency"
+	bankApi "github.com/tizzhh/micro-banking/internal/delivery/http/bank/resource/bank"
+	bankMocks "github.com/tizzhh/micro-banking/internal/delivery/http/bank/resource/bank/mocks"
+	idempotencyMiddleware "github.com/tizzhh/micro-banking/internal/delivery/http/bank/router/middleware/idempotency"
+	idempotencyMocks "github.com/tizzhh/micro-banking/internal/delivery/http/bank/router/middleware/idempotency/mocks"
+	"github.com/tizzhh/micro-banking/internal/domain/idempotency/models"
+	"github.com/tizzhh/micro-banking/pkg/money"
+)
+
+const (
+	testIdempotencyKey    = "3f0c1b9e-4c1e-4b43-9d3a-6a7d2c1e5f10"
+	testIdempotencyKeyTTL = 24 * time.Hour
+	testIdempotencyPath   = "/bank/deposit"
+
+	testIdempotencyUserEmail = "test-user0@gmail.com"
+)
+
+func idempotentDepositRequest(t *testing.T, key string, body []byte) *http.Request {
+	t.Helper()
+
+	ctx := principalContext(testIdempotencyUserEmail)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, testIdempotencyPath, bytes.NewBuffer(body))
+	require.NoError(t, err)
+	if key != "" {
+		req.Header.Set(idempotency.Header, key)
+	}
+	return req
+}
+
+func idempotentDepositHandler(store idempotencyMiddleware.Store, balancer bankApi.Balancer) http.Handler {
+	bank := bankApi.New(log, validation, balancer)
+	return idempotencyMiddleware.New(log, store, testIdempotencyKeyTTL)(http.HandlerFunc(bank.Deposit()))
+}
+
+func TestIdempotentDeposit_Replay(t *testing.T) {
+	testUserEmail := testIdempotencyUserEmail
+	testMoney := money.New(180, "USD")
+	storeKey := "http:" + strconv.FormatUint(testPrincipalUserID, 10) + ":" + http.MethodPost + " " + testIdempotencyPath + ":" + testIdempotencyKey
+
+	reqBody := []byte(fmt.Sprintf(depositRequestTemplate, "1.80"))
+	fingerprint := idempotency.Fingerprint([]byte(http.MethodPost), []byte(testIdempotencyPath), reqBody)
+	expectedResponse := fmt.Sprintf(depositResponseTemplate, testMoney)
+
+	mockBalancer := bankMocks.NewBalancer(t)
+	mockBalancer.On("Deposit", mock.Anything, testUserEmail, testMoney).Return(testMoney, nil).Once()
+
+	var stored []byte
+	mockStore := idempotencyMocks.NewStore(t)
+	mockStore.On("ReserveIdempotencyKey", mock.Anything, storeKey, fingerprint, testIdempotencyKeyTTL).
+		Return(models.Record{}, true, nil).Once()
+	mockStore.On("CompleteIdempotencyKey", mock.Anything, storeKey, http.StatusOK, mock.Anything).
+		Run(func(args mock.Arguments) { stored = args.Get(3).([]byte) }).
+		Return(nil).Once()
+
+	handler := idempotentDepositHandler(mockStore, mockBalancer)
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, idempotentDepositRequest(t, testIdempotencyKey, reqBody))
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, expectedResponse, strings.TrimRight(rr.Body.String(), "\n"))
+	assert.Equal(t, rr.Body.Bytes(), stored)
+
+	completedAt := time.Now()
+	mockStore.On("ReserveIdempotencyKey", mock.Anything, storeKey, fingerprint, testIdempotencyKeyTTL).
+		Return(models.Record{
+			Key:         storeKey,
+			Fingerprint: fingerprint,
+			StatusCode:  http.StatusOK,
+			Response:    stored,
+			CompletedAt: &completedAt,
+		}, false, nil).Once()
+
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, idempotentDepositRequest(t, testIdempotencyKey, reqBody))
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "true", rr.Header().Get(idempotency.ReplayedHeader))
+	assert.Equal(t, expectedResponse, strings.TrimRight(rr.Body.String(), "\n"))
+}
+
+func TestIdempotentDeposit_WithoutKey(t *testing.T) {
+	testUserEmail := testIdempotencyUserEmail
+	testMoney := money.New(180, "USD")
+
+	mockBalancer := bankMocks.NewBalancer(t)
+	mockBalancer.On("Deposit", mock.Anything, testUserEmail, testMoney).Return(testMoney, nil).Twice()
+
+	handler := idempotentDepositHandler(idempotencyMocks.NewStore(t), mockBalancer)
+
+	reqBody := []byte(fmt.Sprintf(depositRequestTemplate, "1.80"))
+	for range 2 {
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, idempotentDepositRequest(t, "", reqBody))
+		assert.Equal(t, http.StatusOK, rr.Code)
+	}
+}
+
+func TestIdempotentDeposit_ReleasesOnServerError(t *testing.T) {
+	testUserEmail := testIdempotencyUserEmail
+	testMoney := money.New(180, "USD")
+
+	mockBalancer := bankMocks.NewBalancer(t)
+	mockBalancer.On("Deposit", mock.Anything, testUserEmail, testMoney).Return(money.Money{}, errors.New("connection reset"))
+
+	mockStore := idempotencyMocks.NewStore(t)
+	mockStore.On("ReserveIdempotencyKey", mock.Anything, mock.Anything, mock.Anything, testIdempotencyKeyTTL).
+		Return(models.Record{}, true, nil)
+	mockStore.On("ReleaseIdempotencyKey", mock.Anything, mock.Anything).Return(nil).Once()
+
+	handler := idempotentDepositHandler(mockStore, mockBalancer)
+
+	reqBody := []byte(fmt.Sprintf(depositRequestTemplate, "1.80"))
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, idempotentDepositRequest(t, testIdempotencyKey, reqBody))
+
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+}
+
+func TestIdempotentDeposit_FailCases(t *testing.T) {
+	reqBody := []byte(fmt.Sprintf(depositRequestTemplate, "1.80"))
+	fingerprint := idempotency.Fingerprint([]byte(http.MethodPost), []byte(testIdempotencyPath), reqBody)
+	completedAt := time.Now()
+
+	tests := []struct {
+		name           string
+		key            string
+		stored         *models.Record
+		expectedErr    string
+		expectedStatus int
+	}{
+		{
+			name:           "Key reused with a different body",
+			key:            testIdempotencyKey,
+			stored:         &models.Record{Fingerprint: "other", StatusCode: http.StatusOK, CompletedAt: &completedAt},
+			expectedErr:    idempotency.ErrKeyReused.Error(),
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:           "Key of a request in progress",
+			key:            testIdempotencyKey,
+			stored:         &models.Record{Fingerprint: fingerprint},
+			expectedErr:    idempotency.ErrInProgress.Error(),
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name:           "Key too long",
+			key:            strings.Repeat("k", idempotency.MaxKeyLength+1),
+			expectedErr:    idempotency.ErrInvalidKey.Error(),
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockStore := idempotencyMocks.NewStore(t)
+			if tt.stored != nil {
+				mockStore.On("ReserveIdempotencyKey", mock.Anything, mock.Anything, fingerprint, testIdempotencyKeyTTL).
+					Return(*tt.stored, false, nil)
+			}
+
+			handler := idempotentDepositHandler(mockStore, bankMocks.NewBalancer(t))
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, idempotentDepositRequest(t, tt.key, reqBody))
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			assert.Equal(t, fmt.Sprintf(
+				errorResponseTemplate,
+				tt.expectedErr,
+			), strings.TrimRight(rr.Body.String(), "\n"))
+		})
+	}
+}
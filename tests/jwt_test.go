@@ -0,0 +1,458 @@
+package tests
+
+import (
+	"context"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tizzhh/micro-banking/internal/config"
+	"github.com/tizzhh/micro-banking/internal/domain/auth/models"
+	jwtpkg "github.com/tizzhh/micro-banking/pkg/jwt"
+)
+
+func TestJWTNewToken_HappyPath(t *testing.T) {
+	cfg := config.Get()
+
+	tests := []struct {
+		name        string
+		signingKey  crypto.Signer
+		expectedAlg string
+	}{
+		{
+			name:        "Ed25519 key",
+			signingKey:  newEd25519Key(t),
+			expectedAlg: "EdDSA",
+		},
+		{
+			name:        "RSA key",
+			signingKey:  newRSAKey(t),
+			expectedAlg: "RS256",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			keys, err := jwtpkg.NewKeyRing(tt.signingKey)
+			require.NoError(t, err)
+			tokens := jwtpkg.New(time.Hour, keys)
+
+			token, err := tokens.NewToken(models.User{ID: 42, Email: "test-user0@gmail.com"})
+			require.NoError(t, err)
+
+			parsedToken, err := tokens.CheckToken(token)
+			require.NoError(t, err)
+
+			kid, err := jwtpkg.Thumbprint(tt.signingKey.Public())
+			require.NoError(t, err)
+			assert.Equal(t, kid, parsedToken.Header["kid"])
+			assert.Equal(t, tt.expectedAlg, parsedToken.Method.Alg())
+
+			claims := parsedToken.Claims.(*jwtpkg.Claims)
+			uid, err := claims.UserID()
+			require.NoError(t, err)
+			assert.Equal(t, uint64(42), uid)
+			assert.Equal(t, "test-user0@gmail.com", claims.Email)
+			assert.Equal(t, cfg.JWT.Issuer, claims.Issuer)
+			assert.Equal(t, jwt.ClaimStrings{cfg.JWT.Audience}, claims.Audience)
+			assert.NotEmpty(t, claims.ID)
+			assert.Equal(t, time.Hour, claims.ExpiresAt.Sub(claims.IssuedAt.Time))
+			assert.Equal(t, claims.IssuedAt, claims.NotBefore)
+		})
+	}
+}
+
+func TestJWTCheckToken_Cases(t *testing.T) {
+	cfg := config.Get()
+	now := time.Now()
+
+	signingKey := newEd25519Key(t)
+	previousKey := newRSAKey(t)
+	unknownKey := newEd25519Key(t)
+
+	keys, err := jwtpkg.NewKeyRing(signingKey, previousKey.Public())
+	require.NoError(t, err)
+
+	validClaims := func() *jwtpkg.Claims {
+		return &jwtpkg.Claims{
+			Email: "test-user0@gmail.com",
+			RegisteredClaims: jwt.RegisteredClaims{
+				Issuer:    cfg.JWT.Issuer,
+				Subject:   "1",
+				Audience:  jwt.ClaimStrings{cfg.JWT.Audience},
+				ExpiresAt: jwt.NewNumericDate(now.Add(time.Hour)),
+				NotBefore: jwt.NewNumericDate(now),
+				IssuedAt:  jwt.NewNumericDate(now),
+				ID:        "jti",
+			},
+		}
+	}
+	withClaims := func(change func(claims *jwtpkg.Claims)) func() *jwtpkg.Claims {
+		return func() *jwtpkg.Claims {
+			claims := validClaims()
+			change(claims)
+			return claims
+		}
+	}
+
+	tests := []struct {
+		name        string
+		method      jwt.SigningMethod
+		key         interface{}
+		kid         crypto.PublicKey
+		claims      func() *jwtpkg.Claims
+		expectedErr error
+	}{
+		{
+			name:   "Valid token",
+			method: jwt.SigningMethodEdDSA,
+			key:    signingKey,
+			kid:    signingKey.Public(),
+			claims: validClaims,
+		},
+		{
+			name:   "Token of a previous key",
+			method: jwt.SigningMethodRS256,
+			key:    previousKey,
+			kid:    previousKey.Public(),
+			claims: validClaims,
+		},
+		{
+			name:   "Expired within leeway",
+			method: jwt.SigningMethodEdDSA,
+			key:    signingKey,
+			kid:    signingKey.Public(),
+			claims: withClaims(func(claims *jwtpkg.Claims) {
+				claims.ExpiresAt = jwt.NewNumericDate(now.Add(-cfg.JWT.Leeway / 2))
+			}),
+		},
+		{
+			name:   "Expired token",
+			method: jwt.SigningMethodEdDSA,
+			key:    signingKey,
+			kid:    signingKey.Public(),
+			claims: withClaims(func(claims *jwtpkg.Claims) {
+				claims.ExpiresAt = jwt.NewNumericDate(now.Add(-time.Hour))
+			}),
+			expectedErr: jwtpkg.ErrTokenExpired,
+		},
+		{
+			name:   "Not yet valid token",
+			method: jwt.SigningMethodEdDSA,
+			key:    signingKey,
+			kid:    signingKey.Public(),
+			claims: withClaims(func(claims *jwtpkg.Claims) {
+				claims.NotBefore = jwt.NewNumericDate(now.Add(time.Hour))
+			}),
+			expectedErr: jwtpkg.ErrTokenNotValidYet,
+		},
+		{
+			name:   "Issued in the future",
+			method: jwt.SigningMethodEdDSA,
+			key:    signingKey,
+			kid:    signingKey.Public(),
+			claims: withClaims(func(claims *jwtpkg.Claims) {
+				claims.IssuedAt = jwt.NewNumericDate(now.Add(time.Hour))
+			}),
+			expectedErr: jwtpkg.ErrTokenNotValidYet,
+		},
+		{
+			name:   "Wrong audience",
+			method: jwt.SigningMethodEdDSA,
+			key:    signingKey,
+			kid:    signingKey.Public(),
+			claims: withClaims(func(claims *jwtpkg.Claims) {
+				claims.Audience = jwt.ClaimStrings{"another-service"}
+			}),
+			expectedErr: jwtpkg.ErrInvalidAudience,
+		},
+		{
+			name:   "Wrong issuer",
+			method: jwt.SigningMethodEdDSA,
+			key:    signingKey,
+			kid:    signingKey.Public(),
+			claims: withClaims(func(claims *jwtpkg.Claims) {
+				claims.Issuer = "another-issuer"
+			}),
+			expectedErr: jwtpkg.ErrInvalidIssuer,
+		},
+		{
+			name:        "Unsigned token",
+			method:      jwt.SigningMethodNone,
+			key:         jwt.UnsafeAllowNoneSignatureType,
+			kid:         signingKey.Public(),
+			claims:      validClaims,
+			expectedErr: jwtpkg.ErrUnexpectedSigningMethod,
+		},
+		{
+			name:        "HMAC signed with the public key",
+			method:      jwt.SigningMethodHS256,
+			key:         []byte(signingKey.Public().(ed25519.PublicKey)),
+			kid:         signingKey.Public(),
+			claims:      validClaims,
+			expectedErr: jwtpkg.ErrUnexpectedSigningMethod,
+		},
+		{
+			name:        "Algorithm of another key",
+			method:      jwt.SigningMethodEdDSA,
+			key:         signingKey,
+			kid:         previousKey.Public(),
+			claims:      validClaims,
+			expectedErr: jwtpkg.ErrUnexpectedSigningMethod,
+		},
+		{
+			name:        "Unknown key",
+			method:      jwt.SigningMethodEdDSA,
+			key:         unknownKey,
+			kid:         unknownKey.Public(),
+			claims:      validClaims,
+			expectedErr: jwtpkg.ErrUnknownKey,
+		},
+		{
+			name:        "Forged signature",
+			method:      jwt.SigningMethodEdDSA,
+			key:         unknownKey,
+			kid:         signingKey.Public(),
+			claims:      validClaims,
+			expectedErr: jwtpkg.ErrInvalidToken,
+		},
+		{
+			name:   "Missing expiration",
+			method: jwt.SigningMethodEdDSA,
+			key:    signingKey,
+			kid:    signingKey.Public(),
+			claims: withClaims(func(claims *jwtpkg.Claims) {
+				claims.ExpiresAt = nil
+			}),
+			expectedErr: jwtpkg.ErrInvalidToken,
+		},
+		{
+			name:   "Missing subject",
+			method: jwt.SigningMethodEdDSA,
+			key:    signingKey,
+			kid:    signingKey.Public(),
+			claims: withClaims(func(claims *jwtpkg.Claims) {
+				claims.Subject = ""
+			}),
+			expectedErr: jwtpkg.ErrInvalidToken,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			kid, err := jwtpkg.Thumbprint(tt.kid)
+			require.NoError(t, err)
+
+			unsigned := jwt.NewWithClaims(tt.method, tt.claims())
+			unsigned.Header["kid"] = kid
+			token, err := unsigned.SignedString(tt.key)
+			require.NoError(t, err)
+
+			parsedToken, err := jwtpkg.New(time.Hour, keys).CheckToken(token)
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, parsedToken)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, parsedToken.Valid)
+		})
+	}
+}
+
+func TestJWTChallengeToken_Cases(t *testing.T) {
+	keys, err := jwtpkg.NewKeyRing(newEd25519Key(t))
+	require.NoError(t, err)
+	tokens := jwtpkg.New(time.Hour, keys)
+
+	user := models.User{ID: 42, Email: "test-user0@gmail.com", Roles: []string{"admin"}}
+
+	challenge, err := tokens.NewChallengeToken(user)
+	require.NoError(t, err)
+
+	_, err = tokens.CheckToken(challenge)
+	assert.ErrorIs(t, err, jwtpkg.ErrInvalidToken, "challenge token accepted as access token")
+
+	parsedChallenge, err := tokens.CheckChallengeToken(challenge)
+	require.NoError(t, err)
+	claims := parsedChallenge.Claims.(*jwtpkg.Claims)
+	assert.Equal(t, jwtpkg.PurposeMFA, claims.Purpose)
+	assert.Empty(t, claims.Roles)
+	assert.Equal(t, config.Get().MFA.ChallengeTTL, claims.ExpiresAt.Sub(claims.IssuedAt.Time))
+
+	token, err := tokens.NewToken(user)
+	require.NoError(t, err)
+	_, err = tokens.CheckChallengeToken(token)
+	assert.ErrorIs(t, err, jwtpkg.ErrInvalidToken, "access token accepted as challenge token")
+
+	parsedToken, err := tokens.CheckToken(token)
+	require.NoError(t, err)
+	assert.True(t, parsedToken.Claims.(*jwtpkg.Claims).MFATime().IsZero())
+
+	verifiedAt := time.Now().Add(-time.Minute).Truncate(time.Second)
+	mfaToken, err := tokens.NewMFAToken(user, verifiedAt)
+	require.NoError(t, err)
+
+	parsedMFAToken, err := tokens.CheckToken(mfaToken)
+	require.NoError(t, err)
+	mfaClaims := parsedMFAToken.Claims.(*jwtpkg.Claims)
+	assert.True(t, verifiedAt.Equal(mfaClaims.MFATime()))
+	assert.Equal(t, user.Roles, mfaClaims.Roles)
+}
+
+func TestJWTVerificationToken_Cases(t *testing.T) {
+	keys, err := jwtpkg.NewKeyRing(newEd25519Key(t))
+	require.NoError(t, err)
+	tokens := jwtpkg.New(time.Hour, keys)
+
+	user := models.User{ID: 42, Email: "test-user0@gmail.com", Roles: []string{"admin"}}
+
+	verification, err := tokens.NewVerificationToken(user)
+	require.NoError(t, err)
+
+	_, err = tokens.CheckToken(verification)
+	assert.ErrorIs(t, err, jwtpkg.ErrInvalidToken, "verification token accepted as access token")
+	_, err = tokens.CheckChallengeToken(verification)
+	assert.ErrorIs(t, err, jwtpkg.ErrInvalidToken, "verification token accepted as challenge token")
+
+	parsed, err := tokens.CheckVerificationToken(verification)
+	require.NoError(t, err)
+	claims := parsed.Claims.(*jwtpkg.Claims)
+	assert.Equal(t, jwtpkg.PurposeEmailVerification, claims.Purpose)
+	assert.Empty(t, claims.Roles)
+	assert.Equal(t, config.Get().EmailVerification.TokenTTL, claims.ExpiresAt.Sub(claims.IssuedAt.Time))
+
+	challenge, err := tokens.NewChallengeToken(user)
+	require.NoError(t, err)
+	_, err = tokens.CheckVerificationToken(challenge)
+	assert.ErrorIs(t, err, jwtpkg.ErrInvalidToken, "challenge token accepted as verification token")
+}
+
+func TestJWTLoadKeyRing_HappyPath(t *testing.T) {
+	dir := t.TempDir()
+
+	signingKey := newEd25519Key(t)
+	previousKey := newRSAKey(t)
+
+	privateDER, err := x509.MarshalPKCS8PrivateKey(signingKey)
+	require.NoError(t, err)
+	signingKeyPath := writePEM(t, dir, "signing.pem", "PRIVATE KEY", privateDER)
+
+	publicDER, err := x509.MarshalPKIXPublicKey(previousKey.Public())
+	require.NoError(t, err)
+	previousKeyPath := writePEM(t, dir, "previous.pem", "PUBLIC KEY", publicDER)
+
+	keys, err := jwtpkg.LoadKeyRing(signingKeyPath, []string{previousKeyPath})
+	require.NoError(t, err)
+
+	jwks, err := keys.JWKS()
+	require.NoError(t, err)
+	require.Len(t, jwks.Keys, 2)
+	assert.Equal(t, "OKP", jwks.Keys[0].Kty)
+	assert.Equal(t, "EdDSA", jwks.Keys[0].Alg)
+	assert.Equal(t, "RSA", jwks.Keys[1].Kty)
+	assert.Equal(t, "RS256", jwks.Keys[1].Alg)
+
+	token, err := jwtpkg.New(time.Hour, keys).NewToken(models.User{ID: 1, Email: "test-user0@gmail.com"})
+	require.NoError(t, err)
+
+	_, err = jwtpkg.New(time.Hour, jwtpkg.KeyRingFromJWKS(jwks)).CheckToken(token)
+	require.NoError(t, err)
+}
+
+func TestJWTRemoteKeySet_Rotation(t *testing.T) {
+	oldKey := newEd25519Key(t)
+	newKey := newEd25519Key(t)
+
+	oldKeys, err := jwtpkg.NewKeyRing(oldKey)
+	require.NoError(t, err)
+	newKeys, err := jwtpkg.NewKeyRing(newKey, oldKey.Public())
+	require.NoError(t, err)
+
+	refresh := 50 * time.Millisecond
+
+	published := oldKeys
+	fetches := 0
+	remote := jwtpkg.NewRemoteKeySet(func(ctx context.Context) (jwtpkg.JWKS, error) {
+		fetches++
+		return published.JWKS()
+	}, refresh)
+	verifier := jwtpkg.New(time.Hour, remote)
+
+	oldToken, err := jwtpkg.New(time.Hour, oldKeys).NewToken(models.User{ID: 1, Email: "test-user0@gmail.com"})
+	require.NoError(t, err)
+	_, err = verifier.CheckToken(oldToken)
+	require.NoError(t, err)
+	assert.Equal(t, 1, fetches)
+
+	// unknown kids do not make the key set fetch again right away
+	published = newKeys
+	newToken, err := jwtpkg.New(time.Hour, newKeys).NewToken(models.User{ID: 1, Email: "test-user0@gmail.com"})
+	require.NoError(t, err)
+	_, err = verifier.CheckToken(newToken)
+	assert.ErrorIs(t, err, jwtpkg.ErrUnknownKey)
+	assert.Equal(t, 1, fetches)
+
+	time.Sleep(refresh)
+
+	_, err = verifier.CheckToken(newToken)
+	require.NoError(t, err)
+	assert.Equal(t, 2, fetches)
+
+	_, err = verifier.CheckToken(oldToken)
+	require.NoError(t, err)
+	assert.Equal(t, 2, fetches)
+
+	_, _, err = remote.SigningKey()
+	assert.ErrorIs(t, err, jwtpkg.ErrNoSigningKey)
+}
+
+func TestJWTRemoteKeySet_FetchFails(t *testing.T) {
+	remote := jwtpkg.NewRemoteKeySet(func(ctx context.Context) (jwtpkg.JWKS, error) {
+		return jwtpkg.JWKS{}, errors.New("auth service unavailable")
+	}, time.Hour)
+
+	_, err := remote.JWKS()
+	require.Error(t, err)
+
+	// failed fetches are not repeated on every request
+	_, err = remote.VerificationKey("kid")
+	assert.ErrorIs(t, err, jwtpkg.ErrUnknownKey)
+}
+
+func newEd25519Key(t *testing.T) ed25519.PrivateKey {
+	t.Helper()
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	return key
+}
+
+func newRSAKey(t *testing.T) *rsa.PrivateKey {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	return key
+}
+
+func writePEM(t *testing.T, dir string, name string, blockType string, der []byte) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0o600)
+	require.NoError(t, err)
+	return path
+}
@@ -0,0 +1,31 @@
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tizzhh/micro-banking/internal/services/auth"
+)
+
+func TestLoginThrottleBackoff_Cases(t *testing.T) {
+	throttle := auth.LoginThrottle{BaseBackoff: time.Second, MaxBackoff: time.Minute}
+
+	tests := []struct {
+		name     string
+		failures int64
+		expected time.Duration
+	}{
+		{name: "No failures", failures: 0, expected: 0},
+		{name: "First failure", failures: 1, expected: time.Second},
+		{name: "Third failure", failures: 3, expected: 4 * time.Second},
+		{name: "Capped at the max backoff", failures: 10, expected: time.Minute},
+		{name: "Many failures", failures: 1000, expected: time.Minute},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, throttle.Backoff(tt.failures))
+		})
+	}
+}
@@ -0,0 +1,384 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	authApi "github.com/tizzhh/micro-banking/internal/delivery/http/bank/resource/auth"
+	authMocks "github.com/tizzhh/micro-banking/internal/delivery/http/bank/resource/auth/mocks"
+)
+
+const (
+	mfaLoginResponseTemplate = `{"mfa_token":"%s"}`
+
+	enrollMFAResponseTemplate = `{"secret":"%s","uri":"%s"}`
+
+	mfaCodeRequestTemplate        = `{"code": "%s"}`
+	recoveryCodesResponseTemplate = `{"recovery_codes":["%s","%s"]}`
+
+	disableMFARequestTemplate = `{"password": "%s","code": "%s"}`
+
+	verifyMFARequestTemplate = `{"mfa_token": "%s","code": "%s"}`
+
+	stepUpResponseTemplate = `{"token":"%s"}`
+)
+
+func TestLoginMFARequired_HappyPath(t *testing.T) {
+	testUserEmail := "test-user0@gmail.com"
+	testPassword := "testt"
+	testMFAToken := "mfa-token"
+
+	reqBody := []byte(fmt.Sprintf(loginRequestTemplate, testUserEmail, testPassword))
+
+	req, err := http.NewRequest(http.MethodPost, "/auth/login", bytes.NewBuffer(reqBody))
+	require.NoError(t, err)
+
+	mockClient := authMocks.NewAuthClient(t)
+	mockClient.On(
+		"Login",
+		context.Background(),
+		testUserEmail,
+		testPassword,
+	).Return(authApi.LoginResponse{MFAToken: testMFAToken}, nil)
+	auth := authApi.New(log, validation, mockClient)
+
+	rr := httptest.NewRecorder()
+	http.HandlerFunc(auth.LoginUser()).ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, fmt.Sprintf(
+		mfaLoginResponseTemplate,
+		testMFAToken,
+	), strings.TrimRight(rr.Body.String(), "\n"))
+}
+
+func TestEnrollMFA_HappyPath(t *testing.T) {
+	testUserEmail := "test-user0@gmail.com"
+	testSecret := "JBSWY3DPEHPK3PXP"
+	testURI := "otpauth://totp/micro-banking:test-user0@gmail.com?secret=JBSWY3DPEHPK3PXP"
+
+	ctx := principalContext(testUserEmail)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "/auth/mfa/enroll", nil)
+	require.NoError(t, err)
+
+	mockClient := authMocks.NewAuthClient(t)
+	mockClient.On(
+		"EnrollMFA",
+		ctx,
+		testUserEmail,
+	).Return(authApi.EnrollMFAResponse{Secret: testSecret, URI: testURI}, nil)
+	auth := authApi.New(log, validation, mockClient)
+
+	rr := httptest.NewRecorder()
+	http.HandlerFunc(auth.EnrollMFA()).ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, fmt.Sprintf(
+		enrollMFAResponseTemplate,
+		testSecret,
+		testURI,
+	), strings.TrimRight(rr.Body.String(), "\n"))
+}
+
+func TestConfirmMFA_HappyPath(t *testing.T) {
+	testUserEmail := "test-user0@gmail.com"
+	testCode := "123456"
+	testRecoveryCodes := []string{"abcde-fghij", "klmno-pqrst"}
+
+	reqBody := []byte(fmt.Sprintf(mfaCodeRequestTemplate, testCode))
+
+	ctx := principalContext(testUserEmail)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "/auth/mfa/confirm", bytes.NewBuffer(reqBody))
+	require.NoError(t, err)
+
+	mockClient := authMocks.NewAuthClient(t)
+	mockClient.On(
+		"ConfirmMFA",
+		ctx,
+		testUserEmail,
+		testCode,
+	).Return(testRecoveryCodes, nil)
+	auth := authApi.New(log, validation, mockClient)
+
+	rr := httptest.NewRecorder()
+	http.HandlerFunc(auth.ConfirmMFA()).ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, fmt.Sprintf(
+		recoveryCodesResponseTemplate,
+		testRecoveryCodes[0],
+		testRecoveryCodes[1],
+	), strings.TrimRight(rr.Body.String(), "\n"))
+}
+
+func TestConfirmMFAHttp_FailCases(t *testing.T) {
+	tests := []struct {
+		name           string
+		code           string
+		grpcErr        error
+		expectedErr    string
+		expectedStatus int
+	}{
+		{
+			name:           "Confirm without code",
+			code:           "",
+			expectedErr:    "field Code is a required field",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Confirm with a recovery code",
+			code:           "abcde-fghij",
+			expectedErr:    "field Code is not valid",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Confirm with a wrong code",
+			code:           "000000",
+			grpcErr:        status.Error(codes.InvalidArgument, "invalid mfa code"),
+			expectedErr:    "invalid mfa code",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Confirm without enrolling",
+			code:           "123456",
+			grpcErr:        status.Error(codes.FailedPrecondition, "mfa is not enabled"),
+			expectedErr:    "mfa is not enabled",
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			testUserEmail := "test-user0@gmail.com"
+
+			reqBody := []byte(fmt.Sprintf(mfaCodeRequestTemplate, tt.code))
+
+			ctx := principalContext(testUserEmail)
+			req, err := http.NewRequestWithContext(ctx, http.MethodPost, "/auth/mfa/confirm", bytes.NewBuffer(reqBody))
+			require.NoError(t, err)
+
+			mockClient := authMocks.NewAuthClient(t)
+			if tt.grpcErr != nil {
+				mockClient.On(
+					"ConfirmMFA",
+					ctx,
+					testUserEmail,
+					tt.code,
+				).Return(nil, tt.grpcErr)
+			}
+			auth := authApi.New(log, validation, mockClient)
+
+			rr := httptest.NewRecorder()
+			http.HandlerFunc(auth.ConfirmMFA()).ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			assert.Equal(t, fmt.Sprintf(
+				errorResponseTemplate,
+				tt.expectedErr,
+			), strings.TrimRight(rr.Body.String(), "\n"))
+		})
+	}
+}
+
+func TestDisableMFA_HappyPath(t *testing.T) {
+	testUserEmail := "test-user0@gmail.com"
+	testPassword := "testt"
+	testCode := "abcde-fghij"
+
+	reqBody := []byte(fmt.Sprintf(disableMFARequestTemplate, testPassword, testCode))
+
+	ctx := principalContext(testUserEmail)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "/auth/mfa/disable", bytes.NewBuffer(reqBody))
+	require.NoError(t, err)
+
+	mockClient := authMocks.NewAuthClient(t)
+	mockClient.On(
+		"DisableMFA",
+		ctx,
+		testUserEmail,
+		testPassword,
+		testCode,
+	).Return(nil)
+	auth := authApi.New(log, validation, mockClient)
+
+	rr := httptest.NewRecorder()
+	http.HandlerFunc(auth.DisableMFA()).ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, `{"message":"MFA disabled successfully"}`, strings.TrimRight(rr.Body.String(), "\n"))
+}
+
+func TestRegenerateRecoveryCodes_HappyPath(t *testing.T) {
+	testUserEmail := "test-user0@gmail.com"
+	testCode := "123456"
+	testRecoveryCodes := []string{"abcde-fghij", "klmno-pqrst"}
+
+	reqBody := []byte(fmt.Sprintf(mfaCodeRequestTemplate, testCode))
+
+	ctx := principalContext(testUserEmail)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "/auth/mfa/recovery-codes", bytes.NewBuffer(reqBody))
+	require.NoError(t, err)
+
+	mockClient := authMocks.NewAuthClient(t)
+	mockClient.On(
+		"RegenerateRecoveryCodes",
+		ctx,
+		testUserEmail,
+		testCode,
+	).Return(testRecoveryCodes, nil)
+	auth := authApi.New(log, validation, mockClient)
+
+	rr := httptest.NewRecorder()
+	http.HandlerFunc(auth.RegenerateRecoveryCodes()).ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, fmt.Sprintf(
+		recoveryCodesResponseTemplate,
+		testRecoveryCodes[0],
+		testRecoveryCodes[1],
+	), strings.TrimRight(rr.Body.String(), "\n"))
+}
+
+func TestVerifyMFA_HappyPath(t *testing.T) {
+	testMFAToken := "mfa-token"
+	testCode := "123456"
+	testToken := "token"
+	testRefreshToken := "refresh-token"
+
+	reqBody := []byte(fmt.Sprintf(verifyMFARequestTemplate, testMFAToken, testCode))
+
+	req, err := http.NewRequest(http.MethodPost, "/auth/mfa/verify", bytes.NewBuffer(reqBody))
+	require.NoError(t, err)
+
+	mockClient := authMocks.NewAuthClient(t)
+	mockClient.On(
+		"VerifyMFA",
+		context.Background(),
+		testMFAToken,
+		testCode,
+	).Return(authApi.LoginResponse{Token: testToken, RefreshToken: testRefreshToken}, nil)
+	auth := authApi.New(log, validation, mockClient)
+
+	rr := httptest.NewRecorder()
+	http.HandlerFunc(auth.VerifyMFA()).ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, fmt.Sprintf(
+		loginResponseTemplate,
+		testToken,
+		testRefreshToken,
+	), strings.TrimRight(rr.Body.String(), "\n"))
+}
+
+func TestVerifyMFAHttp_FailCases(t *testing.T) {
+	tests := []struct {
+		name           string
+		mfaToken       string
+		code           string
+		grpcErr        error
+		expectedErr    string
+		expectedStatus int
+	}{
+		{
+			name:           "Verify without token",
+			mfaToken:       "",
+			code:           "123456",
+			expectedErr:    "field MFAToken is a required field",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Verify with a short code",
+			mfaToken:       "mfa-token",
+			code:           "123",
+			expectedErr:    "field Code should be greater or equal to 6",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Verify with a used token",
+			mfaToken:       "used-mfa-token",
+			code:           "123456",
+			grpcErr:        status.Error(codes.Unauthenticated, "invalid mfa token"),
+			expectedErr:    "invalid mfa token",
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "Verify with a wrong code",
+			mfaToken:       "mfa-token",
+			code:           "000000",
+			grpcErr:        status.Error(codes.InvalidArgument, "invalid mfa code"),
+			expectedErr:    "invalid mfa code",
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			reqBody := []byte(fmt.Sprintf(verifyMFARequestTemplate, tt.mfaToken, tt.code))
+
+			req, err := http.NewRequest(http.MethodPost, "/auth/mfa/verify", bytes.NewBuffer(reqBody))
+			require.NoError(t, err)
+
+			mockClient := authMocks.NewAuthClient(t)
+			if tt.grpcErr != nil {
+				mockClient.On(
+					"VerifyMFA",
+					context.Background(),
+					tt.mfaToken,
+					tt.code,
+				).Return(authApi.LoginResponse{}, tt.grpcErr)
+			}
+			auth := authApi.New(log, validation, mockClient)
+
+			rr := httptest.NewRecorder()
+			http.HandlerFunc(auth.VerifyMFA()).ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			assert.Equal(t, fmt.Sprintf(
+				errorResponseTemplate,
+				tt.expectedErr,
+			), strings.TrimRight(rr.Body.String(), "\n"))
+		})
+	}
+}
+
+func TestStepUp_HappyPath(t *testing.T) {
+	testUserEmail := "test-user0@gmail.com"
+	testCode := "123456"
+	testToken := "stepped-up-token"
+
+	reqBody := []byte(fmt.Sprintf(mfaCodeRequestTemplate, testCode))
+
+	ctx := principalContext(testUserEmail)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "/auth/mfa/step-up", bytes.NewBuffer(reqBody))
+	require.NoError(t, err)
+
+	mockClient := authMocks.NewAuthClient(t)
+	mockClient.On(
+		"StepUp",
+		ctx,
+		testUserEmail,
+		testCode,
+	).Return(testToken, nil)
+	auth := authApi.New(log, validation, mockClient)
+
+	rr := httptest.NewRecorder()
+	http.HandlerFunc(auth.StepUp()).ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, fmt.Sprintf(
+		stepUpResponseTemplate,
+		testToken,
+	), strings.TrimRight(rr.Body.String(), "\n"))
+}
@@ -0,0 +1,122 @@
+package tests
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"fmt"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/tizzhh/micro-banking/internal/domain/auth/models"
+	authService "github.com/tizzhh/micro-banking/internal/services/auth"
+	auth "github.com/tizzhh/micro-banking/internal/services/auth/errors"
+	authMocks "github.com/tizzhh/micro-banking/internal/services/auth/mocks"
+	jwtpkg "github.com/tizzhh/micro-banking/pkg/jwt"
+	"github.com/tizzhh/micro-banking/pkg/totp"
+)
+
+var testMFAThrottle = authService.LoginThrottle{
+	MaxMFAFailures:  3,
+	FailureWindow:   15 * time.Minute,
+	LockoutDuration: 15 * time.Minute,
+}
+
+// mfaService returns an auth service whose user has confirmed MFA with
+// secret.
+func mfaService(t *testing.T, user models.User, secret string, mfaStore *authMocks.MFAStore, attempts *authMocks.LoginAttempts) *authService.Auth {
+	t.Helper()
+
+	_, signingKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	keys, err := jwtpkg.NewKeyRing(signingKey)
+	require.NoError(t, err)
+
+	confirmedAt := time.Now()
+	mfaStore.On("MFASecret", mock.Anything, user.ID).
+		Return(models.MFASecret{UserID: user.ID, Secret: secret, ConfirmedAt: &confirmedAt}, nil).Maybe()
+
+	userProvider := authMocks.NewUserProvider(t)
+	userProvider.On("User", mock.Anything, user.Email).Return(user, nil)
+
+	return authService.New(
+		log, jwtpkg.New(time.Hour, keys), time.Hour, "micro-banking",
+		authService.Verification{}, authService.PasswordReset{}, testMFAThrottle,
+		nil, userProvider, nil, nil, nil, mfaStore, nil, nil, nil, nil, nil, attempts,
+	)
+}
+
+// wrongMFACode returns a code that is not valid for secret now.
+func wrongMFACode(t *testing.T, secret string) string {
+	t.Helper()
+
+	for i := range 1000 {
+		code := fmt.Sprintf("%06d", i)
+		if _, err := totp.Validate(secret, code, time.Now()); err != nil {
+			return code
+		}
+	}
+	t.Fatal("no wrong mfa code found")
+	return ""
+}
+
+func TestStepUpMFALockout_Fail(t *testing.T) {
+	ctx := context.Background()
+	user := models.User{ID: 7, Email: "test-user0@gmail.com"}
+	key := "mfa:" + strconv.FormatUint(user.ID, 10)
+
+	secret, err := totp.NewSecret()
+	require.NoError(t, err)
+
+	mockAttempts := authMocks.NewLoginAttempts(t)
+	mockAttempts.On("LoginsBlockedFor", mock.Anything, key).Return(time.Duration(0), nil).
+		Times(testMFAThrottle.MaxMFAFailures)
+	for failures := 1; failures <= testMFAThrottle.MaxMFAFailures; failures++ {
+		mockAttempts.On("AddLoginFailure", mock.Anything, key, testMFAThrottle.FailureWindow).
+			Return(int64(failures), nil).Once()
+	}
+	mockAttempts.On("BlockLogins", mock.Anything, key, testMFAThrottle.LockoutDuration).Return(nil).Once()
+	mockAttempts.On("LoginsBlockedFor", mock.Anything, key).Return(testMFAThrottle.LockoutDuration, nil).Once()
+
+	// UseMFAStep is not expected, so the valid code must not be checked
+	service := mfaService(t, user, secret, authMocks.NewMFAStore(t), mockAttempts)
+
+	for range testMFAThrottle.MaxMFAFailures {
+		_, err := service.StepUp(ctx, user.Email, wrongMFACode(t, secret))
+		require.ErrorIs(t, err, auth.ErrInvalidMFACode)
+	}
+
+	code, err := totp.Code(secret, time.Now())
+	require.NoError(t, err)
+
+	_, err = service.StepUp(ctx, user.Email, code)
+	require.ErrorIs(t, err, auth.ErrTooManyMFAAttempts)
+}
+
+func TestStepUpMFA_ResetsFailures(t *testing.T) {
+	ctx := context.Background()
+	user := models.User{ID: 7, Email: "test-user0@gmail.com"}
+	key := "mfa:" + strconv.FormatUint(user.ID, 10)
+
+	secret, err := totp.NewSecret()
+	require.NoError(t, err)
+
+	mockAttempts := authMocks.NewLoginAttempts(t)
+	mockAttempts.On("LoginsBlockedFor", mock.Anything, key).Return(time.Duration(0), nil).Once()
+	mockAttempts.On("ResetLoginFailures", mock.Anything, key).Return(nil).Once()
+
+	mockMFAStore := authMocks.NewMFAStore(t)
+	mockMFAStore.On("UseMFAStep", mock.Anything, user.ID, mock.Anything).Return(nil).Once()
+
+	service := mfaService(t, user, secret, mockMFAStore, mockAttempts)
+
+	code, err := totp.Code(secret, time.Now())
+	require.NoError(t, err)
+
+	token, err := service.StepUp(ctx, user.Email, code)
+	require.NoError(t, err)
+	require.NotEmpty(t, token)
+}
@@ -0,0 +1,39 @@
+-- +goose Up
+-- +goose StatementBegin
+INSERT INTO users (id, email, pass_hash, first_name, last_name, balance, age, status)
+VALUES (10000, 'test@gmail.com', '$2a$10$IF6t0BJ/uEZfNnKkuMExjOg/mTxq1xn.y3X7stLCCLl54nTiN5A1.', 'admin', 'admin', 1000, 20, 'active');
+
+INSERT INTO user_wallets (user_id, currency_id, balance)
+VALUES (10000, 1, 0),
+       (10000, 2, 0),
+       (10000, 3, 0),
+       (10000, 4, 0);
+
+INSERT INTO ledger_accounts (user_id, currency_id, kind)
+SELECT NULL, id, 'external' FROM currencies WHERE code = 'USD'
+ON CONFLICT DO NOTHING;
+
+INSERT INTO ledger_accounts (user_id, currency_id, kind)
+SELECT 10000, id, 'user_cash' FROM currencies WHERE code = 'USD';
+
+INSERT INTO transactions (id, user_id, type)
+VALUES (10000, 10000, 'opening_balance');
+
+INSERT INTO ledger_entries (transaction_id, account_id, direction, amount)
+SELECT 10000, a.id, 'debit', 1000
+FROM ledger_accounts a JOIN currencies c ON c.id = a.currency_id
+WHERE a.user_id IS NULL AND a.kind = 'external' AND c.code = 'USD'
+UNION ALL
+SELECT 10000, a.id, 'credit', 1000
+FROM ledger_accounts a
+WHERE a.user_id = 10000 AND a.kind = 'user_cash';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DELETE FROM ledger_entries WHERE transaction_id IN (SELECT id FROM transactions WHERE user_id = 10000);
+DELETE FROM transactions WHERE user_id = 10000;
+DELETE FROM ledger_accounts WHERE user_id = 10000;
+DELETE FROM user_wallets WHERE user_id = 10000;
+DELETE FROM users WHERE id = 10000;
+-- +goose StatementEnd
@@ -0,0 +1,235 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tizzhh/micro-banking/pkg/money"
+)
+
+func TestMoneyParse_HappyPath(t *testing.T) {
+	tests := []struct {
+		name           string
+		value          string
+		currency       string
+		expectedAmount int64
+		expectedString string
+	}{
+		{
+			name:           "Whole amount",
+			value:          "12",
+			currency:       "USD",
+			expectedAmount: 1200,
+			expectedString: "12.00",
+		},
+		{
+			name:           "One decimal place",
+			value:          "0.1",
+			currency:       "EUR",
+			expectedAmount: 10,
+			expectedString: "0.10",
+		},
+		{
+			name:           "Cents",
+			value:          "0.07",
+			currency:       "USD",
+			expectedAmount: 7,
+			expectedString: "0.07",
+		},
+		{
+			name:           "Negative amount",
+			value:          "-3.50",
+			currency:       "USD",
+			expectedAmount: -350,
+			expectedString: "-3.50",
+		},
+		{
+			name:           "Currency without minor units",
+			value:          "150",
+			currency:       "JPY",
+			expectedAmount: 150,
+			expectedString: "150",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			amount, err := money.Parse(tt.value, tt.currency)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedAmount, amount.Amount())
+			assert.Equal(t, tt.currency, amount.Currency())
+			assert.Equal(t, tt.expectedString, amount.String())
+		})
+	}
+}
+
+func TestMoneyParse_FailCases(t *testing.T) {
+	tests := []struct {
+		name        string
+		value       string
+		currency    string
+		expectedErr error
+	}{
+		{
+			name:        "Empty value",
+			value:       "",
+			currency:    "USD",
+			expectedErr: money.ErrInvalidAmount,
+		},
+		{
+			name:        "Exponent notation",
+			value:       "1e3",
+			currency:    "USD",
+			expectedErr: money.ErrInvalidAmount,
+		},
+		{
+			name:        "Fraction of a cent",
+			value:       "0.001",
+			currency:    "USD",
+			expectedErr: money.ErrTooPrecise,
+		},
+		{
+			name:        "Fraction of a yen",
+			value:       "1.5",
+			currency:    "JPY",
+			expectedErr: money.ErrTooPrecise,
+		},
+		{
+			name:        "Too large",
+			value:       "92233720368547758.08",
+			currency:    "USD",
+			expectedErr: money.ErrOverflow,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := money.Parse(tt.value, tt.currency)
+			require.ErrorIs(t, err, tt.expectedErr)
+		})
+	}
+}
+
+func TestMoneyParseExponent_HappyPath(t *testing.T) {
+	amount, err := money.ParseExponent("2.5", "BHD", 3)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2500), amount.Amount())
+
+	_, err = money.ParseExponent("2.5", "BHD", 0)
+	require.ErrorIs(t, err, money.ErrTooPrecise)
+}
+
+func TestMoneyConvert_Rounding(t *testing.T) {
+	tests := []struct {
+		name     string
+		amount   money.Money
+		rate     string
+		currency string
+		mode     money.RoundingMode
+		expected money.Money
+	}{
+		{
+			name:     "Exact conversion",
+			amount:   money.New(1000, "USD"),
+			rate:     "0.9",
+			currency: "EUR",
+			mode:     money.RoundHalfEven,
+			expected: money.New(900, "EUR"),
+		},
+		{
+			name:     "Cost rounds up",
+			amount:   money.New(100, "EUR"),
+			rate:     "0.9",
+			currency: "USD",
+			mode:     money.RoundUp,
+			expected: money.New(112, "USD"),
+		},
+		{
+			name:     "Proceeds round down",
+			amount:   money.New(100, "EUR"),
+			rate:     "0.9",
+			currency: "USD",
+			mode:     money.RoundDown,
+			expected: money.New(111, "USD"),
+		},
+		{
+			name:     "Half to even rounds down on even",
+			amount:   money.New(5, "USD"),
+			rate:     "0.5",
+			currency: "EUR",
+			mode:     money.RoundHalfEven,
+			expected: money.New(2, "EUR"),
+		},
+		{
+			name:     "Half to even rounds up on odd",
+			amount:   money.New(15, "USD"),
+			rate:     "0.5",
+			currency: "EUR",
+			mode:     money.RoundHalfEven,
+			expected: money.New(8, "EUR"),
+		},
+		{
+			name:     "Different exponents",
+			amount:   money.New(1000, "USD"),
+			rate:     "151.5",
+			currency: "JPY",
+			mode:     money.RoundDown,
+			expected: money.New(1515, "JPY"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			rate, err := money.ParseRate(tt.rate)
+			require.NoError(t, err)
+
+			// The EUR to USD cases quote the USD to EUR rate, as the rates API does.
+			if tt.amount.Currency() == "EUR" {
+				rate = rate.Inverse()
+			}
+
+			converted, err := money.Convert(tt.amount, rate, tt.currency, tt.mode)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, converted)
+		})
+	}
+}
+
+func TestMoneyParseRate_FailCases(t *testing.T) {
+	for _, value := range []string{"", "0", "-1.2", "abc", "1/3"} {
+		_, err := money.ParseRate(value)
+		assert.ErrorIs(t, err, money.ErrInvalidRate, value)
+	}
+}
+
+func TestMoneyRateMul_CrossRate(t *testing.T) {
+	eurPerUSD, err := money.ParseRate("0.92")
+	require.NoError(t, err)
+	cnyPerUSD, err := money.ParseRate("7.36")
+	require.NoError(t, err)
+
+	cnyPerEUR := eurPerUSD.Inverse().Mul(cnyPerUSD)
+	assert.Equal(t, "8", cnyPerEUR.String())
+
+	converted, err := money.Convert(money.New(1050, "EUR"), cnyPerEUR, "CNY", money.RoundDown)
+	require.NoError(t, err)
+	assert.Equal(t, money.New(8400, "CNY"), converted)
+}
+
+func TestMoneyRateCmp_HappyPath(t *testing.T) {
+	low, err := money.ParseRate("0.915")
+	require.NoError(t, err)
+	high, err := money.ParseRate("0.920000000000")
+	require.NoError(t, err)
+
+	assert.Equal(t, -1, low.Cmp(high))
+	assert.Equal(t, 1, high.Cmp(low))
+	assert.Equal(t, 0, high.Cmp(money.NewRate(92, 100)))
+}
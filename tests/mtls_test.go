@@ -0,0 +1,163 @@
+package tests
+
+import (
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tizzhh/micro-banking/pkg/mtls"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+const testReloadInterval = 10 * time.Millisecond
+
+func startMTLSServer(t *testing.T, dir string) string {
+	t.Helper()
+
+	creds, err := mtls.ServerCredentials(mtls.Options{
+		Enabled:        true,
+		CertFile:       filepath.Join(dir, mtls.ServerFile),
+		KeyFile:        filepath.Join(dir, mtls.ServerKeyFile),
+		CAFile:         filepath.Join(dir, mtls.CAFile),
+		ClientAuth:     true,
+		ReloadInterval: testReloadInterval,
+	})
+	require.NoError(t, err)
+
+	server := grpc.NewServer(grpc.Creds(creds))
+	healthpb.RegisterHealthServer(server, health.NewServer())
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go func() { _ = server.Serve(l) }()
+	t.Cleanup(server.Stop)
+
+	return l.Addr().String()
+}
+
+func checkMTLSHealth(t *testing.T, addr string, opts mtls.Options) error {
+	t.Helper()
+
+	opts.Enabled = true
+	opts.ReloadInterval = testReloadInterval
+	creds, err := mtls.ClientCredentials(opts)
+	require.NoError(t, err)
+
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(creds))
+	require.NoError(t, err)
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_, err = healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{})
+	return err
+}
+
+func clientOptions(dir string) mtls.Options {
+	return mtls.Options{
+		CertFile: filepath.Join(dir, mtls.ClientFile),
+		KeyFile:  filepath.Join(dir, mtls.ClientKeyFile),
+		CAFile:   filepath.Join(dir, mtls.CAFile),
+	}
+}
+
+func TestMTLS_Cases(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, mtls.GenerateDevCA(dir, []string{"localhost", "127.0.0.1"}, time.Hour))
+	otherDir := t.TempDir()
+	require.NoError(t, mtls.GenerateDevCA(otherDir, []string{"localhost", "127.0.0.1"}, time.Hour))
+
+	addr := startMTLSServer(t, dir)
+
+	tests := []struct {
+		name      string
+		opts      mtls.Options
+		expectErr bool
+	}{
+		{
+			name: "Client certificate issued by the CA",
+			opts: clientOptions(dir),
+		},
+		{
+			name:      "No client certificate",
+			opts:      mtls.Options{CAFile: filepath.Join(dir, mtls.CAFile)},
+			expectErr: true,
+		},
+		{
+			name: "Client certificate issued by another CA",
+			opts: mtls.Options{
+				CertFile: filepath.Join(otherDir, mtls.ClientFile),
+				KeyFile:  filepath.Join(otherDir, mtls.ClientKeyFile),
+				CAFile:   filepath.Join(dir, mtls.CAFile),
+			},
+			expectErr: true,
+		},
+		{
+			name:      "Server certificate issued by another CA",
+			opts:      clientOptions(otherDir),
+			expectErr: true,
+		},
+		{
+			name: "Server name not in the certificate",
+			opts: mtls.Options{
+				CertFile:   filepath.Join(dir, mtls.ClientFile),
+				KeyFile:    filepath.Join(dir, mtls.ClientKeyFile),
+				CAFile:     filepath.Join(dir, mtls.CAFile),
+				ServerName: "currency.example.com",
+			},
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkMTLSHealth(t, addr, tt.opts)
+			if tt.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestMTLS_ReloadsRotatedCertificates(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, mtls.GenerateDevCA(dir, []string{"127.0.0.1"}, time.Hour))
+
+	oldDir := t.TempDir()
+	for _, name := range []string{mtls.CAFile, mtls.ClientFile, mtls.ClientKeyFile} {
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(filepath.Join(oldDir, name), data, 0o600))
+	}
+
+	addr := startMTLSServer(t, dir)
+	require.NoError(t, checkMTLSHealth(t, addr, clientOptions(oldDir)))
+
+	// a new CA replaces the files the server was started with
+	require.NoError(t, mtls.GenerateDevCA(dir, []string{"127.0.0.1"}, time.Hour))
+	time.Sleep(2 * testReloadInterval)
+
+	assert.Error(t, checkMTLSHealth(t, addr, clientOptions(oldDir)))
+	assert.NoError(t, checkMTLSHealth(t, addr, clientOptions(dir)))
+}
+
+func TestMTLS_Disabled(t *testing.T) {
+	creds, err := mtls.ServerCredentials(mtls.Options{})
+	require.NoError(t, err)
+	assert.Equal(t, "insecure", creds.Info().SecurityProtocol)
+
+	_, err = mtls.ServerCredentials(mtls.Options{Enabled: true})
+	assert.ErrorIs(t, err, mtls.ErrMissingCertificate)
+
+	_, err = mtls.ServerCredentials(mtls.Options{Enabled: true, CertFile: "cert.pem", KeyFile: "key.pem", ClientAuth: true})
+	assert.ErrorIs(t, err, mtls.ErrMissingCA)
+}
@@ -0,0 +1,96 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/tizzhh/micro-banking/internal/config"
+	"github.com/tizzhh/micro-banking/internal/domain/outbox/models"
+	"github.com/tizzhh/micro-banking/internal/services/outbox"
+	outboxMocks "github.com/tizzhh/micro-banking/internal/services/outbox/mocks"
+)
+
+var testOutboxConfig = config.Outbox{
+	PollInterval: time.Second,
+	BatchSize:    10,
+	Lease:        30 * time.Second,
+	MinBackoff:   time.Second,
+	MaxBackoff:   time.Minute,
+}
+
+func TestOutboxRelay_HappyPath(t *testing.T) {
+	ctx := context.Background()
+
+	messages := []models.Message{
+		{ID: 1, EmailAddr: "sender@gmail.com", Message: "sent"},
+		{ID: 2, EmailAddr: "recipient@gmail.com", Message: "received"},
+	}
+
+	mockStore := outboxMocks.NewStore(t)
+	mockStore.On("ClaimOutbox", ctx, testOutboxConfig.BatchSize, testOutboxConfig.Lease).Return(messages, nil)
+	mockStore.On("MarkOutboxSent", ctx, uint64(1)).Return(nil).Once()
+	mockStore.On("MarkOutboxSent", ctx, uint64(2)).Return(nil).Once()
+
+	mockProducer := outboxMocks.NewProducer(t)
+	mockProducer.On("Produce", "sender@gmail.com", "sent").Return(nil).Once()
+	mockProducer.On("Produce", "recipient@gmail.com", "received").Return(nil).Once()
+
+	claimed, err := outbox.New(log, mockStore, mockProducer, testOutboxConfig).RelayBatch(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, len(messages), claimed)
+}
+
+func TestOutboxRelay_RetriesFailedPublish(t *testing.T) {
+	ctx := context.Background()
+
+	message := models.Message{ID: 7, EmailAddr: "user@gmail.com", Message: "deposit", Attempts: 2}
+
+	mockStore := outboxMocks.NewStore(t)
+	mockStore.On("ClaimOutbox", ctx, testOutboxConfig.BatchSize, testOutboxConfig.Lease).Return([]models.Message{message}, nil)
+
+	before := time.Now()
+	mockStore.On("RetryOutbox", ctx, message.ID, mock.MatchedBy(func(next time.Time) bool {
+		delay := next.Sub(before)
+		return delay >= 4*time.Second && delay < 5*time.Second
+	}), "kafka: broker not available").Return(nil).Once()
+
+	mockProducer := outboxMocks.NewProducer(t)
+	mockProducer.On("Produce", message.EmailAddr, message.Message).Return(errors.New("kafka: broker not available")).Once()
+
+	claimed, err := outbox.New(log, mockStore, mockProducer, testOutboxConfig).RelayBatch(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, claimed)
+}
+
+func TestOutboxRelay_ClaimFails(t *testing.T) {
+	ctx := context.Background()
+
+	mockStore := outboxMocks.NewStore(t)
+	mockStore.On("ClaimOutbox", ctx, testOutboxConfig.BatchSize, testOutboxConfig.Lease).Return(nil, errors.New("connection refused"))
+
+	_, err := outbox.New(log, mockStore, outboxMocks.NewProducer(t), testOutboxConfig).RelayBatch(ctx)
+	require.Error(t, err)
+	assert.ErrorContains(t, err, "connection refused")
+}
+
+func TestOutboxBackoff(t *testing.T) {
+	tests := []struct {
+		attempts int
+		expected time.Duration
+	}{
+		{attempts: 0, expected: time.Second},
+		{attempts: 1, expected: 2 * time.Second},
+		{attempts: 5, expected: 32 * time.Second},
+		{attempts: 6, expected: time.Minute},
+		{attempts: 100, expected: time.Minute},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, outbox.Backoff(tt.attempts, time.Second, time.Minute), "attempts: %d", tt.attempts)
+	}
+}
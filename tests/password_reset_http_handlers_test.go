@@ -0,0 +1,177 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	authApi "github.com/tizzhh/micro-banking/internal/delivery/http/bank/resource/auth"
+	authMocks "github.com/tizzhh/micro-banking/internal/delivery/http/bank/resource/auth/mocks"
+)
+
+const (
+	passwordResetRequestTemplate = `{"email": "%s"}`
+	resetPasswordRequestTemplate = `{"token": "%s","new_password": "%s"}`
+)
+
+func TestRequestPasswordReset_HappyPath(t *testing.T) {
+	testUserEmail := "test-user0@gmail.com"
+
+	reqBody := []byte(fmt.Sprintf(passwordResetRequestTemplate, testUserEmail))
+
+	req, err := http.NewRequest(http.MethodPost, "/auth/password-reset", bytes.NewBuffer(reqBody))
+	require.NoError(t, err)
+
+	mockClient := authMocks.NewAuthClient(t)
+	mockClient.On(
+		"RequestPasswordReset",
+		context.Background(),
+		testUserEmail,
+	).Return(nil)
+	auth := authApi.New(log, validation, mockClient)
+
+	rr := httptest.NewRecorder()
+	http.HandlerFunc(auth.RequestPasswordReset()).ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, `{"message":"If the email is registered, a password reset mail was sent"}`, strings.TrimRight(rr.Body.String(), "\n"))
+}
+
+func TestRequestPasswordResetHttp_FailCases(t *testing.T) {
+	tests := []struct {
+		name        string
+		email       string
+		expectedErr string
+	}{
+		{
+			name:        "Request without email",
+			email:       "",
+			expectedErr: "field Email is a required field",
+		},
+		{
+			name:        "Request with an invalid email",
+			email:       "test-user0",
+			expectedErr: "field Email is not a valid email",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			reqBody := []byte(fmt.Sprintf(passwordResetRequestTemplate, tt.email))
+
+			req, err := http.NewRequest(http.MethodPost, "/auth/password-reset", bytes.NewBuffer(reqBody))
+			require.NoError(t, err)
+
+			mockClient := authMocks.NewAuthClient(t)
+			auth := authApi.New(log, validation, mockClient)
+
+			rr := httptest.NewRecorder()
+			http.HandlerFunc(auth.RequestPasswordReset()).ServeHTTP(rr, req)
+
+			assert.Equal(t, http.StatusBadRequest, rr.Code)
+			assert.Equal(t, fmt.Sprintf(
+				errorResponseTemplate,
+				tt.expectedErr,
+			), strings.TrimRight(rr.Body.String(), "\n"))
+		})
+	}
+}
+
+func TestResetPassword_HappyPath(t *testing.T) {
+	testToken := "reset-token"
+	testPassword := "new-password"
+
+	reqBody := []byte(fmt.Sprintf(resetPasswordRequestTemplate, testToken, testPassword))
+
+	req, err := http.NewRequest(http.MethodPost, "/auth/password-reset/confirm", bytes.NewBuffer(reqBody))
+	require.NoError(t, err)
+
+	mockClient := authMocks.NewAuthClient(t)
+	mockClient.On(
+		"ResetPassword",
+		context.Background(),
+		testToken,
+		testPassword,
+	).Return(nil)
+	auth := authApi.New(log, validation, mockClient)
+
+	rr := httptest.NewRecorder()
+	http.HandlerFunc(auth.ResetPassword()).ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, `{"message":"Password reset successfully"}`, strings.TrimRight(rr.Body.String(), "\n"))
+}
+
+func TestResetPasswordHttp_FailCases(t *testing.T) {
+	tests := []struct {
+		name           string
+		token          string
+		password       string
+		grpcErr        error
+		expectedErr    string
+		expectedStatus int
+	}{
+		{
+			name:           "Reset without token",
+			token:          "",
+			password:       "new-password",
+			expectedErr:    "field Token is a required field",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Reset with a short password",
+			token:          "reset-token",
+			password:       "new",
+			expectedErr:    "field NewPassword should be greater or equal to 5",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Reset with a used token",
+			token:          "used-reset-token",
+			password:       "new-password",
+			grpcErr:        status.Error(codes.InvalidArgument, "invalid password reset token"),
+			expectedErr:    "invalid password reset token",
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			reqBody := []byte(fmt.Sprintf(resetPasswordRequestTemplate, tt.token, tt.password))
+
+			req, err := http.NewRequest(http.MethodPost, "/auth/password-reset/confirm", bytes.NewBuffer(reqBody))
+			require.NoError(t, err)
+
+			mockClient := authMocks.NewAuthClient(t)
+			if tt.grpcErr != nil {
+				mockClient.On(
+					"ResetPassword",
+					context.Background(),
+					tt.token,
+					tt.password,
+				).Return(tt.grpcErr)
+			}
+			auth := authApi.New(log, validation, mockClient)
+
+			rr := httptest.NewRecorder()
+			http.HandlerFunc(auth.ResetPassword()).ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			assert.Equal(t, fmt.Sprintf(
+				errorResponseTemplate,
+				tt.expectedErr,
+			), strings.TrimRight(rr.Body.String(), "\n"))
+		})
+	}
+}
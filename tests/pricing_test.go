@@ -0,0 +1,167 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tizzhh/micro-banking/pkg/money"
+	"github.com/tizzhh/micro-banking/pkg/pricing"
+)
+
+var testPricingConfig = pricing.Config{
+	DefaultSpreadBps: 0,
+	SpreadBps:        map[string]int64{"EUR": 100},
+	FeeTiers: []pricing.FeeTier{
+		{UpTo: 100000, Bps: 100},
+		{UpTo: 0, Bps: 50},
+	},
+	MinFee: 50,
+}
+
+func TestPricing_HappyPath(t *testing.T) {
+	tests := []struct {
+		name           string
+		sell           bool
+		amount         money.Money
+		expectedRate   string
+		expectedGross  int64
+		expectedSpread int64
+		expectedFee    int64
+		expectedTotal  int64
+	}{
+		{
+			name:           "Buy at the ask",
+			amount:         money.New(10000, "EUR"),
+			expectedRate:   "1.01",
+			expectedGross:  10100,
+			expectedSpread: 100,
+			expectedFee:    101,
+			expectedTotal:  10201,
+		},
+		{
+			name:           "Sell at the bid",
+			sell:           true,
+			amount:         money.New(10000, "EUR"),
+			expectedRate:   "0.99",
+			expectedGross:  9900,
+			expectedSpread: 100,
+			expectedFee:    99,
+			expectedTotal:  9801,
+		},
+		{
+			name:           "Buy in the unlimited fee tier",
+			amount:         money.New(200000, "EUR"),
+			expectedRate:   "1.01",
+			expectedGross:  202000,
+			expectedSpread: 2000,
+			expectedFee:    1010,
+			expectedTotal:  203010,
+		},
+		{
+			name:           "Buy below the minimum fee",
+			amount:         money.New(100, "EUR"),
+			expectedRate:   "1.01",
+			expectedGross:  101,
+			expectedSpread: 1,
+			expectedFee:    50,
+			expectedTotal:  151,
+		},
+		{
+			name:           "Buy with the default spread",
+			amount:         money.New(10000, "GBP"),
+			expectedRate:   "1",
+			expectedGross:  10000,
+			expectedSpread: 0,
+			expectedFee:    100,
+			expectedTotal:  10100,
+		},
+	}
+
+	engine, err := pricing.New(testPricingConfig)
+	require.NoError(t, err)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			price := engine.Buy
+			if tt.sell {
+				price = engine.Sell
+			}
+			breakdown, err := price(tt.amount, money.NewRate(1, 1))
+			require.NoError(t, err)
+
+			assert.Equal(t, "1", breakdown.MidRate.String())
+			assert.Equal(t, tt.expectedRate, breakdown.Rate.String())
+			assert.Equal(t, money.New(tt.expectedGross, "USD"), breakdown.Gross)
+			assert.Equal(t, money.New(tt.expectedSpread, "USD"), breakdown.Spread)
+			assert.Equal(t, money.New(tt.expectedFee, "USD"), breakdown.Fee)
+			assert.Equal(t, money.New(tt.expectedTotal, "USD"), breakdown.Total)
+		})
+	}
+}
+
+func TestPricingSellFeeAboveProceeds_Fail(t *testing.T) {
+	engine, err := pricing.New(testPricingConfig)
+	require.NoError(t, err)
+
+	_, err = engine.Sell(money.New(10, "EUR"), money.NewRate(1, 1))
+	require.ErrorIs(t, err, pricing.ErrFeeAboveProceeds)
+}
+
+func TestPricingZeroConfig_MidRate(t *testing.T) {
+	engine, err := pricing.New(pricing.Config{})
+	require.NoError(t, err)
+
+	breakdown, err := engine.Buy(money.New(100, "EUR"), money.NewRate(10, 11))
+	require.NoError(t, err)
+
+	assert.Equal(t, "1.1", breakdown.Rate.String())
+	assert.Equal(t, money.New(110, "USD"), breakdown.Total)
+	assert.True(t, breakdown.Fee.IsZero())
+	assert.True(t, breakdown.Spread.IsZero())
+}
+
+func TestPricingNew_FailCases(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  pricing.Config
+	}{
+		{
+			name: "Negative default spread",
+			cfg:  pricing.Config{DefaultSpreadBps: -1},
+		},
+		{
+			name: "Spread of the whole rate",
+			cfg:  pricing.Config{SpreadBps: map[string]int64{"EUR": 10000}},
+		},
+		{
+			name: "Unlimited fee tier before the last one",
+			cfg: pricing.Config{FeeTiers: []pricing.FeeTier{
+				{UpTo: 0, Bps: 100},
+				{UpTo: 100000, Bps: 50},
+			}},
+		},
+		{
+			name: "Descending fee tiers",
+			cfg: pricing.Config{FeeTiers: []pricing.FeeTier{
+				{UpTo: 100000, Bps: 100},
+				{UpTo: 50000, Bps: 50},
+			}},
+		},
+		{
+			name: "Negative minimum fee",
+			cfg:  pricing.Config{MinFee: -1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := pricing.New(tt.cfg)
+			require.ErrorIs(t, err, pricing.ErrInvalidConfig)
+		})
+	}
+}
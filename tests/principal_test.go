@@ -0,0 +1,96 @@
+package tests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tizzhh/micro-banking/internal/api/principal"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+const testPrincipalUserID uint64 = 1
+
+// principalContext returns the context the auth middleware hands to the
+// handlers of an authenticated request.
+func principalContext(email string) context.Context {
+	return principal.WithPrincipal(context.Background(), principal.Principal{
+		UserID: testPrincipalUserID,
+		Email:  email,
+	})
+}
+
+func TestPrincipal_MetadataRoundTrip(t *testing.T) {
+	sent := principal.Principal{UserID: 42, Email: "test-user0@gmail.com", Roles: []string{"user", "admin"}}
+
+	ctx := principal.AppendToOutgoingContext(principal.WithPrincipal(context.Background(), sent))
+	md, ok := metadata.FromOutgoingContext(ctx)
+	require.True(t, ok)
+
+	received, ok := principal.FromIncomingContext(metadata.NewIncomingContext(context.Background(), md))
+	require.True(t, ok)
+	assert.Equal(t, sent, received)
+	assert.True(t, received.HasRole("admin"))
+	assert.False(t, received.HasRole("auditor"))
+}
+
+func TestPrincipal_FromIncomingContextFailCases(t *testing.T) {
+	tests := []struct {
+		name string
+		md   metadata.MD
+	}{
+		{
+			name: "No principal",
+			md:   metadata.Pairs(),
+		},
+		{
+			name: "Missing email",
+			md:   metadata.Pairs(principal.MetadataUserID, "42"),
+		},
+		{
+			name: "Malformed user id",
+			md:   metadata.Pairs(principal.MetadataUserID, "forty-two", principal.MetadataEmail, "test-user0@gmail.com"),
+		},
+		{
+			name: "Repeated user id",
+			md: metadata.Pairs(
+				principal.MetadataUserID, "42",
+				principal.MetadataUserID, "43",
+				principal.MetadataEmail, "test-user0@gmail.com",
+			),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, ok := principal.FromIncomingContext(metadata.NewIncomingContext(context.Background(), tt.md))
+			assert.False(t, ok)
+		})
+	}
+}
+
+func TestPrincipal_NotForwardedWithoutPrincipal(t *testing.T) {
+	ctx := principal.AppendToOutgoingContext(context.Background())
+
+	_, ok := metadata.FromOutgoingContext(ctx)
+	assert.False(t, ok)
+}
+
+func TestPrincipal_ForwardCredentials(t *testing.T) {
+	service := principal.Service{Name: "bank", Token: "service-token"}
+	ctx := principal.WithToken(principalContext("test-user0@gmail.com"), "token")
+
+	var md metadata.MD
+	invoker := func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		md, _ = metadata.FromOutgoingContext(ctx)
+		return nil
+	}
+	require.NoError(t, principal.Forward(service)(ctx, "/test.Test/Owner", nil, nil, nil, invoker))
+
+	assert.Equal(t, []string{"Bearer token"}, md.Get(principal.MetadataAuthorization))
+	assert.Equal(t, []string{"bank"}, md.Get(principal.MetadataServiceName))
+	assert.Equal(t, []string{"service-token"}, md.Get(principal.MetadataServiceToken))
+	assert.Equal(t, []string{"test-user0@gmail.com"}, md.Get(principal.MetadataEmail))
+}
@@ -0,0 +1,258 @@
+package tests
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	authApi "github.com/tizzhh/micro-banking/internal/delivery/http/bank/resource/auth"
+	authMocks "github.com/tizzhh/micro-banking/internal/delivery/http/bank/resource/auth/mocks"
+)
+
+const (
+	updateProfileResponseTemplate = `{"user_id":%d,"email":"%s","first_name":"%s","last_name":"%s","balance":0,"age":%d,"email_verified":true,"phone_number":"%s","status":"active"}`
+	changeEmailRequestTemplate    = `{"password": "%s","new_email": "%s"}`
+)
+
+func TestUpdateProfile_HappyPath(t *testing.T) {
+	testUserEmail := "test-user0@gmail.com"
+	firstName := "Alice"
+	phoneNumber := "+14155550100"
+
+	ctx := principalContext(testUserEmail)
+	reqBody := []byte(fmt.Sprintf(`{"first_name": "%s","phone_number": "%s"}`, firstName, phoneNumber))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPatch, "/auth/user", bytes.NewBuffer(reqBody))
+	require.NoError(t, err)
+
+	mockClient := authMocks.NewAuthClient(t)
+	mockClient.On(
+		"UpdateProfile",
+		ctx,
+		testUserEmail,
+		authApi.UpdateProfileRequest{FirstName: &firstName, PhoneNumber: &phoneNumber},
+	).Return(authApi.UserResponse{
+		ID:            testPrincipalUserID,
+		Email:         testUserEmail,
+		FirstName:     firstName,
+		LastName:      "Smith",
+		Age:           30,
+		EmailVerified: true,
+		PhoneNumber:   phoneNumber,
+		Status:        "active",
+	}, nil)
+	auth := authApi.New(log, validation, mockClient)
+
+	rr := httptest.NewRecorder()
+	http.HandlerFunc(auth.UpdateProfile()).ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, fmt.Sprintf(
+		updateProfileResponseTemplate,
+		testPrincipalUserID,
+		testUserEmail,
+		firstName,
+		"Smith",
+		30,
+		phoneNumber,
+	), strings.TrimRight(rr.Body.String(), "\n"))
+}
+
+func TestUpdateProfileHttp_FailCases(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		grpcErr        error
+		expectedErr    string
+		expectedStatus int
+	}{
+		{
+			name:           "Update with a short first name",
+			body:           `{"first_name": "A"}`,
+			expectedErr:    "field FirstName should be greater or equal to 2",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Update with an underage age",
+			body:           `{"age": 10}`,
+			expectedErr:    "field Age should be greater or equal to 18",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Update with an invalid phone number",
+			body:           `{"phone_number": "12345"}`,
+			expectedErr:    "field PhoneNumber is not valid",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Update without fields",
+			body:           `{}`,
+			grpcErr:        status.Error(codes.InvalidArgument, "nothing to update"),
+			expectedErr:    "nothing to update",
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			testUserEmail := "test-user0@gmail.com"
+
+			ctx := principalContext(testUserEmail)
+			req, err := http.NewRequestWithContext(ctx, http.MethodPatch, "/auth/user", bytes.NewBufferString(tt.body))
+			require.NoError(t, err)
+
+			mockClient := authMocks.NewAuthClient(t)
+			if tt.grpcErr != nil {
+				mockClient.On(
+					"UpdateProfile",
+					ctx,
+					testUserEmail,
+					authApi.UpdateProfileRequest{},
+				).Return(authApi.UserResponse{}, tt.grpcErr)
+			}
+			auth := authApi.New(log, validation, mockClient)
+
+			rr := httptest.NewRecorder()
+			http.HandlerFunc(auth.UpdateProfile()).ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			assert.Equal(t, fmt.Sprintf(
+				errorResponseTemplate,
+				tt.expectedErr,
+			), strings.TrimRight(rr.Body.String(), "\n"))
+		})
+	}
+}
+
+func TestUpdateProfileRemovePhone_HappyPath(t *testing.T) {
+	testUserEmail := "test-user0@gmail.com"
+	phoneNumber := ""
+
+	ctx := principalContext(testUserEmail)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPatch, "/auth/user", bytes.NewBufferString(`{"phone_number": ""}`))
+	require.NoError(t, err)
+
+	mockClient := authMocks.NewAuthClient(t)
+	mockClient.On(
+		"UpdateProfile",
+		ctx,
+		testUserEmail,
+		authApi.UpdateProfileRequest{PhoneNumber: &phoneNumber},
+	).Return(authApi.UserResponse{Email: testUserEmail, Status: "active"}, nil)
+	auth := authApi.New(log, validation, mockClient)
+
+	rr := httptest.NewRecorder()
+	http.HandlerFunc(auth.UpdateProfile()).ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+}
+
+func TestChangeEmail_HappyPath(t *testing.T) {
+	testUserEmail := "test-user0@gmail.com"
+	newEmail := "test-user0-new@gmail.com"
+	password := randomFakePassword()
+
+	ctx := principalContext(testUserEmail)
+	reqBody := []byte(fmt.Sprintf(changeEmailRequestTemplate, password, newEmail))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, "/auth/change-email", bytes.NewBuffer(reqBody))
+	require.NoError(t, err)
+
+	mockClient := authMocks.NewAuthClient(t)
+	mockClient.On(
+		"ChangeEmail",
+		ctx,
+		testUserEmail,
+		password,
+		newEmail,
+	).Return(nil)
+	auth := authApi.New(log, validation, mockClient)
+
+	rr := httptest.NewRecorder()
+	http.HandlerFunc(auth.ChangeEmail()).ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, `{"message":"Email changed, a verification mail was sent to the new address"}`, strings.TrimRight(rr.Body.String(), "\n"))
+}
+
+func TestChangeEmailHttp_FailCases(t *testing.T) {
+	tests := []struct {
+		name           string
+		password       string
+		newEmail       string
+		grpcErr        error
+		expectedErr    string
+		expectedStatus int
+	}{
+		{
+			name:           "Change to an invalid email",
+			password:       "password",
+			newEmail:       "not-an-email",
+			expectedErr:    "field NewEmail is not a valid email",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Change without password",
+			password:       "",
+			newEmail:       "test-user0-new@gmail.com",
+			expectedErr:    "field Password is a required field",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Change with a wrong password",
+			password:       "wrong-password",
+			newEmail:       "test-user0-new@gmail.com",
+			grpcErr:        status.Error(codes.InvalidArgument, "invalid credentials"),
+			expectedErr:    "invalid credentials",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Change to a taken email",
+			password:       "password",
+			newEmail:       "test-user1@gmail.com",
+			grpcErr:        status.Error(codes.AlreadyExists, "user already exists"),
+			expectedErr:    "user already exists",
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			testUserEmail := "test-user0@gmail.com"
+
+			ctx := principalContext(testUserEmail)
+			reqBody := []byte(fmt.Sprintf(changeEmailRequestTemplate, tt.password, tt.newEmail))
+			req, err := http.NewRequestWithContext(ctx, http.MethodPut, "/auth/change-email", bytes.NewBuffer(reqBody))
+			require.NoError(t, err)
+
+			mockClient := authMocks.NewAuthClient(t)
+			if tt.grpcErr != nil {
+				mockClient.On(
+					"ChangeEmail",
+					ctx,
+					testUserEmail,
+					tt.password,
+					tt.newEmail,
+				).Return(tt.grpcErr)
+			}
+			auth := authApi.New(log, validation, mockClient)
+
+			rr := httptest.NewRecorder()
+			http.HandlerFunc(auth.ChangeEmail()).ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			assert.Equal(t, fmt.Sprintf(
+				errorResponseTemplate,
+				tt.expectedErr,
+			), strings.TrimRight(rr.Body.String(), "\n"))
+		})
+	}
+}
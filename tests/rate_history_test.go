@@ -0,0 +1,207 @@
+package tests
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	currencyApi "github.com/tizzhh/micro-banking/internal/delivery/http/bank/resource/currency"
+	currencyMocks "github.com/tizzhh/micro-banking/internal/delivery/http/bank/resource/currency/mocks"
+	currencyModels "github.com/tizzhh/micro-banking/internal/domain/currency/models"
+	currencyService "github.com/tizzhh/micro-banking/internal/services/currency"
+	currency "github.com/tizzhh/micro-banking/internal/services/currency/errors"
+	catalogMocks "github.com/tizzhh/micro-banking/internal/services/currency/mocks"
+	"github.com/tizzhh/micro-banking/internal/storage"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	rateHistoryResponseTemplate = `{"currency_code":"EUR","rates":[{"rate":"0.92","source":"currencyapi","fetched_at":"2024-05-01T12:00:00Z"}],"candles":[{"start":"2024-05-01T12:00:00Z","open":"0.92","high":"0.92","low":"0.92","close":"0.92","samples":1}]}`
+)
+
+// rateHistoryRouter serves the rate history route the way the bank router
+// does.
+func rateHistoryRouter(client currencyApi.CurrencyClient) http.Handler {
+	api := currencyApi.New(log, validation, client)
+
+	router := chi.NewRouter()
+	router.Method(http.MethodGet, "/v1/rates/{code}/history", api.RateHistory())
+	return router
+}
+
+func TestRateHistory_HappyPath(t *testing.T) {
+	fetchedAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	req, err := http.NewRequest(http.MethodGet, "/v1/rates/eur/history?from=2024-05-01T00:00:00Z&interval=1h", nil)
+	require.NoError(t, err)
+
+	mockClient := currencyMocks.NewCurrencyClient(t)
+	mockClient.On("RateHistory", mock.Anything, currencyApi.RateHistoryFilter{
+		CurrencyCode: "EUR",
+		From:         time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC),
+		Interval:     "1h",
+	}).Return(currencyApi.RateHistoryResponse{
+		CurrencyCode: "EUR",
+		Rates:        []currencyApi.RatePoint{{Rate: "0.92", Source: "currencyapi", FetchedAt: fetchedAt}},
+		Candles: []currencyApi.RateCandle{
+			{Start: fetchedAt, Open: "0.92", High: "0.92", Low: "0.92", Close: "0.92", Samples: 1},
+		},
+	}, nil)
+
+	rr := httptest.NewRecorder()
+	rateHistoryRouter(mockClient).ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, rateHistoryResponseTemplate, strings.TrimRight(rr.Body.String(), "\n"))
+}
+
+func TestRateHistoryHttp_FailCases(t *testing.T) {
+	tests := []struct {
+		name           string
+		url            string
+		expectedErr    string
+		expectedStatus int
+	}{
+		{
+			name:           "Rate history with malformed currency code",
+			url:            "/v1/rates/EURO/history",
+			expectedErr:    "field CurrencyCode is not valid",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Rate history with unknown interval",
+			url:            "/v1/rates/EUR/history?interval=2h",
+			expectedErr:    "field Interval is not valid",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Rate history with malformed from",
+			url:            "/v1/rates/EUR/history?from=yesterday",
+			expectedErr:    "field From is not valid",
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, tt.url, nil)
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+			rateHistoryRouter(currencyMocks.NewCurrencyClient(t)).ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			assert.Contains(t, rr.Body.String(), tt.expectedErr)
+		})
+	}
+}
+
+func TestRateHistoryUnknownCurrency_Fail(t *testing.T) {
+	req, err := http.NewRequest(http.MethodGet, "/v1/rates/XYZ/history", nil)
+	require.NoError(t, err)
+
+	mockClient := currencyMocks.NewCurrencyClient(t)
+	mockClient.On("RateHistory", mock.Anything, currencyApi.RateHistoryFilter{CurrencyCode: "XYZ"}).
+		Return(currencyApi.RateHistoryResponse{}, status.Error(codes.NotFound, currency.ErrCurrencyCodeNotFound.Error()))
+
+	rr := httptest.NewRecorder()
+	rateHistoryRouter(mockClient).ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
+
+func TestRateHistoryService_HappyPath(t *testing.T) {
+	ctx := context.Background()
+	from := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(24 * time.Hour)
+
+	rates := []currencyModels.ExchangeRate{
+		{Rate: "0.920000000000", Source: "currencyapi", FetchedAt: from.Add(10 * time.Minute)},
+		{Rate: "0.930000000000", Source: "currencyapi", FetchedAt: from.Add(20 * time.Minute)},
+		{Rate: "0.910000000000", Source: "currencyapi", FetchedAt: from.Add(30 * time.Minute)},
+		{Rate: "0.915000000000", Source: "currencyapi", FetchedAt: from.Add(40 * time.Minute)},
+		{Rate: "0.940000000000", Source: "currencyapi", FetchedAt: from.Add(3*time.Hour + 5*time.Minute)},
+	}
+
+	mockCatalog := catalogMocks.NewCatalog(t)
+	mockCatalog.On("Currency", ctx, "EUR").Return(currencyModels.Currency{Code: "EUR", Exponent: 2, Enabled: true}, nil)
+	mockHistory := catalogMocks.NewRateHistory(t)
+	mockHistory.On("Rates", ctx, "EUR", from, to).Return(rates, nil)
+	service := currencyService.New(log, nil, nil, nil, nil, nil, mockCatalog, nil, 0, nil, mockHistory)
+
+	history, err := service.RateHistory(ctx, "EUR", from, to, "1h")
+	require.NoError(t, err)
+
+	assert.Equal(t, "EUR", history.CurrencyCode)
+	require.Len(t, history.Rates, 5)
+	assert.Equal(t, "0.92", history.Rates[0].Rate)
+	assert.Equal(t, []currencyModels.RateCandle{
+		{Start: from, Open: "0.92", High: "0.93", Low: "0.91", Close: "0.915", Samples: 4},
+		{Start: from.Add(3 * time.Hour), Open: "0.94", High: "0.94", Low: "0.94", Close: "0.94", Samples: 1},
+	}, history.Candles)
+}
+
+func TestRateHistoryService_FailCases(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		code        string
+		from        time.Time
+		to          time.Time
+		interval    string
+		expectedErr error
+	}{
+		{
+			name:        "Rate history with unknown interval",
+			code:        "EUR",
+			interval:    "2h",
+			expectedErr: currency.ErrInvalidInterval,
+		},
+		{
+			name:        "Rate history ending before it starts",
+			code:        "EUR",
+			from:        now,
+			to:          now.Add(-time.Hour),
+			expectedErr: currency.ErrInvalidPeriod,
+		},
+		{
+			name:        "Rate history over too long a period",
+			code:        "EUR",
+			from:        now.Add(-32 * 24 * time.Hour),
+			to:          now,
+			expectedErr: currency.ErrInvalidPeriod,
+		},
+		{
+			name:        "Rate history of the base currency",
+			code:        "USD",
+			expectedErr: currency.ErrBaseCurrency,
+		},
+		{
+			name:        "Rate history of unknown currency",
+			code:        "XYZ",
+			expectedErr: currency.ErrCurrencyCodeNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			mockCatalog := catalogMocks.NewCatalog(t)
+			mockCatalog.On("Currency", ctx, tt.code).Return(currencyModels.Currency{}, storage.ErrCurrencyCodeNotFound).Maybe()
+			service := currencyService.New(log, nil, nil, nil, nil, nil, mockCatalog, nil, 0, nil, catalogMocks.NewRateHistory(t))
+
+			_, err := service.RateHistory(ctx, tt.code, tt.from, tt.to, tt.interval)
+			require.ErrorIs(t, err, tt.expectedErr)
+		})
+	}
+}
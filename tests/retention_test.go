@@ -0,0 +1,54 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tizzhh/micro-banking/internal/config"
+	"github.com/tizzhh/micro-banking/internal/services/retention"
+	retentionMocks "github.com/tizzhh/micro-banking/internal/services/retention/mocks"
+)
+
+var testRetentionConfig = config.Retention{
+	Period:       365 * 24 * time.Hour,
+	PollInterval: time.Hour,
+	BatchSize:    10,
+}
+
+func TestRetentionAnonymize_HappyPath(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	mockStore := retentionMocks.NewStore(t)
+	mockStore.On(
+		"AnonymizeClosedUsers",
+		ctx,
+		time.Date(2023, 5, 2, 12, 0, 0, 0, time.UTC),
+		testRetentionConfig.BatchSize,
+	).Return(3, nil).Once()
+
+	anonymized, err := retention.New(log, mockStore, testRetentionConfig).AnonymizeBatch(ctx, now)
+	require.NoError(t, err)
+	assert.Equal(t, 3, anonymized)
+}
+
+func TestRetentionAnonymize_StoreFails(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+
+	mockStore := retentionMocks.NewStore(t)
+	mockStore.On(
+		"AnonymizeClosedUsers",
+		ctx,
+		now.Add(-testRetentionConfig.Period),
+		testRetentionConfig.BatchSize,
+	).Return(0, errors.New("connection refused")).Once()
+
+	anonymized, err := retention.New(log, mockStore, testRetentionConfig).AnonymizeBatch(ctx, now)
+	require.ErrorContains(t, err, "connection refused")
+	assert.Zero(t, anonymized)
+}
@@ -7,9 +7,10 @@ import (
 	"testing"
 
 	authv1 "github.com/tizzhh/micro-banking/gen/go/protos/proto/auth"
+	"github.com/tizzhh/micro-banking/internal/api/principal"
 	"github.com/tizzhh/micro-banking/internal/config"
+	"github.com/tizzhh/micro-banking/pkg/mtls"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
 )
 
 type Suite struct {
@@ -35,7 +36,18 @@ func New(t *testing.T) (context.Context, *Suite) {
 		cancel()
 	})
 
-	grpc, err := grpc.NewClient(grpcAddress(cfg), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	// the tests act as a trusted service, so that they may call methods
+	// for any user
+	service := principal.Service{Name: cfg.ServiceAuth.Name, Token: cfg.ServiceAuth.Token}
+	creds, err := mtls.ClientCredentials(cfg.Clients.TLS.Options())
+	if err != nil {
+		t.Fatalf("failed to load client credentials: %v", err)
+	}
+	grpc, err := grpc.NewClient(
+		grpcAddress(cfg),
+		grpc.WithTransportCredentials(creds),
+		grpc.WithUnaryInterceptor(principal.Forward(service)),
+	)
 	if err != nil {
 		t.Fatalf("grpc server connection failed: %v", err)
 	}
@@ -0,0 +1,129 @@
+package tests
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tizzhh/micro-banking/pkg/totp"
+)
+
+// rfc6238Secret is the SHA1 secret of the RFC 6238 test vectors,
+// "12345678901234567890", in base32.
+const rfc6238Secret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestTOTPCode_RFCVectors(t *testing.T) {
+	tests := []struct {
+		unix         int64
+		expectedCode string
+	}{
+		{unix: 59, expectedCode: "287082"},
+		{unix: 1111111109, expectedCode: "081804"},
+		{unix: 1111111111, expectedCode: "050471"},
+		{unix: 1234567890, expectedCode: "005924"},
+		{unix: 2000000000, expectedCode: "279037"},
+		{unix: 20000000000, expectedCode: "353130"},
+	}
+
+	for _, tt := range tests {
+		code, err := totp.Code(rfc6238Secret, time.Unix(tt.unix, 0))
+		require.NoError(t, err)
+		assert.Equal(t, tt.expectedCode, code, "time %d", tt.unix)
+	}
+}
+
+func TestTOTPValidate_Cases(t *testing.T) {
+	now := time.Unix(1234567890, 0)
+
+	tests := []struct {
+		name         string
+		secret       string
+		codeAt       time.Time
+		code         string
+		expectedStep int64
+		expectedErr  error
+	}{
+		{
+			name:         "Current code",
+			secret:       rfc6238Secret,
+			codeAt:       now,
+			expectedStep: totp.Step(now),
+		},
+		{
+			name:         "Code of the previous period",
+			secret:       rfc6238Secret,
+			codeAt:       now.Add(-totp.Period),
+			expectedStep: totp.Step(now) - 1,
+		},
+		{
+			name:         "Code of the next period",
+			secret:       rfc6238Secret,
+			codeAt:       now.Add(totp.Period),
+			expectedStep: totp.Step(now) + 1,
+		},
+		{
+			name:        "Code older than the skew",
+			secret:      rfc6238Secret,
+			codeAt:      now.Add(-2 * totp.Period),
+			expectedErr: totp.ErrInvalidCode,
+		},
+		{
+			name:        "Wrong code",
+			secret:      rfc6238Secret,
+			code:        "000000",
+			expectedErr: totp.ErrInvalidCode,
+		},
+		{
+			name:        "Too short code",
+			secret:      rfc6238Secret,
+			code:        "00592",
+			expectedErr: totp.ErrInvalidCode,
+		},
+		{
+			name:        "Invalid secret",
+			secret:      "not base32!",
+			code:        "005924",
+			expectedErr: totp.ErrInvalidSecret,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code := tt.code
+			if code == "" {
+				var err error
+				code, err = totp.Code(tt.secret, tt.codeAt)
+				require.NoError(t, err)
+			}
+
+			step, err := totp.Validate(tt.secret, code, now)
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedStep, step)
+		})
+	}
+}
+
+func TestTOTPNewSecret_HappyPath(t *testing.T) {
+	secret, err := totp.NewSecret()
+	require.NoError(t, err)
+
+	code, err := totp.Code(secret, time.Now())
+	require.NoError(t, err)
+	assert.True(t, totp.IsCode(code))
+
+	_, err = totp.Validate(secret, code, time.Now())
+	require.NoError(t, err)
+
+	uri := totp.URI("micro-banking", "test-user0@gmail.com", secret)
+	assert.True(t, strings.HasPrefix(uri, "otpauth://totp/micro-banking:test-user0@gmail.com?"))
+	assert.Contains(t, uri, "secret="+secret)
+	assert.Contains(t, uri, "issuer=micro-banking")
+	assert.Contains(t, uri, "digits=6")
+	assert.Contains(t, uri, "period=30")
+}
@@ -0,0 +1,176 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	authApi "github.com/tizzhh/micro-banking/internal/delivery/http/bank/resource/auth"
+	authMocks "github.com/tizzhh/micro-banking/internal/delivery/http/bank/resource/auth/mocks"
+)
+
+const verifyEmailRequestTemplate = `{"token": "%s"}`
+
+func TestVerifyEmail_HappyPath(t *testing.T) {
+	testToken := "verification-token"
+
+	reqBody := []byte(fmt.Sprintf(verifyEmailRequestTemplate, testToken))
+
+	req, err := http.NewRequest(http.MethodPost, "/auth/verify-email", bytes.NewBuffer(reqBody))
+	require.NoError(t, err)
+
+	mockClient := authMocks.NewAuthClient(t)
+	mockClient.On(
+		"VerifyEmail",
+		context.Background(),
+		testToken,
+	).Return(nil)
+	auth := authApi.New(log, validation, mockClient)
+
+	rr := httptest.NewRecorder()
+	http.HandlerFunc(auth.VerifyEmail()).ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, `{"message":"Email verified successfully"}`, strings.TrimRight(rr.Body.String(), "\n"))
+}
+
+func TestVerifyEmailHttp_FailCases(t *testing.T) {
+	tests := []struct {
+		name           string
+		token          string
+		grpcErr        error
+		expectedErr    string
+		expectedStatus int
+	}{
+		{
+			name:           "Verify without token",
+			token:          "",
+			expectedErr:    "field Token is a required field",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Verify with an invalid token",
+			token:          "used-token",
+			grpcErr:        status.Error(codes.InvalidArgument, "invalid verification token"),
+			expectedErr:    "invalid verification token",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Verify a verified email",
+			token:          "verification-token",
+			grpcErr:        status.Error(codes.FailedPrecondition, "email is already verified"),
+			expectedErr:    "email is already verified",
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			reqBody := []byte(fmt.Sprintf(verifyEmailRequestTemplate, tt.token))
+
+			req, err := http.NewRequest(http.MethodPost, "/auth/verify-email", bytes.NewBuffer(reqBody))
+			require.NoError(t, err)
+
+			mockClient := authMocks.NewAuthClient(t)
+			if tt.grpcErr != nil {
+				mockClient.On(
+					"VerifyEmail",
+					context.Background(),
+					tt.token,
+				).Return(tt.grpcErr)
+			}
+			auth := authApi.New(log, validation, mockClient)
+
+			rr := httptest.NewRecorder()
+			http.HandlerFunc(auth.VerifyEmail()).ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			assert.Equal(t, fmt.Sprintf(
+				errorResponseTemplate,
+				tt.expectedErr,
+			), strings.TrimRight(rr.Body.String(), "\n"))
+		})
+	}
+}
+
+func TestResendVerification_HappyPath(t *testing.T) {
+	testUserEmail := "test-user0@gmail.com"
+
+	ctx := principalContext(testUserEmail)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "/auth/verify-email/resend", nil)
+	require.NoError(t, err)
+
+	mockClient := authMocks.NewAuthClient(t)
+	mockClient.On(
+		"ResendVerification",
+		ctx,
+		testUserEmail,
+	).Return(nil)
+	auth := authApi.New(log, validation, mockClient)
+
+	rr := httptest.NewRecorder()
+	http.HandlerFunc(auth.ResendVerification()).ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, `{"message":"Verification mail sent"}`, strings.TrimRight(rr.Body.String(), "\n"))
+}
+
+func TestResendVerificationHttp_FailCases(t *testing.T) {
+	tests := []struct {
+		name           string
+		grpcErr        error
+		expectedErr    string
+		expectedStatus int
+	}{
+		{
+			name:           "Resend too often",
+			grpcErr:        status.Error(codes.ResourceExhausted, "too many requests, try again later"),
+			expectedErr:    "too many requests, try again later",
+			expectedStatus: http.StatusTooManyRequests,
+		},
+		{
+			name:           "Resend for a verified email",
+			grpcErr:        status.Error(codes.FailedPrecondition, "email is already verified"),
+			expectedErr:    "email is already verified",
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			testUserEmail := "test-user0@gmail.com"
+
+			ctx := principalContext(testUserEmail)
+			req, err := http.NewRequestWithContext(ctx, http.MethodPost, "/auth/verify-email/resend", nil)
+			require.NoError(t, err)
+
+			mockClient := authMocks.NewAuthClient(t)
+			mockClient.On(
+				"ResendVerification",
+				ctx,
+				testUserEmail,
+			).Return(tt.grpcErr)
+			auth := authApi.New(log, validation, mockClient)
+
+			rr := httptest.NewRecorder()
+			http.HandlerFunc(auth.ResendVerification()).ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			assert.Equal(t, fmt.Sprintf(
+				errorResponseTemplate,
+				tt.expectedErr,
+			), strings.TrimRight(rr.Body.String(), "\n"))
+		})
+	}
+}